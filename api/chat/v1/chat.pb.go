@@ -30,15 +30,34 @@ type Message struct {
 	UserId    int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Username  string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"` // Denormalized for performance
 	Content   string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
-	Type      string                 `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"` // text, image, file, system
+	Type      string                 `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"` // text, image, file, poll, system
 	IsEdited  bool                   `protobuf:"varint,7,opt,name=is_edited,json=isEdited,proto3" json:"is_edited,omitempty"`
 	CreatedAt int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix timestamp
 	EditedAt  int64                  `protobuf:"varint,9,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`
 	// File attachment fields
-	FileUrl       string `protobuf:"bytes,10,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`     // URL to the file in storage
-	FileName      string `protobuf:"bytes,11,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`  // Original filename
-	FileSize      int64  `protobuf:"varint,12,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"` // File size in bytes
-	MimeType      string `protobuf:"bytes,13,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`  // MIME type (e.g., image/png, application/pdf)
+	FileUrl  string `protobuf:"bytes,10,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`     // URL to the file in storage
+	FileName string `protobuf:"bytes,11,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`  // Original filename
+	FileSize int64  `protobuf:"varint,12,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"` // File size in bytes
+	MimeType string `protobuf:"bytes,13,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`  // MIME type (e.g., image/png, application/pdf)
+	// Tombstone fields: deleted messages keep their place in history
+	IsDeleted bool  `protobuf:"varint,14,opt,name=is_deleted,json=isDeleted,proto3" json:"is_deleted,omitempty"`
+	DeletedAt int64 `protobuf:"varint,15,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Thread fields: replies point at their root, roots carry reply stats
+	ParentMessageId int64       `protobuf:"varint,16,opt,name=parent_message_id,json=parentMessageId,proto3" json:"parent_message_id,omitempty"`
+	ReplyCount      int32       `protobuf:"varint,17,opt,name=reply_count,json=replyCount,proto3" json:"reply_count,omitempty"`
+	LastReplyAt     int64       `protobuf:"varint,18,opt,name=last_reply_at,json=lastReplyAt,proto3" json:"last_reply_at,omitempty"`
+	Reactions       []*Reaction `protobuf:"bytes,19,rep,name=reactions,proto3" json:"reactions,omitempty"`                   // Aggregated per emoji
+	ExpiresAt       int64       `protobuf:"varint,20,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix timestamp; 0 means the message never expires
+	// Snapshot of the quoted or forwarded message, unaffected by later edits or deletes
+	QuotedMessage *QuotedMessage `protobuf:"bytes,21,opt,name=quoted_message,json=quotedMessage,proto3" json:"quoted_message,omitempty"`
+	IsForwarded   bool           `protobuf:"varint,22,opt,name=is_forwarded,json=isForwarded,proto3" json:"is_forwarded,omitempty"` // Sent with ForwardMessage rather than quoted in a reply
+	// Previews of links in the content, filled in shortly after sending
+	LinkPreviews  []*LinkPreview `protobuf:"bytes,23,rep,name=link_previews,json=linkPreviews,proto3" json:"link_previews,omitempty"`
+	Format        string         `protobuf:"bytes,24,opt,name=format,proto3" json:"format,omitempty"`                                // plain or markdown
+	ContentHtml   string         `protobuf:"bytes,25,opt,name=content_html,json=contentHtml,proto3" json:"content_html,omitempty"`   // Sanitized HTML rendering of markdown content
+	Poll          *Poll          `protobuf:"bytes,26,opt,name=poll,proto3" json:"poll,omitempty"`                                    // Set on poll messages, with the tally relative to the caller
+	SystemEvent   *SystemEvent   `protobuf:"bytes,27,opt,name=system_event,json=systemEvent,proto3" json:"system_event,omitempty"`   // Set on system messages
+	ClientMsgId   string         `protobuf:"bytes,28,opt,name=client_msg_id,json=clientMsgId,proto3" json:"client_msg_id,omitempty"` // Sender-generated ID the message was sent with, if any
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -164,131 +183,206 @@ func (x *Message) GetMimeType() string {
 	return ""
 }
 
-// Room model
-type Room struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"` // public, private, direct
-	CreatedBy     int64                  `protobuf:"varint,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
-	MemberCount   int32                  `protobuf:"varint,6,opt,name=member_count,json=memberCount,proto3" json:"member_count,omitempty"`
-	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Members       []*RoomMember          `protobuf:"bytes,8,rep,name=members,proto3" json:"members,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Message) GetIsDeleted() bool {
+	if x != nil {
+		return x.IsDeleted
+	}
+	return false
 }
 
-func (x *Room) Reset() {
-	*x = Room{}
-	mi := &file_api_chat_v1_chat_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Message) GetDeletedAt() int64 {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return 0
 }
 
-func (x *Room) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Message) GetParentMessageId() int64 {
+	if x != nil {
+		return x.ParentMessageId
+	}
+	return 0
 }
 
-func (*Room) ProtoMessage() {}
+func (x *Message) GetReplyCount() int32 {
+	if x != nil {
+		return x.ReplyCount
+	}
+	return 0
+}
 
-func (x *Room) ProtoReflect() protoreflect.Message {
-	mi := &file_api_chat_v1_chat_proto_msgTypes[1]
+func (x *Message) GetLastReplyAt() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.LastReplyAt
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use Room.ProtoReflect.Descriptor instead.
-func (*Room) Descriptor() ([]byte, []int) {
-	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{1}
+func (x *Message) GetReactions() []*Reaction {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
 }
 
-func (x *Room) GetId() int64 {
+func (x *Message) GetExpiresAt() int64 {
 	if x != nil {
-		return x.Id
+		return x.ExpiresAt
 	}
 	return 0
 }
 
-func (x *Room) GetName() string {
+func (x *Message) GetQuotedMessage() *QuotedMessage {
 	if x != nil {
-		return x.Name
+		return x.QuotedMessage
+	}
+	return nil
+}
+
+func (x *Message) GetIsForwarded() bool {
+	if x != nil {
+		return x.IsForwarded
+	}
+	return false
+}
+
+func (x *Message) GetLinkPreviews() []*LinkPreview {
+	if x != nil {
+		return x.LinkPreviews
+	}
+	return nil
+}
+
+func (x *Message) GetFormat() string {
+	if x != nil {
+		return x.Format
 	}
 	return ""
 }
 
-func (x *Room) GetDescription() string {
+func (x *Message) GetContentHtml() string {
 	if x != nil {
-		return x.Description
+		return x.ContentHtml
 	}
 	return ""
 }
 
-func (x *Room) GetType() string {
+func (x *Message) GetPoll() *Poll {
 	if x != nil {
-		return x.Type
+		return x.Poll
+	}
+	return nil
+}
+
+func (x *Message) GetSystemEvent() *SystemEvent {
+	if x != nil {
+		return x.SystemEvent
+	}
+	return nil
+}
+
+func (x *Message) GetClientMsgId() string {
+	if x != nil {
+		return x.ClientMsgId
 	}
 	return ""
 }
 
-func (x *Room) GetCreatedBy() int64 {
+// Room lifecycle event recorded by a system message
+type SystemEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`                                                                                 // member_joined, member_left, room_renamed, role_changed, message_pinned, message_unpinned
+	ActorId       int64                  `protobuf:"varint,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`                                                           // User who made the change
+	TargetId      int64                  `protobuf:"varint,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`                                                        // User or message the change applies to; 0 for the room itself
+	Details       map[string]string      `protobuf:"bytes,4,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Kind-specific fields, e.g. old_name/new_name or role
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemEvent) Reset() {
+	*x = SystemEvent{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemEvent) ProtoMessage() {}
+
+func (x *SystemEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[1]
 	if x != nil {
-		return x.CreatedBy
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Room) GetMemberCount() int32 {
+// Deprecated: Use SystemEvent.ProtoReflect.Descriptor instead.
+func (*SystemEvent) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SystemEvent) GetKind() string {
 	if x != nil {
-		return x.MemberCount
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SystemEvent) GetActorId() int64 {
+	if x != nil {
+		return x.ActorId
 	}
 	return 0
 }
 
-func (x *Room) GetCreatedAt() int64 {
+func (x *SystemEvent) GetTargetId() int64 {
 	if x != nil {
-		return x.CreatedAt
+		return x.TargetId
 	}
 	return 0
 }
 
-func (x *Room) GetMembers() []*RoomMember {
+func (x *SystemEvent) GetDetails() map[string]string {
 	if x != nil {
-		return x.Members
+		return x.Details
 	}
 	return nil
 }
 
-type RoomMember struct {
+// LinkPreview is the OpenGraph / Twitter card metadata of a linked page
+type LinkPreview struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"` // admin, moderator, member
-	JoinedAt      int64                  `protobuf:"varint,4,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,4,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	SiteName      string                 `protobuf:"bytes,5,opt,name=site_name,json=siteName,proto3" json:"site_name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RoomMember) Reset() {
-	*x = RoomMember{}
+func (x *LinkPreview) Reset() {
+	*x = LinkPreview{}
 	mi := &file_api_chat_v1_chat_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RoomMember) String() string {
+func (x *LinkPreview) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoomMember) ProtoMessage() {}
+func (*LinkPreview) ProtoMessage() {}
 
-func (x *RoomMember) ProtoReflect() protoreflect.Message {
+func (x *LinkPreview) ProtoReflect() protoreflect.Message {
 	mi := &file_api_chat_v1_chat_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -300,68 +394,75 @@ func (x *RoomMember) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoomMember.ProtoReflect.Descriptor instead.
-func (*RoomMember) Descriptor() ([]byte, []int) {
+// Deprecated: Use LinkPreview.ProtoReflect.Descriptor instead.
+func (*LinkPreview) Descriptor() ([]byte, []int) {
 	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *RoomMember) GetUserId() int64 {
+func (x *LinkPreview) GetUrl() string {
 	if x != nil {
-		return x.UserId
+		return x.Url
 	}
-	return 0
+	return ""
 }
 
-func (x *RoomMember) GetUsername() string {
+func (x *LinkPreview) GetTitle() string {
 	if x != nil {
-		return x.Username
+		return x.Title
 	}
 	return ""
 }
 
-func (x *RoomMember) GetRole() string {
+func (x *LinkPreview) GetDescription() string {
 	if x != nil {
-		return x.Role
+		return x.Description
 	}
 	return ""
 }
 
-func (x *RoomMember) GetJoinedAt() int64 {
+func (x *LinkPreview) GetImageUrl() string {
 	if x != nil {
-		return x.JoinedAt
+		return x.ImageUrl
 	}
-	return 0
+	return ""
 }
 
-// Request/Response messages
-type SendMessageRequest struct {
-	state   protoimpl.MessageState `protogen:"open.v1"`
-	RoomId  int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
-	Content string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	Type    string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"` // text, image, file
-	// File attachment fields (used when type is image or file)
-	FileUrl       string `protobuf:"bytes,4,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
-	FileName      string `protobuf:"bytes,5,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
-	FileSize      int64  `protobuf:"varint,6,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
-	MimeType      string `protobuf:"bytes,7,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *LinkPreview) GetSiteName() string {
+	if x != nil {
+		return x.SiteName
+	}
+	return ""
 }
 
-func (x *SendMessageRequest) Reset() {
-	*x = SendMessageRequest{}
+// Poll attached to a poll message
+type Poll struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Question       string                 `protobuf:"bytes,1,opt,name=question,proto3" json:"question,omitempty"`
+	Options        []*PollOption          `protobuf:"bytes,2,rep,name=options,proto3" json:"options,omitempty"`
+	MultipleChoice bool                   `protobuf:"varint,3,opt,name=multiple_choice,json=multipleChoice,proto3" json:"multiple_choice,omitempty"` // Voters may pick more than one option
+	Anonymous      bool                   `protobuf:"varint,4,opt,name=anonymous,proto3" json:"anonymous,omitempty"`                                 // Voter IDs are never revealed
+	ClosesAt       int64                  `protobuf:"varint,5,opt,name=closes_at,json=closesAt,proto3" json:"closes_at,omitempty"`                   // Unix timestamp; 0 means open until closed
+	IsClosed       bool                   `protobuf:"varint,6,opt,name=is_closed,json=isClosed,proto3" json:"is_closed,omitempty"`
+	ClosedAt       int64                  `protobuf:"varint,7,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	VoterCount     int32                  `protobuf:"varint,8,opt,name=voter_count,json=voterCount,proto3" json:"voter_count,omitempty"` // Distinct users who voted
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Poll) Reset() {
+	*x = Poll{}
 	mi := &file_api_chat_v1_chat_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendMessageRequest) String() string {
+func (x *Poll) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendMessageRequest) ProtoMessage() {}
+func (*Poll) ProtoMessage() {}
 
-func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
+func (x *Poll) ProtoReflect() protoreflect.Message {
 	mi := &file_api_chat_v1_chat_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -373,86 +474,92 @@ func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
-func (*SendMessageRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Poll.ProtoReflect.Descriptor instead.
+func (*Poll) Descriptor() ([]byte, []int) {
 	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *SendMessageRequest) GetRoomId() int64 {
+func (x *Poll) GetQuestion() string {
 	if x != nil {
-		return x.RoomId
+		return x.Question
 	}
-	return 0
+	return ""
 }
 
-func (x *SendMessageRequest) GetContent() string {
+func (x *Poll) GetOptions() []*PollOption {
 	if x != nil {
-		return x.Content
+		return x.Options
 	}
-	return ""
+	return nil
 }
 
-func (x *SendMessageRequest) GetType() string {
+func (x *Poll) GetMultipleChoice() bool {
 	if x != nil {
-		return x.Type
+		return x.MultipleChoice
 	}
-	return ""
+	return false
 }
 
-func (x *SendMessageRequest) GetFileUrl() string {
+func (x *Poll) GetAnonymous() bool {
 	if x != nil {
-		return x.FileUrl
+		return x.Anonymous
 	}
-	return ""
+	return false
 }
 
-func (x *SendMessageRequest) GetFileName() string {
+func (x *Poll) GetClosesAt() int64 {
 	if x != nil {
-		return x.FileName
+		return x.ClosesAt
 	}
-	return ""
+	return 0
 }
 
-func (x *SendMessageRequest) GetFileSize() int64 {
+func (x *Poll) GetIsClosed() bool {
 	if x != nil {
-		return x.FileSize
+		return x.IsClosed
+	}
+	return false
+}
+
+func (x *Poll) GetClosedAt() int64 {
+	if x != nil {
+		return x.ClosedAt
 	}
 	return 0
 }
 
-func (x *SendMessageRequest) GetMimeType() string {
+func (x *Poll) GetVoterCount() int32 {
 	if x != nil {
-		return x.MimeType
+		return x.VoterCount
 	}
-	return ""
+	return 0
 }
 
-// File upload response
-type UploadFileResponse struct {
+type PollOption struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileUrl       string                 `protobuf:"bytes,1,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
-	FileName      string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
-	FileSize      int64                  `protobuf:"varint,3,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
-	MimeType      string                 `protobuf:"bytes,4,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
-	MessageType   string                 `protobuf:"bytes,5,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"` // "image" or "file" based on mime type
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	VoteCount     int32                  `protobuf:"varint,3,opt,name=vote_count,json=voteCount,proto3" json:"vote_count,omitempty"`
+	VotedByMe     bool                   `protobuf:"varint,4,opt,name=voted_by_me,json=votedByMe,proto3" json:"voted_by_me,omitempty"`
+	VoterIds      []int64                `protobuf:"varint,5,rep,packed,name=voter_ids,json=voterIds,proto3" json:"voter_ids,omitempty"` // Empty for anonymous polls
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadFileResponse) Reset() {
-	*x = UploadFileResponse{}
+func (x *PollOption) Reset() {
+	*x = PollOption{}
 	mi := &file_api_chat_v1_chat_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadFileResponse) String() string {
+func (x *PollOption) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadFileResponse) ProtoMessage() {}
+func (*PollOption) ProtoMessage() {}
 
-func (x *UploadFileResponse) ProtoReflect() protoreflect.Message {
+func (x *PollOption) ProtoReflect() protoreflect.Message {
 	mi := &file_api_chat_v1_chat_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -464,69 +571,78 @@ func (x *UploadFileResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadFileResponse.ProtoReflect.Descriptor instead.
-func (*UploadFileResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PollOption.ProtoReflect.Descriptor instead.
+func (*PollOption) Descriptor() ([]byte, []int) {
 	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *UploadFileResponse) GetFileUrl() string {
+func (x *PollOption) GetId() int32 {
 	if x != nil {
-		return x.FileUrl
+		return x.Id
 	}
-	return ""
+	return 0
 }
 
-func (x *UploadFileResponse) GetFileName() string {
+func (x *PollOption) GetText() string {
 	if x != nil {
-		return x.FileName
+		return x.Text
 	}
 	return ""
 }
 
-func (x *UploadFileResponse) GetFileSize() int64 {
+func (x *PollOption) GetVoteCount() int32 {
 	if x != nil {
-		return x.FileSize
+		return x.VoteCount
 	}
 	return 0
 }
 
-func (x *UploadFileResponse) GetMimeType() string {
+func (x *PollOption) GetVotedByMe() bool {
 	if x != nil {
-		return x.MimeType
+		return x.VotedByMe
 	}
-	return ""
+	return false
 }
 
-func (x *UploadFileResponse) GetMessageType() string {
+func (x *PollOption) GetVoterIds() []int64 {
 	if x != nil {
-		return x.MessageType
+		return x.VoterIds
 	}
-	return ""
+	return nil
 }
 
-type GetMessagesRequest struct {
+// QuotedMessage is a copy of a message taken when it was quoted or forwarded
+type QuotedMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                       // Number of messages to return
-	BeforeId      int64                  `protobuf:"varint,3,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"` // Get messages before this ID (for pagination)
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	RoomId        int64                  `protobuf:"varint,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"` // Origin room
+	UserId        int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	Type          string                 `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	FileUrl       string                 `protobuf:"bytes,7,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
+	FileName      string                 `protobuf:"bytes,8,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileSize      int64                  `protobuf:"varint,9,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	MimeType      string                 `protobuf:"bytes,10,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMessagesRequest) Reset() {
-	*x = GetMessagesRequest{}
+func (x *QuotedMessage) Reset() {
+	*x = QuotedMessage{}
 	mi := &file_api_chat_v1_chat_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMessagesRequest) String() string {
+func (x *QuotedMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMessagesRequest) ProtoMessage() {}
+func (*QuotedMessage) ProtoMessage() {}
 
-func (x *GetMessagesRequest) ProtoReflect() protoreflect.Message {
+func (x *QuotedMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_api_chat_v1_chat_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -538,54 +654,112 @@ func (x *GetMessagesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMessagesRequest.ProtoReflect.Descriptor instead.
-func (*GetMessagesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use QuotedMessage.ProtoReflect.Descriptor instead.
+func (*QuotedMessage) Descriptor() ([]byte, []int) {
 	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetMessagesRequest) GetRoomId() int64 {
+func (x *QuotedMessage) GetMessageId() int64 {
 	if x != nil {
-		return x.RoomId
+		return x.MessageId
 	}
 	return 0
 }
 
-func (x *GetMessagesRequest) GetLimit() int32 {
+func (x *QuotedMessage) GetRoomId() int64 {
 	if x != nil {
-		return x.Limit
+		return x.RoomId
 	}
 	return 0
 }
 
-func (x *GetMessagesRequest) GetBeforeId() int64 {
+func (x *QuotedMessage) GetUserId() int64 {
 	if x != nil {
-		return x.BeforeId
+		return x.UserId
 	}
 	return 0
 }
 
-type GetMessagesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
-	HasMore       bool                   `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetMessagesResponse) Reset() {
-	*x = GetMessagesResponse{}
+func (x *QuotedMessage) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *QuotedMessage) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *QuotedMessage) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *QuotedMessage) GetFileUrl() string {
+	if x != nil {
+		return x.FileUrl
+	}
+	return ""
+}
+
+func (x *QuotedMessage) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *QuotedMessage) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *QuotedMessage) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *QuotedMessage) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// Reaction is the aggregate of one emoji on a message
+type Reaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Emoji         string                 `protobuf:"bytes,1,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	ReactedByMe   bool                   `protobuf:"varint,3,opt,name=reacted_by_me,json=reactedByMe,proto3" json:"reacted_by_me,omitempty"` // Whether the requesting user added this emoji
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reaction) Reset() {
+	*x = Reaction{}
 	mi := &file_api_chat_v1_chat_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMessagesResponse) String() string {
+func (x *Reaction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMessagesResponse) ProtoMessage() {}
+func (*Reaction) ProtoMessage() {}
 
-func (x *GetMessagesResponse) ProtoReflect() protoreflect.Message {
+func (x *Reaction) ProtoReflect() protoreflect.Message {
 	mi := &file_api_chat_v1_chat_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -597,47 +771,57 @@ func (x *GetMessagesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMessagesResponse.ProtoReflect.Descriptor instead.
-func (*GetMessagesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Reaction.ProtoReflect.Descriptor instead.
+func (*Reaction) Descriptor() ([]byte, []int) {
 	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetMessagesResponse) GetMessages() []*Message {
+func (x *Reaction) GetEmoji() string {
 	if x != nil {
-		return x.Messages
+		return x.Emoji
 	}
-	return nil
+	return ""
 }
 
-func (x *GetMessagesResponse) GetHasMore() bool {
+func (x *Reaction) GetCount() int32 {
 	if x != nil {
-		return x.HasMore
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Reaction) GetReactedByMe() bool {
+	if x != nil {
+		return x.ReactedByMe
 	}
 	return false
 }
 
-type StreamMessagesRequest struct {
+// Mention of a user in a message
+type Mention struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
-	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Message       *Message               `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	MentionType   string                 `protobuf:"bytes,3,opt,name=mention_type,json=mentionType,proto3" json:"mention_type,omitempty"` // user (@username), room (@room), here (@here)
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StreamMessagesRequest) Reset() {
-	*x = StreamMessagesRequest{}
+func (x *Mention) Reset() {
+	*x = Mention{}
 	mi := &file_api_chat_v1_chat_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamMessagesRequest) String() string {
+func (x *Mention) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamMessagesRequest) ProtoMessage() {}
+func (*Mention) ProtoMessage() {}
 
-func (x *StreamMessagesRequest) ProtoReflect() protoreflect.Message {
+func (x *Mention) ProtoReflect() protoreflect.Message {
 	mi := &file_api_chat_v1_chat_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -649,47 +833,64 @@ func (x *StreamMessagesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamMessagesRequest.ProtoReflect.Descriptor instead.
-func (*StreamMessagesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Mention.ProtoReflect.Descriptor instead.
+func (*Mention) Descriptor() ([]byte, []int) {
 	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *StreamMessagesRequest) GetRoomId() int64 {
+func (x *Mention) GetId() int64 {
 	if x != nil {
-		return x.RoomId
+		return x.Id
 	}
 	return 0
 }
 
-func (x *StreamMessagesRequest) GetUserId() int64 {
+func (x *Mention) GetMessage() *Message {
 	if x != nil {
-		return x.UserId
+		return x.Message
+	}
+	return nil
+}
+
+func (x *Mention) GetMentionType() string {
+	if x != nil {
+		return x.MentionType
+	}
+	return ""
+}
+
+func (x *Mention) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
 	}
 	return 0
 }
 
-type MarkAsReadRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
-	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Pin of a message in its room
+type Pin struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Message          *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	PinnedBy         int64                  `protobuf:"varint,2,opt,name=pinned_by,json=pinnedBy,proto3" json:"pinned_by,omitempty"`
+	PinnedByUsername string                 `protobuf:"bytes,3,opt,name=pinned_by_username,json=pinnedByUsername,proto3" json:"pinned_by_username,omitempty"`
+	PinnedAt         int64                  `protobuf:"varint,4,opt,name=pinned_at,json=pinnedAt,proto3" json:"pinned_at,omitempty"` // Unix timestamp
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *MarkAsReadRequest) Reset() {
-	*x = MarkAsReadRequest{}
+func (x *Pin) Reset() {
+	*x = Pin{}
 	mi := &file_api_chat_v1_chat_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MarkAsReadRequest) String() string {
+func (x *Pin) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MarkAsReadRequest) ProtoMessage() {}
+func (*Pin) ProtoMessage() {}
 
-func (x *MarkAsReadRequest) ProtoReflect() protoreflect.Message {
+func (x *Pin) ProtoReflect() protoreflect.Message {
 	mi := &file_api_chat_v1_chat_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -701,46 +902,70 @@ func (x *MarkAsReadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MarkAsReadRequest.ProtoReflect.Descriptor instead.
-func (*MarkAsReadRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Pin.ProtoReflect.Descriptor instead.
+func (*Pin) Descriptor() ([]byte, []int) {
 	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *MarkAsReadRequest) GetMessageId() int64 {
+func (x *Pin) GetMessage() *Message {
 	if x != nil {
-		return x.MessageId
+		return x.Message
+	}
+	return nil
+}
+
+func (x *Pin) GetPinnedBy() int64 {
+	if x != nil {
+		return x.PinnedBy
 	}
 	return 0
 }
 
-func (x *MarkAsReadRequest) GetUserId() int64 {
+func (x *Pin) GetPinnedByUsername() string {
 	if x != nil {
-		return x.UserId
+		return x.PinnedByUsername
+	}
+	return ""
+}
+
+func (x *Pin) GetPinnedAt() int64 {
+	if x != nil {
+		return x.PinnedAt
 	}
 	return 0
 }
 
-type MarkAsReadResponse struct {
+// Room model
+type Room struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"` // public, private, direct
+	CreatedBy     int64                  `protobuf:"varint,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	MemberCount   int32                  `protobuf:"varint,6,opt,name=member_count,json=memberCount,proto3" json:"member_count,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Members       []*RoomMember          `protobuf:"bytes,8,rep,name=members,proto3" json:"members,omitempty"`
+	MessageTtl    int32                  `protobuf:"varint,9,opt,name=message_ttl,json=messageTtl,proto3" json:"message_ttl,omitempty"` // Default message lifetime in seconds; 0 means messages never expire
+	Retention     *RetentionPolicy       `protobuf:"bytes,10,opt,name=retention,proto3" json:"retention,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MarkAsReadResponse) Reset() {
-	*x = MarkAsReadResponse{}
+func (x *Room) Reset() {
+	*x = Room{}
 	mi := &file_api_chat_v1_chat_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MarkAsReadResponse) String() string {
+func (x *Room) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MarkAsReadResponse) ProtoMessage() {}
+func (*Room) ProtoMessage() {}
 
-func (x *MarkAsReadResponse) ProtoReflect() protoreflect.Message {
+func (x *Room) ProtoReflect() protoreflect.Message {
 	mi := &file_api_chat_v1_chat_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -752,42 +977,3658 @@ func (x *MarkAsReadResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MarkAsReadResponse.ProtoReflect.Descriptor instead.
-func (*MarkAsReadResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Room.ProtoReflect.Descriptor instead.
+func (*Room) Descriptor() ([]byte, []int) {
 	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *MarkAsReadResponse) GetSuccess() bool {
+func (x *Room) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Room) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Room) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Room) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Room) GetCreatedBy() int64 {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return 0
+}
+
+func (x *Room) GetMemberCount() int32 {
+	if x != nil {
+		return x.MemberCount
+	}
+	return 0
+}
+
+func (x *Room) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Room) GetMembers() []*RoomMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *Room) GetMessageTtl() int32 {
+	if x != nil {
+		return x.MessageTtl
+	}
+	return 0
+}
+
+func (x *Room) GetRetention() *RetentionPolicy {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+// How long a room keeps its messages before they are purged
+type RetentionPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          string                 `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`    // default (the server's policy), forever, days or messages
+	Value         int32                  `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"` // Days to keep for "days", newest messages to keep for "messages"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetentionPolicy) Reset() {
+	*x = RetentionPolicy{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetentionPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetentionPolicy) ProtoMessage() {}
+
+func (x *RetentionPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetentionPolicy.ProtoReflect.Descriptor instead.
+func (*RetentionPolicy) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RetentionPolicy) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *RetentionPolicy) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type RoomMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"` // admin, moderator, member
+	JoinedAt      int64                  `protobuf:"varint,4,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomMember) Reset() {
+	*x = RoomMember{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomMember) ProtoMessage() {}
+
+func (x *RoomMember) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomMember.ProtoReflect.Descriptor instead.
+func (*RoomMember) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RoomMember) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *RoomMember) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RoomMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *RoomMember) GetJoinedAt() int64 {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return 0
+}
+
+// Request/Response messages
+type SendMessageRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	RoomId  int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Content string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Type    string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"` // text, image, file, poll
+	// File attachment fields (used when type is image or file)
+	FileUrl         string       `protobuf:"bytes,4,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
+	FileName        string       `protobuf:"bytes,5,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileSize        int64        `protobuf:"varint,6,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	MimeType        string       `protobuf:"bytes,7,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	ParentMessageId int64        `protobuf:"varint,8,opt,name=parent_message_id,json=parentMessageId,proto3" json:"parent_message_id,omitempty"`  // Reply to this root message (thread)
+	ExpiresIn       int32        `protobuf:"varint,9,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`                      // Delete the message after this many seconds; 0 uses the room's message_ttl
+	QuotedMessageId int64        `protobuf:"varint,10,opt,name=quoted_message_id,json=quotedMessageId,proto3" json:"quoted_message_id,omitempty"` // Quote this message (any room the sender can read)
+	Format          string       `protobuf:"bytes,11,opt,name=format,proto3" json:"format,omitempty"`                                             // plain (default) or markdown
+	Poll            *PollRequest `protobuf:"bytes,12,opt,name=poll,proto3" json:"poll,omitempty"`                                                 // Required when type is poll
+	// Sender-generated ID, unique per user; retrying a send with the same ID
+	// returns the original message instead of posting a duplicate
+	ClientMsgId   string `protobuf:"bytes,13,opt,name=client_msg_id,json=clientMsgId,proto3" json:"client_msg_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMessageRequest) Reset() {
+	*x = SendMessageRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageRequest) ProtoMessage() {}
+
+func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendMessageRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SendMessageRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *SendMessageRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetFileUrl() string {
+	if x != nil {
+		return x.FileUrl
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *SendMessageRequest) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetParentMessageId() int64 {
+	if x != nil {
+		return x.ParentMessageId
+	}
+	return 0
+}
+
+func (x *SendMessageRequest) GetExpiresIn() int32 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *SendMessageRequest) GetQuotedMessageId() int64 {
+	if x != nil {
+		return x.QuotedMessageId
+	}
+	return 0
+}
+
+func (x *SendMessageRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetPoll() *PollRequest {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+func (x *SendMessageRequest) GetClientMsgId() string {
+	if x != nil {
+		return x.ClientMsgId
+	}
+	return ""
+}
+
+type PollRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Question       string                 `protobuf:"bytes,1,opt,name=question,proto3" json:"question,omitempty"`
+	Options        []string               `protobuf:"bytes,2,rep,name=options,proto3" json:"options,omitempty"`
+	MultipleChoice bool                   `protobuf:"varint,3,opt,name=multiple_choice,json=multipleChoice,proto3" json:"multiple_choice,omitempty"`
+	Anonymous      bool                   `protobuf:"varint,4,opt,name=anonymous,proto3" json:"anonymous,omitempty"`
+	ClosesAt       int64                  `protobuf:"varint,5,opt,name=closes_at,json=closesAt,proto3" json:"closes_at,omitempty"` // Unix timestamp; 0 keeps the poll open until closed
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PollRequest) Reset() {
+	*x = PollRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollRequest) ProtoMessage() {}
+
+func (x *PollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollRequest.ProtoReflect.Descriptor instead.
+func (*PollRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PollRequest) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *PollRequest) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *PollRequest) GetMultipleChoice() bool {
+	if x != nil {
+		return x.MultipleChoice
+	}
+	return false
+}
+
+func (x *PollRequest) GetAnonymous() bool {
+	if x != nil {
+		return x.Anonymous
+	}
+	return false
+}
+
+func (x *PollRequest) GetClosesAt() int64 {
+	if x != nil {
+		return x.ClosesAt
+	}
+	return 0
+}
+
+// Message composed now and sent by the chat service at send_at
+type ScheduledMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Message       *SendMessageRequest    `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	SendAt        int64                  `protobuf:"varint,4,opt,name=send_at,json=sendAt,proto3" json:"send_at,omitempty"`          // Unix timestamp
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`                         // pending, sending, sent, failed, cancelled
+	MessageId     int64                  `protobuf:"varint,6,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"` // Set once sent
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledMessage) Reset() {
+	*x = ScheduledMessage{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledMessage) ProtoMessage() {}
+
+func (x *ScheduledMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledMessage.ProtoReflect.Descriptor instead.
+func (*ScheduledMessage) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ScheduledMessage) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ScheduledMessage) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ScheduledMessage) GetMessage() *SendMessageRequest {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ScheduledMessage) GetSendAt() int64 {
+	if x != nil {
+		return x.SendAt
+	}
+	return 0
+}
+
+func (x *ScheduledMessage) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *ScheduledMessage) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type ScheduleMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *SendMessageRequest    `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	SendAt        int64                  `protobuf:"varint,2,opt,name=send_at,json=sendAt,proto3" json:"send_at,omitempty"` // Unix timestamp, must be in the future
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleMessageRequest) Reset() {
+	*x = ScheduleMessageRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleMessageRequest) ProtoMessage() {}
+
+func (x *ScheduleMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleMessageRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleMessageRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ScheduleMessageRequest) GetMessage() *SendMessageRequest {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ScheduleMessageRequest) GetSendAt() int64 {
+	if x != nil {
+		return x.SendAt
+	}
+	return 0
+}
+
+type ListScheduledMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"` // Optional: only this room
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledMessagesRequest) Reset() {
+	*x = ListScheduledMessagesRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledMessagesRequest) ProtoMessage() {}
+
+func (x *ListScheduledMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListScheduledMessagesRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+type ListScheduledMessagesResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledMessages []*ScheduledMessage    `protobuf:"bytes,1,rep,name=scheduled_messages,json=scheduledMessages,proto3" json:"scheduled_messages,omitempty"` // Soonest first
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListScheduledMessagesResponse) Reset() {
+	*x = ListScheduledMessagesResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledMessagesResponse) ProtoMessage() {}
+
+func (x *ListScheduledMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListScheduledMessagesResponse) GetScheduledMessages() []*ScheduledMessage {
+	if x != nil {
+		return x.ScheduledMessages
+	}
+	return nil
+}
+
+type CancelScheduledMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledMessageRequest) Reset() {
+	*x = CancelScheduledMessageRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledMessageRequest) ProtoMessage() {}
+
+func (x *CancelScheduledMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledMessageRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledMessageRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CancelScheduledMessageRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CancelScheduledMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledMessageResponse) Reset() {
+	*x = CancelScheduledMessageResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledMessageResponse) ProtoMessage() {}
+
+func (x *CancelScheduledMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledMessageResponse.ProtoReflect.Descriptor instead.
+func (*CancelScheduledMessageResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CancelScheduledMessageResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// File upload response
+type UploadFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileUrl       string                 `protobuf:"bytes,1,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
+	FileName      string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileSize      int64                  `protobuf:"varint,3,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	MimeType      string                 `protobuf:"bytes,4,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	MessageType   string                 `protobuf:"bytes,5,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"` // "image" or "file" based on mime type
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadFileResponse) Reset() {
+	*x = UploadFileResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFileResponse) ProtoMessage() {}
+
+func (x *UploadFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadFileResponse.ProtoReflect.Descriptor instead.
+func (*UploadFileResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UploadFileResponse) GetFileUrl() string {
+	if x != nil {
+		return x.FileUrl
+	}
+	return ""
+}
+
+func (x *UploadFileResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *UploadFileResponse) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *UploadFileResponse) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *UploadFileResponse) GetMessageType() string {
+	if x != nil {
+		return x.MessageType
+	}
+	return ""
+}
+
+// At most one of before_id, after_id and around_id may be set;
+// with none set the latest messages are returned
+type GetMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                       // Number of messages to return
+	BeforeId      int64                  `protobuf:"varint,3,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"` // Get messages before this ID (scroll back)
+	AfterId       int64                  `protobuf:"varint,4,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`    // Get messages after this ID (scroll forward)
+	AroundId      int64                  `protobuf:"varint,5,opt,name=around_id,json=aroundId,proto3" json:"around_id,omitempty"` // Get messages centered on this ID (jump to message)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessagesRequest) Reset() {
+	*x = GetMessagesRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessagesRequest) ProtoMessage() {}
+
+func (x *GetMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessagesRequest.ProtoReflect.Descriptor instead.
+func (*GetMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetMessagesRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *GetMessagesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetMessagesRequest) GetBeforeId() int64 {
+	if x != nil {
+		return x.BeforeId
+	}
+	return 0
+}
+
+func (x *GetMessagesRequest) GetAfterId() int64 {
+	if x != nil {
+		return x.AfterId
+	}
+	return 0
+}
+
+func (x *GetMessagesRequest) GetAroundId() int64 {
+	if x != nil {
+		return x.AroundId
+	}
+	return 0
+}
+
+type GetMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`                  // Newest first
+	HasMore       bool                   `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`    // More messages in the paging direction (newer for after_id, older otherwise)
+	HasNewer      bool                   `protobuf:"varint,3,opt,name=has_newer,json=hasNewer,proto3" json:"has_newer,omitempty"` // around_id only: newer messages follow this page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessagesResponse) Reset() {
+	*x = GetMessagesResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessagesResponse) ProtoMessage() {}
+
+func (x *GetMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessagesResponse.ProtoReflect.Descriptor instead.
+func (*GetMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetMessagesResponse) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *GetMessagesResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *GetMessagesResponse) GetHasNewer() bool {
+	if x != nil {
+		return x.HasNewer
+	}
+	return false
+}
+
+// Search needs a query or a file name; the other fields narrow it down
+type SearchMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`                       // Web search syntax: words, "quoted phrases", -excluded
+	RoomId        int64                  `protobuf:"varint,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`      // Only this room
+	UserId        int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`      // Only messages by this author
+	From          int64                  `protobuf:"varint,4,opt,name=from,proto3" json:"from,omitempty"`                        // Unix timestamp, inclusive
+	To            int64                  `protobuf:"varint,5,opt,name=to,proto3" json:"to,omitempty"`                            // Unix timestamp, exclusive
+	Type          string                 `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`                         // text, image, file
+	FileName      string                 `protobuf:"bytes,7,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"` // Attachment file name contains this (case-insensitive)
+	Limit         int32                  `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	BeforeId      int64                  `protobuf:"varint,9,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"` // Get results before this message ID (for pagination)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMessagesRequest) Reset() {
+	*x = SearchMessagesRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesRequest) ProtoMessage() {}
+
+func (x *SearchMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesRequest.ProtoReflect.Descriptor instead.
+func (*SearchMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SearchMessagesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *SearchMessagesRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SearchMessagesRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *SearchMessagesRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *SearchMessagesRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchMessagesRequest) GetBeforeId() int64 {
+	if x != nil {
+		return x.BeforeId
+	}
+	return 0
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Snippet       string                 `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"` // HTML-escaped matching content with terms wrapped in <mark></mark>
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SearchResult) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *SearchResult) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+type SearchMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // Newest first
+	HasMore       bool                   `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMessagesResponse) Reset() {
+	*x = SearchMessagesResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesResponse) ProtoMessage() {}
+
+func (x *SearchMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesResponse.ProtoReflect.Descriptor instead.
+func (*SearchMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SearchMessagesResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchMessagesResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type GetThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"` // Thread root message
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	AfterId       int64                  `protobuf:"varint,3,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"` // Get replies after this ID (for pagination)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThreadRequest) Reset() {
+	*x = GetThreadRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThreadRequest) ProtoMessage() {}
+
+func (x *GetThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThreadRequest.ProtoReflect.Descriptor instead.
+func (*GetThreadRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetThreadRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *GetThreadRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetThreadRequest) GetAfterId() int64 {
+	if x != nil {
+		return x.AfterId
+	}
+	return 0
+}
+
+type GetThreadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Root          *Message               `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Replies       []*Message             `protobuf:"bytes,2,rep,name=replies,proto3" json:"replies,omitempty"` // Oldest first
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThreadResponse) Reset() {
+	*x = GetThreadResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThreadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThreadResponse) ProtoMessage() {}
+
+func (x *GetThreadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThreadResponse.ProtoReflect.Descriptor instead.
+func (*GetThreadResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetThreadResponse) GetRoot() *Message {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+func (x *GetThreadResponse) GetReplies() []*Message {
+	if x != nil {
+		return x.Replies
+	}
+	return nil
+}
+
+func (x *GetThreadResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type StreamMessagesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RoomId         int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	UserId         int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                           // ignored; the caller is taken from the token
+	AfterMessageId int64                  `protobuf:"varint,3,opt,name=after_message_id,json=afterMessageId,proto3" json:"after_message_id,omitempty"` // replay messages after this ID before live ones (0 = live only)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StreamMessagesRequest) Reset() {
+	*x = StreamMessagesRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamMessagesRequest) ProtoMessage() {}
+
+func (x *StreamMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamMessagesRequest.ProtoReflect.Descriptor instead.
+func (*StreamMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *StreamMessagesRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *StreamMessagesRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *StreamMessagesRequest) GetAfterMessageId() int64 {
+	if x != nil {
+		return x.AfterMessageId
+	}
+	return 0
+}
+
+type ForwardMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"` // Message to forward
+	RoomId        int64                  `protobuf:"varint,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`          // Destination room
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`                       // Optional text sent with the forward
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForwardMessageRequest) Reset() {
+	*x = ForwardMessageRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForwardMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardMessageRequest) ProtoMessage() {}
+
+func (x *ForwardMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardMessageRequest.ProtoReflect.Descriptor instead.
+func (*ForwardMessageRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ForwardMessageRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *ForwardMessageRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *ForwardMessageRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type EditMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMessageRequest) Reset() {
+	*x = EditMessageRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageRequest) ProtoMessage() {}
+
+func (x *EditMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageRequest.ProtoReflect.Descriptor instead.
+func (*EditMessageRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *EditMessageRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *EditMessageRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type DeleteMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMessageRequest) Reset() {
+	*x = DeleteMessageRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMessageRequest) ProtoMessage() {}
+
+func (x *DeleteMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMessageRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMessageRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeleteMessageRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+type DeleteMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMessageResponse) Reset() {
+	*x = DeleteMessageResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMessageResponse) ProtoMessage() {}
+
+func (x *DeleteMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMessageResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMessageResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeleteMessageResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type AddReactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Emoji         string                 `protobuf:"bytes,2,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddReactionRequest) Reset() {
+	*x = AddReactionRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReactionRequest) ProtoMessage() {}
+
+func (x *AddReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReactionRequest.ProtoReflect.Descriptor instead.
+func (*AddReactionRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AddReactionRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *AddReactionRequest) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+type AddReactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reactions     []*Reaction            `protobuf:"bytes,1,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddReactionResponse) Reset() {
+	*x = AddReactionResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddReactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReactionResponse) ProtoMessage() {}
+
+func (x *AddReactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReactionResponse.ProtoReflect.Descriptor instead.
+func (*AddReactionResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AddReactionResponse) GetReactions() []*Reaction {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+type RemoveReactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Emoji         string                 `protobuf:"bytes,2,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveReactionRequest) Reset() {
+	*x = RemoveReactionRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveReactionRequest) ProtoMessage() {}
+
+func (x *RemoveReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveReactionRequest.ProtoReflect.Descriptor instead.
+func (*RemoveReactionRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RemoveReactionRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *RemoveReactionRequest) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+type RemoveReactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reactions     []*Reaction            `protobuf:"bytes,1,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveReactionResponse) Reset() {
+	*x = RemoveReactionResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveReactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveReactionResponse) ProtoMessage() {}
+
+func (x *RemoveReactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveReactionResponse.ProtoReflect.Descriptor instead.
+func (*RemoveReactionResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RemoveReactionResponse) GetReactions() []*Reaction {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+type VotePollRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	OptionIds     []int32                `protobuf:"varint,2,rep,packed,name=option_ids,json=optionIds,proto3" json:"option_ids,omitempty"` // Empty retracts the caller's votes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VotePollRequest) Reset() {
+	*x = VotePollRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VotePollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VotePollRequest) ProtoMessage() {}
+
+func (x *VotePollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VotePollRequest.ProtoReflect.Descriptor instead.
+func (*VotePollRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *VotePollRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *VotePollRequest) GetOptionIds() []int32 {
+	if x != nil {
+		return x.OptionIds
+	}
+	return nil
+}
+
+type VotePollResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Poll          *Poll                  `protobuf:"bytes,1,opt,name=poll,proto3" json:"poll,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VotePollResponse) Reset() {
+	*x = VotePollResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VotePollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VotePollResponse) ProtoMessage() {}
+
+func (x *VotePollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VotePollResponse.ProtoReflect.Descriptor instead.
+func (*VotePollResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *VotePollResponse) GetPoll() *Poll {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+type ClosePollRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClosePollRequest) Reset() {
+	*x = ClosePollRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClosePollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClosePollRequest) ProtoMessage() {}
+
+func (x *ClosePollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClosePollRequest.ProtoReflect.Descriptor instead.
+func (*ClosePollRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ClosePollRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+type ClosePollResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Poll          *Poll                  `protobuf:"bytes,1,opt,name=poll,proto3" json:"poll,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClosePollResponse) Reset() {
+	*x = ClosePollResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClosePollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClosePollResponse) ProtoMessage() {}
+
+func (x *ClosePollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClosePollResponse.ProtoReflect.Descriptor instead.
+func (*ClosePollResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ClosePollResponse) GetPoll() *Poll {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+type PinMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinMessageRequest) Reset() {
+	*x = PinMessageRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinMessageRequest) ProtoMessage() {}
+
+func (x *PinMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinMessageRequest.ProtoReflect.Descriptor instead.
+func (*PinMessageRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PinMessageRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+type PinMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pin           *Pin                   `protobuf:"bytes,1,opt,name=pin,proto3" json:"pin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinMessageResponse) Reset() {
+	*x = PinMessageResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinMessageResponse) ProtoMessage() {}
+
+func (x *PinMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinMessageResponse.ProtoReflect.Descriptor instead.
+func (*PinMessageResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PinMessageResponse) GetPin() *Pin {
+	if x != nil {
+		return x.Pin
+	}
+	return nil
+}
+
+type UnpinMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpinMessageRequest) Reset() {
+	*x = UnpinMessageRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpinMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinMessageRequest) ProtoMessage() {}
+
+func (x *UnpinMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinMessageRequest.ProtoReflect.Descriptor instead.
+func (*UnpinMessageRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UnpinMessageRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+type UnpinMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpinMessageResponse) Reset() {
+	*x = UnpinMessageResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpinMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinMessageResponse) ProtoMessage() {}
+
+func (x *UnpinMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinMessageResponse.ProtoReflect.Descriptor instead.
+func (*UnpinMessageResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *UnpinMessageResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListPinnedMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPinnedMessagesRequest) Reset() {
+	*x = ListPinnedMessagesRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinnedMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinnedMessagesRequest) ProtoMessage() {}
+
+func (x *ListPinnedMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinnedMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListPinnedMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListPinnedMessagesRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+type ListPinnedMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pins          []*Pin                 `protobuf:"bytes,1,rep,name=pins,proto3" json:"pins,omitempty"` // Most recently pinned first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPinnedMessagesResponse) Reset() {
+	*x = ListPinnedMessagesResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinnedMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinnedMessagesResponse) ProtoMessage() {}
+
+func (x *ListPinnedMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinnedMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListPinnedMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListPinnedMessagesResponse) GetPins() []*Pin {
+	if x != nil {
+		return x.Pins
+	}
+	return nil
+}
+
+type ListMentionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	BeforeId      int64                  `protobuf:"varint,2,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"` // Get mentions before this ID (for pagination)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMentionsRequest) Reset() {
+	*x = ListMentionsRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMentionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMentionsRequest) ProtoMessage() {}
+
+func (x *ListMentionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMentionsRequest.ProtoReflect.Descriptor instead.
+func (*ListMentionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListMentionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListMentionsRequest) GetBeforeId() int64 {
+	if x != nil {
+		return x.BeforeId
+	}
+	return 0
+}
+
+type ListMentionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mentions      []*Mention             `protobuf:"bytes,1,rep,name=mentions,proto3" json:"mentions,omitempty"` // Newest first
+	HasMore       bool                   `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMentionsResponse) Reset() {
+	*x = ListMentionsResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMentionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMentionsResponse) ProtoMessage() {}
+
+func (x *ListMentionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMentionsResponse.ProtoReflect.Descriptor instead.
+func (*ListMentionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListMentionsResponse) GetMentions() []*Mention {
+	if x != nil {
+		return x.Mentions
+	}
+	return nil
+}
+
+func (x *ListMentionsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type MarkAsReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkAsReadRequest) Reset() {
+	*x = MarkAsReadRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkAsReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkAsReadRequest) ProtoMessage() {}
+
+func (x *MarkAsReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkAsReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkAsReadRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *MarkAsReadRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *MarkAsReadRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type MarkAsReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkAsReadResponse) Reset() {
+	*x = MarkAsReadResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkAsReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkAsReadResponse) ProtoMessage() {}
+
+func (x *MarkAsReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkAsReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkAsReadResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *MarkAsReadResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// A member's read position in a room: every message up to
+// last_read_message_id has been seen
+type ReadCursor struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	UserId            int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username          string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	LastReadMessageId int64                  `protobuf:"varint,3,opt,name=last_read_message_id,json=lastReadMessageId,proto3" json:"last_read_message_id,omitempty"`
+	ReadAt            int64                  `protobuf:"varint,4,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"` // Unix timestamp of the last move; 0 if never read
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ReadCursor) Reset() {
+	*x = ReadCursor{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadCursor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadCursor) ProtoMessage() {}
+
+func (x *ReadCursor) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadCursor.ProtoReflect.Descriptor instead.
+func (*ReadCursor) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ReadCursor) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ReadCursor) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ReadCursor) GetLastReadMessageId() int64 {
+	if x != nil {
+		return x.LastReadMessageId
+	}
+	return 0
+}
+
+func (x *ReadCursor) GetReadAt() int64 {
+	if x != nil {
+		return x.ReadAt
+	}
+	return 0
+}
+
+type MarkRoomReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	UpToMessageId int64                  `protobuf:"varint,2,opt,name=up_to_message_id,json=upToMessageId,proto3" json:"up_to_message_id,omitempty"` // 0 marks the latest message read
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkRoomReadRequest) Reset() {
+	*x = MarkRoomReadRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkRoomReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkRoomReadRequest) ProtoMessage() {}
+
+func (x *MarkRoomReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkRoomReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkRoomReadRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *MarkRoomReadRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *MarkRoomReadRequest) GetUpToMessageId() int64 {
+	if x != nil {
+		return x.UpToMessageId
+	}
+	return 0
+}
+
+type MarkRoomReadResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	LastReadMessageId int64                  `protobuf:"varint,1,opt,name=last_read_message_id,json=lastReadMessageId,proto3" json:"last_read_message_id,omitempty"` // Cursors never move back
+	UnreadCount       int32                  `protobuf:"varint,2,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`                       // Messages still unread after the cursor
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MarkRoomReadResponse) Reset() {
+	*x = MarkRoomReadResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkRoomReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkRoomReadResponse) ProtoMessage() {}
+
+func (x *MarkRoomReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkRoomReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkRoomReadResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *MarkRoomReadResponse) GetLastReadMessageId() int64 {
+	if x != nil {
+		return x.LastReadMessageId
+	}
+	return 0
+}
+
+func (x *MarkRoomReadResponse) GetUnreadCount() int32 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+type ListReadCursorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReadCursorsRequest) Reset() {
+	*x = ListReadCursorsRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReadCursorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReadCursorsRequest) ProtoMessage() {}
+
+func (x *ListReadCursorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReadCursorsRequest.ProtoReflect.Descriptor instead.
+func (*ListReadCursorsRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListReadCursorsRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+type ListReadCursorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursors       []*ReadCursor          `protobuf:"bytes,1,rep,name=cursors,proto3" json:"cursors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReadCursorsResponse) Reset() {
+	*x = ListReadCursorsResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReadCursorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReadCursorsResponse) ProtoMessage() {}
+
+func (x *ListReadCursorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReadCursorsResponse.ProtoReflect.Descriptor instead.
+func (*ListReadCursorsResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListReadCursorsResponse) GetCursors() []*ReadCursor {
+	if x != nil {
+		return x.Cursors
+	}
+	return nil
+}
+
+type GetMessageReadersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessageReadersRequest) Reset() {
+	*x = GetMessageReadersRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessageReadersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessageReadersRequest) ProtoMessage() {}
+
+func (x *GetMessageReadersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessageReadersRequest.ProtoReflect.Descriptor instead.
+func (*GetMessageReadersRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetMessageReadersRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+type GetMessageReadersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Readers       []*ReadCursor          `protobuf:"bytes,1,rep,name=readers,proto3" json:"readers,omitempty"` // Members other than the author whose cursor has reached the message
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessageReadersResponse) Reset() {
+	*x = GetMessageReadersResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessageReadersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessageReadersResponse) ProtoMessage() {}
+
+func (x *GetMessageReadersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessageReadersResponse.ProtoReflect.Descriptor instead.
+func (*GetMessageReadersResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetMessageReadersResponse) GetReaders() []*ReadCursor {
+	if x != nil {
+		return x.Readers
+	}
+	return nil
+}
+
+type GetUnreadMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnreadMessagesRequest) Reset() {
+	*x = GetUnreadMessagesRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnreadMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnreadMessagesRequest) ProtoMessage() {}
+
+func (x *GetUnreadMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnreadMessagesRequest.ProtoReflect.Descriptor instead.
+func (*GetUnreadMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetUnreadMessagesRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *GetUnreadMessagesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetUnreadMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"` // Oldest first, starting after the read cursor
+	HasMore       bool                   `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnreadMessagesResponse) Reset() {
+	*x = GetUnreadMessagesResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnreadMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnreadMessagesResponse) ProtoMessage() {}
+
+func (x *GetUnreadMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnreadMessagesResponse.ProtoReflect.Descriptor instead.
+func (*GetUnreadMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetUnreadMessagesResponse) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *GetUnreadMessagesResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type GetUnreadSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnreadSummaryRequest) Reset() {
+	*x = GetUnreadSummaryRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnreadSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnreadSummaryRequest) ProtoMessage() {}
+
+func (x *GetUnreadSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnreadSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetUnreadSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{60}
+}
+
+// The caller's unread state in one room
+type RoomUnread struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	RoomId               int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	UnreadCount          int32                  `protobuf:"varint,2,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`                                // Stops at 100, shown as "99+"
+	MentionCount         int32                  `protobuf:"varint,3,opt,name=mention_count,json=mentionCount,proto3" json:"mention_count,omitempty"`                             // Unread mentions of the caller
+	FirstUnreadMessageId int64                  `protobuf:"varint,4,opt,name=first_unread_message_id,json=firstUnreadMessageId,proto3" json:"first_unread_message_id,omitempty"` // 0 if nothing is unread
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RoomUnread) Reset() {
+	*x = RoomUnread{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomUnread) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomUnread) ProtoMessage() {}
+
+func (x *RoomUnread) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomUnread.ProtoReflect.Descriptor instead.
+func (*RoomUnread) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *RoomUnread) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *RoomUnread) GetUnreadCount() int32 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+func (x *RoomUnread) GetMentionCount() int32 {
+	if x != nil {
+		return x.MentionCount
+	}
+	return 0
+}
+
+func (x *RoomUnread) GetFirstUnreadMessageId() int64 {
+	if x != nil {
+		return x.FirstUnreadMessageId
+	}
+	return 0
+}
+
+type GetUnreadSummaryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rooms         []*RoomUnread          `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnreadSummaryResponse) Reset() {
+	*x = GetUnreadSummaryResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnreadSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnreadSummaryResponse) ProtoMessage() {}
+
+func (x *GetUnreadSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnreadSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetUnreadSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetUnreadSummaryResponse) GetRooms() []*RoomUnread {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+type CreateRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`                                // public, private
+	MessageTtl    int32                  `protobuf:"varint,4,opt,name=message_ttl,json=messageTtl,proto3" json:"message_ttl,omitempty"` // Default message lifetime in seconds; 0 means messages never expire
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoomRequest) Reset() {
+	*x = CreateRoomRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoomRequest) ProtoMessage() {}
+
+func (x *CreateRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoomRequest.ProtoReflect.Descriptor instead.
+func (*CreateRoomRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *CreateRoomRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateRoomRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateRoomRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateRoomRequest) GetMessageTtl() int32 {
+	if x != nil {
+		return x.MessageTtl
+	}
+	return 0
+}
+
+type GetRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoomRequest) Reset() {
+	*x = GetRoomRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoomRequest) ProtoMessage() {}
+
+func (x *GetRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoomRequest.ProtoReflect.Descriptor instead.
+func (*GetRoomRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetRoomRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListRoomsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoomsRequest) Reset() {
+	*x = ListRoomsRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoomsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoomsRequest) ProtoMessage() {}
+
+func (x *ListRoomsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoomsRequest.ProtoReflect.Descriptor instead.
+func (*ListRoomsRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ListRoomsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListRoomsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRoomsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListRoomsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rooms         []*Room                `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoomsResponse) Reset() {
+	*x = ListRoomsResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoomsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoomsResponse) ProtoMessage() {}
+
+func (x *ListRoomsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoomsResponse.ProtoReflect.Descriptor instead.
+func (*ListRoomsResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListRoomsResponse) GetRooms() []*Room {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+func (x *ListRoomsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type JoinRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinRoomRequest) Reset() {
+	*x = JoinRoomRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRoomRequest) ProtoMessage() {}
+
+func (x *JoinRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRoomRequest.ProtoReflect.Descriptor instead.
+func (*JoinRoomRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *JoinRoomRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *JoinRoomRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type JoinRoomResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Room          *Room                  `protobuf:"bytes,2,opt,name=room,proto3" json:"room,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinRoomResponse) Reset() {
+	*x = JoinRoomResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinRoomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRoomResponse) ProtoMessage() {}
+
+func (x *JoinRoomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRoomResponse.ProtoReflect.Descriptor instead.
+func (*JoinRoomResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *JoinRoomResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *JoinRoomResponse) GetRoom() *Room {
+	if x != nil {
+		return x.Room
+	}
+	return nil
+}
+
+type LeaveRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveRoomRequest) Reset() {
+	*x = LeaveRoomRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveRoomRequest) ProtoMessage() {}
+
+func (x *LeaveRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveRoomRequest.ProtoReflect.Descriptor instead.
+func (*LeaveRoomRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *LeaveRoomRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *LeaveRoomRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type LeaveRoomResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveRoomResponse) Reset() {
+	*x = LeaveRoomResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveRoomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveRoomResponse) ProtoMessage() {}
+
+func (x *LeaveRoomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveRoomResponse.ProtoReflect.Descriptor instead.
+func (*LeaveRoomResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *LeaveRoomResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type RenameRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameRoomRequest) Reset() {
+	*x = RenameRoomRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameRoomRequest) ProtoMessage() {}
+
+func (x *RenameRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameRoomRequest.ProtoReflect.Descriptor instead.
+func (*RenameRoomRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *RenameRoomRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *RenameRoomRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SetMemberRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"` // admin, moderator, member
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMemberRoleRequest) Reset() {
+	*x = SetMemberRoleRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMemberRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMemberRoleRequest) ProtoMessage() {}
+
+func (x *SetMemberRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMemberRoleRequest.ProtoReflect.Descriptor instead.
+func (*SetMemberRoleRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *SetMemberRoleRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *SetMemberRoleRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetMemberRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type SetMessageTTLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	MessageTtl    int32                  `protobuf:"varint,2,opt,name=message_ttl,json=messageTtl,proto3" json:"message_ttl,omitempty"` // Seconds; 0 turns expiry off for new messages
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMessageTTLRequest) Reset() {
+	*x = SetMessageTTLRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMessageTTLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMessageTTLRequest) ProtoMessage() {}
+
+func (x *SetMessageTTLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMessageTTLRequest.ProtoReflect.Descriptor instead.
+func (*SetMessageTTLRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *SetMessageTTLRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *SetMessageTTLRequest) GetMessageTtl() int32 {
+	if x != nil {
+		return x.MessageTtl
+	}
+	return 0
+}
+
+type SetRetentionPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Retention     *RetentionPolicy       `protobuf:"bytes,2,opt,name=retention,proto3" json:"retention,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRetentionPolicyRequest) Reset() {
+	*x = SetRetentionPolicyRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRetentionPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRetentionPolicyRequest) ProtoMessage() {}
+
+func (x *SetRetentionPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRetentionPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetRetentionPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *SetRetentionPolicyRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *SetRetentionPolicyRequest) GetRetention() *RetentionPolicy {
 	if x != nil {
-		return x.Success
+		return x.Retention
 	}
-	return false
+	return nil
 }
 
-type CreateRoomRequest struct {
+type ImportHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"` // public, private
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`                           // slack or jsonl
+	FileUrl       string                 `protobuf:"bytes,2,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`          // where the server downloads the export from
+	SlackToken    string                 `protobuf:"bytes,3,opt,name=slack_token,json=slackToken,proto3" json:"slack_token,omitempty"` // optional; sent when downloading Slack attachments
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateRoomRequest) Reset() {
-	*x = CreateRoomRequest{}
-	mi := &file_api_chat_v1_chat_proto_msgTypes[10]
+func (x *ImportHistoryRequest) Reset() {
+	*x = ImportHistoryRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateRoomRequest) String() string {
+func (x *ImportHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRoomRequest) ProtoMessage() {}
+func (*ImportHistoryRequest) ProtoMessage() {}
 
-func (x *CreateRoomRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_chat_v1_chat_proto_msgTypes[10]
+func (x *ImportHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -798,54 +4639,54 @@ func (x *CreateRoomRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateRoomRequest.ProtoReflect.Descriptor instead.
-func (*CreateRoomRequest) Descriptor() ([]byte, []int) {
-	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ImportHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ImportHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *CreateRoomRequest) GetName() string {
+func (x *ImportHistoryRequest) GetFormat() string {
 	if x != nil {
-		return x.Name
+		return x.Format
 	}
 	return ""
 }
 
-func (x *CreateRoomRequest) GetDescription() string {
+func (x *ImportHistoryRequest) GetFileUrl() string {
 	if x != nil {
-		return x.Description
+		return x.FileUrl
 	}
 	return ""
 }
 
-func (x *CreateRoomRequest) GetType() string {
+func (x *ImportHistoryRequest) GetSlackToken() string {
 	if x != nil {
-		return x.Type
+		return x.SlackToken
 	}
 	return ""
 }
 
-type GetRoomRequest struct {
+type GetImportJobRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetRoomRequest) Reset() {
-	*x = GetRoomRequest{}
-	mi := &file_api_chat_v1_chat_proto_msgTypes[11]
+func (x *GetImportJobRequest) Reset() {
+	*x = GetImportJobRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRoomRequest) String() string {
+func (x *GetImportJobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRoomRequest) ProtoMessage() {}
+func (*GetImportJobRequest) ProtoMessage() {}
 
-func (x *GetRoomRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_chat_v1_chat_proto_msgTypes[11]
+func (x *GetImportJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -856,42 +4697,47 @@ func (x *GetRoomRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRoomRequest.ProtoReflect.Descriptor instead.
-func (*GetRoomRequest) Descriptor() ([]byte, []int) {
-	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetImportJobRequest.ProtoReflect.Descriptor instead.
+func (*GetImportJobRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *GetRoomRequest) GetId() int64 {
+func (x *GetImportJobRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
-	return 0
+	return ""
 }
 
-type ListRoomsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// What an import did; a re-run counts what was already imported as skipped
+type ImportReport struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Rooms            int32                  `protobuf:"varint,1,opt,name=rooms,proto3" json:"rooms,omitempty"`
+	UsersMatched     int32                  `protobuf:"varint,2,opt,name=users_matched,json=usersMatched,proto3" json:"users_matched,omitempty"`
+	UsersCreated     int32                  `protobuf:"varint,3,opt,name=users_created,json=usersCreated,proto3" json:"users_created,omitempty"`
+	MessagesImported int32                  `protobuf:"varint,4,opt,name=messages_imported,json=messagesImported,proto3" json:"messages_imported,omitempty"`
+	MessagesSkipped  int32                  `protobuf:"varint,5,opt,name=messages_skipped,json=messagesSkipped,proto3" json:"messages_skipped,omitempty"`
+	FilesUploaded    int32                  `protobuf:"varint,6,opt,name=files_uploaded,json=filesUploaded,proto3" json:"files_uploaded,omitempty"`
+	FilesFailed      int32                  `protobuf:"varint,7,opt,name=files_failed,json=filesFailed,proto3" json:"files_failed,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *ListRoomsRequest) Reset() {
-	*x = ListRoomsRequest{}
-	mi := &file_api_chat_v1_chat_proto_msgTypes[12]
+func (x *ImportReport) Reset() {
+	*x = ImportReport{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRoomsRequest) String() string {
+func (x *ImportReport) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRoomsRequest) ProtoMessage() {}
+func (*ImportReport) ProtoMessage() {}
 
-func (x *ListRoomsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_chat_v1_chat_proto_msgTypes[12]
+func (x *ImportReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -902,55 +4748,88 @@ func (x *ListRoomsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRoomsRequest.ProtoReflect.Descriptor instead.
-func (*ListRoomsRequest) Descriptor() ([]byte, []int) {
-	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ImportReport.ProtoReflect.Descriptor instead.
+func (*ImportReport) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *ListRoomsRequest) GetUserId() int64 {
+func (x *ImportReport) GetRooms() int32 {
 	if x != nil {
-		return x.UserId
+		return x.Rooms
 	}
 	return 0
 }
 
-func (x *ListRoomsRequest) GetLimit() int32 {
+func (x *ImportReport) GetUsersMatched() int32 {
 	if x != nil {
-		return x.Limit
+		return x.UsersMatched
 	}
 	return 0
 }
 
-func (x *ListRoomsRequest) GetOffset() int32 {
+func (x *ImportReport) GetUsersCreated() int32 {
 	if x != nil {
-		return x.Offset
+		return x.UsersCreated
 	}
 	return 0
 }
 
-type ListRoomsResponse struct {
+func (x *ImportReport) GetMessagesImported() int32 {
+	if x != nil {
+		return x.MessagesImported
+	}
+	return 0
+}
+
+func (x *ImportReport) GetMessagesSkipped() int32 {
+	if x != nil {
+		return x.MessagesSkipped
+	}
+	return 0
+}
+
+func (x *ImportReport) GetFilesUploaded() int32 {
+	if x != nil {
+		return x.FilesUploaded
+	}
+	return 0
+}
+
+func (x *ImportReport) GetFilesFailed() int32 {
+	if x != nil {
+		return x.FilesFailed
+	}
+	return 0
+}
+
+type ImportJob struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Rooms         []*Room                `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // running, completed or failed
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Report        *ImportReport          `protobuf:"bytes,5,opt,name=report,proto3" json:"report,omitempty"` // filled in when the import finishes
+	StartedAt     int64                  `protobuf:"varint,6,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    int64                  `protobuf:"varint,7,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListRoomsResponse) Reset() {
-	*x = ListRoomsResponse{}
-	mi := &file_api_chat_v1_chat_proto_msgTypes[13]
+func (x *ImportJob) Reset() {
+	*x = ImportJob{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRoomsResponse) String() string {
+func (x *ImportJob) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRoomsResponse) ProtoMessage() {}
+func (*ImportJob) ProtoMessage() {}
 
-func (x *ListRoomsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_chat_v1_chat_proto_msgTypes[13]
+func (x *ImportJob) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -961,100 +4840,82 @@ func (x *ListRoomsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRoomsResponse.ProtoReflect.Descriptor instead.
-func (*ListRoomsResponse) Descriptor() ([]byte, []int) {
-	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ImportJob.ProtoReflect.Descriptor instead.
+func (*ImportJob) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *ListRoomsResponse) GetRooms() []*Room {
+func (x *ImportJob) GetId() string {
 	if x != nil {
-		return x.Rooms
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *ListRoomsResponse) GetTotal() int32 {
+func (x *ImportJob) GetFormat() string {
 	if x != nil {
-		return x.Total
+		return x.Format
 	}
-	return 0
-}
-
-type JoinRoomRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
-	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *JoinRoomRequest) Reset() {
-	*x = JoinRoomRequest{}
-	mi := &file_api_chat_v1_chat_proto_msgTypes[14]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return ""
 }
 
-func (x *JoinRoomRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ImportJob) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
 }
 
-func (*JoinRoomRequest) ProtoMessage() {}
-
-func (x *JoinRoomRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_chat_v1_chat_proto_msgTypes[14]
+func (x *ImportJob) GetError() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Error
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use JoinRoomRequest.ProtoReflect.Descriptor instead.
-func (*JoinRoomRequest) Descriptor() ([]byte, []int) {
-	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{14}
+func (x *ImportJob) GetReport() *ImportReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
 }
 
-func (x *JoinRoomRequest) GetRoomId() int64 {
+func (x *ImportJob) GetStartedAt() int64 {
 	if x != nil {
-		return x.RoomId
+		return x.StartedAt
 	}
 	return 0
 }
 
-func (x *JoinRoomRequest) GetUserId() int64 {
+func (x *ImportJob) GetFinishedAt() int64 {
 	if x != nil {
-		return x.UserId
+		return x.FinishedAt
 	}
 	return 0
 }
 
-type JoinRoomResponse struct {
+type GetPresenceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Room          *Room                  `protobuf:"bytes,2,opt,name=room,proto3" json:"room,omitempty"`
+	UserIds       []int64                `protobuf:"varint,1,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"` // at most 100
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *JoinRoomResponse) Reset() {
-	*x = JoinRoomResponse{}
-	mi := &file_api_chat_v1_chat_proto_msgTypes[15]
+func (x *GetPresenceRequest) Reset() {
+	*x = GetPresenceRequest{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JoinRoomResponse) String() string {
+func (x *GetPresenceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinRoomResponse) ProtoMessage() {}
+func (*GetPresenceRequest) ProtoMessage() {}
 
-func (x *JoinRoomResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_chat_v1_chat_proto_msgTypes[15]
+func (x *GetPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1065,48 +4926,43 @@ func (x *JoinRoomResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinRoomResponse.ProtoReflect.Descriptor instead.
-func (*JoinRoomResponse) Descriptor() ([]byte, []int) {
-	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{15}
-}
-
-func (x *JoinRoomResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
+// Deprecated: Use GetPresenceRequest.ProtoReflect.Descriptor instead.
+func (*GetPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{79}
 }
 
-func (x *JoinRoomResponse) GetRoom() *Room {
+func (x *GetPresenceRequest) GetUserIds() []int64 {
 	if x != nil {
-		return x.Room
+		return x.UserIds
 	}
 	return nil
 }
 
-type LeaveRoomRequest struct {
+type UserPresence struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
-	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                      // online, away or offline
+	LastSeen      int64                  `protobuf:"varint,3,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"` // now unless offline
+	Connections   int32                  `protobuf:"varint,4,opt,name=connections,proto3" json:"connections,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LeaveRoomRequest) Reset() {
-	*x = LeaveRoomRequest{}
-	mi := &file_api_chat_v1_chat_proto_msgTypes[16]
+func (x *UserPresence) Reset() {
+	*x = UserPresence{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LeaveRoomRequest) String() string {
+func (x *UserPresence) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LeaveRoomRequest) ProtoMessage() {}
+func (*UserPresence) ProtoMessage() {}
 
-func (x *LeaveRoomRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_chat_v1_chat_proto_msgTypes[16]
+func (x *UserPresence) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1117,47 +4973,61 @@ func (x *LeaveRoomRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LeaveRoomRequest.ProtoReflect.Descriptor instead.
-func (*LeaveRoomRequest) Descriptor() ([]byte, []int) {
-	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use UserPresence.ProtoReflect.Descriptor instead.
+func (*UserPresence) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *LeaveRoomRequest) GetRoomId() int64 {
+func (x *UserPresence) GetUserId() int64 {
 	if x != nil {
-		return x.RoomId
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *LeaveRoomRequest) GetUserId() int64 {
+func (x *UserPresence) GetStatus() string {
 	if x != nil {
-		return x.UserId
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UserPresence) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
 	}
 	return 0
 }
 
-type LeaveRoomResponse struct {
+func (x *UserPresence) GetConnections() int32 {
+	if x != nil {
+		return x.Connections
+	}
+	return 0
+}
+
+type GetPresenceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Presences     []*UserPresence        `protobuf:"bytes,1,rep,name=presences,proto3" json:"presences,omitempty"` // unknown users and users sharing no room with the caller are left out
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LeaveRoomResponse) Reset() {
-	*x = LeaveRoomResponse{}
-	mi := &file_api_chat_v1_chat_proto_msgTypes[17]
+func (x *GetPresenceResponse) Reset() {
+	*x = GetPresenceResponse{}
+	mi := &file_api_chat_v1_chat_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LeaveRoomResponse) String() string {
+func (x *GetPresenceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LeaveRoomResponse) ProtoMessage() {}
+func (*GetPresenceResponse) ProtoMessage() {}
 
-func (x *LeaveRoomResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_chat_v1_chat_proto_msgTypes[17]
+func (x *GetPresenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_chat_v1_chat_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1168,23 +5038,23 @@ func (x *LeaveRoomResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LeaveRoomResponse.ProtoReflect.Descriptor instead.
-func (*LeaveRoomResponse) Descriptor() ([]byte, []int) {
-	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use GetPresenceResponse.ProtoReflect.Descriptor instead.
+func (*GetPresenceResponse) Descriptor() ([]byte, []int) {
+	return file_api_chat_v1_chat_proto_rawDescGZIP(), []int{81}
 }
 
-func (x *LeaveRoomResponse) GetSuccess() bool {
+func (x *GetPresenceResponse) GetPresences() []*UserPresence {
 	if x != nil {
-		return x.Success
+		return x.Presences
 	}
-	return false
+	return nil
 }
 
 var File_api_chat_v1_chat_proto protoreflect.FileDescriptor
 
 const file_api_chat_v1_chat_proto_rawDesc = "" +
 	"\n" +
-	"\x16api/chat/v1/chat.proto\x12\vapi.chat.v1\x1a\x1cgoogle/api/annotations.proto\"\xe0\x02\n" +
+	"\x16api/chat/v1/chat.proto\x12\vapi.chat.v1\x1a\x1cgoogle/api/annotations.proto\"\xcb\a\n" +
 	"\aMessage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
 	"\aroom_id\x18\x02 \x01(\x03R\x06roomId\x12\x17\n" +
@@ -1200,7 +5070,88 @@ const file_api_chat_v1_chat_proto_rawDesc = "" +
 	" \x01(\tR\afileUrl\x12\x1b\n" +
 	"\tfile_name\x18\v \x01(\tR\bfileName\x12\x1b\n" +
 	"\tfile_size\x18\f \x01(\x03R\bfileSize\x12\x1b\n" +
-	"\tmime_type\x18\r \x01(\tR\bmimeType\"\xf4\x01\n" +
+	"\tmime_type\x18\r \x01(\tR\bmimeType\x12\x1d\n" +
+	"\n" +
+	"is_deleted\x18\x0e \x01(\bR\tisDeleted\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\x0f \x01(\x03R\tdeletedAt\x12*\n" +
+	"\x11parent_message_id\x18\x10 \x01(\x03R\x0fparentMessageId\x12\x1f\n" +
+	"\vreply_count\x18\x11 \x01(\x05R\n" +
+	"replyCount\x12\"\n" +
+	"\rlast_reply_at\x18\x12 \x01(\x03R\vlastReplyAt\x123\n" +
+	"\treactions\x18\x13 \x03(\v2\x15.api.chat.v1.ReactionR\treactions\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x14 \x01(\x03R\texpiresAt\x12A\n" +
+	"\x0equoted_message\x18\x15 \x01(\v2\x1a.api.chat.v1.QuotedMessageR\rquotedMessage\x12!\n" +
+	"\fis_forwarded\x18\x16 \x01(\bR\visForwarded\x12=\n" +
+	"\rlink_previews\x18\x17 \x03(\v2\x18.api.chat.v1.LinkPreviewR\flinkPreviews\x12\x16\n" +
+	"\x06format\x18\x18 \x01(\tR\x06format\x12!\n" +
+	"\fcontent_html\x18\x19 \x01(\tR\vcontentHtml\x12%\n" +
+	"\x04poll\x18\x1a \x01(\v2\x11.api.chat.v1.PollR\x04poll\x12;\n" +
+	"\fsystem_event\x18\x1b \x01(\v2\x18.api.chat.v1.SystemEventR\vsystemEvent\x12\"\n" +
+	"\rclient_msg_id\x18\x1c \x01(\tR\vclientMsgId\"\xd6\x01\n" +
+	"\vSystemEvent\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x19\n" +
+	"\bactor_id\x18\x02 \x01(\x03R\aactorId\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\x03R\btargetId\x12?\n" +
+	"\adetails\x18\x04 \x03(\v2%.api.chat.v1.SystemEvent.DetailsEntryR\adetails\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x91\x01\n" +
+	"\vLinkPreview\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
+	"\timage_url\x18\x04 \x01(\tR\bimageUrl\x12\x1b\n" +
+	"\tsite_name\x18\x05 \x01(\tR\bsiteName\"\x94\x02\n" +
+	"\x04Poll\x12\x1a\n" +
+	"\bquestion\x18\x01 \x01(\tR\bquestion\x121\n" +
+	"\aoptions\x18\x02 \x03(\v2\x17.api.chat.v1.PollOptionR\aoptions\x12'\n" +
+	"\x0fmultiple_choice\x18\x03 \x01(\bR\x0emultipleChoice\x12\x1c\n" +
+	"\tanonymous\x18\x04 \x01(\bR\tanonymous\x12\x1b\n" +
+	"\tcloses_at\x18\x05 \x01(\x03R\bclosesAt\x12\x1b\n" +
+	"\tis_closed\x18\x06 \x01(\bR\bisClosed\x12\x1b\n" +
+	"\tclosed_at\x18\a \x01(\x03R\bclosedAt\x12\x1f\n" +
+	"\vvoter_count\x18\b \x01(\x05R\n" +
+	"voterCount\"\x8c\x01\n" +
+	"\n" +
+	"PollOption\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x1d\n" +
+	"\n" +
+	"vote_count\x18\x03 \x01(\x05R\tvoteCount\x12\x1e\n" +
+	"\vvoted_by_me\x18\x04 \x01(\bR\tvotedByMe\x12\x1b\n" +
+	"\tvoter_ids\x18\x05 \x03(\x03R\bvoterIds\"\xbb\x02\n" +
+	"\rQuotedMessage\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\x03R\x06roomId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x03R\x06userId\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x12\x12\n" +
+	"\x04type\x18\x06 \x01(\tR\x04type\x12\x19\n" +
+	"\bfile_url\x18\a \x01(\tR\afileUrl\x12\x1b\n" +
+	"\tfile_name\x18\b \x01(\tR\bfileName\x12\x1b\n" +
+	"\tfile_size\x18\t \x01(\x03R\bfileSize\x12\x1b\n" +
+	"\tmime_type\x18\n" +
+	" \x01(\tR\bmimeType\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\x03R\tcreatedAt\"Z\n" +
+	"\bReaction\x12\x14\n" +
+	"\x05emoji\x18\x01 \x01(\tR\x05emoji\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\"\n" +
+	"\rreacted_by_me\x18\x03 \x01(\bR\vreactedByMe\"\x8b\x01\n" +
+	"\aMention\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12.\n" +
+	"\amessage\x18\x02 \x01(\v2\x14.api.chat.v1.MessageR\amessage\x12!\n" +
+	"\fmention_type\x18\x03 \x01(\tR\vmentionType\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\"\x9d\x01\n" +
+	"\x03Pin\x12.\n" +
+	"\amessage\x18\x01 \x01(\v2\x14.api.chat.v1.MessageR\amessage\x12\x1b\n" +
+	"\tpinned_by\x18\x02 \x01(\x03R\bpinnedBy\x12,\n" +
+	"\x12pinned_by_username\x18\x03 \x01(\tR\x10pinnedByUsername\x12\x1b\n" +
+	"\tpinned_at\x18\x04 \x01(\x03R\bpinnedAt\"\xd1\x02\n" +
 	"\x04Room\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -1211,13 +5162,20 @@ const file_api_chat_v1_chat_proto_rawDesc = "" +
 	"\fmember_count\x18\x06 \x01(\x05R\vmemberCount\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\a \x01(\x03R\tcreatedAt\x121\n" +
-	"\amembers\x18\b \x03(\v2\x17.api.chat.v1.RoomMemberR\amembers\"r\n" +
+	"\amembers\x18\b \x03(\v2\x17.api.chat.v1.RoomMemberR\amembers\x12\x1f\n" +
+	"\vmessage_ttl\x18\t \x01(\x05R\n" +
+	"messageTtl\x12:\n" +
+	"\tretention\x18\n" +
+	" \x01(\v2\x1c.api.chat.v1.RetentionPolicyR\tretention\";\n" +
+	"\x0fRetentionPolicy\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value\"r\n" +
 	"\n" +
 	"RoomMember\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x12\n" +
 	"\x04role\x18\x03 \x01(\tR\x04role\x12\x1b\n" +
-	"\tjoined_at\x18\x04 \x01(\x03R\bjoinedAt\"\xcd\x01\n" +
+	"\tjoined_at\x18\x04 \x01(\x03R\bjoinedAt\"\xae\x03\n" +
 	"\x12SendMessageRequest\x12\x17\n" +
 	"\aroom_id\x18\x01 \x01(\x03R\x06roomId\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12\x12\n" +
@@ -1225,33 +5183,193 @@ const file_api_chat_v1_chat_proto_rawDesc = "" +
 	"\bfile_url\x18\x04 \x01(\tR\afileUrl\x12\x1b\n" +
 	"\tfile_name\x18\x05 \x01(\tR\bfileName\x12\x1b\n" +
 	"\tfile_size\x18\x06 \x01(\x03R\bfileSize\x12\x1b\n" +
-	"\tmime_type\x18\a \x01(\tR\bmimeType\"\xa9\x01\n" +
+	"\tmime_type\x18\a \x01(\tR\bmimeType\x12*\n" +
+	"\x11parent_message_id\x18\b \x01(\x03R\x0fparentMessageId\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\t \x01(\x05R\texpiresIn\x12*\n" +
+	"\x11quoted_message_id\x18\n" +
+	" \x01(\x03R\x0fquotedMessageId\x12\x16\n" +
+	"\x06format\x18\v \x01(\tR\x06format\x12,\n" +
+	"\x04poll\x18\f \x01(\v2\x18.api.chat.v1.PollRequestR\x04poll\x12\"\n" +
+	"\rclient_msg_id\x18\r \x01(\tR\vclientMsgId\"\xa7\x01\n" +
+	"\vPollRequest\x12\x1a\n" +
+	"\bquestion\x18\x01 \x01(\tR\bquestion\x12\x18\n" +
+	"\aoptions\x18\x02 \x03(\tR\aoptions\x12'\n" +
+	"\x0fmultiple_choice\x18\x03 \x01(\bR\x0emultipleChoice\x12\x1c\n" +
+	"\tanonymous\x18\x04 \x01(\bR\tanonymous\x12\x1b\n" +
+	"\tcloses_at\x18\x05 \x01(\x03R\bclosesAt\"\xe5\x01\n" +
+	"\x10ScheduledMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x129\n" +
+	"\amessage\x18\x03 \x01(\v2\x1f.api.chat.v1.SendMessageRequestR\amessage\x12\x17\n" +
+	"\asend_at\x18\x04 \x01(\x03R\x06sendAt\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x06 \x01(\x03R\tmessageId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\"l\n" +
+	"\x16ScheduleMessageRequest\x129\n" +
+	"\amessage\x18\x01 \x01(\v2\x1f.api.chat.v1.SendMessageRequestR\amessage\x12\x17\n" +
+	"\asend_at\x18\x02 \x01(\x03R\x06sendAt\"7\n" +
+	"\x1cListScheduledMessagesRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x03R\x06roomId\"m\n" +
+	"\x1dListScheduledMessagesResponse\x12L\n" +
+	"\x12scheduled_messages\x18\x01 \x03(\v2\x1d.api.chat.v1.ScheduledMessageR\x11scheduledMessages\"/\n" +
+	"\x1dCancelScheduledMessageRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\":\n" +
+	"\x1eCancelScheduledMessageResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xa9\x01\n" +
 	"\x12UploadFileResponse\x12\x19\n" +
 	"\bfile_url\x18\x01 \x01(\tR\afileUrl\x12\x1b\n" +
 	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12\x1b\n" +
 	"\tfile_size\x18\x03 \x01(\x03R\bfileSize\x12\x1b\n" +
 	"\tmime_type\x18\x04 \x01(\tR\bmimeType\x12!\n" +
-	"\fmessage_type\x18\x05 \x01(\tR\vmessageType\"`\n" +
+	"\fmessage_type\x18\x05 \x01(\tR\vmessageType\"\x98\x01\n" +
 	"\x12GetMessagesRequest\x12\x17\n" +
 	"\aroom_id\x18\x01 \x01(\x03R\x06roomId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1b\n" +
-	"\tbefore_id\x18\x03 \x01(\x03R\bbeforeId\"b\n" +
+	"\tbefore_id\x18\x03 \x01(\x03R\bbeforeId\x12\x19\n" +
+	"\bafter_id\x18\x04 \x01(\x03R\aafterId\x12\x1b\n" +
+	"\taround_id\x18\x05 \x01(\x03R\baroundId\"\x7f\n" +
 	"\x13GetMessagesResponse\x120\n" +
 	"\bmessages\x18\x01 \x03(\v2\x14.api.chat.v1.MessageR\bmessages\x12\x19\n" +
-	"\bhas_more\x18\x02 \x01(\bR\ahasMore\"I\n" +
+	"\bhas_more\x18\x02 \x01(\bR\ahasMore\x12\x1b\n" +
+	"\thas_newer\x18\x03 \x01(\bR\bhasNewer\"\xe7\x01\n" +
+	"\x15SearchMessagesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\x03R\x06roomId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\x03R\x02to\x12\x12\n" +
+	"\x04type\x18\x06 \x01(\tR\x04type\x12\x1b\n" +
+	"\tfile_name\x18\a \x01(\tR\bfileName\x12\x14\n" +
+	"\x05limit\x18\b \x01(\x05R\x05limit\x12\x1b\n" +
+	"\tbefore_id\x18\t \x01(\x03R\bbeforeId\"X\n" +
+	"\fSearchResult\x12.\n" +
+	"\amessage\x18\x01 \x01(\v2\x14.api.chat.v1.MessageR\amessage\x12\x18\n" +
+	"\asnippet\x18\x02 \x01(\tR\asnippet\"h\n" +
+	"\x16SearchMessagesResponse\x123\n" +
+	"\aresults\x18\x01 \x03(\v2\x19.api.chat.v1.SearchResultR\aresults\x12\x19\n" +
+	"\bhas_more\x18\x02 \x01(\bR\ahasMore\"b\n" +
+	"\x10GetThreadRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x19\n" +
+	"\bafter_id\x18\x03 \x01(\x03R\aafterId\"\x88\x01\n" +
+	"\x11GetThreadResponse\x12(\n" +
+	"\x04root\x18\x01 \x01(\v2\x14.api.chat.v1.MessageR\x04root\x12.\n" +
+	"\areplies\x18\x02 \x03(\v2\x14.api.chat.v1.MessageR\areplies\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\"s\n" +
 	"\x15StreamMessagesRequest\x12\x17\n" +
 	"\aroom_id\x18\x01 \x01(\x03R\x06roomId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\x03R\x06userId\"K\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12(\n" +
+	"\x10after_message_id\x18\x03 \x01(\x03R\x0eafterMessageId\"i\n" +
+	"\x15ForwardMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\x03R\x06roomId\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\"M\n" +
+	"\x12EditMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\"5\n" +
+	"\x14DeleteMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\"1\n" +
+	"\x15DeleteMessageResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"I\n" +
+	"\x12AddReactionRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\x12\x14\n" +
+	"\x05emoji\x18\x02 \x01(\tR\x05emoji\"J\n" +
+	"\x13AddReactionResponse\x123\n" +
+	"\treactions\x18\x01 \x03(\v2\x15.api.chat.v1.ReactionR\treactions\"L\n" +
+	"\x15RemoveReactionRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\x12\x14\n" +
+	"\x05emoji\x18\x02 \x01(\tR\x05emoji\"M\n" +
+	"\x16RemoveReactionResponse\x123\n" +
+	"\treactions\x18\x01 \x03(\v2\x15.api.chat.v1.ReactionR\treactions\"O\n" +
+	"\x0fVotePollRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\x12\x1d\n" +
+	"\n" +
+	"option_ids\x18\x02 \x03(\x05R\toptionIds\"9\n" +
+	"\x10VotePollResponse\x12%\n" +
+	"\x04poll\x18\x01 \x01(\v2\x11.api.chat.v1.PollR\x04poll\"1\n" +
+	"\x10ClosePollRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\":\n" +
+	"\x11ClosePollResponse\x12%\n" +
+	"\x04poll\x18\x01 \x01(\v2\x11.api.chat.v1.PollR\x04poll\"2\n" +
+	"\x11PinMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\"8\n" +
+	"\x12PinMessageResponse\x12\"\n" +
+	"\x03pin\x18\x01 \x01(\v2\x10.api.chat.v1.PinR\x03pin\"4\n" +
+	"\x13UnpinMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\"0\n" +
+	"\x14UnpinMessageResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"4\n" +
+	"\x19ListPinnedMessagesRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x03R\x06roomId\"B\n" +
+	"\x1aListPinnedMessagesResponse\x12$\n" +
+	"\x04pins\x18\x01 \x03(\v2\x10.api.chat.v1.PinR\x04pins\"H\n" +
+	"\x13ListMentionsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x1b\n" +
+	"\tbefore_id\x18\x02 \x01(\x03R\bbeforeId\"c\n" +
+	"\x14ListMentionsResponse\x120\n" +
+	"\bmentions\x18\x01 \x03(\v2\x14.api.chat.v1.MentionR\bmentions\x12\x19\n" +
+	"\bhas_more\x18\x02 \x01(\bR\ahasMore\"K\n" +
 	"\x11MarkAsReadRequest\x12\x1d\n" +
 	"\n" +
 	"message_id\x18\x01 \x01(\x03R\tmessageId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x03R\x06userId\".\n" +
 	"\x12MarkAsReadResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"]\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x8b\x01\n" +
+	"\n" +
+	"ReadCursor\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12/\n" +
+	"\x14last_read_message_id\x18\x03 \x01(\x03R\x11lastReadMessageId\x12\x17\n" +
+	"\aread_at\x18\x04 \x01(\x03R\x06readAt\"W\n" +
+	"\x13MarkRoomReadRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x03R\x06roomId\x12'\n" +
+	"\x10up_to_message_id\x18\x02 \x01(\x03R\rupToMessageId\"j\n" +
+	"\x14MarkRoomReadResponse\x12/\n" +
+	"\x14last_read_message_id\x18\x01 \x01(\x03R\x11lastReadMessageId\x12!\n" +
+	"\funread_count\x18\x02 \x01(\x05R\vunreadCount\"1\n" +
+	"\x16ListReadCursorsRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x03R\x06roomId\"L\n" +
+	"\x17ListReadCursorsResponse\x121\n" +
+	"\acursors\x18\x01 \x03(\v2\x17.api.chat.v1.ReadCursorR\acursors\"9\n" +
+	"\x18GetMessageReadersRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\"N\n" +
+	"\x19GetMessageReadersResponse\x121\n" +
+	"\areaders\x18\x01 \x03(\v2\x17.api.chat.v1.ReadCursorR\areaders\"I\n" +
+	"\x18GetUnreadMessagesRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x03R\x06roomId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"h\n" +
+	"\x19GetUnreadMessagesResponse\x120\n" +
+	"\bmessages\x18\x01 \x03(\v2\x14.api.chat.v1.MessageR\bmessages\x12\x19\n" +
+	"\bhas_more\x18\x02 \x01(\bR\ahasMore\"\x19\n" +
+	"\x17GetUnreadSummaryRequest\"\xa4\x01\n" +
+	"\n" +
+	"RoomUnread\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x03R\x06roomId\x12!\n" +
+	"\funread_count\x18\x02 \x01(\x05R\vunreadCount\x12#\n" +
+	"\rmention_count\x18\x03 \x01(\x05R\fmentionCount\x125\n" +
+	"\x17first_unread_message_id\x18\x04 \x01(\x03R\x14firstUnreadMessageId\"I\n" +
+	"\x18GetUnreadSummaryResponse\x12-\n" +
+	"\x05rooms\x18\x01 \x03(\v2\x17.api.chat.v1.RoomUnreadR\x05rooms\"~\n" +
 	"\x11CreateRoomRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
-	"\x04type\x18\x03 \x01(\tR\x04type\" \n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1f\n" +
+	"\vmessage_ttl\x18\x04 \x01(\x05R\n" +
+	"messageTtl\" \n" +
 	"\x0eGetRoomRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\"Y\n" +
 	"\x10ListRoomsRequest\x12\x17\n" +
@@ -1271,20 +5389,100 @@ const file_api_chat_v1_chat_proto_rawDesc = "" +
 	"\aroom_id\x18\x01 \x01(\x03R\x06roomId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x03R\x06userId\"-\n" +
 	"\x11LeaveRoomResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xb8\x03\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"@\n" +
+	"\x11RenameRoomRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x03R\x06roomId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\\\n" +
+	"\x14SetMemberRoleRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x03R\x06roomId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"P\n" +
+	"\x14SetMessageTTLRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x03R\x06roomId\x12\x1f\n" +
+	"\vmessage_ttl\x18\x02 \x01(\x05R\n" +
+	"messageTtl\"p\n" +
+	"\x19SetRetentionPolicyRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x03R\x06roomId\x12:\n" +
+	"\tretention\x18\x02 \x01(\v2\x1c.api.chat.v1.RetentionPolicyR\tretention\"j\n" +
+	"\x14ImportHistoryRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x19\n" +
+	"\bfile_url\x18\x02 \x01(\tR\afileUrl\x12\x1f\n" +
+	"\vslack_token\x18\x03 \x01(\tR\n" +
+	"slackToken\"%\n" +
+	"\x13GetImportJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x90\x02\n" +
+	"\fImportReport\x12\x14\n" +
+	"\x05rooms\x18\x01 \x01(\x05R\x05rooms\x12#\n" +
+	"\rusers_matched\x18\x02 \x01(\x05R\fusersMatched\x12#\n" +
+	"\rusers_created\x18\x03 \x01(\x05R\fusersCreated\x12+\n" +
+	"\x11messages_imported\x18\x04 \x01(\x05R\x10messagesImported\x12)\n" +
+	"\x10messages_skipped\x18\x05 \x01(\x05R\x0fmessagesSkipped\x12%\n" +
+	"\x0efiles_uploaded\x18\x06 \x01(\x05R\rfilesUploaded\x12!\n" +
+	"\ffiles_failed\x18\a \x01(\x05R\vfilesFailed\"\xd4\x01\n" +
+	"\tImportJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x121\n" +
+	"\x06report\x18\x05 \x01(\v2\x19.api.chat.v1.ImportReportR\x06report\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x06 \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\a \x01(\x03R\n" +
+	"finishedAt\"/\n" +
+	"\x12GetPresenceRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\x03R\auserIds\"~\n" +
+	"\fUserPresence\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1b\n" +
+	"\tlast_seen\x18\x03 \x01(\x03R\blastSeen\x12 \n" +
+	"\vconnections\x18\x04 \x01(\x05R\vconnections\"N\n" +
+	"\x13GetPresenceResponse\x127\n" +
+	"\tpresences\x18\x01 \x03(\v2\x19.api.chat.v1.UserPresenceR\tpresences2\xfd\x18\n" +
 	"\vChatService\x12a\n" +
-	"\vSendMessage\x12\x1f.api.chat.v1.SendMessageRequest\x1a\x14.api.chat.v1.Message\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/api/v1/messages\x12z\n" +
-	"\vGetMessages\x12\x1f.api.chat.v1.GetMessagesRequest\x1a .api.chat.v1.GetMessagesResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /api/v1/rooms/{room_id}/messages\x12L\n" +
+	"\vSendMessage\x12\x1f.api.chat.v1.SendMessageRequest\x1a\x14.api.chat.v1.Message\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/api/v1/messages\x12|\n" +
+	"\x0fScheduleMessage\x12#.api.chat.v1.ScheduleMessageRequest\x1a\x1d.api.chat.v1.ScheduledMessage\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/api/v1/messages/scheduled\x12\x92\x01\n" +
+	"\x15ListScheduledMessages\x12).api.chat.v1.ListScheduledMessagesRequest\x1a*.api.chat.v1.ListScheduledMessagesResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/api/v1/messages/scheduled\x12\x9a\x01\n" +
+	"\x16CancelScheduledMessage\x12*.api.chat.v1.CancelScheduledMessageRequest\x1a+.api.chat.v1.CancelScheduledMessageResponse\"'\x82\xd3\xe4\x93\x02!*\x1f/api/v1/messages/scheduled/{id}\x12z\n" +
+	"\vGetMessages\x12\x1f.api.chat.v1.GetMessagesRequest\x1a .api.chat.v1.GetMessagesResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /api/v1/rooms/{room_id}/messages\x12z\n" +
+	"\x0eSearchMessages\x12\".api.chat.v1.SearchMessagesRequest\x1a#.api.chat.v1.SearchMessagesResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/messages/search\x12x\n" +
+	"\tGetThread\x12\x1d.api.chat.v1.GetThreadRequest\x1a\x1e.api.chat.v1.GetThreadResponse\",\x82\xd3\xe4\x93\x02&\x12$/api/v1/messages/{message_id}/thread\x12L\n" +
 	"\x0eStreamMessages\x12\".api.chat.v1.StreamMessagesRequest\x1a\x14.api.chat.v1.Message0\x01\x12|\n" +
+	"\x0eForwardMessage\x12\".api.chat.v1.ForwardMessageRequest\x1a\x14.api.chat.v1.Message\"0\x82\xd3\xe4\x93\x02*:\x01*\"%/api/v1/messages/{message_id}/forward\x12n\n" +
+	"\vEditMessage\x12\x1f.api.chat.v1.EditMessageRequest\x1a\x14.api.chat.v1.Message\"(\x82\xd3\xe4\x93\x02\":\x01*\x1a\x1d/api/v1/messages/{message_id}\x12}\n" +
+	"\rDeleteMessage\x12!.api.chat.v1.DeleteMessageRequest\x1a\".api.chat.v1.DeleteMessageResponse\"%\x82\xd3\xe4\x93\x02\x1f*\x1d/api/v1/messages/{message_id}\x12\x84\x01\n" +
+	"\vAddReaction\x12\x1f.api.chat.v1.AddReactionRequest\x1a .api.chat.v1.AddReactionResponse\"2\x82\xd3\xe4\x93\x02,:\x01*\"'/api/v1/messages/{message_id}/reactions\x12\x8a\x01\n" +
+	"\x0eRemoveReaction\x12\".api.chat.v1.RemoveReactionRequest\x1a#.api.chat.v1.RemoveReactionResponse\"/\x82\xd3\xe4\x93\x02)*'/api/v1/messages/{message_id}/reactions\x12|\n" +
+	"\bVotePoll\x12\x1c.api.chat.v1.VotePollRequest\x1a\x1d.api.chat.v1.VotePollResponse\"3\x82\xd3\xe4\x93\x02-:\x01*\"(/api/v1/messages/{message_id}/poll/votes\x12\x7f\n" +
+	"\tClosePoll\x12\x1d.api.chat.v1.ClosePollRequest\x1a\x1e.api.chat.v1.ClosePollResponse\"3\x82\xd3\xe4\x93\x02-:\x01*\"(/api/v1/messages/{message_id}/poll/close\x12{\n" +
 	"\n" +
-	"MarkAsRead\x12\x1e.api.chat.v1.MarkAsReadRequest\x1a\x1f.api.chat.v1.MarkAsReadResponse\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/api/v1/messages/{message_id}/read2\x9a\x04\n" +
+	"PinMessage\x12\x1e.api.chat.v1.PinMessageRequest\x1a\x1f.api.chat.v1.PinMessageResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/api/v1/messages/{message_id}/pin\x12~\n" +
+	"\fUnpinMessage\x12 .api.chat.v1.UnpinMessageRequest\x1a!.api.chat.v1.UnpinMessageResponse\")\x82\xd3\xe4\x93\x02#*!/api/v1/messages/{message_id}/pin\x12\x8b\x01\n" +
+	"\x12ListPinnedMessages\x12&.api.chat.v1.ListPinnedMessagesRequest\x1a'.api.chat.v1.ListPinnedMessagesResponse\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/api/v1/rooms/{room_id}/pins\x12m\n" +
+	"\fListMentions\x12 .api.chat.v1.ListMentionsRequest\x1a!.api.chat.v1.ListMentionsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v1/mentions\x12|\n" +
+	"\n" +
+	"MarkAsRead\x12\x1e.api.chat.v1.MarkAsReadRequest\x1a\x1f.api.chat.v1.MarkAsReadResponse\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/api/v1/messages/{message_id}/read\x12|\n" +
+	"\fMarkRoomRead\x12 .api.chat.v1.MarkRoomReadRequest\x1a!.api.chat.v1.MarkRoomReadResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/rooms/{room_id}/read\x12\x8a\x01\n" +
+	"\x0fListReadCursors\x12#.api.chat.v1.ListReadCursorsRequest\x1a$.api.chat.v1.ListReadCursorsResponse\",\x82\xd3\xe4\x93\x02&\x12$/api/v1/rooms/{room_id}/read_cursors\x12\x91\x01\n" +
+	"\x11GetMessageReaders\x12%.api.chat.v1.GetMessageReadersRequest\x1a&.api.chat.v1.GetMessageReadersResponse\"-\x82\xd3\xe4\x93\x02'\x12%/api/v1/messages/{message_id}/readers\x12\x8a\x01\n" +
+	"\x11GetUnreadMessages\x12%.api.chat.v1.GetUnreadMessagesRequest\x1a&.api.chat.v1.GetUnreadMessagesResponse\"&\x82\xd3\xe4\x93\x02 \x12\x1e/api/v1/rooms/{room_id}/unread\x12w\n" +
+	"\x10GetUnreadSummary\x12$.api.chat.v1.GetUnreadSummaryRequest\x1a%.api.chat.v1.GetUnreadSummaryResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/api/v1/unread2\x83\b\n" +
 	"\vRoomService\x12Y\n" +
 	"\n" +
 	"CreateRoom\x12\x1e.api.chat.v1.CreateRoomRequest\x1a\x11.api.chat.v1.Room\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/api/v1/rooms\x12U\n" +
 	"\aGetRoom\x12\x1b.api.chat.v1.GetRoomRequest\x1a\x11.api.chat.v1.Room\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/rooms/{id}\x12q\n" +
 	"\tListRooms\x12\x1d.api.chat.v1.ListRoomsRequest\x1a\x1e.api.chat.v1.ListRoomsResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/api/v1/users/{user_id}/rooms\x12p\n" +
 	"\bJoinRoom\x12\x1c.api.chat.v1.JoinRoomRequest\x1a\x1d.api.chat.v1.JoinRoomResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/rooms/{room_id}/join\x12t\n" +
-	"\tLeaveRoom\x12\x1d.api.chat.v1.LeaveRoomRequest\x1a\x1e.api.chat.v1.LeaveRoomResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/v1/rooms/{room_id}/leaveB1Z/github.com/yourusername/chat-app/api/chat/v1;v1b\x06proto3"
+	"\tLeaveRoom\x12\x1d.api.chat.v1.LeaveRoomRequest\x1a\x1e.api.chat.v1.LeaveRoomResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/v1/rooms/{room_id}/leave\x12u\n" +
+	"\rSetMessageTTL\x12!.api.chat.v1.SetMessageTTLRequest\x1a\x11.api.chat.v1.Room\".\x82\xd3\xe4\x93\x02(:\x01*\x1a#/api/v1/rooms/{room_id}/message_ttl\x12}\n" +
+	"\x12SetRetentionPolicy\x12&.api.chat.v1.SetRetentionPolicyRequest\x1a\x11.api.chat.v1.Room\",\x82\xd3\xe4\x93\x02&:\x01*\x1a!/api/v1/rooms/{room_id}/retention\x12h\n" +
+	"\n" +
+	"RenameRoom\x12\x1e.api.chat.v1.RenameRoomRequest\x1a\x11.api.chat.v1.Room\"'\x82\xd3\xe4\x93\x02!:\x01*\x1a\x1c/api/v1/rooms/{room_id}/name\x12\x86\x01\n" +
+	"\rSetMemberRole\x12!.api.chat.v1.SetMemberRoleRequest\x1a\x17.api.chat.v1.RoomMember\"9\x82\xd3\xe4\x93\x023:\x01*\x1a./api/v1/rooms/{room_id}/members/{user_id}/role2\xdf\x01\n" +
+	"\rImportService\x12f\n" +
+	"\rImportHistory\x12!.api.chat.v1.ImportHistoryRequest\x1a\x16.api.chat.v1.ImportJob\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/api/v1/imports\x12f\n" +
+	"\fGetImportJob\x12 .api.chat.v1.GetImportJobRequest\x1a\x16.api.chat.v1.ImportJob\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/imports/{id}2}\n" +
+	"\x0fPresenceService\x12j\n" +
+	"\vGetPresence\x12\x1f.api.chat.v1.GetPresenceRequest\x1a .api.chat.v1.GetPresenceResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v1/presenceB1Z/github.com/yourusername/chat-app/api/chat/v1;v1b\x06proto3"
 
 var (
 	file_api_chat_v1_chat_proto_rawDescOnce sync.Once
@@ -1298,55 +5496,208 @@ func file_api_chat_v1_chat_proto_rawDescGZIP() []byte {
 	return file_api_chat_v1_chat_proto_rawDescData
 }
 
-var file_api_chat_v1_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_api_chat_v1_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 83)
 var file_api_chat_v1_chat_proto_goTypes = []any{
-	(*Message)(nil),               // 0: api.chat.v1.Message
-	(*Room)(nil),                  // 1: api.chat.v1.Room
-	(*RoomMember)(nil),            // 2: api.chat.v1.RoomMember
-	(*SendMessageRequest)(nil),    // 3: api.chat.v1.SendMessageRequest
-	(*UploadFileResponse)(nil),    // 4: api.chat.v1.UploadFileResponse
-	(*GetMessagesRequest)(nil),    // 5: api.chat.v1.GetMessagesRequest
-	(*GetMessagesResponse)(nil),   // 6: api.chat.v1.GetMessagesResponse
-	(*StreamMessagesRequest)(nil), // 7: api.chat.v1.StreamMessagesRequest
-	(*MarkAsReadRequest)(nil),     // 8: api.chat.v1.MarkAsReadRequest
-	(*MarkAsReadResponse)(nil),    // 9: api.chat.v1.MarkAsReadResponse
-	(*CreateRoomRequest)(nil),     // 10: api.chat.v1.CreateRoomRequest
-	(*GetRoomRequest)(nil),        // 11: api.chat.v1.GetRoomRequest
-	(*ListRoomsRequest)(nil),      // 12: api.chat.v1.ListRoomsRequest
-	(*ListRoomsResponse)(nil),     // 13: api.chat.v1.ListRoomsResponse
-	(*JoinRoomRequest)(nil),       // 14: api.chat.v1.JoinRoomRequest
-	(*JoinRoomResponse)(nil),      // 15: api.chat.v1.JoinRoomResponse
-	(*LeaveRoomRequest)(nil),      // 16: api.chat.v1.LeaveRoomRequest
-	(*LeaveRoomResponse)(nil),     // 17: api.chat.v1.LeaveRoomResponse
+	(*Message)(nil),                        // 0: api.chat.v1.Message
+	(*SystemEvent)(nil),                    // 1: api.chat.v1.SystemEvent
+	(*LinkPreview)(nil),                    // 2: api.chat.v1.LinkPreview
+	(*Poll)(nil),                           // 3: api.chat.v1.Poll
+	(*PollOption)(nil),                     // 4: api.chat.v1.PollOption
+	(*QuotedMessage)(nil),                  // 5: api.chat.v1.QuotedMessage
+	(*Reaction)(nil),                       // 6: api.chat.v1.Reaction
+	(*Mention)(nil),                        // 7: api.chat.v1.Mention
+	(*Pin)(nil),                            // 8: api.chat.v1.Pin
+	(*Room)(nil),                           // 9: api.chat.v1.Room
+	(*RetentionPolicy)(nil),                // 10: api.chat.v1.RetentionPolicy
+	(*RoomMember)(nil),                     // 11: api.chat.v1.RoomMember
+	(*SendMessageRequest)(nil),             // 12: api.chat.v1.SendMessageRequest
+	(*PollRequest)(nil),                    // 13: api.chat.v1.PollRequest
+	(*ScheduledMessage)(nil),               // 14: api.chat.v1.ScheduledMessage
+	(*ScheduleMessageRequest)(nil),         // 15: api.chat.v1.ScheduleMessageRequest
+	(*ListScheduledMessagesRequest)(nil),   // 16: api.chat.v1.ListScheduledMessagesRequest
+	(*ListScheduledMessagesResponse)(nil),  // 17: api.chat.v1.ListScheduledMessagesResponse
+	(*CancelScheduledMessageRequest)(nil),  // 18: api.chat.v1.CancelScheduledMessageRequest
+	(*CancelScheduledMessageResponse)(nil), // 19: api.chat.v1.CancelScheduledMessageResponse
+	(*UploadFileResponse)(nil),             // 20: api.chat.v1.UploadFileResponse
+	(*GetMessagesRequest)(nil),             // 21: api.chat.v1.GetMessagesRequest
+	(*GetMessagesResponse)(nil),            // 22: api.chat.v1.GetMessagesResponse
+	(*SearchMessagesRequest)(nil),          // 23: api.chat.v1.SearchMessagesRequest
+	(*SearchResult)(nil),                   // 24: api.chat.v1.SearchResult
+	(*SearchMessagesResponse)(nil),         // 25: api.chat.v1.SearchMessagesResponse
+	(*GetThreadRequest)(nil),               // 26: api.chat.v1.GetThreadRequest
+	(*GetThreadResponse)(nil),              // 27: api.chat.v1.GetThreadResponse
+	(*StreamMessagesRequest)(nil),          // 28: api.chat.v1.StreamMessagesRequest
+	(*ForwardMessageRequest)(nil),          // 29: api.chat.v1.ForwardMessageRequest
+	(*EditMessageRequest)(nil),             // 30: api.chat.v1.EditMessageRequest
+	(*DeleteMessageRequest)(nil),           // 31: api.chat.v1.DeleteMessageRequest
+	(*DeleteMessageResponse)(nil),          // 32: api.chat.v1.DeleteMessageResponse
+	(*AddReactionRequest)(nil),             // 33: api.chat.v1.AddReactionRequest
+	(*AddReactionResponse)(nil),            // 34: api.chat.v1.AddReactionResponse
+	(*RemoveReactionRequest)(nil),          // 35: api.chat.v1.RemoveReactionRequest
+	(*RemoveReactionResponse)(nil),         // 36: api.chat.v1.RemoveReactionResponse
+	(*VotePollRequest)(nil),                // 37: api.chat.v1.VotePollRequest
+	(*VotePollResponse)(nil),               // 38: api.chat.v1.VotePollResponse
+	(*ClosePollRequest)(nil),               // 39: api.chat.v1.ClosePollRequest
+	(*ClosePollResponse)(nil),              // 40: api.chat.v1.ClosePollResponse
+	(*PinMessageRequest)(nil),              // 41: api.chat.v1.PinMessageRequest
+	(*PinMessageResponse)(nil),             // 42: api.chat.v1.PinMessageResponse
+	(*UnpinMessageRequest)(nil),            // 43: api.chat.v1.UnpinMessageRequest
+	(*UnpinMessageResponse)(nil),           // 44: api.chat.v1.UnpinMessageResponse
+	(*ListPinnedMessagesRequest)(nil),      // 45: api.chat.v1.ListPinnedMessagesRequest
+	(*ListPinnedMessagesResponse)(nil),     // 46: api.chat.v1.ListPinnedMessagesResponse
+	(*ListMentionsRequest)(nil),            // 47: api.chat.v1.ListMentionsRequest
+	(*ListMentionsResponse)(nil),           // 48: api.chat.v1.ListMentionsResponse
+	(*MarkAsReadRequest)(nil),              // 49: api.chat.v1.MarkAsReadRequest
+	(*MarkAsReadResponse)(nil),             // 50: api.chat.v1.MarkAsReadResponse
+	(*ReadCursor)(nil),                     // 51: api.chat.v1.ReadCursor
+	(*MarkRoomReadRequest)(nil),            // 52: api.chat.v1.MarkRoomReadRequest
+	(*MarkRoomReadResponse)(nil),           // 53: api.chat.v1.MarkRoomReadResponse
+	(*ListReadCursorsRequest)(nil),         // 54: api.chat.v1.ListReadCursorsRequest
+	(*ListReadCursorsResponse)(nil),        // 55: api.chat.v1.ListReadCursorsResponse
+	(*GetMessageReadersRequest)(nil),       // 56: api.chat.v1.GetMessageReadersRequest
+	(*GetMessageReadersResponse)(nil),      // 57: api.chat.v1.GetMessageReadersResponse
+	(*GetUnreadMessagesRequest)(nil),       // 58: api.chat.v1.GetUnreadMessagesRequest
+	(*GetUnreadMessagesResponse)(nil),      // 59: api.chat.v1.GetUnreadMessagesResponse
+	(*GetUnreadSummaryRequest)(nil),        // 60: api.chat.v1.GetUnreadSummaryRequest
+	(*RoomUnread)(nil),                     // 61: api.chat.v1.RoomUnread
+	(*GetUnreadSummaryResponse)(nil),       // 62: api.chat.v1.GetUnreadSummaryResponse
+	(*CreateRoomRequest)(nil),              // 63: api.chat.v1.CreateRoomRequest
+	(*GetRoomRequest)(nil),                 // 64: api.chat.v1.GetRoomRequest
+	(*ListRoomsRequest)(nil),               // 65: api.chat.v1.ListRoomsRequest
+	(*ListRoomsResponse)(nil),              // 66: api.chat.v1.ListRoomsResponse
+	(*JoinRoomRequest)(nil),                // 67: api.chat.v1.JoinRoomRequest
+	(*JoinRoomResponse)(nil),               // 68: api.chat.v1.JoinRoomResponse
+	(*LeaveRoomRequest)(nil),               // 69: api.chat.v1.LeaveRoomRequest
+	(*LeaveRoomResponse)(nil),              // 70: api.chat.v1.LeaveRoomResponse
+	(*RenameRoomRequest)(nil),              // 71: api.chat.v1.RenameRoomRequest
+	(*SetMemberRoleRequest)(nil),           // 72: api.chat.v1.SetMemberRoleRequest
+	(*SetMessageTTLRequest)(nil),           // 73: api.chat.v1.SetMessageTTLRequest
+	(*SetRetentionPolicyRequest)(nil),      // 74: api.chat.v1.SetRetentionPolicyRequest
+	(*ImportHistoryRequest)(nil),           // 75: api.chat.v1.ImportHistoryRequest
+	(*GetImportJobRequest)(nil),            // 76: api.chat.v1.GetImportJobRequest
+	(*ImportReport)(nil),                   // 77: api.chat.v1.ImportReport
+	(*ImportJob)(nil),                      // 78: api.chat.v1.ImportJob
+	(*GetPresenceRequest)(nil),             // 79: api.chat.v1.GetPresenceRequest
+	(*UserPresence)(nil),                   // 80: api.chat.v1.UserPresence
+	(*GetPresenceResponse)(nil),            // 81: api.chat.v1.GetPresenceResponse
+	nil,                                    // 82: api.chat.v1.SystemEvent.DetailsEntry
 }
 var file_api_chat_v1_chat_proto_depIdxs = []int32{
-	2,  // 0: api.chat.v1.Room.members:type_name -> api.chat.v1.RoomMember
-	0,  // 1: api.chat.v1.GetMessagesResponse.messages:type_name -> api.chat.v1.Message
-	1,  // 2: api.chat.v1.ListRoomsResponse.rooms:type_name -> api.chat.v1.Room
-	1,  // 3: api.chat.v1.JoinRoomResponse.room:type_name -> api.chat.v1.Room
-	3,  // 4: api.chat.v1.ChatService.SendMessage:input_type -> api.chat.v1.SendMessageRequest
-	5,  // 5: api.chat.v1.ChatService.GetMessages:input_type -> api.chat.v1.GetMessagesRequest
-	7,  // 6: api.chat.v1.ChatService.StreamMessages:input_type -> api.chat.v1.StreamMessagesRequest
-	8,  // 7: api.chat.v1.ChatService.MarkAsRead:input_type -> api.chat.v1.MarkAsReadRequest
-	10, // 8: api.chat.v1.RoomService.CreateRoom:input_type -> api.chat.v1.CreateRoomRequest
-	11, // 9: api.chat.v1.RoomService.GetRoom:input_type -> api.chat.v1.GetRoomRequest
-	12, // 10: api.chat.v1.RoomService.ListRooms:input_type -> api.chat.v1.ListRoomsRequest
-	14, // 11: api.chat.v1.RoomService.JoinRoom:input_type -> api.chat.v1.JoinRoomRequest
-	16, // 12: api.chat.v1.RoomService.LeaveRoom:input_type -> api.chat.v1.LeaveRoomRequest
-	0,  // 13: api.chat.v1.ChatService.SendMessage:output_type -> api.chat.v1.Message
-	6,  // 14: api.chat.v1.ChatService.GetMessages:output_type -> api.chat.v1.GetMessagesResponse
-	0,  // 15: api.chat.v1.ChatService.StreamMessages:output_type -> api.chat.v1.Message
-	9,  // 16: api.chat.v1.ChatService.MarkAsRead:output_type -> api.chat.v1.MarkAsReadResponse
-	1,  // 17: api.chat.v1.RoomService.CreateRoom:output_type -> api.chat.v1.Room
-	1,  // 18: api.chat.v1.RoomService.GetRoom:output_type -> api.chat.v1.Room
-	13, // 19: api.chat.v1.RoomService.ListRooms:output_type -> api.chat.v1.ListRoomsResponse
-	15, // 20: api.chat.v1.RoomService.JoinRoom:output_type -> api.chat.v1.JoinRoomResponse
-	17, // 21: api.chat.v1.RoomService.LeaveRoom:output_type -> api.chat.v1.LeaveRoomResponse
-	13, // [13:22] is the sub-list for method output_type
-	4,  // [4:13] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	6,  // 0: api.chat.v1.Message.reactions:type_name -> api.chat.v1.Reaction
+	5,  // 1: api.chat.v1.Message.quoted_message:type_name -> api.chat.v1.QuotedMessage
+	2,  // 2: api.chat.v1.Message.link_previews:type_name -> api.chat.v1.LinkPreview
+	3,  // 3: api.chat.v1.Message.poll:type_name -> api.chat.v1.Poll
+	1,  // 4: api.chat.v1.Message.system_event:type_name -> api.chat.v1.SystemEvent
+	82, // 5: api.chat.v1.SystemEvent.details:type_name -> api.chat.v1.SystemEvent.DetailsEntry
+	4,  // 6: api.chat.v1.Poll.options:type_name -> api.chat.v1.PollOption
+	0,  // 7: api.chat.v1.Mention.message:type_name -> api.chat.v1.Message
+	0,  // 8: api.chat.v1.Pin.message:type_name -> api.chat.v1.Message
+	11, // 9: api.chat.v1.Room.members:type_name -> api.chat.v1.RoomMember
+	10, // 10: api.chat.v1.Room.retention:type_name -> api.chat.v1.RetentionPolicy
+	13, // 11: api.chat.v1.SendMessageRequest.poll:type_name -> api.chat.v1.PollRequest
+	12, // 12: api.chat.v1.ScheduledMessage.message:type_name -> api.chat.v1.SendMessageRequest
+	12, // 13: api.chat.v1.ScheduleMessageRequest.message:type_name -> api.chat.v1.SendMessageRequest
+	14, // 14: api.chat.v1.ListScheduledMessagesResponse.scheduled_messages:type_name -> api.chat.v1.ScheduledMessage
+	0,  // 15: api.chat.v1.GetMessagesResponse.messages:type_name -> api.chat.v1.Message
+	0,  // 16: api.chat.v1.SearchResult.message:type_name -> api.chat.v1.Message
+	24, // 17: api.chat.v1.SearchMessagesResponse.results:type_name -> api.chat.v1.SearchResult
+	0,  // 18: api.chat.v1.GetThreadResponse.root:type_name -> api.chat.v1.Message
+	0,  // 19: api.chat.v1.GetThreadResponse.replies:type_name -> api.chat.v1.Message
+	6,  // 20: api.chat.v1.AddReactionResponse.reactions:type_name -> api.chat.v1.Reaction
+	6,  // 21: api.chat.v1.RemoveReactionResponse.reactions:type_name -> api.chat.v1.Reaction
+	3,  // 22: api.chat.v1.VotePollResponse.poll:type_name -> api.chat.v1.Poll
+	3,  // 23: api.chat.v1.ClosePollResponse.poll:type_name -> api.chat.v1.Poll
+	8,  // 24: api.chat.v1.PinMessageResponse.pin:type_name -> api.chat.v1.Pin
+	8,  // 25: api.chat.v1.ListPinnedMessagesResponse.pins:type_name -> api.chat.v1.Pin
+	7,  // 26: api.chat.v1.ListMentionsResponse.mentions:type_name -> api.chat.v1.Mention
+	51, // 27: api.chat.v1.ListReadCursorsResponse.cursors:type_name -> api.chat.v1.ReadCursor
+	51, // 28: api.chat.v1.GetMessageReadersResponse.readers:type_name -> api.chat.v1.ReadCursor
+	0,  // 29: api.chat.v1.GetUnreadMessagesResponse.messages:type_name -> api.chat.v1.Message
+	61, // 30: api.chat.v1.GetUnreadSummaryResponse.rooms:type_name -> api.chat.v1.RoomUnread
+	9,  // 31: api.chat.v1.ListRoomsResponse.rooms:type_name -> api.chat.v1.Room
+	9,  // 32: api.chat.v1.JoinRoomResponse.room:type_name -> api.chat.v1.Room
+	10, // 33: api.chat.v1.SetRetentionPolicyRequest.retention:type_name -> api.chat.v1.RetentionPolicy
+	77, // 34: api.chat.v1.ImportJob.report:type_name -> api.chat.v1.ImportReport
+	80, // 35: api.chat.v1.GetPresenceResponse.presences:type_name -> api.chat.v1.UserPresence
+	12, // 36: api.chat.v1.ChatService.SendMessage:input_type -> api.chat.v1.SendMessageRequest
+	15, // 37: api.chat.v1.ChatService.ScheduleMessage:input_type -> api.chat.v1.ScheduleMessageRequest
+	16, // 38: api.chat.v1.ChatService.ListScheduledMessages:input_type -> api.chat.v1.ListScheduledMessagesRequest
+	18, // 39: api.chat.v1.ChatService.CancelScheduledMessage:input_type -> api.chat.v1.CancelScheduledMessageRequest
+	21, // 40: api.chat.v1.ChatService.GetMessages:input_type -> api.chat.v1.GetMessagesRequest
+	23, // 41: api.chat.v1.ChatService.SearchMessages:input_type -> api.chat.v1.SearchMessagesRequest
+	26, // 42: api.chat.v1.ChatService.GetThread:input_type -> api.chat.v1.GetThreadRequest
+	28, // 43: api.chat.v1.ChatService.StreamMessages:input_type -> api.chat.v1.StreamMessagesRequest
+	29, // 44: api.chat.v1.ChatService.ForwardMessage:input_type -> api.chat.v1.ForwardMessageRequest
+	30, // 45: api.chat.v1.ChatService.EditMessage:input_type -> api.chat.v1.EditMessageRequest
+	31, // 46: api.chat.v1.ChatService.DeleteMessage:input_type -> api.chat.v1.DeleteMessageRequest
+	33, // 47: api.chat.v1.ChatService.AddReaction:input_type -> api.chat.v1.AddReactionRequest
+	35, // 48: api.chat.v1.ChatService.RemoveReaction:input_type -> api.chat.v1.RemoveReactionRequest
+	37, // 49: api.chat.v1.ChatService.VotePoll:input_type -> api.chat.v1.VotePollRequest
+	39, // 50: api.chat.v1.ChatService.ClosePoll:input_type -> api.chat.v1.ClosePollRequest
+	41, // 51: api.chat.v1.ChatService.PinMessage:input_type -> api.chat.v1.PinMessageRequest
+	43, // 52: api.chat.v1.ChatService.UnpinMessage:input_type -> api.chat.v1.UnpinMessageRequest
+	45, // 53: api.chat.v1.ChatService.ListPinnedMessages:input_type -> api.chat.v1.ListPinnedMessagesRequest
+	47, // 54: api.chat.v1.ChatService.ListMentions:input_type -> api.chat.v1.ListMentionsRequest
+	49, // 55: api.chat.v1.ChatService.MarkAsRead:input_type -> api.chat.v1.MarkAsReadRequest
+	52, // 56: api.chat.v1.ChatService.MarkRoomRead:input_type -> api.chat.v1.MarkRoomReadRequest
+	54, // 57: api.chat.v1.ChatService.ListReadCursors:input_type -> api.chat.v1.ListReadCursorsRequest
+	56, // 58: api.chat.v1.ChatService.GetMessageReaders:input_type -> api.chat.v1.GetMessageReadersRequest
+	58, // 59: api.chat.v1.ChatService.GetUnreadMessages:input_type -> api.chat.v1.GetUnreadMessagesRequest
+	60, // 60: api.chat.v1.ChatService.GetUnreadSummary:input_type -> api.chat.v1.GetUnreadSummaryRequest
+	63, // 61: api.chat.v1.RoomService.CreateRoom:input_type -> api.chat.v1.CreateRoomRequest
+	64, // 62: api.chat.v1.RoomService.GetRoom:input_type -> api.chat.v1.GetRoomRequest
+	65, // 63: api.chat.v1.RoomService.ListRooms:input_type -> api.chat.v1.ListRoomsRequest
+	67, // 64: api.chat.v1.RoomService.JoinRoom:input_type -> api.chat.v1.JoinRoomRequest
+	69, // 65: api.chat.v1.RoomService.LeaveRoom:input_type -> api.chat.v1.LeaveRoomRequest
+	73, // 66: api.chat.v1.RoomService.SetMessageTTL:input_type -> api.chat.v1.SetMessageTTLRequest
+	74, // 67: api.chat.v1.RoomService.SetRetentionPolicy:input_type -> api.chat.v1.SetRetentionPolicyRequest
+	71, // 68: api.chat.v1.RoomService.RenameRoom:input_type -> api.chat.v1.RenameRoomRequest
+	72, // 69: api.chat.v1.RoomService.SetMemberRole:input_type -> api.chat.v1.SetMemberRoleRequest
+	75, // 70: api.chat.v1.ImportService.ImportHistory:input_type -> api.chat.v1.ImportHistoryRequest
+	76, // 71: api.chat.v1.ImportService.GetImportJob:input_type -> api.chat.v1.GetImportJobRequest
+	79, // 72: api.chat.v1.PresenceService.GetPresence:input_type -> api.chat.v1.GetPresenceRequest
+	0,  // 73: api.chat.v1.ChatService.SendMessage:output_type -> api.chat.v1.Message
+	14, // 74: api.chat.v1.ChatService.ScheduleMessage:output_type -> api.chat.v1.ScheduledMessage
+	17, // 75: api.chat.v1.ChatService.ListScheduledMessages:output_type -> api.chat.v1.ListScheduledMessagesResponse
+	19, // 76: api.chat.v1.ChatService.CancelScheduledMessage:output_type -> api.chat.v1.CancelScheduledMessageResponse
+	22, // 77: api.chat.v1.ChatService.GetMessages:output_type -> api.chat.v1.GetMessagesResponse
+	25, // 78: api.chat.v1.ChatService.SearchMessages:output_type -> api.chat.v1.SearchMessagesResponse
+	27, // 79: api.chat.v1.ChatService.GetThread:output_type -> api.chat.v1.GetThreadResponse
+	0,  // 80: api.chat.v1.ChatService.StreamMessages:output_type -> api.chat.v1.Message
+	0,  // 81: api.chat.v1.ChatService.ForwardMessage:output_type -> api.chat.v1.Message
+	0,  // 82: api.chat.v1.ChatService.EditMessage:output_type -> api.chat.v1.Message
+	32, // 83: api.chat.v1.ChatService.DeleteMessage:output_type -> api.chat.v1.DeleteMessageResponse
+	34, // 84: api.chat.v1.ChatService.AddReaction:output_type -> api.chat.v1.AddReactionResponse
+	36, // 85: api.chat.v1.ChatService.RemoveReaction:output_type -> api.chat.v1.RemoveReactionResponse
+	38, // 86: api.chat.v1.ChatService.VotePoll:output_type -> api.chat.v1.VotePollResponse
+	40, // 87: api.chat.v1.ChatService.ClosePoll:output_type -> api.chat.v1.ClosePollResponse
+	42, // 88: api.chat.v1.ChatService.PinMessage:output_type -> api.chat.v1.PinMessageResponse
+	44, // 89: api.chat.v1.ChatService.UnpinMessage:output_type -> api.chat.v1.UnpinMessageResponse
+	46, // 90: api.chat.v1.ChatService.ListPinnedMessages:output_type -> api.chat.v1.ListPinnedMessagesResponse
+	48, // 91: api.chat.v1.ChatService.ListMentions:output_type -> api.chat.v1.ListMentionsResponse
+	50, // 92: api.chat.v1.ChatService.MarkAsRead:output_type -> api.chat.v1.MarkAsReadResponse
+	53, // 93: api.chat.v1.ChatService.MarkRoomRead:output_type -> api.chat.v1.MarkRoomReadResponse
+	55, // 94: api.chat.v1.ChatService.ListReadCursors:output_type -> api.chat.v1.ListReadCursorsResponse
+	57, // 95: api.chat.v1.ChatService.GetMessageReaders:output_type -> api.chat.v1.GetMessageReadersResponse
+	59, // 96: api.chat.v1.ChatService.GetUnreadMessages:output_type -> api.chat.v1.GetUnreadMessagesResponse
+	62, // 97: api.chat.v1.ChatService.GetUnreadSummary:output_type -> api.chat.v1.GetUnreadSummaryResponse
+	9,  // 98: api.chat.v1.RoomService.CreateRoom:output_type -> api.chat.v1.Room
+	9,  // 99: api.chat.v1.RoomService.GetRoom:output_type -> api.chat.v1.Room
+	66, // 100: api.chat.v1.RoomService.ListRooms:output_type -> api.chat.v1.ListRoomsResponse
+	68, // 101: api.chat.v1.RoomService.JoinRoom:output_type -> api.chat.v1.JoinRoomResponse
+	70, // 102: api.chat.v1.RoomService.LeaveRoom:output_type -> api.chat.v1.LeaveRoomResponse
+	9,  // 103: api.chat.v1.RoomService.SetMessageTTL:output_type -> api.chat.v1.Room
+	9,  // 104: api.chat.v1.RoomService.SetRetentionPolicy:output_type -> api.chat.v1.Room
+	9,  // 105: api.chat.v1.RoomService.RenameRoom:output_type -> api.chat.v1.Room
+	11, // 106: api.chat.v1.RoomService.SetMemberRole:output_type -> api.chat.v1.RoomMember
+	78, // 107: api.chat.v1.ImportService.ImportHistory:output_type -> api.chat.v1.ImportJob
+	78, // 108: api.chat.v1.ImportService.GetImportJob:output_type -> api.chat.v1.ImportJob
+	81, // 109: api.chat.v1.PresenceService.GetPresence:output_type -> api.chat.v1.GetPresenceResponse
+	73, // [73:110] is the sub-list for method output_type
+	36, // [36:73] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_api_chat_v1_chat_proto_init() }
@@ -1360,9 +5711,9 @@ func file_api_chat_v1_chat_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_chat_v1_chat_proto_rawDesc), len(file_api_chat_v1_chat_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   18,
+			NumMessages:   83,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   4,
 		},
 		GoTypes:           file_api_chat_v1_chat_proto_goTypes,
 		DependencyIndexes: file_api_chat_v1_chat_proto_depIdxs,
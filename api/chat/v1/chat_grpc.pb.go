@@ -19,10 +19,31 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ChatService_SendMessage_FullMethodName    = "/api.chat.v1.ChatService/SendMessage"
-	ChatService_GetMessages_FullMethodName    = "/api.chat.v1.ChatService/GetMessages"
-	ChatService_StreamMessages_FullMethodName = "/api.chat.v1.ChatService/StreamMessages"
-	ChatService_MarkAsRead_FullMethodName     = "/api.chat.v1.ChatService/MarkAsRead"
+	ChatService_SendMessage_FullMethodName            = "/api.chat.v1.ChatService/SendMessage"
+	ChatService_ScheduleMessage_FullMethodName        = "/api.chat.v1.ChatService/ScheduleMessage"
+	ChatService_ListScheduledMessages_FullMethodName  = "/api.chat.v1.ChatService/ListScheduledMessages"
+	ChatService_CancelScheduledMessage_FullMethodName = "/api.chat.v1.ChatService/CancelScheduledMessage"
+	ChatService_GetMessages_FullMethodName            = "/api.chat.v1.ChatService/GetMessages"
+	ChatService_SearchMessages_FullMethodName         = "/api.chat.v1.ChatService/SearchMessages"
+	ChatService_GetThread_FullMethodName              = "/api.chat.v1.ChatService/GetThread"
+	ChatService_StreamMessages_FullMethodName         = "/api.chat.v1.ChatService/StreamMessages"
+	ChatService_ForwardMessage_FullMethodName         = "/api.chat.v1.ChatService/ForwardMessage"
+	ChatService_EditMessage_FullMethodName            = "/api.chat.v1.ChatService/EditMessage"
+	ChatService_DeleteMessage_FullMethodName          = "/api.chat.v1.ChatService/DeleteMessage"
+	ChatService_AddReaction_FullMethodName            = "/api.chat.v1.ChatService/AddReaction"
+	ChatService_RemoveReaction_FullMethodName         = "/api.chat.v1.ChatService/RemoveReaction"
+	ChatService_VotePoll_FullMethodName               = "/api.chat.v1.ChatService/VotePoll"
+	ChatService_ClosePoll_FullMethodName              = "/api.chat.v1.ChatService/ClosePoll"
+	ChatService_PinMessage_FullMethodName             = "/api.chat.v1.ChatService/PinMessage"
+	ChatService_UnpinMessage_FullMethodName           = "/api.chat.v1.ChatService/UnpinMessage"
+	ChatService_ListPinnedMessages_FullMethodName     = "/api.chat.v1.ChatService/ListPinnedMessages"
+	ChatService_ListMentions_FullMethodName           = "/api.chat.v1.ChatService/ListMentions"
+	ChatService_MarkAsRead_FullMethodName             = "/api.chat.v1.ChatService/MarkAsRead"
+	ChatService_MarkRoomRead_FullMethodName           = "/api.chat.v1.ChatService/MarkRoomRead"
+	ChatService_ListReadCursors_FullMethodName        = "/api.chat.v1.ChatService/ListReadCursors"
+	ChatService_GetMessageReaders_FullMethodName      = "/api.chat.v1.ChatService/GetMessageReaders"
+	ChatService_GetUnreadMessages_FullMethodName      = "/api.chat.v1.ChatService/GetUnreadMessages"
+	ChatService_GetUnreadSummary_FullMethodName       = "/api.chat.v1.ChatService/GetUnreadSummary"
 )
 
 // ChatServiceClient is the client API for ChatService service.
@@ -33,12 +54,55 @@ const (
 type ChatServiceClient interface {
 	// Send a message
 	SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*Message, error)
+	// Schedule a message to be sent at a future time
+	ScheduleMessage(ctx context.Context, in *ScheduleMessageRequest, opts ...grpc.CallOption) (*ScheduledMessage, error)
+	// List the caller's pending scheduled messages
+	ListScheduledMessages(ctx context.Context, in *ListScheduledMessagesRequest, opts ...grpc.CallOption) (*ListScheduledMessagesResponse, error)
+	// Cancel a pending scheduled message (author only)
+	CancelScheduledMessage(ctx context.Context, in *CancelScheduledMessageRequest, opts ...grpc.CallOption) (*CancelScheduledMessageResponse, error)
 	// Get messages for a room
 	GetMessages(ctx context.Context, in *GetMessagesRequest, opts ...grpc.CallOption) (*GetMessagesResponse, error)
-	// Stream messages in real-time (gRPC only - no HTTP mapping)
+	// Full-text search over messages in the caller's rooms
+	SearchMessages(ctx context.Context, in *SearchMessagesRequest, opts ...grpc.CallOption) (*SearchMessagesResponse, error)
+	// Get replies to a thread root message
+	GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*GetThreadResponse, error)
+	// Stream messages in real-time (gRPC only - no HTTP mapping).
+	// Authenticate with an "authorization: Bearer <token>" metadata entry.
 	StreamMessages(ctx context.Context, in *StreamMessagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Message], error)
+	// Forward a message into another room
+	ForwardMessage(ctx context.Context, in *ForwardMessageRequest, opts ...grpc.CallOption) (*Message, error)
+	// Edit a message (author only)
+	EditMessage(ctx context.Context, in *EditMessageRequest, opts ...grpc.CallOption) (*Message, error)
+	// Delete a message (author, room admin or moderator)
+	DeleteMessage(ctx context.Context, in *DeleteMessageRequest, opts ...grpc.CallOption) (*DeleteMessageResponse, error)
+	// React to a message with an emoji
+	AddReaction(ctx context.Context, in *AddReactionRequest, opts ...grpc.CallOption) (*AddReactionResponse, error)
+	// Remove the caller's emoji reaction from a message
+	RemoveReaction(ctx context.Context, in *RemoveReactionRequest, opts ...grpc.CallOption) (*RemoveReactionResponse, error)
+	// Vote in a poll, replacing the caller's previous votes
+	VotePoll(ctx context.Context, in *VotePollRequest, opts ...grpc.CallOption) (*VotePollResponse, error)
+	// Close a poll to further votes (poll author, room admin or moderator)
+	ClosePoll(ctx context.Context, in *ClosePollRequest, opts ...grpc.CallOption) (*ClosePollResponse, error)
+	// Pin a message to its room (room admin or moderator)
+	PinMessage(ctx context.Context, in *PinMessageRequest, opts ...grpc.CallOption) (*PinMessageResponse, error)
+	// Unpin a message from its room (room admin or moderator)
+	UnpinMessage(ctx context.Context, in *UnpinMessageRequest, opts ...grpc.CallOption) (*UnpinMessageResponse, error)
+	// List the messages pinned in a room
+	ListPinnedMessages(ctx context.Context, in *ListPinnedMessagesRequest, opts ...grpc.CallOption) (*ListPinnedMessagesResponse, error)
+	// List the caller's unread mentions across all rooms
+	ListMentions(ctx context.Context, in *ListMentionsRequest, opts ...grpc.CallOption) (*ListMentionsResponse, error)
 	// Mark message as read
 	MarkAsRead(ctx context.Context, in *MarkAsReadRequest, opts ...grpc.CallOption) (*MarkAsReadResponse, error)
+	// Move the caller's read cursor in a room forward and notify the room
+	MarkRoomRead(ctx context.Context, in *MarkRoomReadRequest, opts ...grpc.CallOption) (*MarkRoomReadResponse, error)
+	// List every member's read cursor in a room
+	ListReadCursors(ctx context.Context, in *ListReadCursorsRequest, opts ...grpc.CallOption) (*ListReadCursorsResponse, error)
+	// List the members who have seen a message
+	GetMessageReaders(ctx context.Context, in *GetMessageReadersRequest, opts ...grpc.CallOption) (*GetMessageReadersResponse, error)
+	// List the caller's unread messages in a room, oldest first
+	GetUnreadMessages(ctx context.Context, in *GetUnreadMessagesRequest, opts ...grpc.CallOption) (*GetUnreadMessagesResponse, error)
+	// Unread and mention counts for every room the caller belongs to
+	GetUnreadSummary(ctx context.Context, in *GetUnreadSummaryRequest, opts ...grpc.CallOption) (*GetUnreadSummaryResponse, error)
 }
 
 type chatServiceClient struct {
@@ -59,6 +123,36 @@ func (c *chatServiceClient) SendMessage(ctx context.Context, in *SendMessageRequ
 	return out, nil
 }
 
+func (c *chatServiceClient) ScheduleMessage(ctx context.Context, in *ScheduleMessageRequest, opts ...grpc.CallOption) (*ScheduledMessage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledMessage)
+	err := c.cc.Invoke(ctx, ChatService_ScheduleMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListScheduledMessages(ctx context.Context, in *ListScheduledMessagesRequest, opts ...grpc.CallOption) (*ListScheduledMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduledMessagesResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListScheduledMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) CancelScheduledMessage(ctx context.Context, in *CancelScheduledMessageRequest, opts ...grpc.CallOption) (*CancelScheduledMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelScheduledMessageResponse)
+	err := c.cc.Invoke(ctx, ChatService_CancelScheduledMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *chatServiceClient) GetMessages(ctx context.Context, in *GetMessagesRequest, opts ...grpc.CallOption) (*GetMessagesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetMessagesResponse)
@@ -69,6 +163,26 @@ func (c *chatServiceClient) GetMessages(ctx context.Context, in *GetMessagesRequ
 	return out, nil
 }
 
+func (c *chatServiceClient) SearchMessages(ctx context.Context, in *SearchMessagesRequest, opts ...grpc.CallOption) (*SearchMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchMessagesResponse)
+	err := c.cc.Invoke(ctx, ChatService_SearchMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*GetThreadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetThreadResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *chatServiceClient) StreamMessages(ctx context.Context, in *StreamMessagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Message], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &ChatService_ServiceDesc.Streams[0], ChatService_StreamMessages_FullMethodName, cOpts...)
@@ -88,6 +202,116 @@ func (c *chatServiceClient) StreamMessages(ctx context.Context, in *StreamMessag
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ChatService_StreamMessagesClient = grpc.ServerStreamingClient[Message]
 
+func (c *chatServiceClient) ForwardMessage(ctx context.Context, in *ForwardMessageRequest, opts ...grpc.CallOption) (*Message, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Message)
+	err := c.cc.Invoke(ctx, ChatService_ForwardMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) EditMessage(ctx context.Context, in *EditMessageRequest, opts ...grpc.CallOption) (*Message, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Message)
+	err := c.cc.Invoke(ctx, ChatService_EditMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) DeleteMessage(ctx context.Context, in *DeleteMessageRequest, opts ...grpc.CallOption) (*DeleteMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMessageResponse)
+	err := c.cc.Invoke(ctx, ChatService_DeleteMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) AddReaction(ctx context.Context, in *AddReactionRequest, opts ...grpc.CallOption) (*AddReactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddReactionResponse)
+	err := c.cc.Invoke(ctx, ChatService_AddReaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) RemoveReaction(ctx context.Context, in *RemoveReactionRequest, opts ...grpc.CallOption) (*RemoveReactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveReactionResponse)
+	err := c.cc.Invoke(ctx, ChatService_RemoveReaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) VotePoll(ctx context.Context, in *VotePollRequest, opts ...grpc.CallOption) (*VotePollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VotePollResponse)
+	err := c.cc.Invoke(ctx, ChatService_VotePoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ClosePoll(ctx context.Context, in *ClosePollRequest, opts ...grpc.CallOption) (*ClosePollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClosePollResponse)
+	err := c.cc.Invoke(ctx, ChatService_ClosePoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) PinMessage(ctx context.Context, in *PinMessageRequest, opts ...grpc.CallOption) (*PinMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PinMessageResponse)
+	err := c.cc.Invoke(ctx, ChatService_PinMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UnpinMessage(ctx context.Context, in *UnpinMessageRequest, opts ...grpc.CallOption) (*UnpinMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnpinMessageResponse)
+	err := c.cc.Invoke(ctx, ChatService_UnpinMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListPinnedMessages(ctx context.Context, in *ListPinnedMessagesRequest, opts ...grpc.CallOption) (*ListPinnedMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPinnedMessagesResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListPinnedMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListMentions(ctx context.Context, in *ListMentionsRequest, opts ...grpc.CallOption) (*ListMentionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMentionsResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListMentions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *chatServiceClient) MarkAsRead(ctx context.Context, in *MarkAsReadRequest, opts ...grpc.CallOption) (*MarkAsReadResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(MarkAsReadResponse)
@@ -98,6 +322,56 @@ func (c *chatServiceClient) MarkAsRead(ctx context.Context, in *MarkAsReadReques
 	return out, nil
 }
 
+func (c *chatServiceClient) MarkRoomRead(ctx context.Context, in *MarkRoomReadRequest, opts ...grpc.CallOption) (*MarkRoomReadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkRoomReadResponse)
+	err := c.cc.Invoke(ctx, ChatService_MarkRoomRead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListReadCursors(ctx context.Context, in *ListReadCursorsRequest, opts ...grpc.CallOption) (*ListReadCursorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReadCursorsResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListReadCursors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetMessageReaders(ctx context.Context, in *GetMessageReadersRequest, opts ...grpc.CallOption) (*GetMessageReadersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMessageReadersResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetMessageReaders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetUnreadMessages(ctx context.Context, in *GetUnreadMessagesRequest, opts ...grpc.CallOption) (*GetUnreadMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUnreadMessagesResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetUnreadMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetUnreadSummary(ctx context.Context, in *GetUnreadSummaryRequest, opts ...grpc.CallOption) (*GetUnreadSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUnreadSummaryResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetUnreadSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChatServiceServer is the server API for ChatService service.
 // All implementations must embed UnimplementedChatServiceServer
 // for forward compatibility.
@@ -106,12 +380,55 @@ func (c *chatServiceClient) MarkAsRead(ctx context.Context, in *MarkAsReadReques
 type ChatServiceServer interface {
 	// Send a message
 	SendMessage(context.Context, *SendMessageRequest) (*Message, error)
+	// Schedule a message to be sent at a future time
+	ScheduleMessage(context.Context, *ScheduleMessageRequest) (*ScheduledMessage, error)
+	// List the caller's pending scheduled messages
+	ListScheduledMessages(context.Context, *ListScheduledMessagesRequest) (*ListScheduledMessagesResponse, error)
+	// Cancel a pending scheduled message (author only)
+	CancelScheduledMessage(context.Context, *CancelScheduledMessageRequest) (*CancelScheduledMessageResponse, error)
 	// Get messages for a room
 	GetMessages(context.Context, *GetMessagesRequest) (*GetMessagesResponse, error)
-	// Stream messages in real-time (gRPC only - no HTTP mapping)
+	// Full-text search over messages in the caller's rooms
+	SearchMessages(context.Context, *SearchMessagesRequest) (*SearchMessagesResponse, error)
+	// Get replies to a thread root message
+	GetThread(context.Context, *GetThreadRequest) (*GetThreadResponse, error)
+	// Stream messages in real-time (gRPC only - no HTTP mapping).
+	// Authenticate with an "authorization: Bearer <token>" metadata entry.
 	StreamMessages(*StreamMessagesRequest, grpc.ServerStreamingServer[Message]) error
+	// Forward a message into another room
+	ForwardMessage(context.Context, *ForwardMessageRequest) (*Message, error)
+	// Edit a message (author only)
+	EditMessage(context.Context, *EditMessageRequest) (*Message, error)
+	// Delete a message (author, room admin or moderator)
+	DeleteMessage(context.Context, *DeleteMessageRequest) (*DeleteMessageResponse, error)
+	// React to a message with an emoji
+	AddReaction(context.Context, *AddReactionRequest) (*AddReactionResponse, error)
+	// Remove the caller's emoji reaction from a message
+	RemoveReaction(context.Context, *RemoveReactionRequest) (*RemoveReactionResponse, error)
+	// Vote in a poll, replacing the caller's previous votes
+	VotePoll(context.Context, *VotePollRequest) (*VotePollResponse, error)
+	// Close a poll to further votes (poll author, room admin or moderator)
+	ClosePoll(context.Context, *ClosePollRequest) (*ClosePollResponse, error)
+	// Pin a message to its room (room admin or moderator)
+	PinMessage(context.Context, *PinMessageRequest) (*PinMessageResponse, error)
+	// Unpin a message from its room (room admin or moderator)
+	UnpinMessage(context.Context, *UnpinMessageRequest) (*UnpinMessageResponse, error)
+	// List the messages pinned in a room
+	ListPinnedMessages(context.Context, *ListPinnedMessagesRequest) (*ListPinnedMessagesResponse, error)
+	// List the caller's unread mentions across all rooms
+	ListMentions(context.Context, *ListMentionsRequest) (*ListMentionsResponse, error)
 	// Mark message as read
 	MarkAsRead(context.Context, *MarkAsReadRequest) (*MarkAsReadResponse, error)
+	// Move the caller's read cursor in a room forward and notify the room
+	MarkRoomRead(context.Context, *MarkRoomReadRequest) (*MarkRoomReadResponse, error)
+	// List every member's read cursor in a room
+	ListReadCursors(context.Context, *ListReadCursorsRequest) (*ListReadCursorsResponse, error)
+	// List the members who have seen a message
+	GetMessageReaders(context.Context, *GetMessageReadersRequest) (*GetMessageReadersResponse, error)
+	// List the caller's unread messages in a room, oldest first
+	GetUnreadMessages(context.Context, *GetUnreadMessagesRequest) (*GetUnreadMessagesResponse, error)
+	// Unread and mention counts for every room the caller belongs to
+	GetUnreadSummary(context.Context, *GetUnreadSummaryRequest) (*GetUnreadSummaryResponse, error)
 	mustEmbedUnimplementedChatServiceServer()
 }
 
@@ -125,15 +442,78 @@ type UnimplementedChatServiceServer struct{}
 func (UnimplementedChatServiceServer) SendMessage(context.Context, *SendMessageRequest) (*Message, error) {
 	return nil, status.Error(codes.Unimplemented, "method SendMessage not implemented")
 }
+func (UnimplementedChatServiceServer) ScheduleMessage(context.Context, *ScheduleMessageRequest) (*ScheduledMessage, error) {
+	return nil, status.Error(codes.Unimplemented, "method ScheduleMessage not implemented")
+}
+func (UnimplementedChatServiceServer) ListScheduledMessages(context.Context, *ListScheduledMessagesRequest) (*ListScheduledMessagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListScheduledMessages not implemented")
+}
+func (UnimplementedChatServiceServer) CancelScheduledMessage(context.Context, *CancelScheduledMessageRequest) (*CancelScheduledMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelScheduledMessage not implemented")
+}
 func (UnimplementedChatServiceServer) GetMessages(context.Context, *GetMessagesRequest) (*GetMessagesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetMessages not implemented")
 }
+func (UnimplementedChatServiceServer) SearchMessages(context.Context, *SearchMessagesRequest) (*SearchMessagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchMessages not implemented")
+}
+func (UnimplementedChatServiceServer) GetThread(context.Context, *GetThreadRequest) (*GetThreadResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetThread not implemented")
+}
 func (UnimplementedChatServiceServer) StreamMessages(*StreamMessagesRequest, grpc.ServerStreamingServer[Message]) error {
 	return status.Error(codes.Unimplemented, "method StreamMessages not implemented")
 }
+func (UnimplementedChatServiceServer) ForwardMessage(context.Context, *ForwardMessageRequest) (*Message, error) {
+	return nil, status.Error(codes.Unimplemented, "method ForwardMessage not implemented")
+}
+func (UnimplementedChatServiceServer) EditMessage(context.Context, *EditMessageRequest) (*Message, error) {
+	return nil, status.Error(codes.Unimplemented, "method EditMessage not implemented")
+}
+func (UnimplementedChatServiceServer) DeleteMessage(context.Context, *DeleteMessageRequest) (*DeleteMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteMessage not implemented")
+}
+func (UnimplementedChatServiceServer) AddReaction(context.Context, *AddReactionRequest) (*AddReactionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddReaction not implemented")
+}
+func (UnimplementedChatServiceServer) RemoveReaction(context.Context, *RemoveReactionRequest) (*RemoveReactionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveReaction not implemented")
+}
+func (UnimplementedChatServiceServer) VotePoll(context.Context, *VotePollRequest) (*VotePollResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VotePoll not implemented")
+}
+func (UnimplementedChatServiceServer) ClosePoll(context.Context, *ClosePollRequest) (*ClosePollResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClosePoll not implemented")
+}
+func (UnimplementedChatServiceServer) PinMessage(context.Context, *PinMessageRequest) (*PinMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PinMessage not implemented")
+}
+func (UnimplementedChatServiceServer) UnpinMessage(context.Context, *UnpinMessageRequest) (*UnpinMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnpinMessage not implemented")
+}
+func (UnimplementedChatServiceServer) ListPinnedMessages(context.Context, *ListPinnedMessagesRequest) (*ListPinnedMessagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPinnedMessages not implemented")
+}
+func (UnimplementedChatServiceServer) ListMentions(context.Context, *ListMentionsRequest) (*ListMentionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMentions not implemented")
+}
 func (UnimplementedChatServiceServer) MarkAsRead(context.Context, *MarkAsReadRequest) (*MarkAsReadResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method MarkAsRead not implemented")
 }
+func (UnimplementedChatServiceServer) MarkRoomRead(context.Context, *MarkRoomReadRequest) (*MarkRoomReadResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MarkRoomRead not implemented")
+}
+func (UnimplementedChatServiceServer) ListReadCursors(context.Context, *ListReadCursorsRequest) (*ListReadCursorsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListReadCursors not implemented")
+}
+func (UnimplementedChatServiceServer) GetMessageReaders(context.Context, *GetMessageReadersRequest) (*GetMessageReadersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMessageReaders not implemented")
+}
+func (UnimplementedChatServiceServer) GetUnreadMessages(context.Context, *GetUnreadMessagesRequest) (*GetUnreadMessagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUnreadMessages not implemented")
+}
+func (UnimplementedChatServiceServer) GetUnreadSummary(context.Context, *GetUnreadSummaryRequest) (*GetUnreadSummaryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUnreadSummary not implemented")
+}
 func (UnimplementedChatServiceServer) mustEmbedUnimplementedChatServiceServer() {}
 func (UnimplementedChatServiceServer) testEmbeddedByValue()                     {}
 
@@ -173,6 +553,60 @@ func _ChatService_SendMessage_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChatService_ScheduleMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ScheduleMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ScheduleMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ScheduleMessage(ctx, req.(*ScheduleMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListScheduledMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListScheduledMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListScheduledMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListScheduledMessages(ctx, req.(*ListScheduledMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_CancelScheduledMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CancelScheduledMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_CancelScheduledMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CancelScheduledMessage(ctx, req.(*CancelScheduledMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ChatService_GetMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetMessagesRequest)
 	if err := dec(in); err != nil {
@@ -191,6 +625,42 @@ func _ChatService_GetMessages_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChatService_SearchMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).SearchMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_SearchMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).SearchMessages(ctx, req.(*SearchMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetThread(ctx, req.(*GetThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ChatService_StreamMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(StreamMessagesRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -202,6 +672,204 @@ func _ChatService_StreamMessages_Handler(srv interface{}, stream grpc.ServerStre
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ChatService_StreamMessagesServer = grpc.ServerStreamingServer[Message]
 
+func _ChatService_ForwardMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForwardMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ForwardMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ForwardMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ForwardMessage(ctx, req.(*ForwardMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_EditMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).EditMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_EditMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).EditMessage(ctx, req.(*EditMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_DeleteMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).DeleteMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_DeleteMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).DeleteMessage(ctx, req.(*DeleteMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_AddReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).AddReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_AddReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).AddReaction(ctx, req.(*AddReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_RemoveReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).RemoveReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_RemoveReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).RemoveReaction(ctx, req.(*RemoveReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_VotePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VotePollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).VotePoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_VotePoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).VotePoll(ctx, req.(*VotePollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ClosePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClosePollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ClosePoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ClosePoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ClosePoll(ctx, req.(*ClosePollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_PinMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).PinMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_PinMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).PinMessage(ctx, req.(*PinMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UnpinMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpinMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UnpinMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UnpinMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UnpinMessage(ctx, req.(*UnpinMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListPinnedMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPinnedMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListPinnedMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListPinnedMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListPinnedMessages(ctx, req.(*ListPinnedMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListMentions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMentionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListMentions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListMentions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListMentions(ctx, req.(*ListMentionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ChatService_MarkAsRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(MarkAsReadRequest)
 	if err := dec(in); err != nil {
@@ -220,6 +888,96 @@ func _ChatService_MarkAsRead_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChatService_MarkRoomRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkRoomReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).MarkRoomRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_MarkRoomRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).MarkRoomRead(ctx, req.(*MarkRoomReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListReadCursors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReadCursorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListReadCursors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListReadCursors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListReadCursors(ctx, req.(*ListReadCursorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetMessageReaders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMessageReadersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetMessageReaders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetMessageReaders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetMessageReaders(ctx, req.(*GetMessageReadersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetUnreadMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnreadMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetUnreadMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetUnreadMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetUnreadMessages(ctx, req.(*GetUnreadMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetUnreadSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnreadSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetUnreadSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetUnreadSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetUnreadSummary(ctx, req.(*GetUnreadSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ChatService_ServiceDesc is the grpc.ServiceDesc for ChatService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -228,16 +986,100 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 	HandlerType: (*ChatServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "SendMessage",
-			Handler:    _ChatService_SendMessage_Handler,
+			MethodName: "SendMessage",
+			Handler:    _ChatService_SendMessage_Handler,
+		},
+		{
+			MethodName: "ScheduleMessage",
+			Handler:    _ChatService_ScheduleMessage_Handler,
+		},
+		{
+			MethodName: "ListScheduledMessages",
+			Handler:    _ChatService_ListScheduledMessages_Handler,
+		},
+		{
+			MethodName: "CancelScheduledMessage",
+			Handler:    _ChatService_CancelScheduledMessage_Handler,
+		},
+		{
+			MethodName: "GetMessages",
+			Handler:    _ChatService_GetMessages_Handler,
+		},
+		{
+			MethodName: "SearchMessages",
+			Handler:    _ChatService_SearchMessages_Handler,
+		},
+		{
+			MethodName: "GetThread",
+			Handler:    _ChatService_GetThread_Handler,
+		},
+		{
+			MethodName: "ForwardMessage",
+			Handler:    _ChatService_ForwardMessage_Handler,
+		},
+		{
+			MethodName: "EditMessage",
+			Handler:    _ChatService_EditMessage_Handler,
+		},
+		{
+			MethodName: "DeleteMessage",
+			Handler:    _ChatService_DeleteMessage_Handler,
+		},
+		{
+			MethodName: "AddReaction",
+			Handler:    _ChatService_AddReaction_Handler,
+		},
+		{
+			MethodName: "RemoveReaction",
+			Handler:    _ChatService_RemoveReaction_Handler,
+		},
+		{
+			MethodName: "VotePoll",
+			Handler:    _ChatService_VotePoll_Handler,
+		},
+		{
+			MethodName: "ClosePoll",
+			Handler:    _ChatService_ClosePoll_Handler,
+		},
+		{
+			MethodName: "PinMessage",
+			Handler:    _ChatService_PinMessage_Handler,
+		},
+		{
+			MethodName: "UnpinMessage",
+			Handler:    _ChatService_UnpinMessage_Handler,
+		},
+		{
+			MethodName: "ListPinnedMessages",
+			Handler:    _ChatService_ListPinnedMessages_Handler,
+		},
+		{
+			MethodName: "ListMentions",
+			Handler:    _ChatService_ListMentions_Handler,
+		},
+		{
+			MethodName: "MarkAsRead",
+			Handler:    _ChatService_MarkAsRead_Handler,
+		},
+		{
+			MethodName: "MarkRoomRead",
+			Handler:    _ChatService_MarkRoomRead_Handler,
+		},
+		{
+			MethodName: "ListReadCursors",
+			Handler:    _ChatService_ListReadCursors_Handler,
 		},
 		{
-			MethodName: "GetMessages",
-			Handler:    _ChatService_GetMessages_Handler,
+			MethodName: "GetMessageReaders",
+			Handler:    _ChatService_GetMessageReaders_Handler,
 		},
 		{
-			MethodName: "MarkAsRead",
-			Handler:    _ChatService_MarkAsRead_Handler,
+			MethodName: "GetUnreadMessages",
+			Handler:    _ChatService_GetUnreadMessages_Handler,
+		},
+		{
+			MethodName: "GetUnreadSummary",
+			Handler:    _ChatService_GetUnreadSummary_Handler,
 		},
 	},
 	Streams: []grpc.StreamDesc{
@@ -251,11 +1093,15 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	RoomService_CreateRoom_FullMethodName = "/api.chat.v1.RoomService/CreateRoom"
-	RoomService_GetRoom_FullMethodName    = "/api.chat.v1.RoomService/GetRoom"
-	RoomService_ListRooms_FullMethodName  = "/api.chat.v1.RoomService/ListRooms"
-	RoomService_JoinRoom_FullMethodName   = "/api.chat.v1.RoomService/JoinRoom"
-	RoomService_LeaveRoom_FullMethodName  = "/api.chat.v1.RoomService/LeaveRoom"
+	RoomService_CreateRoom_FullMethodName         = "/api.chat.v1.RoomService/CreateRoom"
+	RoomService_GetRoom_FullMethodName            = "/api.chat.v1.RoomService/GetRoom"
+	RoomService_ListRooms_FullMethodName          = "/api.chat.v1.RoomService/ListRooms"
+	RoomService_JoinRoom_FullMethodName           = "/api.chat.v1.RoomService/JoinRoom"
+	RoomService_LeaveRoom_FullMethodName          = "/api.chat.v1.RoomService/LeaveRoom"
+	RoomService_SetMessageTTL_FullMethodName      = "/api.chat.v1.RoomService/SetMessageTTL"
+	RoomService_SetRetentionPolicy_FullMethodName = "/api.chat.v1.RoomService/SetRetentionPolicy"
+	RoomService_RenameRoom_FullMethodName         = "/api.chat.v1.RoomService/RenameRoom"
+	RoomService_SetMemberRole_FullMethodName      = "/api.chat.v1.RoomService/SetMemberRole"
 )
 
 // RoomServiceClient is the client API for RoomService service.
@@ -274,6 +1120,14 @@ type RoomServiceClient interface {
 	JoinRoom(ctx context.Context, in *JoinRoomRequest, opts ...grpc.CallOption) (*JoinRoomResponse, error)
 	// Leave a room
 	LeaveRoom(ctx context.Context, in *LeaveRoomRequest, opts ...grpc.CallOption) (*LeaveRoomResponse, error)
+	// Set the room's default message lifetime (room admin or moderator)
+	SetMessageTTL(ctx context.Context, in *SetMessageTTLRequest, opts ...grpc.CallOption) (*Room, error)
+	// Set how long the room keeps its messages (room admin)
+	SetRetentionPolicy(ctx context.Context, in *SetRetentionPolicyRequest, opts ...grpc.CallOption) (*Room, error)
+	// Rename a room (room admin or moderator)
+	RenameRoom(ctx context.Context, in *RenameRoomRequest, opts ...grpc.CallOption) (*Room, error)
+	// Change a member's role (room admin)
+	SetMemberRole(ctx context.Context, in *SetMemberRoleRequest, opts ...grpc.CallOption) (*RoomMember, error)
 }
 
 type roomServiceClient struct {
@@ -334,6 +1188,46 @@ func (c *roomServiceClient) LeaveRoom(ctx context.Context, in *LeaveRoomRequest,
 	return out, nil
 }
 
+func (c *roomServiceClient) SetMessageTTL(ctx context.Context, in *SetMessageTTLRequest, opts ...grpc.CallOption) (*Room, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Room)
+	err := c.cc.Invoke(ctx, RoomService_SetMessageTTL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) SetRetentionPolicy(ctx context.Context, in *SetRetentionPolicyRequest, opts ...grpc.CallOption) (*Room, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Room)
+	err := c.cc.Invoke(ctx, RoomService_SetRetentionPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) RenameRoom(ctx context.Context, in *RenameRoomRequest, opts ...grpc.CallOption) (*Room, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Room)
+	err := c.cc.Invoke(ctx, RoomService_RenameRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) SetMemberRole(ctx context.Context, in *SetMemberRoleRequest, opts ...grpc.CallOption) (*RoomMember, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RoomMember)
+	err := c.cc.Invoke(ctx, RoomService_SetMemberRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RoomServiceServer is the server API for RoomService service.
 // All implementations must embed UnimplementedRoomServiceServer
 // for forward compatibility.
@@ -350,6 +1244,14 @@ type RoomServiceServer interface {
 	JoinRoom(context.Context, *JoinRoomRequest) (*JoinRoomResponse, error)
 	// Leave a room
 	LeaveRoom(context.Context, *LeaveRoomRequest) (*LeaveRoomResponse, error)
+	// Set the room's default message lifetime (room admin or moderator)
+	SetMessageTTL(context.Context, *SetMessageTTLRequest) (*Room, error)
+	// Set how long the room keeps its messages (room admin)
+	SetRetentionPolicy(context.Context, *SetRetentionPolicyRequest) (*Room, error)
+	// Rename a room (room admin or moderator)
+	RenameRoom(context.Context, *RenameRoomRequest) (*Room, error)
+	// Change a member's role (room admin)
+	SetMemberRole(context.Context, *SetMemberRoleRequest) (*RoomMember, error)
 	mustEmbedUnimplementedRoomServiceServer()
 }
 
@@ -375,6 +1277,18 @@ func (UnimplementedRoomServiceServer) JoinRoom(context.Context, *JoinRoomRequest
 func (UnimplementedRoomServiceServer) LeaveRoom(context.Context, *LeaveRoomRequest) (*LeaveRoomResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method LeaveRoom not implemented")
 }
+func (UnimplementedRoomServiceServer) SetMessageTTL(context.Context, *SetMessageTTLRequest) (*Room, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetMessageTTL not implemented")
+}
+func (UnimplementedRoomServiceServer) SetRetentionPolicy(context.Context, *SetRetentionPolicyRequest) (*Room, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetRetentionPolicy not implemented")
+}
+func (UnimplementedRoomServiceServer) RenameRoom(context.Context, *RenameRoomRequest) (*Room, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenameRoom not implemented")
+}
+func (UnimplementedRoomServiceServer) SetMemberRole(context.Context, *SetMemberRoleRequest) (*RoomMember, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetMemberRole not implemented")
+}
 func (UnimplementedRoomServiceServer) mustEmbedUnimplementedRoomServiceServer() {}
 func (UnimplementedRoomServiceServer) testEmbeddedByValue()                     {}
 
@@ -486,6 +1400,78 @@ func _RoomService_LeaveRoom_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RoomService_SetMessageTTL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMessageTTLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).SetMessageTTL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_SetMessageTTL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).SetMessageTTL(ctx, req.(*SetMessageTTLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_SetRetentionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRetentionPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).SetRetentionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_SetRetentionPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).SetRetentionPolicy(ctx, req.(*SetRetentionPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_RenameRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).RenameRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_RenameRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).RenameRoom(ctx, req.(*RenameRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_SetMemberRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMemberRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).SetMemberRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_SetMemberRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).SetMemberRole(ctx, req.(*SetMemberRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RoomService_ServiceDesc is the grpc.ServiceDesc for RoomService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -513,6 +1499,278 @@ var RoomService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LeaveRoom",
 			Handler:    _RoomService_LeaveRoom_Handler,
 		},
+		{
+			MethodName: "SetMessageTTL",
+			Handler:    _RoomService_SetMessageTTL_Handler,
+		},
+		{
+			MethodName: "SetRetentionPolicy",
+			Handler:    _RoomService_SetRetentionPolicy_Handler,
+		},
+		{
+			MethodName: "RenameRoom",
+			Handler:    _RoomService_RenameRoom_Handler,
+		},
+		{
+			MethodName: "SetMemberRole",
+			Handler:    _RoomService_SetMemberRole_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/chat/v1/chat.proto",
+}
+
+const (
+	ImportService_ImportHistory_FullMethodName = "/api.chat.v1.ImportService/ImportHistory"
+	ImportService_GetImportJob_FullMethodName  = "/api.chat.v1.ImportService/GetImportJob"
+)
+
+// ImportServiceClient is the client API for ImportService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Import service for bringing in history from other chat systems (chat admins)
+type ImportServiceClient interface {
+	// Start importing a Slack export zip or JSON-lines file in the background
+	ImportHistory(ctx context.Context, in *ImportHistoryRequest, opts ...grpc.CallOption) (*ImportJob, error)
+	// Get the state of an import started by the caller
+	GetImportJob(ctx context.Context, in *GetImportJobRequest, opts ...grpc.CallOption) (*ImportJob, error)
+}
+
+type importServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewImportServiceClient(cc grpc.ClientConnInterface) ImportServiceClient {
+	return &importServiceClient{cc}
+}
+
+func (c *importServiceClient) ImportHistory(ctx context.Context, in *ImportHistoryRequest, opts ...grpc.CallOption) (*ImportJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportJob)
+	err := c.cc.Invoke(ctx, ImportService_ImportHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *importServiceClient) GetImportJob(ctx context.Context, in *GetImportJobRequest, opts ...grpc.CallOption) (*ImportJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportJob)
+	err := c.cc.Invoke(ctx, ImportService_GetImportJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ImportServiceServer is the server API for ImportService service.
+// All implementations must embed UnimplementedImportServiceServer
+// for forward compatibility.
+//
+// Import service for bringing in history from other chat systems (chat admins)
+type ImportServiceServer interface {
+	// Start importing a Slack export zip or JSON-lines file in the background
+	ImportHistory(context.Context, *ImportHistoryRequest) (*ImportJob, error)
+	// Get the state of an import started by the caller
+	GetImportJob(context.Context, *GetImportJobRequest) (*ImportJob, error)
+	mustEmbedUnimplementedImportServiceServer()
+}
+
+// UnimplementedImportServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedImportServiceServer struct{}
+
+func (UnimplementedImportServiceServer) ImportHistory(context.Context, *ImportHistoryRequest) (*ImportJob, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportHistory not implemented")
+}
+func (UnimplementedImportServiceServer) GetImportJob(context.Context, *GetImportJobRequest) (*ImportJob, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetImportJob not implemented")
+}
+func (UnimplementedImportServiceServer) mustEmbedUnimplementedImportServiceServer() {}
+func (UnimplementedImportServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeImportServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ImportServiceServer will
+// result in compilation errors.
+type UnsafeImportServiceServer interface {
+	mustEmbedUnimplementedImportServiceServer()
+}
+
+func RegisterImportServiceServer(s grpc.ServiceRegistrar, srv ImportServiceServer) {
+	// If the following call panics, it indicates UnimplementedImportServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ImportService_ServiceDesc, srv)
+}
+
+func _ImportService_ImportHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImportServiceServer).ImportHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ImportService_ImportHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImportServiceServer).ImportHistory(ctx, req.(*ImportHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImportService_GetImportJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetImportJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImportServiceServer).GetImportJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ImportService_GetImportJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImportServiceServer).GetImportJob(ctx, req.(*GetImportJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ImportService_ServiceDesc is the grpc.ServiceDesc for ImportService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ImportService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.chat.v1.ImportService",
+	HandlerType: (*ImportServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ImportHistory",
+			Handler:    _ImportService_ImportHistory_Handler,
+		},
+		{
+			MethodName: "GetImportJob",
+			Handler:    _ImportService_GetImportJob_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/chat/v1/chat.proto",
+}
+
+const (
+	PresenceService_GetPresence_FullMethodName = "/api.chat.v1.PresenceService/GetPresence"
+)
+
+// PresenceServiceClient is the client API for PresenceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Presence service for users' live status across all chat service instances
+type PresenceServiceClient interface {
+	// Get the presence of up to 100 users: the caller and their roommates
+	GetPresence(ctx context.Context, in *GetPresenceRequest, opts ...grpc.CallOption) (*GetPresenceResponse, error)
+}
+
+type presenceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPresenceServiceClient(cc grpc.ClientConnInterface) PresenceServiceClient {
+	return &presenceServiceClient{cc}
+}
+
+func (c *presenceServiceClient) GetPresence(ctx context.Context, in *GetPresenceRequest, opts ...grpc.CallOption) (*GetPresenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPresenceResponse)
+	err := c.cc.Invoke(ctx, PresenceService_GetPresence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PresenceServiceServer is the server API for PresenceService service.
+// All implementations must embed UnimplementedPresenceServiceServer
+// for forward compatibility.
+//
+// Presence service for users' live status across all chat service instances
+type PresenceServiceServer interface {
+	// Get the presence of up to 100 users: the caller and their roommates
+	GetPresence(context.Context, *GetPresenceRequest) (*GetPresenceResponse, error)
+	mustEmbedUnimplementedPresenceServiceServer()
+}
+
+// UnimplementedPresenceServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPresenceServiceServer struct{}
+
+func (UnimplementedPresenceServiceServer) GetPresence(context.Context, *GetPresenceRequest) (*GetPresenceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPresence not implemented")
+}
+func (UnimplementedPresenceServiceServer) mustEmbedUnimplementedPresenceServiceServer() {}
+func (UnimplementedPresenceServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafePresenceServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PresenceServiceServer will
+// result in compilation errors.
+type UnsafePresenceServiceServer interface {
+	mustEmbedUnimplementedPresenceServiceServer()
+}
+
+func RegisterPresenceServiceServer(s grpc.ServiceRegistrar, srv PresenceServiceServer) {
+	// If the following call panics, it indicates UnimplementedPresenceServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PresenceService_ServiceDesc, srv)
+}
+
+func _PresenceService_GetPresence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPresenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PresenceServiceServer).GetPresence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PresenceService_GetPresence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PresenceServiceServer).GetPresence(ctx, req.(*GetPresenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PresenceService_ServiceDesc is the grpc.ServiceDesc for PresenceService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PresenceService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.chat.v1.PresenceService",
+	HandlerType: (*PresenceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPresence",
+			Handler:    _PresenceService_GetPresence_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/chat/v1/chat.proto",
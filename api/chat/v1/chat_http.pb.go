@@ -19,24 +19,108 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
+const OperationChatServiceAddReaction = "/api.chat.v1.ChatService/AddReaction"
+const OperationChatServiceCancelScheduledMessage = "/api.chat.v1.ChatService/CancelScheduledMessage"
+const OperationChatServiceClosePoll = "/api.chat.v1.ChatService/ClosePoll"
+const OperationChatServiceDeleteMessage = "/api.chat.v1.ChatService/DeleteMessage"
+const OperationChatServiceEditMessage = "/api.chat.v1.ChatService/EditMessage"
+const OperationChatServiceForwardMessage = "/api.chat.v1.ChatService/ForwardMessage"
+const OperationChatServiceGetMessageReaders = "/api.chat.v1.ChatService/GetMessageReaders"
 const OperationChatServiceGetMessages = "/api.chat.v1.ChatService/GetMessages"
+const OperationChatServiceGetThread = "/api.chat.v1.ChatService/GetThread"
+const OperationChatServiceGetUnreadMessages = "/api.chat.v1.ChatService/GetUnreadMessages"
+const OperationChatServiceGetUnreadSummary = "/api.chat.v1.ChatService/GetUnreadSummary"
+const OperationChatServiceListMentions = "/api.chat.v1.ChatService/ListMentions"
+const OperationChatServiceListPinnedMessages = "/api.chat.v1.ChatService/ListPinnedMessages"
+const OperationChatServiceListReadCursors = "/api.chat.v1.ChatService/ListReadCursors"
+const OperationChatServiceListScheduledMessages = "/api.chat.v1.ChatService/ListScheduledMessages"
 const OperationChatServiceMarkAsRead = "/api.chat.v1.ChatService/MarkAsRead"
+const OperationChatServiceMarkRoomRead = "/api.chat.v1.ChatService/MarkRoomRead"
+const OperationChatServicePinMessage = "/api.chat.v1.ChatService/PinMessage"
+const OperationChatServiceRemoveReaction = "/api.chat.v1.ChatService/RemoveReaction"
+const OperationChatServiceScheduleMessage = "/api.chat.v1.ChatService/ScheduleMessage"
+const OperationChatServiceSearchMessages = "/api.chat.v1.ChatService/SearchMessages"
 const OperationChatServiceSendMessage = "/api.chat.v1.ChatService/SendMessage"
+const OperationChatServiceUnpinMessage = "/api.chat.v1.ChatService/UnpinMessage"
+const OperationChatServiceVotePoll = "/api.chat.v1.ChatService/VotePoll"
 
 type ChatServiceHTTPServer interface {
+	// AddReaction React to a message with an emoji
+	AddReaction(context.Context, *AddReactionRequest) (*AddReactionResponse, error)
+	// CancelScheduledMessage Cancel a pending scheduled message (author only)
+	CancelScheduledMessage(context.Context, *CancelScheduledMessageRequest) (*CancelScheduledMessageResponse, error)
+	// ClosePoll Close a poll to further votes (poll author, room admin or moderator)
+	ClosePoll(context.Context, *ClosePollRequest) (*ClosePollResponse, error)
+	// DeleteMessage Delete a message (author, room admin or moderator)
+	DeleteMessage(context.Context, *DeleteMessageRequest) (*DeleteMessageResponse, error)
+	// EditMessage Edit a message (author only)
+	EditMessage(context.Context, *EditMessageRequest) (*Message, error)
+	// ForwardMessage Forward a message into another room
+	ForwardMessage(context.Context, *ForwardMessageRequest) (*Message, error)
+	// GetMessageReaders List the members who have seen a message
+	GetMessageReaders(context.Context, *GetMessageReadersRequest) (*GetMessageReadersResponse, error)
 	// GetMessages Get messages for a room
 	GetMessages(context.Context, *GetMessagesRequest) (*GetMessagesResponse, error)
+	// GetThread Get replies to a thread root message
+	GetThread(context.Context, *GetThreadRequest) (*GetThreadResponse, error)
+	// GetUnreadMessages List the caller's unread messages in a room, oldest first
+	GetUnreadMessages(context.Context, *GetUnreadMessagesRequest) (*GetUnreadMessagesResponse, error)
+	// GetUnreadSummary Unread and mention counts for every room the caller belongs to
+	GetUnreadSummary(context.Context, *GetUnreadSummaryRequest) (*GetUnreadSummaryResponse, error)
+	// ListMentions List the caller's unread mentions across all rooms
+	ListMentions(context.Context, *ListMentionsRequest) (*ListMentionsResponse, error)
+	// ListPinnedMessages List the messages pinned in a room
+	ListPinnedMessages(context.Context, *ListPinnedMessagesRequest) (*ListPinnedMessagesResponse, error)
+	// ListReadCursors List every member's read cursor in a room
+	ListReadCursors(context.Context, *ListReadCursorsRequest) (*ListReadCursorsResponse, error)
+	// ListScheduledMessages List the caller's pending scheduled messages
+	ListScheduledMessages(context.Context, *ListScheduledMessagesRequest) (*ListScheduledMessagesResponse, error)
 	// MarkAsRead Mark message as read
 	MarkAsRead(context.Context, *MarkAsReadRequest) (*MarkAsReadResponse, error)
+	// MarkRoomRead Move the caller's read cursor in a room forward and notify the room
+	MarkRoomRead(context.Context, *MarkRoomReadRequest) (*MarkRoomReadResponse, error)
+	// PinMessage Pin a message to its room (room admin or moderator)
+	PinMessage(context.Context, *PinMessageRequest) (*PinMessageResponse, error)
+	// RemoveReaction Remove the caller's emoji reaction from a message
+	RemoveReaction(context.Context, *RemoveReactionRequest) (*RemoveReactionResponse, error)
+	// ScheduleMessage Schedule a message to be sent at a future time
+	ScheduleMessage(context.Context, *ScheduleMessageRequest) (*ScheduledMessage, error)
+	// SearchMessages Full-text search over messages in the caller's rooms
+	SearchMessages(context.Context, *SearchMessagesRequest) (*SearchMessagesResponse, error)
 	// SendMessage Send a message
 	SendMessage(context.Context, *SendMessageRequest) (*Message, error)
+	// UnpinMessage Unpin a message from its room (room admin or moderator)
+	UnpinMessage(context.Context, *UnpinMessageRequest) (*UnpinMessageResponse, error)
+	// VotePoll Vote in a poll, replacing the caller's previous votes
+	VotePoll(context.Context, *VotePollRequest) (*VotePollResponse, error)
 }
 
 func RegisterChatServiceHTTPServer(s *http.Server, srv ChatServiceHTTPServer) {
 	r := s.Route("/")
 	r.POST("/api/v1/messages", _ChatService_SendMessage0_HTTP_Handler(srv))
+	r.POST("/api/v1/messages/scheduled", _ChatService_ScheduleMessage0_HTTP_Handler(srv))
+	r.GET("/api/v1/messages/scheduled", _ChatService_ListScheduledMessages0_HTTP_Handler(srv))
+	r.DELETE("/api/v1/messages/scheduled/{id}", _ChatService_CancelScheduledMessage0_HTTP_Handler(srv))
 	r.GET("/api/v1/rooms/{room_id}/messages", _ChatService_GetMessages0_HTTP_Handler(srv))
+	r.GET("/api/v1/messages/search", _ChatService_SearchMessages0_HTTP_Handler(srv))
+	r.GET("/api/v1/messages/{message_id}/thread", _ChatService_GetThread0_HTTP_Handler(srv))
+	r.POST("/api/v1/messages/{message_id}/forward", _ChatService_ForwardMessage0_HTTP_Handler(srv))
+	r.PUT("/api/v1/messages/{message_id}", _ChatService_EditMessage0_HTTP_Handler(srv))
+	r.DELETE("/api/v1/messages/{message_id}", _ChatService_DeleteMessage0_HTTP_Handler(srv))
+	r.POST("/api/v1/messages/{message_id}/reactions", _ChatService_AddReaction0_HTTP_Handler(srv))
+	r.DELETE("/api/v1/messages/{message_id}/reactions", _ChatService_RemoveReaction0_HTTP_Handler(srv))
+	r.POST("/api/v1/messages/{message_id}/poll/votes", _ChatService_VotePoll0_HTTP_Handler(srv))
+	r.POST("/api/v1/messages/{message_id}/poll/close", _ChatService_ClosePoll0_HTTP_Handler(srv))
+	r.POST("/api/v1/messages/{message_id}/pin", _ChatService_PinMessage0_HTTP_Handler(srv))
+	r.DELETE("/api/v1/messages/{message_id}/pin", _ChatService_UnpinMessage0_HTTP_Handler(srv))
+	r.GET("/api/v1/rooms/{room_id}/pins", _ChatService_ListPinnedMessages0_HTTP_Handler(srv))
+	r.GET("/api/v1/mentions", _ChatService_ListMentions0_HTTP_Handler(srv))
 	r.POST("/api/v1/messages/{message_id}/read", _ChatService_MarkAsRead0_HTTP_Handler(srv))
+	r.POST("/api/v1/rooms/{room_id}/read", _ChatService_MarkRoomRead0_HTTP_Handler(srv))
+	r.GET("/api/v1/rooms/{room_id}/read_cursors", _ChatService_ListReadCursors0_HTTP_Handler(srv))
+	r.GET("/api/v1/messages/{message_id}/readers", _ChatService_GetMessageReaders0_HTTP_Handler(srv))
+	r.GET("/api/v1/rooms/{room_id}/unread", _ChatService_GetUnreadMessages0_HTTP_Handler(srv))
+	r.GET("/api/v1/unread", _ChatService_GetUnreadSummary0_HTTP_Handler(srv))
 }
 
 func _ChatService_SendMessage0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
@@ -61,31 +145,417 @@ func _ChatService_SendMessage0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx
 	}
 }
 
+func _ChatService_ScheduleMessage0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ScheduleMessageRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceScheduleMessage)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ScheduleMessage(ctx, req.(*ScheduleMessageRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ScheduledMessage)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_ListScheduledMessages0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListScheduledMessagesRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceListScheduledMessages)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListScheduledMessages(ctx, req.(*ListScheduledMessagesRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListScheduledMessagesResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_CancelScheduledMessage0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CancelScheduledMessageRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceCancelScheduledMessage)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CancelScheduledMessage(ctx, req.(*CancelScheduledMessageRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*CancelScheduledMessageResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
 func _ChatService_GetMessages0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
-		var in GetMessagesRequest
+		var in GetMessagesRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceGetMessages)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetMessages(ctx, req.(*GetMessagesRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetMessagesResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_SearchMessages0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in SearchMessagesRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceSearchMessages)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SearchMessages(ctx, req.(*SearchMessagesRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*SearchMessagesResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_GetThread0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetThreadRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceGetThread)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetThread(ctx, req.(*GetThreadRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetThreadResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_ForwardMessage0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ForwardMessageRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceForwardMessage)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ForwardMessage(ctx, req.(*ForwardMessageRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*Message)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_EditMessage0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in EditMessageRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceEditMessage)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.EditMessage(ctx, req.(*EditMessageRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*Message)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_DeleteMessage0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeleteMessageRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceDeleteMessage)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DeleteMessage(ctx, req.(*DeleteMessageRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeleteMessageResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_AddReaction0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in AddReactionRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceAddReaction)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.AddReaction(ctx, req.(*AddReactionRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*AddReactionResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_RemoveReaction0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RemoveReactionRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceRemoveReaction)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RemoveReaction(ctx, req.(*RemoveReactionRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*RemoveReactionResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_VotePoll0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in VotePollRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceVotePoll)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.VotePoll(ctx, req.(*VotePollRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*VotePollResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_ClosePoll0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ClosePollRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceClosePoll)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ClosePoll(ctx, req.(*ClosePollRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ClosePollResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_PinMessage0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in PinMessageRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServicePinMessage)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.PinMessage(ctx, req.(*PinMessageRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*PinMessageResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_UnpinMessage0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UnpinMessageRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceUnpinMessage)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.UnpinMessage(ctx, req.(*UnpinMessageRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UnpinMessageResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_ListPinnedMessages0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListPinnedMessagesRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceListPinnedMessages)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListPinnedMessages(ctx, req.(*ListPinnedMessagesRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListPinnedMessagesResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_ListMentions0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListMentionsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceListMentions)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListMentions(ctx, req.(*ListMentionsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListMentionsResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_MarkAsRead0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in MarkAsReadRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
 		if err := ctx.BindQuery(&in); err != nil {
 			return err
 		}
 		if err := ctx.BindVars(&in); err != nil {
 			return err
 		}
-		http.SetOperation(ctx, OperationChatServiceGetMessages)
+		http.SetOperation(ctx, OperationChatServiceMarkAsRead)
 		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
-			return srv.GetMessages(ctx, req.(*GetMessagesRequest))
+			return srv.MarkAsRead(ctx, req.(*MarkAsReadRequest))
 		})
 		out, err := h(ctx, &in)
 		if err != nil {
 			return err
 		}
-		reply := out.(*GetMessagesResponse)
+		reply := out.(*MarkAsReadResponse)
 		return ctx.Result(200, reply)
 	}
 }
 
-func _ChatService_MarkAsRead0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+func _ChatService_MarkRoomRead0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
-		var in MarkAsReadRequest
+		var in MarkRoomReadRequest
 		if err := ctx.Bind(&in); err != nil {
 			return err
 		}
@@ -95,26 +565,153 @@ func _ChatService_MarkAsRead0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx h
 		if err := ctx.BindVars(&in); err != nil {
 			return err
 		}
-		http.SetOperation(ctx, OperationChatServiceMarkAsRead)
+		http.SetOperation(ctx, OperationChatServiceMarkRoomRead)
 		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
-			return srv.MarkAsRead(ctx, req.(*MarkAsReadRequest))
+			return srv.MarkRoomRead(ctx, req.(*MarkRoomReadRequest))
 		})
 		out, err := h(ctx, &in)
 		if err != nil {
 			return err
 		}
-		reply := out.(*MarkAsReadResponse)
+		reply := out.(*MarkRoomReadResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_ListReadCursors0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListReadCursorsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceListReadCursors)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListReadCursors(ctx, req.(*ListReadCursorsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListReadCursorsResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_GetMessageReaders0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetMessageReadersRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceGetMessageReaders)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetMessageReaders(ctx, req.(*GetMessageReadersRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetMessageReadersResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_GetUnreadMessages0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetUnreadMessagesRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceGetUnreadMessages)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetUnreadMessages(ctx, req.(*GetUnreadMessagesRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetUnreadMessagesResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ChatService_GetUnreadSummary0_HTTP_Handler(srv ChatServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetUnreadSummaryRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatServiceGetUnreadSummary)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetUnreadSummary(ctx, req.(*GetUnreadSummaryRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetUnreadSummaryResponse)
 		return ctx.Result(200, reply)
 	}
 }
 
 type ChatServiceHTTPClient interface {
+	// AddReaction React to a message with an emoji
+	AddReaction(ctx context.Context, req *AddReactionRequest, opts ...http.CallOption) (rsp *AddReactionResponse, err error)
+	// CancelScheduledMessage Cancel a pending scheduled message (author only)
+	CancelScheduledMessage(ctx context.Context, req *CancelScheduledMessageRequest, opts ...http.CallOption) (rsp *CancelScheduledMessageResponse, err error)
+	// ClosePoll Close a poll to further votes (poll author, room admin or moderator)
+	ClosePoll(ctx context.Context, req *ClosePollRequest, opts ...http.CallOption) (rsp *ClosePollResponse, err error)
+	// DeleteMessage Delete a message (author, room admin or moderator)
+	DeleteMessage(ctx context.Context, req *DeleteMessageRequest, opts ...http.CallOption) (rsp *DeleteMessageResponse, err error)
+	// EditMessage Edit a message (author only)
+	EditMessage(ctx context.Context, req *EditMessageRequest, opts ...http.CallOption) (rsp *Message, err error)
+	// ForwardMessage Forward a message into another room
+	ForwardMessage(ctx context.Context, req *ForwardMessageRequest, opts ...http.CallOption) (rsp *Message, err error)
+	// GetMessageReaders List the members who have seen a message
+	GetMessageReaders(ctx context.Context, req *GetMessageReadersRequest, opts ...http.CallOption) (rsp *GetMessageReadersResponse, err error)
 	// GetMessages Get messages for a room
 	GetMessages(ctx context.Context, req *GetMessagesRequest, opts ...http.CallOption) (rsp *GetMessagesResponse, err error)
+	// GetThread Get replies to a thread root message
+	GetThread(ctx context.Context, req *GetThreadRequest, opts ...http.CallOption) (rsp *GetThreadResponse, err error)
+	// GetUnreadMessages List the caller's unread messages in a room, oldest first
+	GetUnreadMessages(ctx context.Context, req *GetUnreadMessagesRequest, opts ...http.CallOption) (rsp *GetUnreadMessagesResponse, err error)
+	// GetUnreadSummary Unread and mention counts for every room the caller belongs to
+	GetUnreadSummary(ctx context.Context, req *GetUnreadSummaryRequest, opts ...http.CallOption) (rsp *GetUnreadSummaryResponse, err error)
+	// ListMentions List the caller's unread mentions across all rooms
+	ListMentions(ctx context.Context, req *ListMentionsRequest, opts ...http.CallOption) (rsp *ListMentionsResponse, err error)
+	// ListPinnedMessages List the messages pinned in a room
+	ListPinnedMessages(ctx context.Context, req *ListPinnedMessagesRequest, opts ...http.CallOption) (rsp *ListPinnedMessagesResponse, err error)
+	// ListReadCursors List every member's read cursor in a room
+	ListReadCursors(ctx context.Context, req *ListReadCursorsRequest, opts ...http.CallOption) (rsp *ListReadCursorsResponse, err error)
+	// ListScheduledMessages List the caller's pending scheduled messages
+	ListScheduledMessages(ctx context.Context, req *ListScheduledMessagesRequest, opts ...http.CallOption) (rsp *ListScheduledMessagesResponse, err error)
 	// MarkAsRead Mark message as read
 	MarkAsRead(ctx context.Context, req *MarkAsReadRequest, opts ...http.CallOption) (rsp *MarkAsReadResponse, err error)
+	// MarkRoomRead Move the caller's read cursor in a room forward and notify the room
+	MarkRoomRead(ctx context.Context, req *MarkRoomReadRequest, opts ...http.CallOption) (rsp *MarkRoomReadResponse, err error)
+	// PinMessage Pin a message to its room (room admin or moderator)
+	PinMessage(ctx context.Context, req *PinMessageRequest, opts ...http.CallOption) (rsp *PinMessageResponse, err error)
+	// RemoveReaction Remove the caller's emoji reaction from a message
+	RemoveReaction(ctx context.Context, req *RemoveReactionRequest, opts ...http.CallOption) (rsp *RemoveReactionResponse, err error)
+	// ScheduleMessage Schedule a message to be sent at a future time
+	ScheduleMessage(ctx context.Context, req *ScheduleMessageRequest, opts ...http.CallOption) (rsp *ScheduledMessage, err error)
+	// SearchMessages Full-text search over messages in the caller's rooms
+	SearchMessages(ctx context.Context, req *SearchMessagesRequest, opts ...http.CallOption) (rsp *SearchMessagesResponse, err error)
 	// SendMessage Send a message
 	SendMessage(ctx context.Context, req *SendMessageRequest, opts ...http.CallOption) (rsp *Message, err error)
+	// UnpinMessage Unpin a message from its room (room admin or moderator)
+	UnpinMessage(ctx context.Context, req *UnpinMessageRequest, opts ...http.CallOption) (rsp *UnpinMessageResponse, err error)
+	// VotePoll Vote in a poll, replacing the caller's previous votes
+	VotePoll(ctx context.Context, req *VotePollRequest, opts ...http.CallOption) (rsp *VotePollResponse, err error)
 }
 
 type ChatServiceHTTPClientImpl struct {
@@ -125,26 +722,278 @@ func NewChatServiceHTTPClient(client *http.Client) ChatServiceHTTPClient {
 	return &ChatServiceHTTPClientImpl{client}
 }
 
-// GetMessages Get messages for a room
-func (c *ChatServiceHTTPClientImpl) GetMessages(ctx context.Context, in *GetMessagesRequest, opts ...http.CallOption) (*GetMessagesResponse, error) {
-	var out GetMessagesResponse
-	pattern := "/api/v1/rooms/{room_id}/messages"
+// AddReaction React to a message with an emoji
+func (c *ChatServiceHTTPClientImpl) AddReaction(ctx context.Context, in *AddReactionRequest, opts ...http.CallOption) (*AddReactionResponse, error) {
+	var out AddReactionResponse
+	pattern := "/api/v1/messages/{message_id}/reactions"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationChatServiceAddReaction))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CancelScheduledMessage Cancel a pending scheduled message (author only)
+func (c *ChatServiceHTTPClientImpl) CancelScheduledMessage(ctx context.Context, in *CancelScheduledMessageRequest, opts ...http.CallOption) (*CancelScheduledMessageResponse, error) {
+	var out CancelScheduledMessageResponse
+	pattern := "/api/v1/messages/scheduled/{id}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationChatServiceCancelScheduledMessage))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "DELETE", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ClosePoll Close a poll to further votes (poll author, room admin or moderator)
+func (c *ChatServiceHTTPClientImpl) ClosePoll(ctx context.Context, in *ClosePollRequest, opts ...http.CallOption) (*ClosePollResponse, error) {
+	var out ClosePollResponse
+	pattern := "/api/v1/messages/{message_id}/poll/close"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationChatServiceClosePoll))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteMessage Delete a message (author, room admin or moderator)
+func (c *ChatServiceHTTPClientImpl) DeleteMessage(ctx context.Context, in *DeleteMessageRequest, opts ...http.CallOption) (*DeleteMessageResponse, error) {
+	var out DeleteMessageResponse
+	pattern := "/api/v1/messages/{message_id}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationChatServiceDeleteMessage))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "DELETE", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// EditMessage Edit a message (author only)
+func (c *ChatServiceHTTPClientImpl) EditMessage(ctx context.Context, in *EditMessageRequest, opts ...http.CallOption) (*Message, error) {
+	var out Message
+	pattern := "/api/v1/messages/{message_id}"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationChatServiceEditMessage))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "PUT", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ForwardMessage Forward a message into another room
+func (c *ChatServiceHTTPClientImpl) ForwardMessage(ctx context.Context, in *ForwardMessageRequest, opts ...http.CallOption) (*Message, error) {
+	var out Message
+	pattern := "/api/v1/messages/{message_id}/forward"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationChatServiceForwardMessage))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMessageReaders List the members who have seen a message
+func (c *ChatServiceHTTPClientImpl) GetMessageReaders(ctx context.Context, in *GetMessageReadersRequest, opts ...http.CallOption) (*GetMessageReadersResponse, error) {
+	var out GetMessageReadersResponse
+	pattern := "/api/v1/messages/{message_id}/readers"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationChatServiceGetMessageReaders))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMessages Get messages for a room
+func (c *ChatServiceHTTPClientImpl) GetMessages(ctx context.Context, in *GetMessagesRequest, opts ...http.CallOption) (*GetMessagesResponse, error) {
+	var out GetMessagesResponse
+	pattern := "/api/v1/rooms/{room_id}/messages"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationChatServiceGetMessages))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetThread Get replies to a thread root message
+func (c *ChatServiceHTTPClientImpl) GetThread(ctx context.Context, in *GetThreadRequest, opts ...http.CallOption) (*GetThreadResponse, error) {
+	var out GetThreadResponse
+	pattern := "/api/v1/messages/{message_id}/thread"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationChatServiceGetThread))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUnreadMessages List the caller's unread messages in a room, oldest first
+func (c *ChatServiceHTTPClientImpl) GetUnreadMessages(ctx context.Context, in *GetUnreadMessagesRequest, opts ...http.CallOption) (*GetUnreadMessagesResponse, error) {
+	var out GetUnreadMessagesResponse
+	pattern := "/api/v1/rooms/{room_id}/unread"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationChatServiceGetUnreadMessages))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUnreadSummary Unread and mention counts for every room the caller belongs to
+func (c *ChatServiceHTTPClientImpl) GetUnreadSummary(ctx context.Context, in *GetUnreadSummaryRequest, opts ...http.CallOption) (*GetUnreadSummaryResponse, error) {
+	var out GetUnreadSummaryResponse
+	pattern := "/api/v1/unread"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationChatServiceGetUnreadSummary))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListMentions List the caller's unread mentions across all rooms
+func (c *ChatServiceHTTPClientImpl) ListMentions(ctx context.Context, in *ListMentionsRequest, opts ...http.CallOption) (*ListMentionsResponse, error) {
+	var out ListMentionsResponse
+	pattern := "/api/v1/mentions"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationChatServiceListMentions))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListPinnedMessages List the messages pinned in a room
+func (c *ChatServiceHTTPClientImpl) ListPinnedMessages(ctx context.Context, in *ListPinnedMessagesRequest, opts ...http.CallOption) (*ListPinnedMessagesResponse, error) {
+	var out ListPinnedMessagesResponse
+	pattern := "/api/v1/rooms/{room_id}/pins"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationChatServiceListPinnedMessages))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListReadCursors List every member's read cursor in a room
+func (c *ChatServiceHTTPClientImpl) ListReadCursors(ctx context.Context, in *ListReadCursorsRequest, opts ...http.CallOption) (*ListReadCursorsResponse, error) {
+	var out ListReadCursorsResponse
+	pattern := "/api/v1/rooms/{room_id}/read_cursors"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationChatServiceListReadCursors))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListScheduledMessages List the caller's pending scheduled messages
+func (c *ChatServiceHTTPClientImpl) ListScheduledMessages(ctx context.Context, in *ListScheduledMessagesRequest, opts ...http.CallOption) (*ListScheduledMessagesResponse, error) {
+	var out ListScheduledMessagesResponse
+	pattern := "/api/v1/messages/scheduled"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationChatServiceListScheduledMessages))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// MarkAsRead Mark message as read
+func (c *ChatServiceHTTPClientImpl) MarkAsRead(ctx context.Context, in *MarkAsReadRequest, opts ...http.CallOption) (*MarkAsReadResponse, error) {
+	var out MarkAsReadResponse
+	pattern := "/api/v1/messages/{message_id}/read"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationChatServiceMarkAsRead))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// MarkRoomRead Move the caller's read cursor in a room forward and notify the room
+func (c *ChatServiceHTTPClientImpl) MarkRoomRead(ctx context.Context, in *MarkRoomReadRequest, opts ...http.CallOption) (*MarkRoomReadResponse, error) {
+	var out MarkRoomReadResponse
+	pattern := "/api/v1/rooms/{room_id}/read"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationChatServiceMarkRoomRead))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PinMessage Pin a message to its room (room admin or moderator)
+func (c *ChatServiceHTTPClientImpl) PinMessage(ctx context.Context, in *PinMessageRequest, opts ...http.CallOption) (*PinMessageResponse, error) {
+	var out PinMessageResponse
+	pattern := "/api/v1/messages/{message_id}/pin"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationChatServicePinMessage))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RemoveReaction Remove the caller's emoji reaction from a message
+func (c *ChatServiceHTTPClientImpl) RemoveReaction(ctx context.Context, in *RemoveReactionRequest, opts ...http.CallOption) (*RemoveReactionResponse, error) {
+	var out RemoveReactionResponse
+	pattern := "/api/v1/messages/{message_id}/reactions"
 	path := binding.EncodeURL(pattern, in, true)
-	opts = append(opts, http.Operation(OperationChatServiceGetMessages))
+	opts = append(opts, http.Operation(OperationChatServiceRemoveReaction))
 	opts = append(opts, http.PathTemplate(pattern))
-	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	err := c.cc.Invoke(ctx, "DELETE", path, nil, &out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return &out, nil
 }
 
-// MarkAsRead Mark message as read
-func (c *ChatServiceHTTPClientImpl) MarkAsRead(ctx context.Context, in *MarkAsReadRequest, opts ...http.CallOption) (*MarkAsReadResponse, error) {
-	var out MarkAsReadResponse
-	pattern := "/api/v1/messages/{message_id}/read"
+// ScheduleMessage Schedule a message to be sent at a future time
+func (c *ChatServiceHTTPClientImpl) ScheduleMessage(ctx context.Context, in *ScheduleMessageRequest, opts ...http.CallOption) (*ScheduledMessage, error) {
+	var out ScheduledMessage
+	pattern := "/api/v1/messages/scheduled"
 	path := binding.EncodeURL(pattern, in, false)
-	opts = append(opts, http.Operation(OperationChatServiceMarkAsRead))
+	opts = append(opts, http.Operation(OperationChatServiceScheduleMessage))
 	opts = append(opts, http.PathTemplate(pattern))
 	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
 	if err != nil {
@@ -153,6 +1002,20 @@ func (c *ChatServiceHTTPClientImpl) MarkAsRead(ctx context.Context, in *MarkAsRe
 	return &out, nil
 }
 
+// SearchMessages Full-text search over messages in the caller's rooms
+func (c *ChatServiceHTTPClientImpl) SearchMessages(ctx context.Context, in *SearchMessagesRequest, opts ...http.CallOption) (*SearchMessagesResponse, error) {
+	var out SearchMessagesResponse
+	pattern := "/api/v1/messages/search"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationChatServiceSearchMessages))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // SendMessage Send a message
 func (c *ChatServiceHTTPClientImpl) SendMessage(ctx context.Context, in *SendMessageRequest, opts ...http.CallOption) (*Message, error) {
 	var out Message
@@ -167,11 +1030,43 @@ func (c *ChatServiceHTTPClientImpl) SendMessage(ctx context.Context, in *SendMes
 	return &out, nil
 }
 
+// UnpinMessage Unpin a message from its room (room admin or moderator)
+func (c *ChatServiceHTTPClientImpl) UnpinMessage(ctx context.Context, in *UnpinMessageRequest, opts ...http.CallOption) (*UnpinMessageResponse, error) {
+	var out UnpinMessageResponse
+	pattern := "/api/v1/messages/{message_id}/pin"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationChatServiceUnpinMessage))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "DELETE", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// VotePoll Vote in a poll, replacing the caller's previous votes
+func (c *ChatServiceHTTPClientImpl) VotePoll(ctx context.Context, in *VotePollRequest, opts ...http.CallOption) (*VotePollResponse, error) {
+	var out VotePollResponse
+	pattern := "/api/v1/messages/{message_id}/poll/votes"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationChatServiceVotePoll))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 const OperationRoomServiceCreateRoom = "/api.chat.v1.RoomService/CreateRoom"
 const OperationRoomServiceGetRoom = "/api.chat.v1.RoomService/GetRoom"
 const OperationRoomServiceJoinRoom = "/api.chat.v1.RoomService/JoinRoom"
 const OperationRoomServiceLeaveRoom = "/api.chat.v1.RoomService/LeaveRoom"
 const OperationRoomServiceListRooms = "/api.chat.v1.RoomService/ListRooms"
+const OperationRoomServiceRenameRoom = "/api.chat.v1.RoomService/RenameRoom"
+const OperationRoomServiceSetMemberRole = "/api.chat.v1.RoomService/SetMemberRole"
+const OperationRoomServiceSetMessageTTL = "/api.chat.v1.RoomService/SetMessageTTL"
+const OperationRoomServiceSetRetentionPolicy = "/api.chat.v1.RoomService/SetRetentionPolicy"
 
 type RoomServiceHTTPServer interface {
 	// CreateRoom Create a new room
@@ -184,6 +1079,14 @@ type RoomServiceHTTPServer interface {
 	LeaveRoom(context.Context, *LeaveRoomRequest) (*LeaveRoomResponse, error)
 	// ListRooms List user's rooms
 	ListRooms(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error)
+	// RenameRoom Rename a room (room admin or moderator)
+	RenameRoom(context.Context, *RenameRoomRequest) (*Room, error)
+	// SetMemberRole Change a member's role (room admin)
+	SetMemberRole(context.Context, *SetMemberRoleRequest) (*RoomMember, error)
+	// SetMessageTTL Set the room's default message lifetime (room admin or moderator)
+	SetMessageTTL(context.Context, *SetMessageTTLRequest) (*Room, error)
+	// SetRetentionPolicy Set how long the room keeps its messages (room admin)
+	SetRetentionPolicy(context.Context, *SetRetentionPolicyRequest) (*Room, error)
 }
 
 func RegisterRoomServiceHTTPServer(s *http.Server, srv RoomServiceHTTPServer) {
@@ -193,6 +1096,10 @@ func RegisterRoomServiceHTTPServer(s *http.Server, srv RoomServiceHTTPServer) {
 	r.GET("/api/v1/users/{user_id}/rooms", _RoomService_ListRooms0_HTTP_Handler(srv))
 	r.POST("/api/v1/rooms/{room_id}/join", _RoomService_JoinRoom0_HTTP_Handler(srv))
 	r.POST("/api/v1/rooms/{room_id}/leave", _RoomService_LeaveRoom0_HTTP_Handler(srv))
+	r.PUT("/api/v1/rooms/{room_id}/message_ttl", _RoomService_SetMessageTTL0_HTTP_Handler(srv))
+	r.PUT("/api/v1/rooms/{room_id}/retention", _RoomService_SetRetentionPolicy0_HTTP_Handler(srv))
+	r.PUT("/api/v1/rooms/{room_id}/name", _RoomService_RenameRoom0_HTTP_Handler(srv))
+	r.PUT("/api/v1/rooms/{room_id}/members/{user_id}/role", _RoomService_SetMemberRole0_HTTP_Handler(srv))
 }
 
 func _RoomService_CreateRoom0_HTTP_Handler(srv RoomServiceHTTPServer) func(ctx http.Context) error {
@@ -311,6 +1218,106 @@ func _RoomService_LeaveRoom0_HTTP_Handler(srv RoomServiceHTTPServer) func(ctx ht
 	}
 }
 
+func _RoomService_SetMessageTTL0_HTTP_Handler(srv RoomServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in SetMessageTTLRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationRoomServiceSetMessageTTL)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SetMessageTTL(ctx, req.(*SetMessageTTLRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*Room)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _RoomService_SetRetentionPolicy0_HTTP_Handler(srv RoomServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in SetRetentionPolicyRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationRoomServiceSetRetentionPolicy)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SetRetentionPolicy(ctx, req.(*SetRetentionPolicyRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*Room)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _RoomService_RenameRoom0_HTTP_Handler(srv RoomServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RenameRoomRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationRoomServiceRenameRoom)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RenameRoom(ctx, req.(*RenameRoomRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*Room)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _RoomService_SetMemberRole0_HTTP_Handler(srv RoomServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in SetMemberRoleRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationRoomServiceSetMemberRole)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SetMemberRole(ctx, req.(*SetMemberRoleRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*RoomMember)
+		return ctx.Result(200, reply)
+	}
+}
+
 type RoomServiceHTTPClient interface {
 	// CreateRoom Create a new room
 	CreateRoom(ctx context.Context, req *CreateRoomRequest, opts ...http.CallOption) (rsp *Room, err error)
@@ -322,6 +1329,14 @@ type RoomServiceHTTPClient interface {
 	LeaveRoom(ctx context.Context, req *LeaveRoomRequest, opts ...http.CallOption) (rsp *LeaveRoomResponse, err error)
 	// ListRooms List user's rooms
 	ListRooms(ctx context.Context, req *ListRoomsRequest, opts ...http.CallOption) (rsp *ListRoomsResponse, err error)
+	// RenameRoom Rename a room (room admin or moderator)
+	RenameRoom(ctx context.Context, req *RenameRoomRequest, opts ...http.CallOption) (rsp *Room, err error)
+	// SetMemberRole Change a member's role (room admin)
+	SetMemberRole(ctx context.Context, req *SetMemberRoleRequest, opts ...http.CallOption) (rsp *RoomMember, err error)
+	// SetMessageTTL Set the room's default message lifetime (room admin or moderator)
+	SetMessageTTL(ctx context.Context, req *SetMessageTTLRequest, opts ...http.CallOption) (rsp *Room, err error)
+	// SetRetentionPolicy Set how long the room keeps its messages (room admin)
+	SetRetentionPolicy(ctx context.Context, req *SetRetentionPolicyRequest, opts ...http.CallOption) (rsp *Room, err error)
 }
 
 type RoomServiceHTTPClientImpl struct {
@@ -401,3 +1416,220 @@ func (c *RoomServiceHTTPClientImpl) ListRooms(ctx context.Context, in *ListRooms
 	}
 	return &out, nil
 }
+
+// RenameRoom Rename a room (room admin or moderator)
+func (c *RoomServiceHTTPClientImpl) RenameRoom(ctx context.Context, in *RenameRoomRequest, opts ...http.CallOption) (*Room, error) {
+	var out Room
+	pattern := "/api/v1/rooms/{room_id}/name"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationRoomServiceRenameRoom))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "PUT", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetMemberRole Change a member's role (room admin)
+func (c *RoomServiceHTTPClientImpl) SetMemberRole(ctx context.Context, in *SetMemberRoleRequest, opts ...http.CallOption) (*RoomMember, error) {
+	var out RoomMember
+	pattern := "/api/v1/rooms/{room_id}/members/{user_id}/role"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationRoomServiceSetMemberRole))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "PUT", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetMessageTTL Set the room's default message lifetime (room admin or moderator)
+func (c *RoomServiceHTTPClientImpl) SetMessageTTL(ctx context.Context, in *SetMessageTTLRequest, opts ...http.CallOption) (*Room, error) {
+	var out Room
+	pattern := "/api/v1/rooms/{room_id}/message_ttl"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationRoomServiceSetMessageTTL))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "PUT", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetRetentionPolicy Set how long the room keeps its messages (room admin)
+func (c *RoomServiceHTTPClientImpl) SetRetentionPolicy(ctx context.Context, in *SetRetentionPolicyRequest, opts ...http.CallOption) (*Room, error) {
+	var out Room
+	pattern := "/api/v1/rooms/{room_id}/retention"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationRoomServiceSetRetentionPolicy))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "PUT", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+const OperationImportServiceGetImportJob = "/api.chat.v1.ImportService/GetImportJob"
+const OperationImportServiceImportHistory = "/api.chat.v1.ImportService/ImportHistory"
+
+type ImportServiceHTTPServer interface {
+	// GetImportJob Get the state of an import started by the caller
+	GetImportJob(context.Context, *GetImportJobRequest) (*ImportJob, error)
+	// ImportHistory Start importing a Slack export zip or JSON-lines file in the background
+	ImportHistory(context.Context, *ImportHistoryRequest) (*ImportJob, error)
+}
+
+func RegisterImportServiceHTTPServer(s *http.Server, srv ImportServiceHTTPServer) {
+	r := s.Route("/")
+	r.POST("/api/v1/imports", _ImportService_ImportHistory0_HTTP_Handler(srv))
+	r.GET("/api/v1/imports/{id}", _ImportService_GetImportJob0_HTTP_Handler(srv))
+}
+
+func _ImportService_ImportHistory0_HTTP_Handler(srv ImportServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ImportHistoryRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationImportServiceImportHistory)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ImportHistory(ctx, req.(*ImportHistoryRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ImportJob)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _ImportService_GetImportJob0_HTTP_Handler(srv ImportServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetImportJobRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationImportServiceGetImportJob)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetImportJob(ctx, req.(*GetImportJobRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ImportJob)
+		return ctx.Result(200, reply)
+	}
+}
+
+type ImportServiceHTTPClient interface {
+	// GetImportJob Get the state of an import started by the caller
+	GetImportJob(ctx context.Context, req *GetImportJobRequest, opts ...http.CallOption) (rsp *ImportJob, err error)
+	// ImportHistory Start importing a Slack export zip or JSON-lines file in the background
+	ImportHistory(ctx context.Context, req *ImportHistoryRequest, opts ...http.CallOption) (rsp *ImportJob, err error)
+}
+
+type ImportServiceHTTPClientImpl struct {
+	cc *http.Client
+}
+
+func NewImportServiceHTTPClient(client *http.Client) ImportServiceHTTPClient {
+	return &ImportServiceHTTPClientImpl{client}
+}
+
+// GetImportJob Get the state of an import started by the caller
+func (c *ImportServiceHTTPClientImpl) GetImportJob(ctx context.Context, in *GetImportJobRequest, opts ...http.CallOption) (*ImportJob, error) {
+	var out ImportJob
+	pattern := "/api/v1/imports/{id}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationImportServiceGetImportJob))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ImportHistory Start importing a Slack export zip or JSON-lines file in the background
+func (c *ImportServiceHTTPClientImpl) ImportHistory(ctx context.Context, in *ImportHistoryRequest, opts ...http.CallOption) (*ImportJob, error) {
+	var out ImportJob
+	pattern := "/api/v1/imports"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationImportServiceImportHistory))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+const OperationPresenceServiceGetPresence = "/api.chat.v1.PresenceService/GetPresence"
+
+type PresenceServiceHTTPServer interface {
+	// GetPresence Get the presence of up to 100 users: the caller and their roommates
+	GetPresence(context.Context, *GetPresenceRequest) (*GetPresenceResponse, error)
+}
+
+func RegisterPresenceServiceHTTPServer(s *http.Server, srv PresenceServiceHTTPServer) {
+	r := s.Route("/")
+	r.GET("/api/v1/presence", _PresenceService_GetPresence0_HTTP_Handler(srv))
+}
+
+func _PresenceService_GetPresence0_HTTP_Handler(srv PresenceServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetPresenceRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPresenceServiceGetPresence)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetPresence(ctx, req.(*GetPresenceRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetPresenceResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+type PresenceServiceHTTPClient interface {
+	// GetPresence Get the presence of up to 100 users: the caller and their roommates
+	GetPresence(ctx context.Context, req *GetPresenceRequest, opts ...http.CallOption) (rsp *GetPresenceResponse, err error)
+}
+
+type PresenceServiceHTTPClientImpl struct {
+	cc *http.Client
+}
+
+func NewPresenceServiceHTTPClient(client *http.Client) PresenceServiceHTTPClient {
+	return &PresenceServiceHTTPClientImpl{client}
+}
+
+// GetPresence Get the presence of up to 100 users: the caller and their roommates
+func (c *PresenceServiceHTTPClientImpl) GetPresence(ctx context.Context, in *GetPresenceRequest, opts ...http.CallOption) (*GetPresenceResponse, error) {
+	var out GetPresenceResponse
+	pattern := "/api/v1/presence"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationPresenceServiceGetPresence))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"flag"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/log"
@@ -15,6 +17,7 @@ import (
 	"github.com/yourusername/chat-app/internal/client"
 	"github.com/yourusername/chat-app/internal/conf"
 	"github.com/yourusername/chat-app/internal/data"
+	"github.com/yourusername/chat-app/internal/middleware"
 	"github.com/yourusername/chat-app/internal/server"
 	"github.com/yourusername/chat-app/internal/service"
 )
@@ -41,6 +44,7 @@ func main() {
 	// Load config from environment
 	dataConf := loadDataConfig()
 	serverConf := loadServerConfig()
+	chatConf := loadChatConfig()
 
 	// ============ 1. CONNECT ============
 	// Connect to Database & Redis
@@ -76,30 +80,57 @@ func main() {
 	roomRepo := data.NewRoomRepo(dataData, logger)
 	bizRoomRepo := data.NewRoomRepoAdapter(roomRepo, logger)
 	messageRepo := data.NewMessageRepo(dataData, logger)
-	chatRepo := data.NewChatRepoAdapter(messageRepo, logger)
+	chatRepo := data.NewChatRepoAdapter(messageRepo, minioStorage, logger)
+	eventPublisher := data.NewEventPublisher(dataData, logger)
+	presenceRepo := data.NewPresenceRepo(dataData, logger)
+	linkUnfurler := data.NewLinkUnfurler(dataData, logger)
 	userRepo := data.NewUserRepo(dataData, logger)
 	bizUserRepo := data.NewUserRepoAdapter(userRepo, logger)
+	importRepo := data.NewImportRepoAdapter(data.NewImportRepo(dataData, logger), minioStorage, logger)
 
 	// Biz layer
-	roomUseCase := biz.NewRoomUseCase(bizRoomRepo, bizUserRepo, logger)
-	chatUseCase := biz.NewChatUseCase(chatRepo, bizRoomRepo, bizUserRepo, logger)
+	systemMessenger := biz.NewSystemMessenger(chatRepo, bizUserRepo, eventPublisher, logger)
+	roomUseCase := biz.NewRoomUseCase(bizRoomRepo, bizUserRepo, systemMessenger, logger)
+	chatUseCase := biz.NewChatUseCase(chatRepo, bizRoomRepo, bizUserRepo, presenceRepo, linkUnfurler, eventPublisher, systemMessenger, chatConf, logger)
+	importUseCase := biz.NewImportUseCase(importRepo, bizUserRepo, chatConf, logger)
+	presenceUseCase := biz.NewPresenceUseCase(presenceRepo, bizRoomRepo, bizUserRepo, eventPublisher, logger)
 
 	// Service layer
 	roomService := service.NewRoomService(roomUseCase, logger)
 	chatService := service.NewChatService(chatUseCase, logger)
+	importService := service.NewImportService(importUseCase, logger)
+	presenceService := service.NewPresenceService(presenceUseCase, logger)
 
 	// ============ 3. CREATE SERVERS ============
 	// gRPC server
 	grpcServer := grpc.NewServer(
 		grpc.Address(grpcAddr),
 		grpc.Middleware(recovery.Recovery()),
+		grpc.StreamInterceptor(middleware.StreamAuthWithUserClient(userClient)),
 	)
 	chatV1.RegisterRoomServiceServer(grpcServer, roomService)
 	chatV1.RegisterChatServiceServer(grpcServer, chatService)
+	chatV1.RegisterImportServiceServer(grpcServer, importService)
+	chatV1.RegisterPresenceServiceServer(grpcServer, presenceService)
 
 	// HTTP server with WebSocket and file upload
 	redisClient := data.NewRedisClient(dataData)
-	httpServer := server.NewHTTPServerWithUserClient(serverConf, roomService, chatService, redisClient, userClient, minioStorage, logger)
+	httpServer := server.NewHTTPServerWithUserClient(serverConf, roomService, chatService, importService, presenceService, presenceUseCase, redisClient, userClient, minioStorage, logger)
+
+	// Background dispatcher for scheduled messages
+	scheduleDispatcher := server.NewScheduleDispatcher(chatUseCase, logger)
+
+	// Background reaper for expired ephemeral messages
+	messageReaper := server.NewMessageReaper(chatUseCase, logger)
+
+	// Background purger for room retention policies
+	retentionPurger := server.NewRetentionPurger(chatUseCase, dataConf, logger)
+
+	// Background unfurler for link previews
+	linkPreviewWorker := server.NewLinkPreviewWorker(chatUseCase, logger)
+
+	// Background sweeper for connections of crashed instances
+	presenceSweeper := server.NewPresenceSweeper(presenceUseCase, logger)
 
 	// ============ 4. START ============
 	app := kratos.New(
@@ -107,7 +138,7 @@ func main() {
 		kratos.Name(Name),
 		kratos.Version(Version),
 		kratos.Logger(logger),
-		kratos.Server(grpcServer, httpServer),
+		kratos.Server(grpcServer, httpServer, scheduleDispatcher, messageReaper, retentionPurger, linkPreviewWorker, presenceSweeper),
 	)
 
 	logHelper.Infof("Chat Service starting - HTTP %s, gRPC %s", httpAddr, grpcAddr)
@@ -150,6 +181,9 @@ func loadDataConfig() *conf.Data {
 		minioPublicURL = "http://localhost:9100"
 	}
 
+	// Retention for rooms without their own policy: forever (default), days or messages
+	retentionValue, _ := strconv.Atoi(os.Getenv("RETENTION_VALUE"))
+
 	return &conf.Data{
 		Database: &conf.Data_Database{
 			Driver: "postgres",
@@ -166,6 +200,28 @@ func loadDataConfig() *conf.Data {
 			UseSsl:     false,
 			PublicUrl:  minioPublicURL,
 		},
+		Retention: &conf.Data_Retention{
+			Mode:  os.Getenv("RETENTION_MODE"),
+			Value: int32(retentionValue),
+		},
+	}
+}
+
+func loadChatConfig() *conf.Chat {
+	// 0 falls back to the built-in default
+	maxPinsPerRoom, _ := strconv.Atoi(os.Getenv("MAX_PINS_PER_ROOM"))
+
+	// Comma-separated IDs of users allowed to import history
+	var adminUserIDs []int64
+	for _, field := range strings.Split(os.Getenv("CHAT_ADMIN_USER_IDS"), ",") {
+		if id, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64); err == nil {
+			adminUserIDs = append(adminUserIDs, id)
+		}
+	}
+
+	return &conf.Chat{
+		MaxPinsPerRoom: int32(maxPinsPerRoom),
+		AdminUserIds:   adminUserIDs,
 	}
 }
 
@@ -16,6 +16,9 @@ var ProviderSet = wire.NewSet(
 
 	// Use cases
 	NewUserUseCase,
+	NewSystemMessenger,
 	NewRoomUseCase,
 	NewChatUseCase,
+	NewImportUseCase,
+	NewPresenceUseCase,
 )
@@ -3,18 +3,30 @@ package biz
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/conf"
 )
 
 var (
 	ErrMessageNotFound   = errors.New("message not found")
 	ErrInvalidMessage    = errors.New("invalid message")
 	ErrCannotSendMessage = errors.New("cannot send message to this room")
+	ErrInvalidThreadRoot = errors.New("invalid thread root message")
+	ErrInvalidCursor     = errors.New("only one of before_id, after_id and around_id may be set")
+
+	// ErrDuplicateClientMsgID is returned by ChatRepo.SendMessage when the
+	// sender already has a message with the same client message ID
+	ErrDuplicateClientMsgID = errors.New("duplicate client_msg_id")
+	ErrClientMsgIDConflict  = errors.New("client_msg_id was already used for a message in another room")
 )
 
+// maxClientMsgIDLength bounds the sender-generated ID of a message
+const maxClientMsgIDLength = 64
+
 // Message represents the message business entity
 type Message struct {
 	ID        int64
@@ -22,10 +34,36 @@ type Message struct {
 	UserID    int64
 	Username  string
 	Content   string
-	Type      string // text, image, file
+	Type      string // text, image, file, poll, system
 	IsEdited  bool
 	EditedAt  *time.Time
+	IsDeleted bool
+	DeletedAt *time.Time
 	CreatedAt time.Time
+	// Thread fields: replies set ParentMessageID, roots track reply stats
+	ParentMessageID int64
+	ReplyCount      int32
+	LastReplyAt     *time.Time
+	// Reactions aggregated per emoji, relative to the requesting user
+	Reactions []*Reaction
+	// ExpiresAt is when the reaper hard-deletes the message (nil = never)
+	ExpiresAt *time.Time
+	// QuotedMessage is the snapshot of the quoted or forwarded message
+	QuotedMessage *QuotedMessage
+	IsForwarded   bool
+	// LinkPreviews are filled in by the unfurler after the message is sent
+	LinkPreviews []*LinkPreview
+	// Format is plain or markdown; markdown content also has a sanitized ContentHTML
+	Format      string
+	ContentHTML string
+	// Poll is set on poll messages, with the tally relative to the requesting user
+	Poll *Poll
+	// SystemEvent is set on system messages, which record room lifecycle events
+	SystemEvent *SystemEvent
+	// ClientMsgID is the sender-generated ID the message was sent with.
+	// Replayed is set when SendMessage returned the earlier send of a retry.
+	ClientMsgID string
+	Replayed    bool
 	// File attachment fields
 	FileURL  string
 	FileName string
@@ -33,6 +71,21 @@ type Message struct {
 	MimeType string
 }
 
+// MessageCursor selects a page of room history. At most one field may be
+// set; the zero value selects the latest messages.
+type MessageCursor struct {
+	BeforeID int64 // messages older than this ID
+	AfterID  int64 // messages newer than this ID
+	AroundID int64 // messages centered on this ID, which is included
+}
+
+// MessagePage is a page of room history, newest first
+type MessagePage struct {
+	Messages []*Message
+	HasMore  bool // more messages in the paging direction (newer for AfterID, older otherwise)
+	HasNewer bool // AroundID only: newer messages follow the page
+}
+
 // MessageRead represents message read receipt
 type MessageRead struct {
 	ID        int64
@@ -45,33 +98,87 @@ type MessageRead struct {
 type ChatRepo interface {
 	SendMessage(ctx context.Context, message *Message) (*Message, error)
 	GetMessage(ctx context.Context, messageID int64) (*Message, error)
-	ListMessages(ctx context.Context, roomID int64, limit, offset int32) ([]*Message, int32, error)
-	EditMessage(ctx context.Context, messageID int64, content string) error
-	DeleteMessage(ctx context.Context, messageID int64) error
-	MarkMessageAsRead(ctx context.Context, messageID, userID int64) error
-	GetUnreadMessages(ctx context.Context, roomID, userID int64) ([]*Message, error)
+	GetMessageByClientMsgID(ctx context.Context, userID int64, clientMsgID string) (*Message, error)
+	ListMessages(ctx context.Context, roomID int64, limit int32, beforeID, afterID int64) ([]*Message, bool, error)
+	ListRoomHistory(ctx context.Context, roomID, afterID int64, limit int32) ([]*Message, error)
+	ListThreadReplies(ctx context.Context, parentID int64, limit int32, afterID int64) ([]*Message, bool, error)
+	SearchMessages(ctx context.Context, userID int64, filter *SearchFilter, limit int32) ([]*SearchResult, bool, error)
+	EditMessage(ctx context.Context, messageID int64, content, contentHTML string) error
+	DeleteMessage(ctx context.Context, messageID, deletedBy int64) error
+	AddReaction(ctx context.Context, messageID, userID int64, emoji string) (bool, error)
+	RemoveReaction(ctx context.Context, messageID, userID int64, emoji string) (bool, error)
+	ListReactions(ctx context.Context, messageIDs []int64, userID int64) (map[int64][]*Reaction, error)
+	CreateMentions(ctx context.Context, mentions []*Mention) error
+	ListUnreadMentions(ctx context.Context, userID int64, limit int32, beforeID int64) ([]*Mention, bool, error)
+	PinMessage(ctx context.Context, roomID, messageID, pinnedBy int64, maxPins int) (bool, error)
+	UnpinMessage(ctx context.Context, roomID, messageID int64) (bool, error)
+	ListPins(ctx context.Context, roomID int64) ([]*Pin, error)
+	CreateScheduledMessage(ctx context.Context, scheduled *ScheduledMessage) (*ScheduledMessage, error)
+	ListPendingScheduledMessages(ctx context.Context, userID, roomID int64) ([]*ScheduledMessage, error)
+	CancelScheduledMessage(ctx context.Context, id, userID int64) (bool, error)
+	ClaimDueScheduledMessages(ctx context.Context, now, reclaimBefore time.Time, limit int32) ([]*ScheduledMessage, error)
+	CompleteScheduledMessage(ctx context.Context, id, messageID int64, failure string) error
+	DeleteExpiredMessages(ctx context.Context, now time.Time, limit int32) ([]*Message, error)
+	DeleteRetainedMessages(ctx context.Context, now time.Time, defaultPolicy RetentionPolicy, limit int32) ([]*Message, error)
+	QueueLinkPreviews(ctx context.Context, messageID int64) error
+	ClaimLinkPreviewJobs(ctx context.Context, now, reclaimBefore time.Time, limit int32) ([]int64, error)
+	CompleteLinkPreviewJob(ctx context.Context, messageID int64, claimedAt time.Time) error
+	SaveLinkPreviews(ctx context.Context, messageID int64, previews []*LinkPreview) error
+	ListLinkPreviews(ctx context.Context, messageIDs []int64) (map[int64][]*LinkPreview, error)
+	ListPolls(ctx context.Context, messageIDs []int64, userID int64) (map[int64]*Poll, error)
+	SetPollVotes(ctx context.Context, messageID, userID int64, optionIDs []int32) (bool, error)
+	ClosePoll(ctx context.Context, messageID int64, closedAt time.Time) (bool, error)
+	CloseDuePolls(ctx context.Context, now time.Time, limit int32) ([]int64, error)
+	MarkRoomRead(ctx context.Context, roomID, userID, messageID int64, readAt time.Time) (bool, error)
+	GetLastReadMessageID(ctx context.Context, roomID, userID int64) (int64, error)
+	CountUnread(ctx context.Context, roomID, userID int64) (int32, error)
+	ListReadCursors(ctx context.Context, roomID int64) ([]*ReadCursor, error)
+	ListUnreadMessages(ctx context.Context, roomID, userID int64, limit int32) ([]*Message, bool, error)
+	ListRoomUnreads(ctx context.Context, userID int64, maxCount int32) ([]*RoomUnread, error)
 }
 
 // ChatUseCase contains chat business logic
 type ChatUseCase struct {
-	repo     ChatRepo
-	roomRepo RoomRepo
-	userRepo UserRepo
-	log      *log.Helper
+	repo      ChatRepo
+	roomRepo  RoomRepo
+	userRepo  UserRepo
+	presence  PresenceRepo
+	unfurler  LinkUnfurler
+	publisher EventPublisher
+	system    *SystemMessenger
+	log       *log.Helper
+
+	maxPinsPerRoom int
 }
 
 // NewChatUseCase creates a new chat use case
-func NewChatUseCase(repo ChatRepo, roomRepo RoomRepo, userRepo UserRepo, logger log.Logger) *ChatUseCase {
+func NewChatUseCase(repo ChatRepo, roomRepo RoomRepo, userRepo UserRepo, presence PresenceRepo, unfurler LinkUnfurler, publisher EventPublisher, system *SystemMessenger, chatConf *conf.Chat, logger log.Logger) *ChatUseCase {
+	maxPinsPerRoom := int(chatConf.GetMaxPinsPerRoom())
+	if maxPinsPerRoom <= 0 {
+		maxPinsPerRoom = defaultMaxPinsPerRoom
+	}
+
 	return &ChatUseCase{
-		repo:     repo,
-		roomRepo: roomRepo,
-		userRepo: userRepo,
-		log:      log.NewHelper(log.With(logger, "module", "biz/chat")),
+		repo:      repo,
+		roomRepo:  roomRepo,
+		userRepo:  userRepo,
+		presence:  presence,
+		unfurler:  unfurler,
+		publisher: publisher,
+		system:    system,
+		log:       log.NewHelper(log.With(logger, "module", "biz/chat")),
+
+		maxPinsPerRoom: maxPinsPerRoom,
 	}
 }
 
 // SendMessage sends a message to a room
 func (uc *ChatUseCase) SendMessage(ctx context.Context, userID int64, req *chatV1.SendMessageRequest) (*Message, error) {
+	return uc.sendMessage(ctx, userID, req, false)
+}
+
+// sendMessage sends a new message, marking it forwarded when it re-sends its quote
+func (uc *ChatUseCase) sendMessage(ctx context.Context, userID int64, req *chatV1.SendMessageRequest, forwarded bool) (*Message, error) {
 	uc.log.Infof("Sending message from user %d to room %d", userID, req.RoomId)
 
 	// Validate input
@@ -88,12 +195,40 @@ func (uc *ChatUseCase) SendMessage(ctx context.Context, userID int64, req *chatV
 		return nil, ErrCannotSendMessage
 	}
 
+	// A retried send returns the message its first attempt stored
+	if req.ClientMsgId != "" {
+		if original, err := uc.replayedMessage(ctx, userID, req); original != nil || err != nil {
+			return original, err
+		}
+	}
+
+	// Replies must target a live root message in the same room
+	if req.ParentMessageId != 0 {
+		parent, err := uc.repo.GetMessage(ctx, req.ParentMessageId)
+		if err != nil || !isThreadRoot(parent, req.RoomId) {
+			return nil, ErrInvalidThreadRoot
+		}
+	}
+
+	var quoted *QuotedMessage
+	if req.QuotedMessageId != 0 {
+		quoted, err = uc.quoteMessage(ctx, userID, req.QuotedMessageId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Get user info for username
 	user, err := uc.userRepo.GetUserByID(ctx, userID)
 	if err != nil {
 		return nil, ErrUserNotFound
 	}
 
+	expiresAt, err := uc.messageExpiry(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create message
 	message := &Message{
 		RoomID:   req.RoomId,
@@ -105,18 +240,69 @@ func (uc *ChatUseCase) SendMessage(ctx context.Context, userID int64, req *chatV
 		FileName: req.FileName,
 		FileSize: req.FileSize,
 		MimeType: req.MimeType,
+
+		ParentMessageID: req.ParentMessageId,
+		ExpiresAt:       quoteExpiry(expiresAt, quoted),
+		QuotedMessage:   quoted,
+		IsForwarded:     forwarded,
+
+		Format:      req.Format,
+		ContentHTML: renderContent(req.Format, req.Content),
+		ClientMsgID: req.ClientMsgId,
+	}
+	if req.Type == "poll" {
+		message.Poll = newPoll(req.Poll)
 	}
 
 	sentMessage, err := uc.repo.SendMessage(ctx, message)
+	if errors.Is(err, ErrDuplicateClientMsgID) {
+		// A concurrent retry stored the message first
+		original, err := uc.replayedMessage(ctx, userID, req)
+		if original == nil && err == nil {
+			err = ErrDuplicateClientMsgID
+		}
+		return original, err
+	}
 	if err != nil {
 		uc.log.Errorf("Failed to send message: %v", err)
 		return nil, err
 	}
 
+	// Thread replies fan out separately so they don't land in the room timeline
+	if sentMessage.ParentMessageID != 0 {
+		uc.publishEvent(ctx, &RoomEvent{
+			Type:   EventThreadReply,
+			RoomID: sentMessage.RoomID,
+			Data:   messageEventData(sentMessage),
+		})
+	}
+
+	uc.processMentions(ctx, sentMessage)
+	uc.queueLinkPreviews(ctx, sentMessage, false)
+
 	uc.log.Infof("Message sent successfully: id=%d, room=%d, user=%d", sentMessage.ID, sentMessage.RoomID, sentMessage.UserID)
 	return sentMessage, nil
 }
 
+// replayedMessage returns the user's earlier message sent with the request's
+// client message ID, marked Replayed, or nil if there is none
+func (uc *ChatUseCase) replayedMessage(ctx context.Context, userID int64, req *chatV1.SendMessageRequest) (*Message, error) {
+	original, err := uc.repo.GetMessageByClientMsgID(ctx, userID, req.ClientMsgId)
+	if errors.Is(err, ErrMessageNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if original.RoomID != req.RoomId {
+		return nil, ErrClientMsgIDConflict
+	}
+
+	original.Replayed = true
+	uc.log.Infof("Replayed message: id=%d, user=%d, client_msg_id=%s", original.ID, userID, req.ClientMsgId)
+	return original, nil
+}
+
 // GetMessage retrieves a message if user has access
 func (uc *ChatUseCase) GetMessage(ctx context.Context, userID, messageID int64) (*Message, error) {
 	message, err := uc.repo.GetMessage(ctx, messageID)
@@ -136,90 +322,283 @@ func (uc *ChatUseCase) GetMessage(ctx context.Context, userID, messageID int64)
 	return message, nil
 }
 
-// ListMessages lists messages in a room
-func (uc *ChatUseCase) ListMessages(ctx context.Context, userID, roomID int64, limit, offset int32) ([]*Message, int32, error) {
+// ListMessages lists a page of messages in a room
+func (uc *ChatUseCase) ListMessages(ctx context.Context, userID, roomID int64, limit int32, cursor MessageCursor) (*MessagePage, error) {
+	if !cursor.isValid() {
+		return nil, ErrInvalidCursor
+	}
+
 	// Check if user has access to the room
 	isMember, err := uc.roomRepo.IsUserInRoom(ctx, roomID, userID)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	if !isMember {
+		return nil, ErrRoomAccessDenied
+	}
+
+	var page *MessagePage
+	if cursor.AroundID != 0 {
+		page, err = uc.listMessagesAround(ctx, roomID, limit, cursor.AroundID)
+	} else {
+		page = &MessagePage{}
+		page.Messages, page.HasMore, err = uc.repo.ListMessages(ctx, roomID, limit, cursor.BeforeID, cursor.AfterID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.attachReactions(ctx, userID, page.Messages); err != nil {
+		return nil, err
+	}
+	if err := uc.attachLinkPreviews(ctx, page.Messages); err != nil {
+		return nil, err
+	}
+	if err := uc.attachPolls(ctx, userID, page.Messages); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// listMessagesAround returns the target message with older messages filling
+// the larger half of the page and newer messages the rest
+func (uc *ChatUseCase) listMessagesAround(ctx context.Context, roomID int64, limit int32, aroundID int64) (*MessagePage, error) {
+	target, err := uc.repo.GetMessage(ctx, aroundID)
+	if err != nil || target.RoomID != roomID || target.ParentMessageID != 0 {
+		return nil, ErrMessageNotFound
+	}
+
+	newerLimit := limit / 2
+	olderLimit := limit - newerLimit
+
+	// Everything before aroundID+1 starts with the target itself
+	older, hasOlder, err := uc.repo.ListMessages(ctx, roomID, olderLimit, aroundID+1, 0)
+	if err != nil {
+		return nil, err
+	}
+	newer, hasNewer, err := uc.repo.ListMessages(ctx, roomID, newerLimit, 0, aroundID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MessagePage{
+		Messages: append(newer, older...),
+		HasMore:  hasOlder,
+		HasNewer: hasNewer,
+	}, nil
+}
+
+// GetThread returns a thread root and a page of its replies, oldest first
+func (uc *ChatUseCase) GetThread(ctx context.Context, userID, messageID int64, limit int32, afterID int64) (*Message, []*Message, bool, error) {
+	root, err := uc.repo.GetMessage(ctx, messageID)
+	if err != nil {
+		return nil, nil, false, ErrMessageNotFound
+	}
+
+	// Check if user has access to the room
+	isMember, err := uc.roomRepo.IsUserInRoom(ctx, root.RoomID, userID)
+	if err != nil {
+		return nil, nil, false, err
 	}
 	if !isMember {
-		return nil, 0, ErrRoomAccessDenied
+		return nil, nil, false, ErrRoomAccessDenied
+	}
+
+	if root.ParentMessageID != 0 {
+		return nil, nil, false, ErrInvalidThreadRoot
 	}
 
-	return uc.repo.ListMessages(ctx, roomID, limit, offset)
+	replies, hasMore, err := uc.repo.ListThreadReplies(ctx, messageID, limit, afterID)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	thread := append([]*Message{root}, replies...)
+	if err := uc.attachReactions(ctx, userID, thread); err != nil {
+		return nil, nil, false, err
+	}
+	if err := uc.attachLinkPreviews(ctx, thread); err != nil {
+		return nil, nil, false, err
+	}
+	if err := uc.attachPolls(ctx, userID, thread); err != nil {
+		return nil, nil, false, err
+	}
+
+	return root, replies, hasMore, nil
 }
 
-// EditMessage edits a message (only by the author)
-func (uc *ChatUseCase) EditMessage(ctx context.Context, userID, messageID int64, content string) error {
+// EditMessage edits a message (only by the author) and notifies the room
+func (uc *ChatUseCase) EditMessage(ctx context.Context, userID, messageID int64, content string) (*Message, error) {
 	// Get message
 	message, err := uc.repo.GetMessage(ctx, messageID)
-	if err != nil {
-		return ErrMessageNotFound
+	if err != nil || message.IsDeleted {
+		return nil, ErrMessageNotFound
 	}
 
 	// Check if user is the author
 	if message.UserID != userID {
-		return errors.New("can only edit your own messages")
+		return nil, errors.New("can only edit your own messages")
+	}
+
+	// A poll's content is its question, which voters have already answered
+	if message.Type == "poll" {
+		return nil, errors.New("polls can't be edited")
+	}
+	if message.Type == "system" {
+		return nil, errors.New("system messages can't be edited")
 	}
 
 	// Validate content
 	if content == "" {
-		return ErrInvalidMessage
+		return nil, ErrInvalidMessage
+	}
+	if len(content) > 4000 {
+		return nil, errors.New("message content too long")
+	}
+
+	hadLinks := len(extractURLs(message.Content)) > 0
+	if err := uc.repo.EditMessage(ctx, messageID, content, renderContent(message.Format, content)); err != nil {
+		uc.log.Errorf("Failed to edit message %d: %v", messageID, err)
+		return nil, err
+	}
+
+	edited, err := uc.repo.GetMessage(ctx, messageID)
+	if err != nil {
+		return nil, ErrMessageNotFound
 	}
 
-	return uc.repo.EditMessage(ctx, messageID, content)
+	data := map[string]interface{}{
+		"message_id": edited.ID,
+		"user_id":    edited.UserID,
+		"content":    edited.Content,
+		"is_edited":  edited.IsEdited,
+	}
+	if edited.EditedAt != nil {
+		data["edited_at"] = edited.EditedAt.Unix()
+	}
+	if edited.ContentHTML != "" {
+		data["content_html"] = edited.ContentHTML
+	}
+	uc.publishEvent(ctx, &RoomEvent{
+		Type:   EventMessageEdited,
+		RoomID: edited.RoomID,
+		Data:   data,
+	})
+
+	uc.queueLinkPreviews(ctx, edited, hadLinks)
+
+	uc.log.Infof("Message edited: id=%d, room=%d, user=%d", edited.ID, edited.RoomID, userID)
+	return edited, nil
 }
 
-// DeleteMessage deletes a message (only by the author or room admin)
+// DeleteMessage replaces a message with a tombstone (by the author or a room admin/moderator)
 func (uc *ChatUseCase) DeleteMessage(ctx context.Context, userID, messageID int64) error {
 	// Get message
 	message, err := uc.repo.GetMessage(ctx, messageID)
-	if err != nil {
+	if err != nil || message.IsDeleted {
 		return ErrMessageNotFound
 	}
 
-	// Check if user is the author
+	// Authors can delete their own messages, moderators anyone's
 	if message.UserID != userID {
-		// TODO: Also allow room admins to delete messages
-		return errors.New("can only delete your own messages")
+		isModerator, err := uc.isRoomModerator(ctx, message.RoomID, userID)
+		if err != nil {
+			return err
+		}
+		if !isModerator {
+			return errors.New("can only delete your own messages")
+		}
+	}
+
+	if err := uc.repo.DeleteMessage(ctx, messageID, userID); err != nil {
+		uc.log.Errorf("Failed to delete message %d: %v", messageID, err)
+		return err
 	}
 
-	return uc.repo.DeleteMessage(ctx, messageID)
+	uc.publishEvent(ctx, &RoomEvent{
+		Type:   EventMessageDeleted,
+		RoomID: message.RoomID,
+		Data: map[string]interface{}{
+			"message_id": messageID,
+			"deleted_by": userID,
+		},
+	})
+
+	uc.log.Infof("Message deleted: id=%d, room=%d, by user=%d", messageID, message.RoomID, userID)
+	return nil
 }
 
-// MarkMessageAsRead marks a message as read by a user
+// MarkMessageAsRead moves the user's read cursor in the message's room up to the message
 func (uc *ChatUseCase) MarkMessageAsRead(ctx context.Context, userID, messageID int64) error {
-	// Get message to verify room access
+	// Get message to find its room
 	message, err := uc.repo.GetMessage(ctx, messageID)
 	if err != nil {
 		return ErrMessageNotFound
 	}
 
-	// Check if user has access to the room
-	isMember, err := uc.roomRepo.IsUserInRoom(ctx, message.RoomID, userID)
-	if err != nil {
-		return err
-	}
-	if !isMember {
-		return ErrRoomAccessDenied
+	_, _, err = uc.MarkRoomRead(ctx, userID, message.RoomID, messageID)
+	return err
+}
+
+// isValid reports whether at most one cursor field is set
+func (c MessageCursor) isValid() bool {
+	set := 0
+	for _, id := range []int64{c.BeforeID, c.AfterID, c.AroundID} {
+		if id < 0 {
+			return false
+		}
+		if id > 0 {
+			set++
+		}
 	}
+	return set <= 1
+}
 
-	return uc.repo.MarkMessageAsRead(ctx, messageID, userID)
+// isThreadRoot reports whether replies in the room can be attached to the message.
+// Threads are one level deep, so replies can't be replied to.
+func isThreadRoot(message *Message, roomID int64) bool {
+	return message.RoomID == roomID && message.ParentMessageID == 0 && !message.IsDeleted
 }
 
-// GetUnreadMessages gets unread messages for a user in a room
-func (uc *ChatUseCase) GetUnreadMessages(ctx context.Context, userID, roomID int64) ([]*Message, error) {
-	// Check if user has access to the room
-	isMember, err := uc.roomRepo.IsUserInRoom(ctx, roomID, userID)
+// isRoomModerator reports whether the user is an admin or moderator of the room
+func (uc *ChatUseCase) isRoomModerator(ctx context.Context, roomID, userID int64) (bool, error) {
+	role, err := uc.roomRepo.GetMemberRole(ctx, roomID, userID)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, ErrUserNotInRoom) {
+			return false, nil
+		}
+		return false, err
 	}
-	if !isMember {
-		return nil, ErrRoomAccessDenied
+	return role == "admin" || role == "moderator", nil
+}
+
+// publishEvent broadcasts a room event to connected clients.
+// The change is already persisted, so failures are only logged.
+func (uc *ChatUseCase) publishEvent(ctx context.Context, event *RoomEvent) {
+	if uc.publisher == nil {
+		return
+	}
+	if err := uc.publisher.PublishRoomEvent(ctx, event); err != nil {
+		uc.log.Warnf("Failed to publish %s event to room %d: %v", event.Type, event.RoomID, err)
+	}
+}
+
+// publishMessage broadcasts a new message to the room the same way the
+// WebSocket send path does. The message is already stored, so failures are only logged.
+func (uc *ChatUseCase) publishMessage(ctx context.Context, message *Message) {
+	if uc.publisher == nil {
+		return
+	}
+	if err := uc.publisher.PublishMessage(ctx, message); err != nil {
+		uc.log.Warnf("Failed to publish message %d to room %d: %v", message.ID, message.RoomID, err)
 	}
+}
 
-	return uc.repo.GetUnreadMessages(ctx, roomID, userID)
+// PublishMessage broadcasts a message sent through the API. WebSocket sends
+// are published by the connection itself.
+func (uc *ChatUseCase) PublishMessage(ctx context.Context, message *Message) {
+	uc.publishMessage(ctx, message)
 }
 
 // validateSendMessageRequest validates message sending input
@@ -234,10 +613,17 @@ func (uc *ChatUseCase) validateSendMessageRequest(req *chatV1.SendMessageRequest
 		"text":  true,
 		"image": true,
 		"file":  true,
+		"poll":  true,
 	}
 	if !validTypes[req.Type] {
 		return errors.New("invalid message type")
 	}
+	if req.Format == "" {
+		req.Format = MessageFormatPlain
+	}
+	if !isValidFormat(req.Format) {
+		return errors.New("invalid message format")
+	}
 
 	// For file/image messages, file_url is required
 	if req.Type == "image" || req.Type == "file" {
@@ -247,15 +633,32 @@ func (uc *ChatUseCase) validateSendMessageRequest(req *chatV1.SendMessageRequest
 		if req.FileName == "" {
 			return errors.New("file_name is required for image/file messages")
 		}
-	} else {
-		// For text messages, content is required
+	} else if req.Type == "poll" {
+		if err := validatePollRequest(req.Poll, time.Now()); err != nil {
+			return err
+		}
+		// The question doubles as the content for search, previews and notifications
 		if req.Content == "" {
+			req.Content = strings.TrimSpace(req.Poll.Question)
+		}
+	} else {
+		// For text messages, content is required unless a message is quoted
+		if req.Content == "" && req.QuotedMessageId == 0 {
 			return errors.New("message content cannot be empty")
 		}
 	}
+	if req.Poll != nil && req.Type != "poll" {
+		return errors.New("poll is only allowed on poll messages")
+	}
 
 	if len(req.Content) > 4000 {
 		return errors.New("message content too long")
 	}
+	if len(req.ClientMsgId) > maxClientMsgIDLength {
+		return errors.New("client_msg_id must be at most 64 characters")
+	}
+	if err := validateMessageTTL(time.Duration(req.ExpiresIn) * time.Second); err != nil {
+		return err
+	}
 	return nil
 }
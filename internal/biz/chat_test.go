@@ -3,6 +3,8 @@ package biz
 import (
 	"context"
 	"io"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,17 +16,34 @@ import (
 
 type MockChatRepo struct {
 	messages    map[int64]*Message
-	readReceipts map[int64]map[int64]bool // messageID -> userID -> read
+	readCursors  map[int64]map[int64]int64 // roomID -> userID -> last read message ID
+	reactions    []*mockReaction             // in the order they were added
+	mentions     []*Mention
+	pins         []*Pin // in the order they were pinned
+	scheduled    []*ScheduledMessage
+	linkJobs     []int64 // queued for the unfurler
+	linkClaims   map[int64]time.Time
+	linkPreviews map[int64][]*LinkPreview
+	polls        map[int64]*Poll             // definitions, tallied from pollVotes
+	pollVotes    map[int64]map[int64][]int32 // messageID -> userID -> option IDs
+	retention    map[int64]RetentionPolicy   // rooms' own policies, as the repo reads them from rooms
 	nextID      int64
 	sendErr     error
 	editErr     error
 	deleteErr   error
+	saveLinkErr error
+}
+
+type mockReaction struct {
+	messageID int64
+	userID    int64
+	emoji     string
 }
 
 func NewMockChatRepo() *MockChatRepo {
 	return &MockChatRepo{
 		messages:     make(map[int64]*Message),
-		readReceipts: make(map[int64]map[int64]bool),
+		readCursors:  make(map[int64]map[int64]int64),
 		nextID:       1,
 	}
 }
@@ -33,10 +52,22 @@ func (m *MockChatRepo) SendMessage(ctx context.Context, message *Message) (*Mess
 	if m.sendErr != nil {
 		return nil, m.sendErr
 	}
+	if message.ClientMsgID != "" {
+		if _, err := m.GetMessageByClientMsgID(ctx, message.UserID, message.ClientMsgID); err == nil {
+			return nil, ErrDuplicateClientMsgID
+		}
+	}
 	message.ID = m.nextID
 	message.CreatedAt = time.Now()
 	m.nextID++
 	m.messages[message.ID] = message
+	if message.Poll != nil {
+		m.savePoll(message.ID, message.Poll)
+	}
+	if parent, ok := m.messages[message.ParentMessageID]; ok {
+		parent.ReplyCount++
+		parent.LastReplyAt = &message.CreatedAt
+	}
 	return message, nil
 }
 
@@ -47,22 +78,86 @@ func (m *MockChatRepo) GetMessage(ctx context.Context, messageID int64) (*Messag
 	return nil, ErrMessageNotFound
 }
 
-func (m *MockChatRepo) ListMessages(ctx context.Context, roomID int64, limit, offset int32) ([]*Message, int32, error) {
+func (m *MockChatRepo) GetMessageByClientMsgID(ctx context.Context, userID int64, clientMsgID string) (*Message, error) {
+	for _, msg := range m.messages {
+		if msg.UserID == userID && msg.ClientMsgID == clientMsgID {
+			copied := *msg
+			return &copied, nil
+		}
+	}
+	return nil, ErrMessageNotFound
+}
+
+func (m *MockChatRepo) ListMessages(ctx context.Context, roomID int64, limit int32, beforeID, afterID int64) ([]*Message, bool, error) {
 	var messages []*Message
 	for _, msg := range m.messages {
-		if msg.RoomID == roomID {
-			messages = append(messages, msg)
+		if msg.RoomID != roomID || msg.ParentMessageID != 0 {
+			continue
+		}
+		if (beforeID > 0 && msg.ID >= beforeID) || (afterID > 0 && msg.ID <= afterID) {
+			continue
+		}
+		messages = append(messages, msg)
+	}
+
+	// Take the page nearest the cursor, then return it newest first
+	if afterID > 0 {
+		sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+	} else {
+		sort.Slice(messages, func(i, j int) bool { return messages[i].ID > messages[j].ID })
+	}
+	hasMore := len(messages) > int(limit)
+	if hasMore {
+		messages = messages[:limit]
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID > messages[j].ID })
+	return messages, hasMore, nil
+}
+
+func (m *MockChatRepo) ListThreadReplies(ctx context.Context, parentID int64, limit int32, afterID int64) ([]*Message, bool, error) {
+	var replies []*Message
+	for _, msg := range m.messages {
+		if msg.ParentMessageID == parentID && msg.ID > afterID {
+			replies = append(replies, msg)
+		}
+	}
+	sort.Slice(replies, func(i, j int) bool { return replies[i].ID < replies[j].ID })
+	if len(replies) > int(limit) {
+		return replies[:limit], true, nil
+	}
+	return replies, false, nil
+}
+
+// SearchMessages does a plain substring match; room membership is the data layer's job
+func (m *MockChatRepo) SearchMessages(ctx context.Context, userID int64, filter *SearchFilter, limit int32) ([]*SearchResult, bool, error) {
+	var results []*SearchResult
+	for _, msg := range m.messages {
+		if msg.IsDeleted || (filter.RoomID != 0 && msg.RoomID != filter.RoomID) || (filter.AuthorID != 0 && msg.UserID != filter.AuthorID) {
+			continue
+		}
+		if (filter.Type != "" && msg.Type != filter.Type) || (filter.BeforeID > 0 && msg.ID >= filter.BeforeID) {
+			continue
 		}
+		if !strings.Contains(strings.ToLower(msg.Content), strings.ToLower(filter.Query)) ||
+			!strings.Contains(strings.ToLower(msg.FileName), strings.ToLower(filter.FileName)) {
+			continue
+		}
+		results = append(results, &SearchResult{Message: msg, Snippet: msg.Content})
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Message.ID > results[j].Message.ID })
+	if len(results) > int(limit) {
+		return results[:limit], true, nil
 	}
-	return messages, int32(len(messages)), nil
+	return results, false, nil
 }
 
-func (m *MockChatRepo) EditMessage(ctx context.Context, messageID int64, content string) error {
+func (m *MockChatRepo) EditMessage(ctx context.Context, messageID int64, content, contentHTML string) error {
 	if m.editErr != nil {
 		return m.editErr
 	}
 	if msg, ok := m.messages[messageID]; ok {
 		msg.Content = content
+		msg.ContentHTML = contentHTML
 		msg.IsEdited = true
 		now := time.Now()
 		msg.EditedAt = &now
@@ -71,35 +166,478 @@ func (m *MockChatRepo) EditMessage(ctx context.Context, messageID int64, content
 	return ErrMessageNotFound
 }
 
-func (m *MockChatRepo) DeleteMessage(ctx context.Context, messageID int64) error {
+func (m *MockChatRepo) DeleteMessage(ctx context.Context, messageID, deletedBy int64) error {
 	if m.deleteErr != nil {
 		return m.deleteErr
 	}
-	if _, ok := m.messages[messageID]; ok {
-		delete(m.messages, messageID)
+	if msg, ok := m.messages[messageID]; ok {
+		now := time.Now()
+		msg.Content = ""
+		msg.FileURL = ""
+		msg.IsDeleted = true
+		msg.DeletedAt = &now
 		return nil
 	}
 	return ErrMessageNotFound
 }
 
-func (m *MockChatRepo) MarkMessageAsRead(ctx context.Context, messageID, userID int64) error {
-	if m.readReceipts[messageID] == nil {
-		m.readReceipts[messageID] = make(map[int64]bool)
+func (m *MockChatRepo) AddReaction(ctx context.Context, messageID, userID int64, emoji string) (bool, error) {
+	for _, r := range m.reactions {
+		if r.messageID == messageID && r.userID == userID && r.emoji == emoji {
+			return false, nil
+		}
+	}
+	m.reactions = append(m.reactions, &mockReaction{messageID: messageID, userID: userID, emoji: emoji})
+	return true, nil
+}
+
+func (m *MockChatRepo) RemoveReaction(ctx context.Context, messageID, userID int64, emoji string) (bool, error) {
+	for i, r := range m.reactions {
+		if r.messageID == messageID && r.userID == userID && r.emoji == emoji {
+			m.reactions = append(m.reactions[:i], m.reactions[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockChatRepo) ListReactions(ctx context.Context, messageIDs []int64, userID int64) (map[int64][]*Reaction, error) {
+	result := make(map[int64][]*Reaction)
+	for _, messageID := range messageIDs {
+		byEmoji := make(map[string]*Reaction)
+		for _, r := range m.reactions {
+			if r.messageID != messageID {
+				continue
+			}
+			reaction, ok := byEmoji[r.emoji]
+			if !ok {
+				reaction = &Reaction{Emoji: r.emoji}
+				byEmoji[r.emoji] = reaction
+				result[messageID] = append(result[messageID], reaction)
+			}
+			reaction.Count++
+			if r.userID == userID {
+				reaction.ReactedByMe = true
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MockChatRepo) CreateMentions(ctx context.Context, mentions []*Mention) error {
+	for _, mention := range mentions {
+		mention.ID = int64(len(m.mentions) + 1)
+		m.mentions = append(m.mentions, mention)
 	}
-	m.readReceipts[messageID][userID] = true
 	return nil
 }
 
-func (m *MockChatRepo) GetUnreadMessages(ctx context.Context, roomID, userID int64) ([]*Message, error) {
-	var unread []*Message
+func (m *MockChatRepo) ListUnreadMentions(ctx context.Context, userID int64, limit int32, beforeID int64) ([]*Mention, bool, error) {
+	var mentions []*Mention
+	for i := len(m.mentions) - 1; i >= 0; i-- {
+		mention := m.mentions[i]
+		if mention.UserID != userID || (beforeID != 0 && mention.ID >= beforeID) {
+			continue
+		}
+		if m.readCursors[mention.RoomID][userID] >= mention.MessageID {
+			continue
+		}
+		mention.Message = m.messages[mention.MessageID]
+		mentions = append(mentions, mention)
+	}
+	if len(mentions) > int(limit) {
+		return mentions[:limit], true, nil
+	}
+	return mentions, false, nil
+}
+
+func (m *MockChatRepo) PinMessage(ctx context.Context, roomID, messageID, pinnedBy int64, maxPins int) (bool, error) {
+	count := 0
+	for _, pin := range m.pins {
+		if pin.Message.ID == messageID {
+			return false, nil
+		}
+		if pin.Message.RoomID == roomID && !pin.Message.IsDeleted {
+			count++
+		}
+	}
+	if count >= maxPins {
+		return false, ErrPinLimitReached
+	}
+	m.pins = append(m.pins, &Pin{Message: m.messages[messageID], PinnedBy: pinnedBy, PinnedAt: time.Now()})
+	return true, nil
+}
+
+func (m *MockChatRepo) UnpinMessage(ctx context.Context, roomID, messageID int64) (bool, error) {
+	for i, pin := range m.pins {
+		if pin.Message.ID == messageID {
+			m.pins = append(m.pins[:i], m.pins[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockChatRepo) ListPins(ctx context.Context, roomID int64) ([]*Pin, error) {
+	var pins []*Pin
+	for i := len(m.pins) - 1; i >= 0; i-- {
+		if m.pins[i].Message.RoomID == roomID && !m.pins[i].Message.IsDeleted {
+			pins = append(pins, m.pins[i])
+		}
+	}
+	return pins, nil
+}
+
+func (m *MockChatRepo) CreateScheduledMessage(ctx context.Context, scheduled *ScheduledMessage) (*ScheduledMessage, error) {
+	scheduled.ID = int64(len(m.scheduled) + 1)
+	scheduled.CreatedAt = time.Now()
+	m.scheduled = append(m.scheduled, scheduled)
+	return scheduled, nil
+}
+
+func (m *MockChatRepo) ListPendingScheduledMessages(ctx context.Context, userID, roomID int64) ([]*ScheduledMessage, error) {
+	var pending []*ScheduledMessage
+	for _, scheduled := range m.scheduled {
+		if scheduled.UserID == userID && scheduled.Status == ScheduledStatusPending && (roomID == 0 || scheduled.Request.RoomId == roomID) {
+			pending = append(pending, scheduled)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].SendAt.Before(pending[j].SendAt) })
+	return pending, nil
+}
+
+func (m *MockChatRepo) CancelScheduledMessage(ctx context.Context, id, userID int64) (bool, error) {
+	for _, scheduled := range m.scheduled {
+		if scheduled.ID == id && scheduled.UserID == userID && scheduled.Status == ScheduledStatusPending {
+			scheduled.Status = ScheduledStatusCancelled
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockChatRepo) ClaimDueScheduledMessages(ctx context.Context, now, reclaimBefore time.Time, limit int32) ([]*ScheduledMessage, error) {
+	var claimed []*ScheduledMessage
+	for _, scheduled := range m.scheduled {
+		due := scheduled.Status == ScheduledStatusPending && !scheduled.SendAt.After(now)
+		lapsed := scheduled.Status == ScheduledStatusSending && scheduled.ClaimedAt.Before(reclaimBefore)
+		if len(claimed) < int(limit) && (due || lapsed) {
+			scheduled.Status = ScheduledStatusSending
+			scheduled.ClaimedAt = now
+			claimed = append(claimed, scheduled)
+		}
+	}
+	return claimed, nil
+}
+
+func (m *MockChatRepo) CompleteScheduledMessage(ctx context.Context, id, messageID int64, failure string) error {
+	for _, scheduled := range m.scheduled {
+		if scheduled.ID == id {
+			scheduled.Status = ScheduledStatusSent
+			scheduled.MessageID = messageID
+			if failure != "" {
+				scheduled.Status = ScheduledStatusFailed
+			}
+		}
+	}
+	return nil
+}
+
+func (m *MockChatRepo) DeleteExpiredMessages(ctx context.Context, now time.Time, limit int32) ([]*Message, error) {
+	expired := make(map[int64]bool)
+	for _, msg := range m.messages {
+		if msg.ExpiresAt != nil && !msg.ExpiresAt.After(now) && len(expired) < int(limit) {
+			expired[msg.ID] = true
+		}
+	}
+
+	var deleted []*Message
+	for id, msg := range m.messages {
+		if expired[id] || expired[msg.ParentMessageID] {
+			deleted = append(deleted, msg)
+			delete(m.messages, id)
+		}
+	}
+	sort.Slice(deleted, func(i, j int) bool { return deleted[i].ID < deleted[j].ID })
+	return deleted, nil
+}
+
+func (m *MockChatRepo) DeleteRetainedMessages(ctx context.Context, now time.Time, defaultPolicy RetentionPolicy, limit int32) ([]*Message, error) {
+	policyOf := func(roomID int64) RetentionPolicy {
+		if policy, ok := m.retention[roomID]; ok && policy.Mode != RetentionDefault {
+			return policy
+		}
+		return defaultPolicy
+	}
+
+	// Newest timeline messages first, to count what a "messages" policy keeps
+	var timeline []*Message
+	for _, msg := range m.messages {
+		if msg.ParentMessageID == 0 {
+			timeline = append(timeline, msg)
+		}
+	}
+	sort.Slice(timeline, func(i, j int) bool { return timeline[i].ID > timeline[j].ID })
+
+	purged := make(map[int64]bool)
+	kept := make(map[int64]int32)
+	for _, msg := range timeline {
+		if policy := policyOf(msg.RoomID); policy.Mode == RetentionMessages {
+			kept[msg.RoomID]++
+			if kept[msg.RoomID] > policy.Value {
+				purged[msg.ID] = true
+			}
+		}
+	}
 	for _, msg := range m.messages {
-		if msg.RoomID == roomID {
-			if m.readReceipts[msg.ID] == nil || !m.readReceipts[msg.ID][userID] {
-				unread = append(unread, msg)
+		policy := policyOf(msg.RoomID)
+		if policy.Mode == RetentionDays && msg.CreatedAt.Before(now.AddDate(0, 0, -int(policy.Value))) {
+			purged[msg.ID] = true
+		}
+	}
+
+	var deleted []*Message
+	for id, msg := range m.messages {
+		if purged[id] || purged[msg.ParentMessageID] {
+			deleted = append(deleted, msg)
+		}
+	}
+	sort.Slice(deleted, func(i, j int) bool { return deleted[i].ID < deleted[j].ID })
+	if len(deleted) > int(limit) {
+		deleted = deleted[:limit]
+	}
+	for _, msg := range deleted {
+		delete(m.messages, msg.ID)
+	}
+	return deleted, nil
+}
+
+func (m *MockChatRepo) QueueLinkPreviews(ctx context.Context, messageID int64) error {
+	delete(m.linkClaims, messageID)
+	for _, id := range m.linkJobs {
+		if id == messageID {
+			return nil
+		}
+	}
+	m.linkJobs = append(m.linkJobs, messageID)
+	return nil
+}
+
+func (m *MockChatRepo) ClaimLinkPreviewJobs(ctx context.Context, now, reclaimBefore time.Time, limit int32) ([]int64, error) {
+	if m.linkClaims == nil {
+		m.linkClaims = make(map[int64]time.Time)
+	}
+	var claimed []int64
+	for _, id := range m.linkJobs {
+		claimedAt, ok := m.linkClaims[id]
+		if len(claimed) < int(limit) && (!ok || claimedAt.Before(reclaimBefore)) {
+			m.linkClaims[id] = now
+			claimed = append(claimed, id)
+		}
+	}
+	return claimed, nil
+}
+
+func (m *MockChatRepo) CompleteLinkPreviewJob(ctx context.Context, messageID int64, claimedAt time.Time) error {
+	if current, ok := m.linkClaims[messageID]; !ok || !current.Equal(claimedAt) {
+		return nil
+	}
+	delete(m.linkClaims, messageID)
+	for i, id := range m.linkJobs {
+		if id == messageID {
+			m.linkJobs = append(m.linkJobs[:i], m.linkJobs[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m *MockChatRepo) SaveLinkPreviews(ctx context.Context, messageID int64, previews []*LinkPreview) error {
+	if m.saveLinkErr != nil {
+		return m.saveLinkErr
+	}
+	if m.linkPreviews == nil {
+		m.linkPreviews = make(map[int64][]*LinkPreview)
+	}
+	m.linkPreviews[messageID] = previews
+	return nil
+}
+
+func (m *MockChatRepo) ListLinkPreviews(ctx context.Context, messageIDs []int64) (map[int64][]*LinkPreview, error) {
+	previews := make(map[int64][]*LinkPreview)
+	for _, id := range messageIDs {
+		if messagePreviews, ok := m.linkPreviews[id]; ok {
+			previews[id] = messagePreviews
+		}
+	}
+	return previews, nil
+}
+
+func (m *MockChatRepo) ListPolls(ctx context.Context, messageIDs []int64, userID int64) (map[int64]*Poll, error) {
+	polls := make(map[int64]*Poll)
+	for _, id := range messageIDs {
+		stored, ok := m.polls[id]
+		if !ok {
+			continue
+		}
+		poll := *stored
+		poll.Options = nil
+		for _, option := range stored.Options {
+			tallied := &PollOption{ID: option.ID, Text: option.Text}
+			for voterID, optionIDs := range m.pollVotes[id] {
+				for _, optionID := range optionIDs {
+					if optionID == option.ID {
+						tallied.VoteCount++
+						tallied.VoterIDs = append(tallied.VoterIDs, voterID)
+						tallied.VotedByMe = tallied.VotedByMe || voterID == userID
+					}
+				}
+			}
+			poll.Options = append(poll.Options, tallied)
+		}
+		for _, optionIDs := range m.pollVotes[id] {
+			if len(optionIDs) > 0 {
+				poll.VoterCount++
 			}
 		}
+		polls[id] = &poll
+	}
+	return polls, nil
+}
+
+func (m *MockChatRepo) SetPollVotes(ctx context.Context, messageID, userID int64, optionIDs []int32) (bool, error) {
+	poll, ok := m.polls[messageID]
+	if !ok || poll.IsClosed(time.Now()) {
+		return false, nil
+	}
+	if m.pollVotes[messageID] == nil {
+		m.pollVotes[messageID] = make(map[int64][]int32)
+	}
+	m.pollVotes[messageID][userID] = optionIDs
+	return true, nil
+}
+
+func (m *MockChatRepo) ClosePoll(ctx context.Context, messageID int64, closedAt time.Time) (bool, error) {
+	poll, ok := m.polls[messageID]
+	if !ok || poll.ClosedAt != nil {
+		return false, nil
+	}
+	poll.ClosedAt = &closedAt
+	return true, nil
+}
+
+func (m *MockChatRepo) CloseDuePolls(ctx context.Context, now time.Time, limit int32) ([]int64, error) {
+	var closed []int64
+	for id, poll := range m.polls {
+		if poll.ClosedAt == nil && poll.ClosesAt != nil && !poll.ClosesAt.After(now) && int32(len(closed)) < limit {
+			poll.ClosedAt = poll.ClosesAt
+			closed = append(closed, id)
+		}
+	}
+	return closed, nil
+}
+
+func (m *MockChatRepo) MarkRoomRead(ctx context.Context, roomID, userID, messageID int64, readAt time.Time) (bool, error) {
+	if m.readCursors[roomID] == nil {
+		m.readCursors[roomID] = make(map[int64]int64)
+	}
+	if m.readCursors[roomID][userID] >= messageID {
+		return false, nil
+	}
+	m.readCursors[roomID][userID] = messageID
+	return true, nil
+}
+
+func (m *MockChatRepo) GetLastReadMessageID(ctx context.Context, roomID, userID int64) (int64, error) {
+	return m.readCursors[roomID][userID], nil
+}
+
+func (m *MockChatRepo) CountUnread(ctx context.Context, roomID, userID int64) (int32, error) {
+	var count int32
+	for _, msg := range m.messages {
+		if msg.RoomID == roomID && m.isUnread(msg, userID) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockChatRepo) ListReadCursors(ctx context.Context, roomID int64) ([]*ReadCursor, error) {
+	var cursors []*ReadCursor
+	for userID, lastRead := range m.readCursors[roomID] {
+		cursors = append(cursors, &ReadCursor{UserID: userID, LastReadMessageID: lastRead})
+	}
+	sort.Slice(cursors, func(i, j int) bool { return cursors[i].UserID < cursors[j].UserID })
+	return cursors, nil
+}
+
+func (m *MockChatRepo) ListRoomHistory(ctx context.Context, roomID, afterID int64, limit int32) ([]*Message, error) {
+	var history []*Message
+	for _, msg := range m.messages {
+		if msg.RoomID == roomID && msg.ID > afterID && !msg.IsDeleted {
+			history = append(history, msg)
+		}
+	}
+	sort.Slice(history, func(i, j int) bool { return history[i].ID < history[j].ID })
+	if len(history) > int(limit) {
+		history = history[:limit]
+	}
+	return history, nil
+}
+
+// isUnread mirrors the repo's rule for what counts towards a user's unread messages
+func (m *MockChatRepo) isUnread(msg *Message, userID int64) bool {
+	return msg.UserID != userID && msg.ParentMessageID == 0 && !msg.IsDeleted &&
+		msg.Type != "system" && msg.ID > m.readCursors[msg.RoomID][userID]
+}
+
+func (m *MockChatRepo) ListUnreadMessages(ctx context.Context, roomID, userID int64, limit int32) ([]*Message, bool, error) {
+	var unread []*Message
+	for _, msg := range m.messages {
+		if msg.RoomID == roomID && m.isUnread(msg, userID) {
+			unread = append(unread, msg)
+		}
+	}
+	sort.Slice(unread, func(i, j int) bool { return unread[i].ID < unread[j].ID })
+	if len(unread) > int(limit) {
+		return unread[:limit], true, nil
+	}
+	return unread, false, nil
+}
+
+// ListRoomUnreads covers every room with messages, as the mock has no memberships
+func (m *MockChatRepo) ListRoomUnreads(ctx context.Context, userID int64, maxCount int32) ([]*RoomUnread, error) {
+	byRoom := make(map[int64]*RoomUnread)
+	for _, msg := range m.messages {
+		room := byRoom[msg.RoomID]
+		if room == nil {
+			room = &RoomUnread{RoomID: msg.RoomID}
+			byRoom[msg.RoomID] = room
+		}
+		if !m.isUnread(msg, userID) {
+			continue
+		}
+		if room.UnreadCount < maxCount {
+			room.UnreadCount++
+		}
+		if room.FirstUnreadMessageID == 0 || msg.ID < room.FirstUnreadMessageID {
+			room.FirstUnreadMessageID = msg.ID
+		}
+	}
+	for _, mention := range m.mentions {
+		msg := m.messages[mention.MessageID]
+		if mention.UserID == userID && msg != nil && !msg.IsDeleted &&
+			mention.MessageID > m.readCursors[mention.RoomID][userID] {
+			byRoom[mention.RoomID].MentionCount++
+		}
+	}
+
+	rooms := make([]*RoomUnread, 0, len(byRoom))
+	for _, room := range byRoom {
+		rooms = append(rooms, room)
 	}
-	return unread, nil
+	sort.Slice(rooms, func(i, j int) bool { return rooms[i].RoomID < rooms[j].RoomID })
+	return rooms, nil
 }
 
 // Helper to add message directly
@@ -107,11 +645,141 @@ func (m *MockChatRepo) AddMessage(msg *Message) {
 	m.messages[msg.ID] = msg
 }
 
+// savePoll stores a copy of a new poll's definition
+func (m *MockChatRepo) savePoll(messageID int64, poll *Poll) {
+	if m.polls == nil {
+		m.polls = make(map[int64]*Poll)
+		m.pollVotes = make(map[int64]map[int64][]int32)
+	}
+	stored := *poll
+	stored.MessageID = messageID
+	m.polls[messageID] = &stored
+}
+
+// ==================== Mock Event Publisher ====================
+
+type MockEventPublisher struct {
+	events   []*RoomEvent
+	messages []*Message
+	live     chan *Message // returned by SubscribeMessages
+}
+
+func (m *MockEventPublisher) PublishRoomEvent(ctx context.Context, event *RoomEvent) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *MockEventPublisher) PublishMessage(ctx context.Context, message *Message) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+func (m *MockEventPublisher) SubscribeMessages(ctx context.Context, roomID int64) (<-chan *Message, error) {
+	if m.live == nil {
+		m.live = make(chan *Message)
+	}
+	return m.live, nil
+}
+
+// ==================== Mock Presence Repository ====================
+
+type MockPresenceRepo struct {
+	online      map[int64]bool
+	connections map[int64]map[string]*PresenceConnection // userID -> connID -> connection
+	statuses    map[int64]string
+}
+
+func NewMockPresenceRepo() *MockPresenceRepo {
+	return &MockPresenceRepo{
+		online:      make(map[int64]bool),
+		connections: make(map[int64]map[string]*PresenceConnection),
+		statuses:    make(map[int64]string),
+	}
+}
+
+func (m *MockPresenceRepo) FilterOnline(ctx context.Context, userIDs []int64) ([]int64, error) {
+	var online []int64
+	for _, userID := range userIDs {
+		if m.online[userID] {
+			online = append(online, userID)
+		}
+	}
+	return online, nil
+}
+
+func (m *MockPresenceRepo) SaveConnection(ctx context.Context, conn *PresenceConnection) error {
+	if m.connections[conn.UserID] == nil {
+		m.connections[conn.UserID] = make(map[string]*PresenceConnection)
+	}
+	saved := *conn
+	m.connections[conn.UserID][conn.ConnID] = &saved
+	return nil
+}
+
+func (m *MockPresenceRepo) RemoveConnection(ctx context.Context, userID int64, connID string) (bool, error) {
+	if _, ok := m.connections[userID][connID]; !ok {
+		return false, nil
+	}
+	delete(m.connections[userID], connID)
+	return true, nil
+}
+
+func (m *MockPresenceRepo) GetConnections(ctx context.Context, userIDs []int64) (map[int64][]*PresenceConnection, error) {
+	result := make(map[int64][]*PresenceConnection)
+	for _, userID := range userIDs {
+		for _, conn := range m.connections[userID] {
+			result[userID] = append(result[userID], conn)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockPresenceRepo) ListExpiredConnections(ctx context.Context, now time.Time, limit int) ([]*PresenceConnection, error) {
+	var expired []*PresenceConnection
+	for _, conns := range m.connections {
+		for _, conn := range conns {
+			if conn.ExpiresAt.Before(now) && len(expired) < limit {
+				expired = append(expired, conn)
+			}
+		}
+	}
+	return expired, nil
+}
+
+func (m *MockPresenceRepo) SwapStatus(ctx context.Context, userID int64, status string) (string, error) {
+	previous := m.statuses[userID]
+	m.statuses[userID] = status
+	return previous, nil
+}
+
 // ==================== Helper ====================
 
 func newTestChatUseCase(chatRepo *MockChatRepo, roomRepo *MockRoomRepo, userRepo *MockUserRepo) *ChatUseCase {
+	return newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, &MockEventPublisher{})
+}
+
+func newTestChatUseCaseWithPublisher(chatRepo *MockChatRepo, roomRepo *MockRoomRepo, userRepo *MockUserRepo, publisher *MockEventPublisher) *ChatUseCase {
+	return newTestChatUseCaseWithPresence(chatRepo, roomRepo, userRepo, NewMockPresenceRepo(), publisher)
+}
+
+func newTestChatUseCaseWithPresence(chatRepo *MockChatRepo, roomRepo *MockRoomRepo, userRepo *MockUserRepo, presence *MockPresenceRepo, publisher *MockEventPublisher) *ChatUseCase {
 	logger := log.NewStdLogger(io.Discard)
-	return NewChatUseCase(chatRepo, roomRepo, userRepo, logger)
+	system := NewSystemMessenger(chatRepo, userRepo, publisher, logger)
+	return NewChatUseCase(chatRepo, roomRepo, userRepo, presence, nil, publisher, system, nil, logger)
+}
+
+// setupTestRoom creates room 10 with member 100 ("sender"), the base of the
+// per-feature room fixtures
+func setupTestRoom() (*MockChatRepo, *MockRoomRepo, *MockUserRepo) {
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddNamedMember(10, 100, "sender")
+	userRepo.usersById[100] = &User{ID: 100, Username: "sender"}
+
+	return chatRepo, roomRepo, userRepo
 }
 
 // ==================== SendMessage Tests ====================
@@ -303,16 +971,135 @@ func TestSendMessage_ContentTooLong(t *testing.T) {
 	}
 }
 
-// ==================== GetMessage Tests ====================
-
-func TestGetMessage_Success(t *testing.T) {
+func TestSendMessage_ClientMsgIDRetryReturnsOriginal(t *testing.T) {
 	// Arrange
 	chatRepo := NewMockChatRepo()
 	roomRepo := NewMockRoomRepo()
 	userRepo := NewMockUserRepo()
 
-	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10, Content: "Test message"})
-	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddRoom(&Room{ID: 1, Name: "Test Room"})
+	roomRepo.AddMember(1, 100)
+	userRepo.usersById[100] = &User{ID: 100, Username: "sender"}
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+	req := &chatV1.SendMessageRequest{RoomId: 1, Content: "Hello", ClientMsgId: "c-1"}
+	original, _ := uc.SendMessage(context.Background(), 100, req)
+
+	// Act
+	retried, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 1, Content: "Hello", ClientMsgId: "c-1"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if retried.ID != original.ID || !retried.Replayed || original.Replayed {
+		t.Errorf("expected a replay of message %d, got id=%d replayed=%v", original.ID, retried.ID, retried.Replayed)
+	}
+	if len(chatRepo.messages) != 1 {
+		t.Errorf("expected 1 stored message, got %d", len(chatRepo.messages))
+	}
+}
+
+func TestSendMessage_ClientMsgIDIsPerUser(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+
+	roomRepo.AddRoom(&Room{ID: 1, Name: "Test Room"})
+	roomRepo.AddMember(1, 100)
+	roomRepo.AddMember(1, 200)
+	userRepo.usersById[100] = &User{ID: 100, Username: "sender"}
+	userRepo.usersById[200] = &User{ID: 200, Username: "other"}
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+	_, _ = uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 1, Content: "Hello", ClientMsgId: "c-1"})
+
+	// Act
+	msg, err := uc.SendMessage(context.Background(), 200, &chatV1.SendMessageRequest{RoomId: 1, Content: "Hi", ClientMsgId: "c-1"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if msg.Replayed || len(chatRepo.messages) != 2 {
+		t.Errorf("expected a new message from user 200, got replayed=%v with %d stored", msg.Replayed, len(chatRepo.messages))
+	}
+}
+
+func TestSendMessage_ClientMsgIDReusedInOtherRoom(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+
+	roomRepo.AddRoom(&Room{ID: 1, Name: "Test Room"})
+	roomRepo.AddRoom(&Room{ID: 2, Name: "Other Room"})
+	roomRepo.AddMember(1, 100)
+	roomRepo.AddMember(2, 100)
+	userRepo.usersById[100] = &User{ID: 100, Username: "sender"}
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+	_, _ = uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 1, Content: "Hello", ClientMsgId: "c-1"})
+
+	// Act
+	_, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 2, Content: "Hello", ClientMsgId: "c-1"})
+
+	// Assert
+	if err != ErrClientMsgIDConflict {
+		t.Errorf("expected ErrClientMsgIDConflict, got %v", err)
+	}
+}
+
+func TestSendMessage_ClientMsgIDTooLong(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	roomRepo.AddRoom(&Room{ID: 1, Name: "Test Room"})
+	roomRepo.AddMember(1, 100)
+	userRepo.usersById[100] = &User{ID: 100, Username: "sender"}
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{
+		RoomId:      1,
+		Content:     "Hello",
+		ClientMsgId: strings.Repeat("x", 65),
+	})
+
+	// Assert
+	if err == nil {
+		t.Error("expected an over-long client_msg_id to be rejected")
+	}
+}
+
+func TestPublishMessage_Broadcasts(t *testing.T) {
+	// Arrange
+	publisher := &MockEventPublisher{}
+	uc := newTestChatUseCaseWithPublisher(NewMockChatRepo(), NewMockRoomRepo(), NewMockUserRepo(), publisher)
+	message := &Message{ID: 7, RoomID: 1, Content: "Hello"}
+
+	// Act
+	uc.PublishMessage(context.Background(), message)
+
+	// Assert
+	if len(publisher.messages) != 1 || publisher.messages[0] != message {
+		t.Errorf("expected the message published to its room, got %v", publisher.messages)
+	}
+}
+
+// ==================== GetMessage Tests ====================
+
+func TestGetMessage_Success(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10, Content: "Test message"})
+	roomRepo.AddRoom(&Room{ID: 10})
 	roomRepo.AddMember(10, 100)
 
 	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
@@ -384,17 +1171,17 @@ func TestListMessages_Success(t *testing.T) {
 	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
 
 	// Act
-	messages, total, err := uc.ListMessages(context.Background(), 100, 10, 50, 0)
+	page, err := uc.ListMessages(context.Background(), 100, 10, 50, MessageCursor{})
 
 	// Assert
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if len(messages) != 2 {
-		t.Errorf("expected 2 messages, got %d", len(messages))
+	if len(page.Messages) != 2 {
+		t.Errorf("expected 2 messages, got %d", len(page.Messages))
 	}
-	if total != 2 {
-		t.Errorf("expected total 2, got %d", total)
+	if page.HasMore {
+		t.Error("expected no more messages")
 	}
 }
 
@@ -410,7 +1197,344 @@ func TestListMessages_AccessDenied(t *testing.T) {
 	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
 
 	// Act
-	_, _, err := uc.ListMessages(context.Background(), 100, 10, 50, 0)
+	_, err := uc.ListMessages(context.Background(), 100, 10, 50, MessageCursor{})
+
+	// Assert
+	if err != ErrRoomAccessDenied {
+		t.Fatalf("expected ErrRoomAccessDenied, got %v", err)
+	}
+}
+
+// addRoomHistory adds messages 1..n to room 10 with user 100 as a member
+func addRoomHistory(chatRepo *MockChatRepo, roomRepo *MockRoomRepo, n int64) {
+	for id := int64(1); id <= n; id++ {
+		chatRepo.AddMessage(&Message{ID: id, RoomID: 10})
+	}
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddMember(10, 100)
+}
+
+// messageIDs returns the IDs of messages in order
+func messageIDs(messages []*Message) []int64 {
+	ids := make([]int64, 0, len(messages))
+	for _, msg := range messages {
+		ids = append(ids, msg.ID)
+	}
+	return ids
+}
+
+func TestListMessages_BeforeID(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	addRoomHistory(chatRepo, roomRepo, 10)
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	page, err := uc.ListMessages(context.Background(), 100, 10, 3, MessageCursor{BeforeID: 5})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ids := messageIDs(page.Messages); len(ids) != 3 || ids[0] != 4 || ids[2] != 2 {
+		t.Errorf("expected messages [4 3 2], got %v", ids)
+	}
+	if !page.HasMore {
+		t.Error("expected more older messages")
+	}
+}
+
+func TestListMessages_AfterID(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	addRoomHistory(chatRepo, roomRepo, 10)
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	page, err := uc.ListMessages(context.Background(), 100, 10, 3, MessageCursor{AfterID: 7})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ids := messageIDs(page.Messages); len(ids) != 3 || ids[0] != 10 || ids[2] != 8 {
+		t.Errorf("expected messages [10 9 8], got %v", ids)
+	}
+	if page.HasMore {
+		t.Error("expected no newer messages")
+	}
+}
+
+func TestListMessages_AroundID(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	addRoomHistory(chatRepo, roomRepo, 10)
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	page, err := uc.ListMessages(context.Background(), 100, 10, 4, MessageCursor{AroundID: 5})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	ids := messageIDs(page.Messages)
+	expected := []int64{7, 6, 5, 4}
+	if len(ids) != len(expected) {
+		t.Fatalf("expected messages %v, got %v", expected, ids)
+	}
+	for i := range expected {
+		if ids[i] != expected[i] {
+			t.Fatalf("expected messages %v, got %v", expected, ids)
+		}
+	}
+	if !page.HasMore || !page.HasNewer {
+		t.Errorf("expected older and newer messages, got has_more=%v has_newer=%v", page.HasMore, page.HasNewer)
+	}
+}
+
+func TestListMessages_AroundIDOtherRoom(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	addRoomHistory(chatRepo, roomRepo, 3)
+	chatRepo.AddMessage(&Message{ID: 99, RoomID: 20})
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, err := uc.ListMessages(context.Background(), 100, 10, 4, MessageCursor{AroundID: 99})
+
+	// Assert
+	if err != ErrMessageNotFound {
+		t.Fatalf("expected ErrMessageNotFound, got %v", err)
+	}
+}
+
+func TestListMessages_MultipleCursors(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	addRoomHistory(chatRepo, roomRepo, 3)
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, err := uc.ListMessages(context.Background(), 100, 10, 4, MessageCursor{BeforeID: 3, AfterID: 1})
+
+	// Assert
+	if err != ErrInvalidCursor {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+// ==================== Thread Tests ====================
+
+func TestSendMessage_ThreadReply(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	publisher := &MockEventPublisher{}
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10, UserID: 200, Content: "Root"})
+	chatRepo.nextID = 2
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddMember(10, 100)
+	userRepo.usersById[100] = &User{ID: 100, Username: "replier"}
+
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+
+	req := &chatV1.SendMessageRequest{
+		RoomId:          10,
+		Content:         "Reply",
+		Type:            "text",
+		ParentMessageId: 1,
+	}
+
+	// Act
+	reply, err := uc.SendMessage(context.Background(), 100, req)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if reply.ParentMessageID != 1 {
+		t.Errorf("expected parent message ID 1, got %d", reply.ParentMessageID)
+	}
+	if chatRepo.messages[1].ReplyCount != 1 {
+		t.Errorf("expected root reply count 1, got %d", chatRepo.messages[1].ReplyCount)
+	}
+	if len(publisher.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(publisher.events))
+	}
+	if publisher.events[0].Type != EventThreadReply {
+		t.Errorf("expected event type '%s', got '%s'", EventThreadReply, publisher.events[0].Type)
+	}
+	if publisher.events[0].Data["parent_message_id"] != int64(1) {
+		t.Errorf("expected parent_message_id 1, got %v", publisher.events[0].Data["parent_message_id"])
+	}
+}
+
+func TestSendMessage_NotThreadReplyPublishesNothing(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	publisher := &MockEventPublisher{}
+
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddMember(10, 100)
+	userRepo.usersById[100] = &User{ID: 100, Username: "sender"}
+
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+
+	// Act
+	_, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "Hi", Type: "text"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(publisher.events) != 0 {
+		t.Errorf("expected no thread events, got %d", len(publisher.events))
+	}
+}
+
+func TestSendMessage_ThreadReplyParentInOtherRoom(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 20})
+	chatRepo.nextID = 2
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddMember(10, 100)
+	userRepo.usersById[100] = &User{ID: 100}
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	req := &chatV1.SendMessageRequest{
+		RoomId:          10,
+		Content:         "Reply",
+		Type:            "text",
+		ParentMessageId: 1,
+	}
+
+	// Act
+	_, err := uc.SendMessage(context.Background(), 100, req)
+
+	// Assert
+	if err != ErrInvalidThreadRoot {
+		t.Fatalf("expected ErrInvalidThreadRoot, got %v", err)
+	}
+}
+
+func TestSendMessage_ThreadReplyToReply(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10})
+	chatRepo.AddMessage(&Message{ID: 2, RoomID: 10, ParentMessageID: 1})
+	chatRepo.nextID = 3
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddMember(10, 100)
+	userRepo.usersById[100] = &User{ID: 100}
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	req := &chatV1.SendMessageRequest{
+		RoomId:          10,
+		Content:         "Nested reply",
+		Type:            "text",
+		ParentMessageId: 2, // Threads are one level deep
+	}
+
+	// Act
+	_, err := uc.SendMessage(context.Background(), 100, req)
+
+	// Assert
+	if err != ErrInvalidThreadRoot {
+		t.Fatalf("expected ErrInvalidThreadRoot, got %v", err)
+	}
+}
+
+func TestGetThread_Success(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10, Content: "Root", ReplyCount: 3})
+	chatRepo.AddMessage(&Message{ID: 2, RoomID: 10, ParentMessageID: 1})
+	chatRepo.AddMessage(&Message{ID: 3, RoomID: 10, ParentMessageID: 1})
+	chatRepo.AddMessage(&Message{ID: 4, RoomID: 10, ParentMessageID: 1})
+	chatRepo.AddMessage(&Message{ID: 5, RoomID: 10}) // Not in the thread
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddMember(10, 100)
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	root, replies, hasMore, err := uc.GetThread(context.Background(), 100, 1, 2, 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if root.ID != 1 {
+		t.Errorf("expected root ID 1, got %d", root.ID)
+	}
+	if len(replies) != 2 || replies[0].ID != 2 || replies[1].ID != 3 {
+		t.Fatalf("expected replies 2 and 3, got %v", replies)
+	}
+	if !hasMore {
+		t.Error("expected has more")
+	}
+
+	// Act - next page
+	_, replies, hasMore, err = uc.GetThread(context.Background(), 100, 1, 2, 3)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(replies) != 1 || replies[0].ID != 4 {
+		t.Fatalf("expected reply 4, got %v", replies)
+	}
+	if hasMore {
+		t.Error("expected no more replies")
+	}
+}
+
+func TestGetThread_AccessDenied(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10})
+	roomRepo.AddRoom(&Room{ID: 10})
+	// User 100 is NOT a member
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, _, _, err := uc.GetThread(context.Background(), 100, 1, 50, 0)
 
 	// Assert
 	if err != ErrRoomAccessDenied {
@@ -418,6 +1542,28 @@ func TestListMessages_AccessDenied(t *testing.T) {
 	}
 }
 
+func TestGetThread_NotARoot(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10})
+	chatRepo.AddMessage(&Message{ID: 2, RoomID: 10, ParentMessageID: 1})
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddMember(10, 100)
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, _, _, err := uc.GetThread(context.Background(), 100, 2, 50, 0)
+
+	// Assert
+	if err != ErrInvalidThreadRoot {
+		t.Fatalf("expected ErrInvalidThreadRoot, got %v", err)
+	}
+}
+
 // ==================== EditMessage Tests ====================
 
 func TestEditMessage_Success(t *testing.T) {
@@ -431,7 +1577,7 @@ func TestEditMessage_Success(t *testing.T) {
 	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
 
 	// Act
-	err := uc.EditMessage(context.Background(), 100, 1, "Edited content")
+	msg, err := uc.EditMessage(context.Background(), 100, 1, "Edited content")
 
 	// Assert
 	if err != nil {
@@ -439,13 +1585,48 @@ func TestEditMessage_Success(t *testing.T) {
 	}
 
 	// Verify content changed
-	msg, _ := chatRepo.GetMessage(context.Background(), 1)
 	if msg.Content != "Edited content" {
 		t.Errorf("expected 'Edited content', got %s", msg.Content)
 	}
 	if !msg.IsEdited {
 		t.Error("expected IsEdited to be true")
 	}
+	if msg.EditedAt == nil {
+		t.Error("expected EditedAt to be set")
+	}
+}
+
+func TestEditMessage_PublishesEvent(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	publisher := &MockEventPublisher{}
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10, UserID: 100, Content: "Original"})
+
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+
+	// Act
+	_, err := uc.EditMessage(context.Background(), 100, 1, "Edited content")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(publisher.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(publisher.events))
+	}
+	event := publisher.events[0]
+	if event.Type != EventMessageEdited {
+		t.Errorf("expected event type %s, got %s", EventMessageEdited, event.Type)
+	}
+	if event.RoomID != 10 {
+		t.Errorf("expected room 10, got %d", event.RoomID)
+	}
+	if event.Data["content"] != "Edited content" {
+		t.Errorf("expected edited content in event, got %v", event.Data["content"])
+	}
 }
 
 func TestEditMessage_NotAuthor(t *testing.T) {
@@ -459,7 +1640,7 @@ func TestEditMessage_NotAuthor(t *testing.T) {
 	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
 
 	// Act - User 100 tries to edit message by user 200
-	err := uc.EditMessage(context.Background(), 100, 1, "Edited")
+	_, err := uc.EditMessage(context.Background(), 100, 1, "Edited")
 
 	// Assert
 	if err == nil {
@@ -478,7 +1659,7 @@ func TestEditMessage_EmptyContent(t *testing.T) {
 	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
 
 	// Act
-	err := uc.EditMessage(context.Background(), 100, 1, "")
+	_, err := uc.EditMessage(context.Background(), 100, 1, "")
 
 	// Assert
 	if err != ErrInvalidMessage {
@@ -486,6 +1667,34 @@ func TestEditMessage_EmptyContent(t *testing.T) {
 	}
 }
 
+func TestEditMessage_ContentTooLong(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	publisher := &MockEventPublisher{}
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10, UserID: 100, Content: "Original"})
+
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+
+	longContent := make([]byte, 4001)
+	for i := range longContent {
+		longContent[i] = 'a'
+	}
+
+	// Act
+	_, err := uc.EditMessage(context.Background(), 100, 1, string(longContent))
+
+	// Assert
+	if err == nil {
+		t.Fatal("expected error for content too long")
+	}
+	if len(publisher.events) != 0 {
+		t.Errorf("expected no events, got %d", len(publisher.events))
+	}
+}
+
 func TestEditMessage_NotFound(t *testing.T) {
 	// Arrange
 	chatRepo := NewMockChatRepo()
@@ -495,7 +1704,7 @@ func TestEditMessage_NotFound(t *testing.T) {
 	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
 
 	// Act
-	err := uc.EditMessage(context.Background(), 100, 999, "Edited")
+	_, err := uc.EditMessage(context.Background(), 100, 999, "Edited")
 
 	// Assert
 	if err != ErrMessageNotFound {
@@ -523,10 +1732,135 @@ func TestDeleteMessage_Success(t *testing.T) {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
-	// Verify deleted
-	_, err = chatRepo.GetMessage(context.Background(), 1)
+	// Verify tombstone
+	msg, _ := chatRepo.GetMessage(context.Background(), 1)
+	if !msg.IsDeleted {
+		t.Error("expected message to be marked deleted")
+	}
+	if msg.Content != "" {
+		t.Errorf("expected content to be cleared, got '%s'", msg.Content)
+	}
+}
+
+func TestDeleteMessage_ModeratorCanDeleteOthers(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10, UserID: 200, Content: "Spam"})
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.SetMemberRole(10, 100, "moderator")
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act - Moderator 100 deletes message by user 200
+	err := uc.DeleteMessage(context.Background(), 100, 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !chatRepo.messages[1].IsDeleted {
+		t.Error("expected message to be marked deleted")
+	}
+}
+
+func TestDeleteMessage_AdminCanDeleteOthers(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10, UserID: 200})
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.SetMemberRole(10, 100, "admin")
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	err := uc.DeleteMessage(context.Background(), 100, 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestDeleteMessage_MemberCannotDeleteOthers(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10, UserID: 200})
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddMember(10, 100) // Plain member
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	err := uc.DeleteMessage(context.Background(), 100, 1)
+
+	// Assert
+	if err == nil {
+		t.Fatal("expected error for member deleting another user's message")
+	}
+	if chatRepo.messages[1].IsDeleted {
+		t.Error("expected message to remain")
+	}
+}
+
+func TestDeleteMessage_AlreadyDeleted(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+
+	deletedAt := time.Now()
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10, UserID: 100, IsDeleted: true, DeletedAt: &deletedAt})
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	err := uc.DeleteMessage(context.Background(), 100, 1)
+
+	// Assert
 	if err != ErrMessageNotFound {
-		t.Error("expected message to be deleted")
+		t.Fatalf("expected ErrMessageNotFound, got %v", err)
+	}
+}
+
+func TestDeleteMessage_PublishesEvent(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	publisher := &MockEventPublisher{}
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10, UserID: 100, Content: "Oops"})
+
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+
+	// Act
+	err := uc.DeleteMessage(context.Background(), 100, 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(publisher.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(publisher.events))
+	}
+	event := publisher.events[0]
+	if event.Type != EventMessageDeleted {
+		t.Errorf("expected event type '%s', got '%s'", EventMessageDeleted, event.Type)
+	}
+	if event.RoomID != 10 {
+		t.Errorf("expected room ID 10, got %d", event.RoomID)
+	}
+	if event.Data["message_id"] != int64(1) {
+		t.Errorf("expected message_id 1, got %v", event.Data["message_id"])
 	}
 }
 
@@ -643,7 +1977,7 @@ func TestGetUnreadMessages_Success(t *testing.T) {
 	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
 
 	// Act
-	messages, err := uc.GetUnreadMessages(context.Background(), 100, 10)
+	messages, _, err := uc.GetUnreadMessages(context.Background(), 100, 10, 50)
 
 	// Assert
 	if err != nil {
@@ -666,7 +2000,7 @@ func TestGetUnreadMessages_AccessDenied(t *testing.T) {
 	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
 
 	// Act
-	_, err := uc.GetUnreadMessages(context.Background(), 100, 10)
+	_, _, err := uc.GetUnreadMessages(context.Background(), 100, 10, 50)
 
 	// Assert
 	if err != ErrRoomAccessDenied {
@@ -0,0 +1,89 @@
+package biz
+
+import (
+	"context"
+)
+
+// Room event types pushed to WebSocket clients
+const (
+	EventMessageEdited   = "message_edited"
+	EventMessageDeleted  = "message_deleted"
+	EventThreadReply     = "thread_reply"
+	EventReactionAdded   = "reaction_added"
+	EventReactionRemoved = "reaction_removed"
+	EventMentioned       = "mentioned"
+	EventMessagePinned   = "message_pinned"
+	EventMessageUnpinned = "message_unpinned"
+	EventMessageExpired  = "message_expired"
+	EventMessagePurged   = "message_purged"
+	EventMessageUpdated  = "message_updated"
+	EventPollUpdated     = "poll_updated"
+	EventReadReceipt     = "read_receipt"
+	EventPresenceChanged = "presence_changed"
+)
+
+// RoomEvent is a realtime event delivered to every client in a room.
+// When UserIDs is set, it is delivered only to those users' connections,
+// whichever room they are currently in.
+type RoomEvent struct {
+	Type    string
+	RoomID  int64
+	Data    map[string]interface{}
+	UserIDs []int64
+}
+
+// EventPublisher fans out room events to all chat service instances
+type EventPublisher interface {
+	PublishRoomEvent(ctx context.Context, event *RoomEvent) error
+	// PublishMessage delivers a new message to the room as a new_message
+	PublishMessage(ctx context.Context, message *Message) error
+	// SubscribeMessages receives the room's new messages, from any instance,
+	// until ctx ends. The channel is closed when the subscription ends, also
+	// when the receiver falls too far behind.
+	SubscribeMessages(ctx context.Context, roomID int64) (<-chan *Message, error)
+}
+
+// messageEventData flattens a message into event data.
+// The message type goes under message_type since type names the event.
+func messageEventData(message *Message) map[string]interface{} {
+	data := map[string]interface{}{
+		"message_id":   message.ID,
+		"user_id":      message.UserID,
+		"username":     message.Username,
+		"content":      message.Content,
+		"message_type": message.Type,
+		"created_at":   message.CreatedAt.Unix(),
+	}
+	if message.Format == MessageFormatMarkdown {
+		data["format"] = message.Format
+		data["content_html"] = message.ContentHTML
+	}
+	if message.FileURL != "" {
+		data["file_url"] = message.FileURL
+		data["file_name"] = message.FileName
+		data["file_size"] = message.FileSize
+		data["mime_type"] = message.MimeType
+	}
+	if message.ParentMessageID != 0 {
+		data["parent_message_id"] = message.ParentMessageID
+	}
+	if message.ExpiresAt != nil {
+		data["expires_at"] = message.ExpiresAt.Unix()
+	}
+	if message.QuotedMessage != nil {
+		data["quoted_message"] = quotedMessageEventData(message.QuotedMessage)
+	}
+	if message.IsForwarded {
+		data["is_forwarded"] = true
+	}
+	if message.ClientMsgID != "" {
+		data["client_msg_id"] = message.ClientMsgID
+	}
+	if message.Poll != nil {
+		data["poll"] = pollEventData(message.Poll)
+	}
+	if message.SystemEvent != nil {
+		data["system_event"] = systemEventData(message.SystemEvent)
+	}
+	return data
+}
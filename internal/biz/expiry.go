@@ -0,0 +1,73 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+)
+
+var (
+	ErrInvalidMessageTTL = errors.New("message lifetime must be between 0 and 30 days")
+)
+
+// maxMessageTTL bounds both expires_in and a room's default message lifetime
+const maxMessageTTL = 30 * 24 * time.Hour
+
+// messageExpiry returns when a new message expires: after its own expires_in,
+// else after the room's default lifetime. nil means it never expires.
+func (uc *ChatUseCase) messageExpiry(ctx context.Context, req *chatV1.SendMessageRequest) (*time.Time, error) {
+	ttl := time.Duration(req.ExpiresIn) * time.Second
+	if ttl == 0 {
+		roomTTL, err := uc.roomRepo.GetMessageTTL(ctx, req.RoomId)
+		if err != nil {
+			return nil, err
+		}
+		ttl = roomTTL
+	}
+	if ttl <= 0 {
+		return nil, nil
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	return &expiresAt, nil
+}
+
+// ReapExpiredMessages hard-deletes up to limit expired messages, with their
+// attachments and the replies of expired thread roots, and tells each room
+// to drop them. It reports how many messages were deleted.
+func (uc *ChatUseCase) ReapExpiredMessages(ctx context.Context, limit int32) (int, error) {
+	deleted, err := uc.repo.DeleteExpiredMessages(ctx, time.Now(), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	uc.publishRemoved(ctx, EventMessageExpired, deleted)
+	return len(deleted), nil
+}
+
+// publishRemoved tells each message's room to drop it
+func (uc *ChatUseCase) publishRemoved(ctx context.Context, eventType string, messages []*Message) {
+	for _, message := range messages {
+		data := map[string]interface{}{
+			"message_id": message.ID,
+		}
+		if message.ParentMessageID != 0 {
+			data["parent_message_id"] = message.ParentMessageID
+		}
+		uc.publishEvent(ctx, &RoomEvent{
+			Type:   eventType,
+			RoomID: message.RoomID,
+			Data:   data,
+		})
+	}
+}
+
+// validateMessageTTL validates a message lifetime (0 = never expire)
+func validateMessageTTL(ttl time.Duration) error {
+	if ttl < 0 || ttl > maxMessageTTL {
+		return ErrInvalidMessageTTL
+	}
+	return nil
+}
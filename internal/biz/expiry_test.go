@@ -0,0 +1,122 @@
+package biz
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+)
+
+// ==================== SendMessage Expiry Tests ====================
+
+func TestSendMessage_ExpiresIn(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	roomRepo.rooms[10].MessageTTL = time.Hour
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	message, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "hunter2", ExpiresIn: 60})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if message.ExpiresAt == nil {
+		t.Fatal("expected message to expire")
+	}
+	if ttl := time.Until(*message.ExpiresAt); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("expected expiry within a minute, got %s", ttl)
+	}
+}
+
+func TestSendMessage_RoomDefaultTTL(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	roomRepo.rooms[10].MessageTTL = time.Hour
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	message, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "incident notes"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if message.ExpiresAt == nil {
+		t.Fatal("expected message to expire")
+	}
+	if ttl := time.Until(*message.ExpiresAt); ttl <= time.Minute || ttl > time.Hour {
+		t.Errorf("expected expiry in about an hour, got %s", ttl)
+	}
+}
+
+func TestSendMessage_NoTTL(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	message, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "hello"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if message.ExpiresAt != nil {
+		t.Errorf("expected message not to expire, got %v", message.ExpiresAt)
+	}
+}
+
+func TestSendMessage_InvalidExpiresIn(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "hello", ExpiresIn: -1})
+
+	// Assert
+	if err != ErrInvalidMessageTTL {
+		t.Errorf("expected ErrInvalidMessageTTL, got %v", err)
+	}
+}
+
+// ==================== ReapExpiredMessages Tests ====================
+
+func TestReapExpiredMessages_DeletesExpiredAndReplies(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	publisher := &MockEventPublisher{}
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+
+	past := time.Now().Add(-time.Second)
+	future := time.Now().Add(time.Hour)
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10, Content: "expired root", ExpiresAt: &past})
+	chatRepo.AddMessage(&Message{ID: 2, RoomID: 10, Content: "reply", ParentMessageID: 1})
+	chatRepo.AddMessage(&Message{ID: 3, RoomID: 10, Content: "later", ExpiresAt: &future})
+	chatRepo.AddMessage(&Message{ID: 4, RoomID: 10, Content: "forever"})
+
+	// Act
+	deleted, err := uc.ReapExpiredMessages(context.Background(), 100)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("expected 2 deleted, got %d", deleted)
+	}
+	if len(chatRepo.messages) != 2 || chatRepo.messages[3] == nil || chatRepo.messages[4] == nil {
+		t.Errorf("expected messages 3 and 4 to remain, got %v", chatRepo.messages)
+	}
+	if len(publisher.events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(publisher.events))
+	}
+	for _, event := range publisher.events {
+		if event.Type != EventMessageExpired || event.RoomID != 10 {
+			t.Errorf("expected %s event for room 10, got %s for room %d", EventMessageExpired, event.Type, event.RoomID)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package biz
+
+import (
+	"context"
+)
+
+// exportBatchSize is how many messages an export loads at a time
+const exportBatchSize = 500
+
+// ExportMessages streams a room's full history to fn, oldest first, one
+// batch at a time, so the room is never held in memory. Thread replies are
+// included and point at their root through ParentMessageID; deleted
+// messages are left out. Access matches ListMessages. An error from fn
+// stops the export and is returned.
+func (uc *ChatUseCase) ExportMessages(ctx context.Context, userID, roomID int64, fn func([]*Message) error) error {
+	// Check if user has access to the room
+	isMember, err := uc.roomRepo.IsUserInRoom(ctx, roomID, userID)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return ErrRoomAccessDenied
+	}
+
+	var afterID int64
+	for {
+		messages, err := uc.repo.ListRoomHistory(ctx, roomID, afterID, exportBatchSize)
+		if err != nil {
+			uc.log.Errorf("Failed to export room %d after message %d: %v", roomID, afterID, err)
+			return err
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+
+		if err := uc.attachReactions(ctx, userID, messages); err != nil {
+			return err
+		}
+		if err := uc.attachPolls(ctx, userID, messages); err != nil {
+			return err
+		}
+
+		if err := fn(messages); err != nil {
+			return err
+		}
+		if len(messages) < exportBatchSize {
+			return nil
+		}
+		afterID = messages[len(messages)-1].ID
+	}
+}
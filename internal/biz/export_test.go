@@ -0,0 +1,88 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// setupExportRoom creates room 10 with member 100 and n messages, replies and
+// a deleted message among them, plus a message in another room
+func setupExportRoom(n int64) (*ChatUseCase, *MockChatRepo) {
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	for id := int64(1); id <= n; id++ {
+		chatRepo.AddMessage(&Message{ID: id, RoomID: 10, UserID: 100, Content: "hi"})
+	}
+	chatRepo.AddMessage(&Message{ID: n + 1, RoomID: 10, UserID: 100, ParentMessageID: 1, Content: "reply"})
+	chatRepo.AddMessage(&Message{ID: n + 2, RoomID: 10, UserID: 100, IsDeleted: true})
+	chatRepo.AddMessage(&Message{ID: n + 3, RoomID: 20, UserID: 100, Content: "elsewhere"})
+
+	return newTestChatUseCase(chatRepo, roomRepo, userRepo), chatRepo
+}
+
+func TestExportMessages_FullHistoryInBatches(t *testing.T) {
+	// Arrange
+	uc, _ := setupExportRoom(exportBatchSize + 10)
+	var batches []int
+	var lastID int64
+	ordered := true
+
+	// Act
+	err := uc.ExportMessages(context.Background(), 100, 10, func(messages []*Message) error {
+		batches = append(batches, len(messages))
+		for _, message := range messages {
+			ordered = ordered && message.ID > lastID
+			lastID = message.ID
+		}
+		return nil
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(batches) != 2 || batches[0] != exportBatchSize || batches[1] != 11 {
+		t.Errorf("expected a full batch then the remaining 10 messages and the reply, got %v", batches)
+	}
+	if !ordered {
+		t.Error("expected messages oldest first")
+	}
+}
+
+func TestExportMessages_NotMember(t *testing.T) {
+	// Arrange
+	uc, _ := setupExportRoom(3)
+	called := false
+
+	// Act
+	err := uc.ExportMessages(context.Background(), 999, 10, func(messages []*Message) error {
+		called = true
+		return nil
+	})
+
+	// Assert
+	if err != ErrRoomAccessDenied {
+		t.Errorf("expected ErrRoomAccessDenied, got %v", err)
+	}
+	if called {
+		t.Error("expected nothing exported")
+	}
+}
+
+func TestExportMessages_StopsOnWriteError(t *testing.T) {
+	// Arrange
+	uc, _ := setupExportRoom(exportBatchSize + 10)
+	errClosed := errors.New("connection closed")
+	calls := 0
+
+	// Act
+	err := uc.ExportMessages(context.Background(), 100, 10, func(messages []*Message) error {
+		calls++
+		return errClosed
+	})
+
+	// Assert
+	if err != errClosed || calls != 1 {
+		t.Errorf("expected the export to stop after the failed batch, got %v after %d batches", err, calls)
+	}
+}
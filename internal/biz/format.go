@@ -0,0 +1,25 @@
+package biz
+
+import (
+	"github.com/yourusername/chat-app/internal/markdown"
+)
+
+// Message content formats
+const (
+	MessageFormatPlain    = "plain"
+	MessageFormatMarkdown = "markdown"
+)
+
+// isValidFormat reports whether format is a known content format
+func isValidFormat(format string) bool {
+	return format == MessageFormatPlain || format == MessageFormatMarkdown
+}
+
+// renderContent returns the sanitized HTML of markdown content.
+// Plain content has no HTML rendering.
+func renderContent(format, content string) string {
+	if format != MessageFormatMarkdown || content == "" {
+		return ""
+	}
+	return markdown.Render(content)
+}
@@ -0,0 +1,106 @@
+package biz
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+)
+
+// setupFormatRoom creates room 10 with member 100
+func setupFormatRoom() (*ChatUseCase, *MockChatRepo) {
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	return newTestChatUseCase(chatRepo, roomRepo, userRepo), chatRepo
+}
+
+// ==================== Markdown Tests ====================
+
+func TestSendMessage_MarkdownRendersSanitizedHTML(t *testing.T) {
+	// Arrange
+	uc, _ := setupFormatRoom()
+
+	// Act
+	message, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{
+		RoomId:  10,
+		Content: "**hi** <script>alert(1)</script> [x](javascript:alert)",
+		Format:  MessageFormatMarkdown,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if message.Content != "**hi** <script>alert(1)</script> [x](javascript:alert)" {
+		t.Errorf("expected raw source kept, got %q", message.Content)
+	}
+	if !strings.Contains(message.ContentHTML, "<strong>hi</strong>") {
+		t.Errorf("expected rendered markdown, got %q", message.ContentHTML)
+	}
+	if strings.Contains(message.ContentHTML, "<script") || strings.Contains(message.ContentHTML, "javascript:") {
+		t.Errorf("expected unsafe constructs stripped, got %q", message.ContentHTML)
+	}
+}
+
+func TestSendMessage_PlainHasNoHTML(t *testing.T) {
+	// Arrange
+	uc, _ := setupFormatRoom()
+
+	// Act
+	message, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "**hi**"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if message.Format != MessageFormatPlain || message.ContentHTML != "" {
+		t.Errorf("expected plain message without HTML, got format %q html %q", message.Format, message.ContentHTML)
+	}
+}
+
+func TestSendMessage_InvalidFormat(t *testing.T) {
+	// Arrange
+	uc, _ := setupFormatRoom()
+
+	// Act
+	_, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "hi", Format: "html"})
+
+	// Assert
+	if err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestSendMessage_MarkdownLengthLimitAppliesToSource(t *testing.T) {
+	// Arrange: the rendering is longer than the limit, the source is not
+	uc, _ := setupFormatRoom()
+	content := strings.Repeat("https://a.io ", 300)
+
+	// Act
+	message, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: content, Format: MessageFormatMarkdown})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(message.ContentHTML) <= 4000 {
+		t.Fatalf("expected a rendering over the limit, got %d bytes", len(message.ContentHTML))
+	}
+}
+
+func TestEditMessage_MarkdownRerendered(t *testing.T) {
+	// Arrange
+	uc, _ := setupFormatRoom()
+	message, _ := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "*old*", Format: MessageFormatMarkdown})
+
+	// Act
+	edited, err := uc.EditMessage(context.Background(), 100, message.ID, "*new*")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if edited.ContentHTML != "<p><em>new</em></p>" {
+		t.Errorf("expected re-rendered HTML, got %q", edited.ContentHTML)
+	}
+}
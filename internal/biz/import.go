@@ -0,0 +1,489 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/yourusername/chat-app/internal/conf"
+)
+
+var (
+	ErrImportDenied      = errors.New("only chat admins can import history")
+	ErrImportJobNotFound = errors.New("import job not found")
+	ErrInvalidImport     = errors.New("invalid import")
+)
+
+// Import job states
+const (
+	ImportJobRunning   = "running"
+	ImportJobCompleted = "completed"
+	ImportJobFailed    = "failed"
+)
+
+// placeholderPasswordHash never matches a bcrypt check, so placeholder
+// users created by an import can't log in
+const placeholderPasswordHash = "!"
+
+// placeholderEmailDomain is the reserved domain of placeholder users' emails
+const placeholderEmailDomain = "import.invalid"
+
+// usernameUnsafeChars matches what a placeholder username may not contain
+var usernameUnsafeChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// ImportUser is a user as the source system knows them
+type ImportUser struct {
+	Key      string // ID in the source, unique within the import
+	Email    string // matched against existing users; may be empty
+	Username string
+}
+
+// ImportRoom is a room as the source system knows it
+type ImportRoom struct {
+	Key         string // globally unique, e.g. "slack:C024BE91L"
+	Name        string
+	Description string
+	Private     bool
+	MemberKeys  []string // ImportUser keys
+}
+
+// ImportFile is an attachment the import copies into storage
+type ImportFile struct {
+	Name     string
+	MimeType string
+	// Open fetches the file's content and its size (-1 if unknown)
+	Open func(ctx context.Context) (io.ReadCloser, int64, error)
+}
+
+// ImportMessage is a message as the source system knows it
+type ImportMessage struct {
+	Key       string // globally unique, e.g. "slack:C024BE91L:1512085950.000216"
+	UserKey   string
+	Text      string
+	CreatedAt time.Time
+	ThreadKey string // Key of the thread root when the message is a reply
+	Files     []*ImportFile
+}
+
+// ImportSource reads the users, rooms and messages of an export
+type ImportSource interface {
+	Users(ctx context.Context) ([]*ImportUser, error)
+	Rooms(ctx context.Context) ([]*ImportRoom, error)
+	// Messages passes a room's messages to fn in batches, oldest first
+	Messages(ctx context.Context, room *ImportRoom, fn func([]*ImportMessage) error) error
+}
+
+// ImportedMessage is a message ready to store under its import key
+type ImportedMessage struct {
+	Key     string
+	Message *Message
+}
+
+// ImportRepo stores imported rooms and messages
+type ImportRepo interface {
+	// UpsertImportedRoom creates the room imported under key, or returns the ID of the one already imported
+	UpsertImportedRoom(ctx context.Context, key string, room *Room) (int64, error)
+	// AddImportedMembers adds users to a room, leaving existing memberships as they are
+	AddImportedMembers(ctx context.Context, roomID int64, userIDs []int64, role string) error
+	// GetImportedMessageIDs maps the keys already imported to their message IDs
+	GetImportedMessageIDs(ctx context.Context, keys []string) (map[string]int64, error)
+	// CreateImportedMessages stores messages with their original timestamps,
+	// skips keys already imported and returns how many were created
+	CreateImportedMessages(ctx context.Context, messages []*ImportedMessage) (int, error)
+	// UploadImportedFile copies an attachment into storage and returns its URL and size.
+	// fileSize may be -1 when the source doesn't know it.
+	UploadImportedFile(ctx context.Context, reader io.Reader, fileName string, fileSize int64, mimeType string) (string, int64, error)
+}
+
+// ImportReport counts what an import did. A re-run counts what was already
+// imported as skipped.
+type ImportReport struct {
+	Rooms            int
+	UsersMatched     int
+	UsersCreated     int
+	MessagesImported int
+	MessagesSkipped  int
+	FilesUploaded    int
+	FilesFailed      int
+}
+
+// ImportJob is an import running in the background
+type ImportJob struct {
+	ID         string
+	UserID     int64
+	Format     string
+	Status     string
+	Error      string
+	Report     ImportReport
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// ImportOpener opens the source of a background import and returns a
+// function that releases it
+type ImportOpener func(ctx context.Context) (ImportSource, func(), error)
+
+// ImportUseCase imports room history exported from other chat systems.
+// Every room and message carries its source key, so an interrupted import
+// can simply be run again.
+type ImportUseCase struct {
+	repo     ImportRepo
+	userRepo UserRepo
+	admins   map[int64]bool
+	log      *log.Helper
+
+	mu   sync.Mutex
+	jobs map[string]*ImportJob
+}
+
+// NewImportUseCase creates a new import use case
+func NewImportUseCase(repo ImportRepo, userRepo UserRepo, chatConf *conf.Chat, logger log.Logger) *ImportUseCase {
+	admins := make(map[int64]bool)
+	for _, id := range chatConf.GetAdminUserIds() {
+		admins[id] = true
+	}
+
+	return &ImportUseCase{
+		repo:     repo,
+		userRepo: userRepo,
+		admins:   admins,
+		log:      log.NewHelper(log.With(logger, "module", "biz/import")),
+		jobs:     make(map[string]*ImportJob),
+	}
+}
+
+// StartImport runs an import for a chat admin in the background, owned by
+// the admin. Jobs are tracked in memory by the replica running them.
+func (uc *ImportUseCase) StartImport(ctx context.Context, userID int64, format string, open ImportOpener) (*ImportJob, error) {
+	if !uc.admins[userID] {
+		return nil, ErrImportDenied
+	}
+
+	job := &ImportJob{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Format:    format,
+		Status:    ImportJobRunning,
+		StartedAt: time.Now(),
+	}
+	snapshot := *job
+	uc.mu.Lock()
+	uc.jobs[job.ID] = job
+	uc.mu.Unlock()
+
+	// The import outlives the request that started it
+	go uc.runJob(context.Background(), job, open)
+
+	return &snapshot, nil
+}
+
+// runJob runs a background import and records how it ended
+func (uc *ImportUseCase) runJob(ctx context.Context, job *ImportJob, open ImportOpener) {
+	report := &ImportReport{}
+	src, release, err := open(ctx)
+	if err == nil {
+		err = uc.Import(ctx, job.UserID, src, report)
+		release()
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	job.Report = *report
+	job.FinishedAt = time.Now()
+	if err != nil {
+		job.Status = ImportJobFailed
+		job.Error = err.Error()
+		uc.log.Errorf("Import %s failed: %v", job.ID, err)
+		return
+	}
+	job.Status = ImportJobCompleted
+	uc.log.Infof("Import %s completed: %+v", job.ID, *report)
+}
+
+// GetImportJob returns a background import started by the caller
+func (uc *ImportUseCase) GetImportJob(ctx context.Context, userID int64, jobID string) (*ImportJob, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	job, ok := uc.jobs[jobID]
+	if !ok || job.UserID != userID {
+		return nil, ErrImportJobNotFound
+	}
+	snapshot := *job
+	return &snapshot, nil
+}
+
+// Import copies a source's users, rooms and messages, adding to report as
+// it goes. ownerID administers the imported rooms. Messages keep their
+// original authors and timestamps; ones already imported are skipped.
+func (uc *ImportUseCase) Import(ctx context.Context, ownerID int64, src ImportSource, report *ImportReport) error {
+	users, err := src.Users(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read users: %w", err)
+	}
+	userIDs, err := uc.importUsers(ctx, users, report)
+	if err != nil {
+		return err
+	}
+
+	rooms, err := src.Rooms(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read rooms: %w", err)
+	}
+	for _, room := range rooms {
+		if err := uc.importRoom(ctx, ownerID, room, userIDs, src, report); err != nil {
+			return fmt.Errorf("failed to import room %s: %w", room.Key, err)
+		}
+		report.Rooms++
+	}
+
+	return nil
+}
+
+// importUsers maps each source user to a local one: by email, or else to
+// a placeholder created on the first import
+func (uc *ImportUseCase) importUsers(ctx context.Context, users []*ImportUser, report *ImportReport) (map[string]int64, error) {
+	userIDs := make(map[string]int64, len(users))
+	for _, user := range users {
+		if user.Key == "" {
+			return nil, fmt.Errorf("%w: user without an id", ErrInvalidImport)
+		}
+
+		if user.Email != "" {
+			if existing, err := uc.userRepo.GetUserByEmail(ctx, user.Email); err == nil {
+				userIDs[user.Key] = existing.ID
+				report.UsersMatched++
+				continue
+			}
+		}
+
+		// A placeholder from an earlier run
+		email := placeholderEmail(user.Key)
+		if existing, err := uc.userRepo.GetUserByEmail(ctx, email); err == nil {
+			userIDs[user.Key] = existing.ID
+			report.UsersMatched++
+			continue
+		}
+
+		username, err := uc.freeUsername(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		created, err := uc.userRepo.CreateUser(ctx, &User{Username: username, Email: email}, placeholderPasswordHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create placeholder user %s: %w", user.Key, err)
+		}
+		userIDs[user.Key] = created.ID
+		report.UsersCreated++
+	}
+	return userIDs, nil
+}
+
+// placeholderEmail is the email of the placeholder user for a source user.
+// The reserved domain keeps the person's real address free to register.
+func placeholderEmail(key string) string {
+	return fmt.Sprintf("%s@%s", strings.ToLower(usernameUnsafeChars.ReplaceAllString(key, "-")), placeholderEmailDomain)
+}
+
+// freeUsername picks an unused username for a placeholder user, numbering
+// it when the source's name is taken
+func (uc *ImportUseCase) freeUsername(ctx context.Context, user *ImportUser) (string, error) {
+	base := strings.Trim(usernameUnsafeChars.ReplaceAllString(user.Username, "-"), "-")
+	if len(base) < 3 {
+		base = "imported-" + usernameUnsafeChars.ReplaceAllString(user.Key, "-")
+	}
+	if len(base) > 40 {
+		base = base[:40]
+	}
+
+	for i := 1; i <= 100; i++ {
+		username := base
+		if i > 1 {
+			username = fmt.Sprintf("%s-%d", base, i)
+		}
+		if _, err := uc.userRepo.GetUserByUsername(ctx, username); err != nil {
+			return username, nil
+		}
+	}
+	return "", fmt.Errorf("no free username for %s", user.Key)
+}
+
+// importRoom creates the room, adds its members and imports its messages
+func (uc *ImportUseCase) importRoom(ctx context.Context, ownerID int64, room *ImportRoom, userIDs map[string]int64,
+	src ImportSource, report *ImportReport) error {
+	if room.Key == "" || strings.TrimSpace(room.Name) == "" {
+		return fmt.Errorf("%w: room needs an id and a name", ErrInvalidImport)
+	}
+
+	roomType := "public"
+	if room.Private {
+		roomType = "private"
+	}
+	roomID, err := uc.repo.UpsertImportedRoom(ctx, room.Key, &Room{
+		Name:        room.Name,
+		Description: room.Description,
+		Type:        roomType,
+		CreatedBy:   ownerID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := uc.repo.AddImportedMembers(ctx, roomID, []int64{ownerID}, "admin"); err != nil {
+		return err
+	}
+	members := make([]int64, 0, len(room.MemberKeys))
+	for _, key := range room.MemberKeys {
+		if id, ok := userIDs[key]; ok && id != ownerID {
+			members = append(members, id)
+		}
+	}
+	if err := uc.repo.AddImportedMembers(ctx, roomID, members, "member"); err != nil {
+		return err
+	}
+
+	return src.Messages(ctx, room, func(batch []*ImportMessage) error {
+		return uc.importMessages(ctx, roomID, batch, userIDs, report)
+	})
+}
+
+// importMessages imports one batch of a room's messages. Keys already
+// imported are skipped before any file is copied, and thread roots are
+// stored before their replies so replies can point at them.
+func (uc *ImportUseCase) importMessages(ctx context.Context, roomID int64, batch []*ImportMessage,
+	userIDs map[string]int64, report *ImportReport) error {
+	keys := make([]string, 0, len(batch))
+	for _, message := range batch {
+		keys = append(keys, message.Key)
+	}
+	existing, err := uc.repo.GetImportedMessageIDs(ctx, keys)
+	if err != nil {
+		return err
+	}
+
+	var roots, replies []*ImportedMessage
+	parentKeys := make(map[string]string)
+	for _, source := range batch {
+		if _, ok := existing[source.Key]; ok {
+			report.MessagesSkipped++
+			continue
+		}
+		userID, ok := userIDs[source.UserKey]
+		if !ok {
+			return fmt.Errorf("%w: message %s is by unknown user %s", ErrInvalidImport, source.Key, source.UserKey)
+		}
+
+		for _, message := range uc.buildMessages(ctx, roomID, userID, source, report) {
+			if source.ThreadKey != "" && source.ThreadKey != source.Key {
+				parentKeys[message.Key] = source.ThreadKey
+				replies = append(replies, message)
+			} else {
+				roots = append(roots, message)
+			}
+		}
+	}
+
+	created, err := uc.repo.CreateImportedMessages(ctx, roots)
+	if err != nil {
+		return err
+	}
+	report.MessagesImported += created
+
+	if len(replies) == 0 {
+		return nil
+	}
+	threadKeys := make([]string, 0, len(parentKeys))
+	for _, key := range parentKeys {
+		threadKeys = append(threadKeys, key)
+	}
+	parents, err := uc.repo.GetImportedMessageIDs(ctx, threadKeys)
+	if err != nil {
+		return err
+	}
+	// A reply whose root never made it into the export stays in the room
+	for _, reply := range replies {
+		reply.Message.ParentMessageID = parents[parentKeys[reply.Key]]
+	}
+	created, err = uc.repo.CreateImportedMessages(ctx, replies)
+	if err != nil {
+		return err
+	}
+	report.MessagesImported += created
+
+	return nil
+}
+
+// buildMessages turns a source message into messages to store. A message
+// holds one attachment, so the first file goes on the message and each
+// further one becomes a message of its own. A file that can't be copied is
+// counted and left out; the text is imported regardless, and a message
+// left with neither is dropped.
+func (uc *ImportUseCase) buildMessages(ctx context.Context, roomID, userID int64, source *ImportMessage, report *ImportReport) []*ImportedMessage {
+	newMessage := func(key, content string) *ImportedMessage {
+		return &ImportedMessage{
+			Key: key,
+			Message: &Message{
+				RoomID:    roomID,
+				UserID:    userID,
+				Content:   content,
+				Type:      "text",
+				Format:    MessageFormatPlain,
+				CreatedAt: source.CreatedAt,
+			},
+		}
+	}
+
+	first := newMessage(source.Key, source.Text)
+	var messages []*ImportedMessage
+	for i, file := range source.Files {
+		target := first
+		if i > 0 {
+			target = newMessage(fmt.Sprintf("%s/file/%d", source.Key, i+1), "")
+		}
+		if err := uc.copyFile(ctx, target.Message, file); err != nil {
+			uc.log.Warnf("Failed to import file %q of message %s: %v", file.Name, source.Key, err)
+			report.FilesFailed++
+			continue
+		}
+		report.FilesUploaded++
+		if target != first {
+			messages = append(messages, target)
+		}
+	}
+
+	if first.Message.Content != "" || first.Message.FileURL != "" {
+		messages = append([]*ImportedMessage{first}, messages...)
+	}
+	return messages
+}
+
+// copyFile uploads a source file and attaches it to the message
+func (uc *ImportUseCase) copyFile(ctx context.Context, message *Message, file *ImportFile) error {
+	reader, size, err := file.Open(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = reader.Close() }()
+
+	url, size, err := uc.repo.UploadImportedFile(ctx, reader, file.Name, size, file.MimeType)
+	if err != nil {
+		return err
+	}
+
+	message.FileURL = url
+	message.FileName = file.Name
+	message.FileSize = size
+	message.MimeType = file.MimeType
+	message.Type = "file"
+	if strings.HasPrefix(file.MimeType, "image/") {
+		message.Type = "image"
+	}
+	return nil
+}
@@ -0,0 +1,386 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/yourusername/chat-app/internal/conf"
+)
+
+// MockImportRepo is a mock implementation of ImportRepo
+type MockImportRepo struct {
+	rooms    map[string]int64 // import key -> room ID
+	members  map[int64]map[int64]string
+	messages map[string]*Message // import key -> message
+	uploads  []string
+	nextID   int64
+}
+
+func NewMockImportRepo() *MockImportRepo {
+	return &MockImportRepo{
+		rooms:    make(map[string]int64),
+		members:  make(map[int64]map[int64]string),
+		messages: make(map[string]*Message),
+		nextID:   1,
+	}
+}
+
+func (m *MockImportRepo) UpsertImportedRoom(ctx context.Context, key string, room *Room) (int64, error) {
+	if id, ok := m.rooms[key]; ok {
+		return id, nil
+	}
+	id := m.nextID
+	m.nextID++
+	m.rooms[key] = id
+	return id, nil
+}
+
+func (m *MockImportRepo) AddImportedMembers(ctx context.Context, roomID int64, userIDs []int64, role string) error {
+	if m.members[roomID] == nil {
+		m.members[roomID] = make(map[int64]string)
+	}
+	for _, userID := range userIDs {
+		if _, ok := m.members[roomID][userID]; !ok {
+			m.members[roomID][userID] = role
+		}
+	}
+	return nil
+}
+
+func (m *MockImportRepo) GetImportedMessageIDs(ctx context.Context, keys []string) (map[string]int64, error) {
+	ids := make(map[string]int64)
+	for _, key := range keys {
+		if message, ok := m.messages[key]; ok {
+			ids[key] = message.ID
+		}
+	}
+	return ids, nil
+}
+
+func (m *MockImportRepo) CreateImportedMessages(ctx context.Context, messages []*ImportedMessage) (int, error) {
+	created := 0
+	for _, imported := range messages {
+		if _, ok := m.messages[imported.Key]; ok {
+			continue
+		}
+		imported.Message.ID = m.nextID
+		m.nextID++
+		m.messages[imported.Key] = imported.Message
+		created++
+	}
+	return created, nil
+}
+
+func (m *MockImportRepo) UploadImportedFile(ctx context.Context, reader io.Reader, fileName string, fileSize int64, mimeType string) (string, int64, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return "", 0, err
+	}
+	m.uploads = append(m.uploads, fileName)
+	return "http://files/" + fileName, int64(len(content)), nil
+}
+
+// MockImportSource is an in-memory export
+type MockImportSource struct {
+	users    []*ImportUser
+	rooms    []*ImportRoom
+	messages map[string][]*ImportMessage // room key -> messages
+	// failAfter stops Messages with an error after that many batches (0 = never)
+	failAfter int
+}
+
+func (s *MockImportSource) Users(ctx context.Context) ([]*ImportUser, error) {
+	return s.users, nil
+}
+
+func (s *MockImportSource) Rooms(ctx context.Context) ([]*ImportRoom, error) {
+	return s.rooms, nil
+}
+
+// Messages hands over one message per batch
+func (s *MockImportSource) Messages(ctx context.Context, room *ImportRoom, fn func([]*ImportMessage) error) error {
+	for i, message := range s.messages[room.Key] {
+		if s.failAfter > 0 && i == s.failAfter {
+			return errors.New("connection lost")
+		}
+		if err := fn([]*ImportMessage{message}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// testFile is an attachment with fixed content, or one that fails to download
+func testFile(name, mimeType, content string, fail bool) *ImportFile {
+	return &ImportFile{
+		Name:     name,
+		MimeType: mimeType,
+		Open: func(ctx context.Context) (io.ReadCloser, int64, error) {
+			if fail {
+				return nil, 0, errors.New("download failed")
+			}
+			return io.NopCloser(strings.NewReader(content)), -1, nil
+		},
+	}
+}
+
+// setupImport creates an import use case with admin 1 (owner@example.com)
+// and existing user 2 (ada@example.com), and an export with users ada and
+// bob in room "ext:general": a thread root, a reply and a message with two files
+func setupImport() (*ImportUseCase, *MockImportRepo, *MockUserRepo, *MockImportSource) {
+	repo := NewMockImportRepo()
+	userRepo := NewMockUserRepo()
+	_, _ = userRepo.CreateUser(context.Background(), &User{Username: "owner", Email: "owner@example.com"}, "hash")
+	_, _ = userRepo.CreateUser(context.Background(), &User{Username: "ada", Email: "ada@example.com"}, "hash")
+
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	src := &MockImportSource{
+		users: []*ImportUser{
+			{Key: "ext:U1", Email: "ada@example.com", Username: "ada.l"},
+			{Key: "ext:U2", Email: "bob@example.com", Username: "ada"},
+		},
+		rooms: []*ImportRoom{
+			{Key: "ext:general", Name: "general", MemberKeys: []string{"ext:U1", "ext:U2"}},
+		},
+		messages: map[string][]*ImportMessage{
+			"ext:general": {
+				{Key: "ext:general:1", UserKey: "ext:U1", Text: "release today?", CreatedAt: start},
+				{Key: "ext:general:2", UserKey: "ext:U2", Text: "yes", CreatedAt: start.Add(time.Minute), ThreadKey: "ext:general:1"},
+				{Key: "ext:general:3", UserKey: "ext:U2", Text: "notes attached", CreatedAt: start.Add(2 * time.Minute),
+					Files: []*ImportFile{
+						testFile("notes.pdf", "application/pdf", "pdf", false),
+						testFile("chart.png", "image/png", "png", false),
+					}},
+			},
+		},
+	}
+
+	chatConf := &conf.Chat{AdminUserIds: []int64{1}}
+	return NewImportUseCase(repo, userRepo, chatConf, log.NewStdLogger(io.Discard)), repo, userRepo, src
+}
+
+// ==================== Import Tests ====================
+
+func TestImport_Success(t *testing.T) {
+	// Arrange
+	uc, repo, _, src := setupImport()
+	report := &ImportReport{}
+
+	// Act
+	err := uc.Import(context.Background(), 1, src, report)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report.Rooms != 1 || report.MessagesImported != 4 || report.FilesUploaded != 2 {
+		t.Errorf("expected 1 room, 4 messages and 2 files, got %+v", report)
+	}
+	root := repo.messages["ext:general:1"]
+	if root.UserID != 2 || !root.CreatedAt.Equal(src.messages["ext:general"][0].CreatedAt) {
+		t.Errorf("expected the root by ada at its original time, got %+v", root)
+	}
+	if reply := repo.messages["ext:general:2"]; reply.ParentMessageID != root.ID {
+		t.Errorf("expected the reply in the root's thread, got parent %d", reply.ParentMessageID)
+	}
+}
+
+func TestImport_MatchesUsersByEmail(t *testing.T) {
+	// Arrange
+	uc, _, userRepo, src := setupImport()
+	report := &ImportReport{}
+
+	// Act
+	_ = uc.Import(context.Background(), 1, src, report)
+
+	// Assert
+	if report.UsersMatched != 1 || report.UsersCreated != 1 {
+		t.Fatalf("expected 1 matched and 1 created user, got %+v", report)
+	}
+	placeholder, err := userRepo.GetUserByEmail(context.Background(), "ext-u2@import.invalid")
+	if err != nil {
+		t.Fatalf("expected a placeholder for bob, got %v", err)
+	}
+	if placeholder.Username != "ada-2" {
+		t.Errorf("expected the taken username numbered, got %q", placeholder.Username)
+	}
+	if userRepo.passwords[placeholder.Email] != placeholderPasswordHash {
+		t.Error("expected the placeholder unable to log in")
+	}
+}
+
+func TestImport_AddsMembers(t *testing.T) {
+	// Arrange
+	uc, repo, _, src := setupImport()
+
+	// Act
+	_ = uc.Import(context.Background(), 1, src, &ImportReport{})
+
+	// Assert
+	members := repo.members[repo.rooms["ext:general"]]
+	if members[1] != "admin" || members[2] != "member" || len(members) != 3 {
+		t.Errorf("expected the owner as admin plus both users, got %v", members)
+	}
+}
+
+func TestImport_ExtraFilesBecomeMessages(t *testing.T) {
+	// Arrange
+	uc, repo, _, src := setupImport()
+
+	// Act
+	_ = uc.Import(context.Background(), 1, src, &ImportReport{})
+
+	// Assert
+	first := repo.messages["ext:general:3"]
+	if first.Type != "file" || first.FileName != "notes.pdf" || first.FileSize != 3 || first.Content != "notes attached" {
+		t.Errorf("expected the first file on the message, got %+v", first)
+	}
+	second := repo.messages["ext:general:3/file/2"]
+	if second == nil || second.Type != "image" || second.FileURL != "http://files/chart.png" {
+		t.Errorf("expected the second file as its own image message, got %+v", second)
+	}
+}
+
+func TestImport_FailedFileKeepsText(t *testing.T) {
+	// Arrange
+	uc, repo, _, src := setupImport()
+	src.messages["ext:general"][2].Files = []*ImportFile{testFile("notes.pdf", "application/pdf", "", true)}
+	report := &ImportReport{}
+
+	// Act
+	err := uc.Import(context.Background(), 1, src, report)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report.FilesFailed != 1 {
+		t.Errorf("expected 1 failed file, got %d", report.FilesFailed)
+	}
+	message := repo.messages["ext:general:3"]
+	if message == nil || message.Content != "notes attached" || message.FileURL != "" {
+		t.Errorf("expected the text imported without the file, got %+v", message)
+	}
+}
+
+func TestImport_RerunSkipsImported(t *testing.T) {
+	// Arrange
+	uc, repo, _, src := setupImport()
+	_ = uc.Import(context.Background(), 1, src, &ImportReport{})
+	uploads := len(repo.uploads)
+	report := &ImportReport{}
+
+	// Act
+	err := uc.Import(context.Background(), 1, src, report)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report.MessagesImported != 0 || report.MessagesSkipped != 3 || report.UsersCreated != 0 {
+		t.Errorf("expected everything skipped, got %+v", report)
+	}
+	if len(repo.uploads) != uploads || len(repo.messages) != 4 {
+		t.Errorf("expected no new uploads or messages, got %d uploads and %d messages", len(repo.uploads), len(repo.messages))
+	}
+}
+
+func TestImport_ResumesAfterFailure(t *testing.T) {
+	// Arrange
+	uc, repo, _, src := setupImport()
+	src.failAfter = 2
+	_ = uc.Import(context.Background(), 1, src, &ImportReport{})
+	src.failAfter = 0
+	report := &ImportReport{}
+
+	// Act
+	err := uc.Import(context.Background(), 1, src, report)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report.MessagesSkipped != 2 || report.MessagesImported != 2 || len(repo.messages) != 4 {
+		t.Errorf("expected the rest imported on the second run, got %+v", report)
+	}
+}
+
+func TestImport_UnknownAuthor(t *testing.T) {
+	// Arrange
+	uc, _, _, src := setupImport()
+	src.messages["ext:general"][0].UserKey = "ext:U9"
+
+	// Act
+	err := uc.Import(context.Background(), 1, src, &ImportReport{})
+
+	// Assert
+	if !errors.Is(err, ErrInvalidImport) {
+		t.Errorf("expected ErrInvalidImport, got %v", err)
+	}
+}
+
+// ==================== Import Job Tests ====================
+
+func TestStartImport_NonAdminDenied(t *testing.T) {
+	// Arrange
+	uc, _, _, src := setupImport()
+
+	// Act
+	_, err := uc.StartImport(context.Background(), 2, "slack", func(ctx context.Context) (ImportSource, func(), error) {
+		return src, func() {}, nil
+	})
+
+	// Assert
+	if err != ErrImportDenied {
+		t.Errorf("expected ErrImportDenied, got %v", err)
+	}
+}
+
+func TestStartImport_RunsInBackground(t *testing.T) {
+	// Arrange
+	uc, _, _, src := setupImport()
+	released := make(chan struct{})
+
+	// Act
+	job, err := uc.StartImport(context.Background(), 1, "slack", func(ctx context.Context) (ImportSource, func(), error) {
+		return src, func() { close(released) }, nil
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	<-released
+	var finished *ImportJob
+	for i := 0; i < 100; i++ {
+		finished, _ = uc.GetImportJob(context.Background(), 1, job.ID)
+		if finished.Status != ImportJobRunning {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if finished.Status != ImportJobCompleted || finished.Report.MessagesImported != 4 {
+		t.Errorf("expected the job completed with 4 messages, got %+v", finished)
+	}
+}
+
+func TestGetImportJob_OtherUser(t *testing.T) {
+	// Arrange
+	uc, _, _, src := setupImport()
+	job, _ := uc.StartImport(context.Background(), 1, "slack", func(ctx context.Context) (ImportSource, func(), error) {
+		return src, func() {}, nil
+	})
+
+	// Act
+	_, err := uc.GetImportJob(context.Background(), 2, job.ID)
+
+	// Assert
+	if err != ErrImportJobNotFound {
+		t.Errorf("expected ErrImportJobNotFound, got %v", err)
+	}
+}
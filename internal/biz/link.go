@@ -0,0 +1,186 @@
+package biz
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxLinkPreviews is how many URLs of one message are unfurled
+const maxLinkPreviews = 5
+
+// linkPreviewClaimLease is how long a claimed message may stay unfurled
+// before the worker claims it again, e.g. after a crash in the middle of a batch
+const linkPreviewClaimLease = 5 * time.Minute
+
+// LinkPreview is the OpenGraph / Twitter card metadata of a linked page
+type LinkPreview struct {
+	URL         string
+	Title       string
+	Description string
+	ImageURL    string
+	SiteName    string
+}
+
+// LinkUnfurler fetches link previews. It returns nil when the page
+// has no preview or can't be fetched.
+type LinkUnfurler interface {
+	Unfurl(ctx context.Context, url string) (*LinkPreview, error)
+}
+
+// urlPattern matches http(s) URLs up to whitespace or a delimiter
+var urlPattern = regexp.MustCompile("https?://[^\\s<>\"'`]+")
+
+// UnfurlLinks fetches previews for up to limit queued messages, stores them
+// and tells each room with a message_updated event. It reports how many
+// messages were processed. A message's job is deleted only once its previews
+// are stored or it has none; otherwise the claim lapses after
+// linkPreviewClaimLease and the message is unfurled again.
+func (uc *ChatUseCase) UnfurlLinks(ctx context.Context, limit int32) (int, error) {
+	now := time.Now()
+	messageIDs, err := uc.repo.ClaimLinkPreviewJobs(ctx, now, now.Add(-linkPreviewClaimLease), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, messageID := range messageIDs {
+		// A hard-deleted message takes its job with it
+		message, err := uc.repo.GetMessage(ctx, messageID)
+		if err != nil {
+			uc.log.Warnf("Failed to load message %d for unfurling, will retry: %v", messageID, err)
+			continue
+		}
+		if message.IsDeleted {
+			uc.completeLinkPreviewJob(ctx, messageID, now)
+			continue
+		}
+
+		previews := uc.fetchLinkPreviews(ctx, extractURLs(message.Content))
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		// Edits may have removed the links, so an edited message is always updated
+		if len(previews) == 0 && !message.IsEdited {
+			uc.completeLinkPreviewJob(ctx, messageID, now)
+			continue
+		}
+
+		if err := uc.repo.SaveLinkPreviews(ctx, messageID, previews); err != nil {
+			uc.log.Errorf("Failed to save link previews of message %d, will retry: %v", messageID, err)
+			continue
+		}
+		uc.completeLinkPreviewJob(ctx, messageID, now)
+
+		data := map[string]interface{}{
+			"message_id":    message.ID,
+			"link_previews": linkPreviewEventData(previews),
+		}
+		if message.ParentMessageID != 0 {
+			data["parent_message_id"] = message.ParentMessageID
+		}
+		uc.publishEvent(ctx, &RoomEvent{
+			Type:   EventMessageUpdated,
+			RoomID: message.RoomID,
+			Data:   data,
+		})
+	}
+
+	return len(messageIDs), nil
+}
+
+// queueLinkPreviews queues a text message for the unfurler when it has links.
+// hadLinks queues it anyway, so previews of removed links are cleared.
+func (uc *ChatUseCase) queueLinkPreviews(ctx context.Context, message *Message, hadLinks bool) {
+	if message.Type != "text" || (!hadLinks && len(extractURLs(message.Content)) == 0) {
+		return
+	}
+	if err := uc.repo.QueueLinkPreviews(ctx, message.ID); err != nil {
+		uc.log.Errorf("Failed to queue link previews of message %d: %v", message.ID, err)
+	}
+}
+
+// completeLinkPreviewJob removes a processed message from the queue. On
+// failure the claim lapses and the message is unfurled again, which is harmless.
+func (uc *ChatUseCase) completeLinkPreviewJob(ctx context.Context, messageID int64, claimedAt time.Time) {
+	if err := uc.repo.CompleteLinkPreviewJob(ctx, messageID, claimedAt); err != nil {
+		uc.log.Errorf("Failed to complete link preview job of message %d: %v", messageID, err)
+	}
+}
+
+// fetchLinkPreviews unfurls each URL, skipping those without a preview
+func (uc *ChatUseCase) fetchLinkPreviews(ctx context.Context, urls []string) []*LinkPreview {
+	if uc.unfurler == nil {
+		return nil
+	}
+
+	var previews []*LinkPreview
+	for _, url := range urls {
+		preview, err := uc.unfurler.Unfurl(ctx, url)
+		if err != nil {
+			uc.log.Warnf("Failed to unfurl %s: %v", url, err)
+			continue
+		}
+		if preview != nil {
+			previews = append(previews, preview)
+		}
+	}
+	return previews
+}
+
+// attachLinkPreviews fills in LinkPreviews on each message in one repo call
+func (uc *ChatUseCase) attachLinkPreviews(ctx context.Context, messages []*Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(messages))
+	for _, message := range messages {
+		ids = append(ids, message.ID)
+	}
+
+	previews, err := uc.repo.ListLinkPreviews(ctx, ids)
+	if err != nil {
+		uc.log.Errorf("Failed to load link previews: %v", err)
+		return err
+	}
+
+	for _, message := range messages {
+		message.LinkPreviews = previews[message.ID]
+	}
+	return nil
+}
+
+// extractURLs returns the distinct URLs in content, in order,
+// without trailing punctuation, at most maxLinkPreviews
+func extractURLs(content string) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	for _, match := range urlPattern.FindAllString(content, -1) {
+		url := strings.TrimRight(match, ".,;:!?)]}*_~")
+		if strings.HasSuffix(url, "://") || seen[url] {
+			continue
+		}
+		seen[url] = true
+		urls = append(urls, url)
+		if len(urls) == maxLinkPreviews {
+			break
+		}
+	}
+	return urls
+}
+
+// linkPreviewEventData flattens link previews into event data
+func linkPreviewEventData(previews []*LinkPreview) []map[string]interface{} {
+	data := make([]map[string]interface{}, 0, len(previews))
+	for _, preview := range previews {
+		data = append(data, map[string]interface{}{
+			"url":         preview.URL,
+			"title":       preview.Title,
+			"description": preview.Description,
+			"image_url":   preview.ImageURL,
+			"site_name":   preview.SiteName,
+		})
+	}
+	return data
+}
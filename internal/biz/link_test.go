@@ -0,0 +1,261 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+)
+
+// ==================== Mock Link Unfurler ====================
+
+type MockLinkUnfurler struct {
+	previews map[string]*LinkPreview
+	fetched  []string
+}
+
+func (m *MockLinkUnfurler) Unfurl(ctx context.Context, url string) (*LinkPreview, error) {
+	m.fetched = append(m.fetched, url)
+	return m.previews[url], nil
+}
+
+// setupLinkRoom creates room 10 with member 100 and an unfurler that knows example.com
+func setupLinkRoom() (*ChatUseCase, *MockChatRepo, *MockLinkUnfurler, *MockEventPublisher) {
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+
+	unfurler := &MockLinkUnfurler{previews: map[string]*LinkPreview{
+		"https://example.com/post": {URL: "https://example.com/post", Title: "A post", SiteName: "Example"},
+	}}
+	publisher := &MockEventPublisher{}
+	logger := log.NewStdLogger(io.Discard)
+	system := NewSystemMessenger(chatRepo, userRepo, publisher, logger)
+	uc := NewChatUseCase(chatRepo, roomRepo, userRepo, NewMockPresenceRepo(), unfurler, publisher, system, nil, logger)
+
+	return uc, chatRepo, unfurler, publisher
+}
+
+// ==================== extractURLs Tests ====================
+
+func TestExtractURLs_TrimsPunctuationAndDedupes(t *testing.T) {
+	// Act
+	urls := extractURLs("see https://example.com/post, (http://go.dev/doc) and https://example.com/post.")
+
+	// Assert
+	if len(urls) != 2 || urls[0] != "https://example.com/post" || urls[1] != "http://go.dev/doc" {
+		t.Errorf("expected [https://example.com/post http://go.dev/doc], got %v", urls)
+	}
+}
+
+func TestExtractURLs_Limit(t *testing.T) {
+	// Act
+	urls := extractURLs("http://a.com http://b.com http://c.com http://d.com http://e.com http://f.com")
+
+	// Assert
+	if len(urls) != maxLinkPreviews {
+		t.Errorf("expected %d urls, got %v", maxLinkPreviews, urls)
+	}
+}
+
+// ==================== Queue Tests ====================
+
+func TestSendMessage_QueuesLinkPreviews(t *testing.T) {
+	// Arrange
+	uc, chatRepo, _, _ := setupLinkRoom()
+
+	// Act
+	message, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "read https://example.com/post"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(chatRepo.linkJobs) != 1 || chatRepo.linkJobs[0] != message.ID {
+		t.Errorf("expected message %d queued, got %v", message.ID, chatRepo.linkJobs)
+	}
+}
+
+func TestSendMessage_WithoutLinksNotQueued(t *testing.T) {
+	// Arrange
+	uc, chatRepo, _, _ := setupLinkRoom()
+
+	// Act
+	_, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "no links here"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(chatRepo.linkJobs) != 0 {
+		t.Errorf("expected nothing queued, got %v", chatRepo.linkJobs)
+	}
+}
+
+// ==================== UnfurlLinks Tests ====================
+
+func TestUnfurlLinks_SavesAndPublishes(t *testing.T) {
+	// Arrange
+	uc, chatRepo, unfurler, publisher := setupLinkRoom()
+	message, _ := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "https://example.com/post https://unknown.test/"})
+
+	// Act
+	processed, err := uc.UnfurlLinks(context.Background(), 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if processed != 1 || len(unfurler.fetched) != 2 {
+		t.Errorf("expected 1 message with 2 urls fetched, got %d messages, %v", processed, unfurler.fetched)
+	}
+	saved := chatRepo.linkPreviews[message.ID]
+	if len(saved) != 1 || saved[0].Title != "A post" {
+		t.Errorf("expected the example.com preview saved, got %+v", saved)
+	}
+	if len(publisher.events) != 1 || publisher.events[0].Type != EventMessageUpdated || publisher.events[0].RoomID != 10 {
+		t.Fatalf("expected a message_updated event in room 10, got %+v", publisher.events)
+	}
+	if publisher.events[0].Data["message_id"] != message.ID {
+		t.Errorf("expected event for message %d, got %v", message.ID, publisher.events[0].Data["message_id"])
+	}
+}
+
+func TestUnfurlLinks_NoPreviewPublishesNothing(t *testing.T) {
+	// Arrange
+	uc, chatRepo, _, publisher := setupLinkRoom()
+	message, _ := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "https://unknown.test/"})
+
+	// Act
+	_, err := uc.UnfurlLinks(context.Background(), 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, saved := chatRepo.linkPreviews[message.ID]; saved {
+		t.Error("expected no previews saved")
+	}
+	if len(publisher.events) != 0 {
+		t.Errorf("expected no events, got %d", len(publisher.events))
+	}
+}
+
+func TestUnfurlLinks_SkipsDeletedMessage(t *testing.T) {
+	// Arrange
+	uc, chatRepo, unfurler, publisher := setupLinkRoom()
+	message, _ := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "https://example.com/post"})
+	_ = chatRepo.DeleteMessage(context.Background(), message.ID, 100)
+
+	// Act
+	processed, err := uc.UnfurlLinks(context.Background(), 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if processed != 1 || len(unfurler.fetched) != 0 || len(publisher.events) != 0 {
+		t.Errorf("expected deleted message skipped, fetched %v, events %d", unfurler.fetched, len(publisher.events))
+	}
+}
+
+func TestUnfurlLinks_CompletesJob(t *testing.T) {
+	// Arrange
+	uc, chatRepo, _, _ := setupLinkRoom()
+	_, _ = uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "https://example.com/post"})
+
+	// Act
+	_, err := uc.UnfurlLinks(context.Background(), 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(chatRepo.linkJobs) != 0 {
+		t.Errorf("expected the job deleted, got %v", chatRepo.linkJobs)
+	}
+}
+
+func TestUnfurlLinks_SaveErrorKeepsJobClaimed(t *testing.T) {
+	// Arrange
+	uc, chatRepo, _, publisher := setupLinkRoom()
+	message, _ := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "https://example.com/post"})
+	chatRepo.saveLinkErr = errors.New("connection reset")
+
+	// Act
+	_, err := uc.UnfurlLinks(context.Background(), 10)
+	processedWhileClaimed, _ := uc.UnfurlLinks(context.Background(), 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(chatRepo.linkJobs) != 1 || chatRepo.linkJobs[0] != message.ID {
+		t.Errorf("expected message %d still queued, got %v", message.ID, chatRepo.linkJobs)
+	}
+	if processedWhileClaimed != 0 || len(publisher.events) != 0 {
+		t.Errorf("expected the claimed job skipped, processed %d, events %d", processedWhileClaimed, len(publisher.events))
+	}
+}
+
+func TestUnfurlLinks_ReclaimsLapsedJob(t *testing.T) {
+	// Arrange
+	uc, chatRepo, _, _ := setupLinkRoom()
+	message, _ := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "https://example.com/post"})
+	chatRepo.linkClaims = map[int64]time.Time{message.ID: time.Now().Add(-linkPreviewClaimLease - time.Minute)}
+
+	// Act
+	processed, err := uc.UnfurlLinks(context.Background(), 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if processed != 1 || len(chatRepo.linkPreviews[message.ID]) != 1 || len(chatRepo.linkJobs) != 0 {
+		t.Errorf("expected the lapsed job unfurled and deleted, processed %d, previews %+v, jobs %v", processed, chatRepo.linkPreviews[message.ID], chatRepo.linkJobs)
+	}
+}
+
+func TestEditMessage_RemovingLinksClearsPreviews(t *testing.T) {
+	// Arrange
+	uc, chatRepo, _, publisher := setupLinkRoom()
+	message, _ := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "https://example.com/post"})
+	_, _ = uc.UnfurlLinks(context.Background(), 10)
+	publisher.events = nil
+
+	// Act
+	_, err := uc.EditMessage(context.Background(), 100, message.ID, "never mind")
+	_, _ = uc.UnfurlLinks(context.Background(), 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(chatRepo.linkPreviews[message.ID]) != 0 {
+		t.Errorf("expected previews cleared, got %+v", chatRepo.linkPreviews[message.ID])
+	}
+	last := publisher.events[len(publisher.events)-1]
+	if last.Type != EventMessageUpdated {
+		t.Errorf("expected a message_updated event after the edit, got %s", last.Type)
+	}
+}
+
+func TestListMessages_AttachesLinkPreviews(t *testing.T) {
+	// Arrange
+	uc, _, _, _ := setupLinkRoom()
+	message, _ := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "https://example.com/post"})
+	_, _ = uc.UnfurlLinks(context.Background(), 10)
+
+	// Act
+	page, err := uc.ListMessages(context.Background(), 100, 10, 20, MessageCursor{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.Messages) != 1 || page.Messages[0].ID != message.ID || len(page.Messages[0].LinkPreviews) != 1 {
+		t.Errorf("expected message %d with its preview, got %+v", message.ID, page.Messages)
+	}
+}
@@ -0,0 +1,175 @@
+package biz
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Mention types, from most to least specific
+const (
+	MentionTypeUser = "user" // @username
+	MentionTypeHere = "here" // @here: members currently connected
+	MentionTypeRoom = "room" // @room: every member
+)
+
+// Mention is a user mentioned by a message
+type Mention struct {
+	ID        int64
+	MessageID int64
+	RoomID    int64
+	UserID    int64
+	Type      string
+	CreatedAt time.Time
+	// Message is set when listing a user's mentions
+	Message *Message
+}
+
+// mentionPattern matches @name tokens that don't follow a word character,
+// so email addresses are not treated as mentions
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_])@([\p{L}\p{N}_.\-]+)`)
+
+// ListMentions lists the user's unread mentions across all rooms, newest first
+func (uc *ChatUseCase) ListMentions(ctx context.Context, userID int64, limit int32, beforeID int64) ([]*Mention, bool, error) {
+	return uc.repo.ListUnreadMentions(ctx, userID, limit, beforeID)
+}
+
+// processMentions stores the mentions in a sent message and notifies the
+// mentioned users. The message is already sent, so failures are only logged.
+func (uc *ChatUseCase) processMentions(ctx context.Context, message *Message) {
+	tokens := parseMentionTokens(message.Content)
+	if len(tokens) == 0 {
+		return
+	}
+
+	mentions, err := uc.resolveMentions(ctx, message, tokens)
+	if err != nil {
+		uc.log.Warnf("Failed to resolve mentions in message %d: %v", message.ID, err)
+		return
+	}
+	if len(mentions) == 0 {
+		return
+	}
+
+	if err := uc.repo.CreateMentions(ctx, mentions); err != nil {
+		uc.log.Warnf("Failed to save mentions in message %d: %v", message.ID, err)
+		return
+	}
+
+	// One event per mention type so clients can tell @here from a direct mention
+	recipients := make(map[string][]int64)
+	for _, mention := range mentions {
+		recipients[mention.Type] = append(recipients[mention.Type], mention.UserID)
+	}
+	for mentionType, userIDs := range recipients {
+		data := messageEventData(message)
+		data["mention_type"] = mentionType
+		uc.publishEvent(ctx, &RoomEvent{
+			Type:    EventMentioned,
+			RoomID:  message.RoomID,
+			Data:    data,
+			UserIDs: userIDs,
+		})
+	}
+
+	uc.log.Infof("Message %d mentions %d users", message.ID, len(mentions))
+}
+
+// resolveMentions maps @tokens to room members. A member mentioned several
+// ways keeps the most specific type; the sender is never mentioned.
+func (uc *ChatUseCase) resolveMentions(ctx context.Context, message *Message, tokens []string) ([]*Mention, error) {
+	members, err := uc.roomRepo.GetRoomMembers(ctx, message.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	byUsername := make(map[string]int64, len(members))
+	var others []int64
+	for _, member := range members {
+		if member.UserID == message.UserID {
+			continue
+		}
+		byUsername[strings.ToLower(member.Username)] = member.UserID
+		others = append(others, member.UserID)
+	}
+
+	mentioned := make(map[int64]string)
+	var order []int64
+	mention := func(userID int64, mentionType string) {
+		if _, ok := mentioned[userID]; !ok {
+			order = append(order, userID)
+			mentioned[userID] = mentionType
+		}
+	}
+
+	var mentionRoom, mentionHere bool
+	for _, token := range tokens {
+		switch token {
+		case "room":
+			mentionRoom = true
+		case "here":
+			mentionHere = true
+		default:
+			if userID, ok := byUsername[token]; ok {
+				mention(userID, MentionTypeUser)
+			}
+		}
+	}
+
+	if mentionHere {
+		online, err := uc.onlineUsers(ctx, others)
+		if err != nil {
+			return nil, err
+		}
+		for _, userID := range online {
+			mention(userID, MentionTypeHere)
+		}
+	}
+	if mentionRoom {
+		for _, userID := range others {
+			mention(userID, MentionTypeRoom)
+		}
+	}
+
+	mentions := make([]*Mention, 0, len(order))
+	for _, userID := range order {
+		mentions = append(mentions, &Mention{
+			MessageID: message.ID,
+			RoomID:    message.RoomID,
+			UserID:    userID,
+			Type:      mentioned[userID],
+			CreatedAt: message.CreatedAt,
+		})
+	}
+	return mentions, nil
+}
+
+// onlineUsers filters userIDs down to those with a live connection
+func (uc *ChatUseCase) onlineUsers(ctx context.Context, userIDs []int64) ([]int64, error) {
+	if uc.presence == nil || len(userIDs) == 0 {
+		return nil, nil
+	}
+	return uc.presence.FilterOnline(ctx, userIDs)
+}
+
+// parseMentionTokens returns the distinct lower-cased names mentioned in content
+func parseMentionTokens(content string) []string {
+	matches := mentionPattern.FindAllStringSubmatch(content, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(matches))
+	tokens := make([]string, 0, len(matches))
+	for _, match := range matches {
+		// Trailing punctuation ends the sentence, not the name
+		token := strings.ToLower(strings.TrimRight(match[1], ".-"))
+		if token == "" || seen[token] {
+			continue
+		}
+		seen[token] = true
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
@@ -0,0 +1,161 @@
+package biz
+
+import (
+	"context"
+	"testing"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+)
+
+// ==================== Mention Parsing Tests ====================
+
+func TestParseMentionTokens(t *testing.T) {
+	// Act
+	tokens := parseMentionTokens("Hey @Alice and @bob.smith, ping @alice again. @here! mail me at carol@example.com")
+
+	// Assert
+	expected := []string{"alice", "bob.smith", "here"}
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, tokens)
+	}
+	for i, token := range expected {
+		if tokens[i] != token {
+			t.Errorf("expected token %d to be '%s', got '%s'", i, token, tokens[i])
+		}
+	}
+}
+
+// ==================== SendMessage Mention Tests ====================
+
+// setupMentionRoom creates room 10 with sender 100 and members alice (200), bob (300), carol (400)
+func setupMentionRoom() (*MockChatRepo, *MockRoomRepo, *MockUserRepo) {
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	roomRepo.AddNamedMember(10, 200, "alice")
+	roomRepo.AddNamedMember(10, 300, "bob")
+	roomRepo.AddNamedMember(10, 400, "carol")
+
+	return chatRepo, roomRepo, userRepo
+}
+
+func TestSendMessage_UserMention(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupMentionRoom()
+	publisher := &MockEventPublisher{}
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+
+	req := &chatV1.SendMessageRequest{RoomId: 10, Content: "@Alice can you review? cc @nobody @sender", Type: "text"}
+
+	// Act
+	_, err := uc.SendMessage(context.Background(), 100, req)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(chatRepo.mentions) != 1 {
+		t.Fatalf("expected 1 mention, got %d", len(chatRepo.mentions))
+	}
+	if chatRepo.mentions[0].UserID != 200 || chatRepo.mentions[0].Type != MentionTypeUser {
+		t.Errorf("expected user mention of 200, got %+v", chatRepo.mentions[0])
+	}
+	if len(publisher.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(publisher.events))
+	}
+	event := publisher.events[0]
+	if event.Type != EventMentioned {
+		t.Errorf("expected event type '%s', got '%s'", EventMentioned, event.Type)
+	}
+	if len(event.UserIDs) != 1 || event.UserIDs[0] != 200 {
+		t.Errorf("expected event targeted at user 200, got %v", event.UserIDs)
+	}
+}
+
+func TestSendMessage_RoomMention(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupMentionRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	req := &chatV1.SendMessageRequest{RoomId: 10, Content: "@room deploy at 5pm, @bob you're on call", Type: "text"}
+
+	// Act
+	_, err := uc.SendMessage(context.Background(), 100, req)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	types := make(map[int64]string)
+	for _, mention := range chatRepo.mentions {
+		types[mention.UserID] = mention.Type
+	}
+	if len(types) != 3 {
+		t.Fatalf("expected 3 mentioned users, got %v", types)
+	}
+	if types[300] != MentionTypeUser {
+		t.Errorf("expected bob to keep the direct mention, got '%s'", types[300])
+	}
+	if types[200] != MentionTypeRoom || types[400] != MentionTypeRoom {
+		t.Errorf("expected alice and carol to get room mentions, got %v", types)
+	}
+	if _, ok := types[100]; ok {
+		t.Error("expected sender not to be mentioned")
+	}
+}
+
+func TestSendMessage_HereMentionOnlyOnline(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupMentionRoom()
+	presence := NewMockPresenceRepo()
+	presence.online[100] = true
+	presence.online[300] = true
+	publisher := &MockEventPublisher{}
+	uc := newTestChatUseCaseWithPresence(chatRepo, roomRepo, userRepo, presence, publisher)
+
+	req := &chatV1.SendMessageRequest{RoomId: 10, Content: "@here standup now", Type: "text"}
+
+	// Act
+	_, err := uc.SendMessage(context.Background(), 100, req)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(chatRepo.mentions) != 1 {
+		t.Fatalf("expected 1 mention, got %d", len(chatRepo.mentions))
+	}
+	if chatRepo.mentions[0].UserID != 300 || chatRepo.mentions[0].Type != MentionTypeHere {
+		t.Errorf("expected here mention of 300, got %+v", chatRepo.mentions[0])
+	}
+	if len(publisher.events) != 1 || publisher.events[0].Data["mention_type"] != MentionTypeHere {
+		t.Errorf("expected one here mention event, got %v", publisher.events)
+	}
+}
+
+// ==================== ListMentions Tests ====================
+
+func TestListMentions_ExcludesRead(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupMentionRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	first, _ := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "@alice one", Type: "text"})
+	_, _ = uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "@alice two", Type: "text"})
+	_ = uc.MarkMessageAsRead(context.Background(), 200, first.ID)
+
+	// Act
+	mentions, hasMore, err := uc.ListMentions(context.Background(), 200, 50, 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(mentions) != 1 {
+		t.Fatalf("expected 1 unread mention, got %d", len(mentions))
+	}
+	if mentions[0].Message == nil || mentions[0].Message.Content != "@alice two" {
+		t.Errorf("expected mention of the second message, got %+v", mentions[0].Message)
+	}
+	if hasMore {
+		t.Error("expected no more mentions")
+	}
+}
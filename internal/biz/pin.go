@@ -0,0 +1,168 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrPinNotAllowed   = errors.New("only room admins and moderators can pin messages")
+	ErrPinLimitReached = errors.New("room pin limit reached")
+)
+
+// defaultMaxPinsPerRoom applies when the chat config doesn't set a limit
+const defaultMaxPinsPerRoom = 50
+
+// Pin is a message pinned to its room
+type Pin struct {
+	Message          *Message
+	PinnedBy         int64
+	PinnedByUsername string
+	PinnedAt         time.Time
+}
+
+// PinMessage pins a message to its room (room admins and moderators only)
+// and notifies the room. Pinning an already pinned message is a no-op. The
+// repo enforces the pin limit as it pins, so concurrent pins can't exceed it.
+func (uc *ChatUseCase) PinMessage(ctx context.Context, userID, messageID int64) (*Pin, error) {
+	message, err := uc.getPinnableMessage(ctx, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	pins, err := uc.repo.ListPins(ctx, message.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	if pin := findPin(pins, messageID); pin != nil {
+		return pin, nil
+	}
+
+	added, err := uc.repo.PinMessage(ctx, message.RoomID, messageID, userID, uc.maxPinsPerRoom)
+	if errors.Is(err, ErrPinLimitReached) {
+		return nil, err
+	}
+	if err != nil {
+		uc.log.Errorf("Failed to pin message %d: %v", messageID, err)
+		return nil, err
+	}
+
+	pins, err = uc.repo.ListPins(ctx, message.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	pin := findPin(pins, messageID)
+	if pin == nil {
+		return nil, ErrMessageNotFound
+	}
+	// A concurrent request pinned it first and notified the room
+	if !added {
+		return pin, nil
+	}
+
+	data := messageEventData(pin.Message)
+	data["pinned_by"] = pin.PinnedBy
+	data["pinned_by_username"] = pin.PinnedByUsername
+	data["pinned_at"] = pin.PinnedAt.Unix()
+	uc.publishEvent(ctx, &RoomEvent{
+		Type:   EventMessagePinned,
+		RoomID: message.RoomID,
+		Data:   data,
+	})
+	uc.system.Post(ctx, message.RoomID, &SystemEvent{Kind: SystemMessagePinned, ActorID: userID, TargetID: messageID})
+
+	uc.log.Infof("Message pinned: id=%d, room=%d, by user=%d", messageID, message.RoomID, userID)
+	return pin, nil
+}
+
+// UnpinMessage removes a message's pin (room admins and moderators only)
+// and notifies the room. Unpinning a message that isn't pinned is a no-op.
+func (uc *ChatUseCase) UnpinMessage(ctx context.Context, userID, messageID int64) error {
+	message, err := uc.getPinnableMessage(ctx, userID, messageID)
+	if err != nil {
+		return err
+	}
+
+	removed, err := uc.repo.UnpinMessage(ctx, message.RoomID, messageID)
+	if err != nil {
+		uc.log.Errorf("Failed to unpin message %d: %v", messageID, err)
+		return err
+	}
+
+	if removed {
+		uc.publishEvent(ctx, &RoomEvent{
+			Type:   EventMessageUnpinned,
+			RoomID: message.RoomID,
+			Data: map[string]interface{}{
+				"message_id":  messageID,
+				"unpinned_by": userID,
+			},
+		})
+		uc.system.Post(ctx, message.RoomID, &SystemEvent{Kind: SystemMessageUnpinned, ActorID: userID, TargetID: messageID})
+		uc.log.Infof("Message unpinned: id=%d, room=%d, by user=%d", messageID, message.RoomID, userID)
+	}
+
+	return nil
+}
+
+// ListPinnedMessages lists the messages pinned in a room, most recently pinned first
+func (uc *ChatUseCase) ListPinnedMessages(ctx context.Context, userID, roomID int64) ([]*Pin, error) {
+	// Check if user has access to the room
+	isMember, err := uc.roomRepo.IsUserInRoom(ctx, roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, ErrRoomAccessDenied
+	}
+
+	pins, err := uc.repo.ListPins(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*Message, 0, len(pins))
+	for _, pin := range pins {
+		messages = append(messages, pin.Message)
+	}
+	if err := uc.attachReactions(ctx, userID, messages); err != nil {
+		return nil, err
+	}
+	if err := uc.attachLinkPreviews(ctx, messages); err != nil {
+		return nil, err
+	}
+	if err := uc.attachPolls(ctx, userID, messages); err != nil {
+		return nil, err
+	}
+
+	return pins, nil
+}
+
+// getPinnableMessage checks the message is live and the user moderates its room
+func (uc *ChatUseCase) getPinnableMessage(ctx context.Context, userID, messageID int64) (*Message, error) {
+	message, err := uc.repo.GetMessage(ctx, messageID)
+	if err != nil || message.IsDeleted {
+		return nil, ErrMessageNotFound
+	}
+
+	isModerator, err := uc.isRoomModerator(ctx, message.RoomID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isModerator {
+		return nil, ErrPinNotAllowed
+	}
+
+	return message, nil
+}
+
+// findPin returns the pin of the message, or nil if it isn't pinned
+func findPin(pins []*Pin, messageID int64) *Pin {
+	for _, pin := range pins {
+		if pin.Message.ID == messageID {
+			return pin
+		}
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package biz
+
+import (
+	"context"
+	"testing"
+)
+
+// ==================== PinMessage Tests ====================
+
+// setupPinRoom creates room 10 with moderator 100, member 200 and messages 1-3
+func setupPinRoom() (*MockChatRepo, *MockRoomRepo, *MockUserRepo) {
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	roomRepo.SetMemberRole(10, 100, "moderator")
+	roomRepo.AddMember(10, 200)
+	for id := int64(1); id <= 3; id++ {
+		chatRepo.AddMessage(&Message{ID: id, RoomID: 10, UserID: 200, Content: "hello", Type: "text"})
+	}
+
+	return chatRepo, roomRepo, userRepo
+}
+
+func TestPinMessage_Success(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupPinRoom()
+	publisher := &MockEventPublisher{}
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+
+	// Act
+	pin, err := uc.PinMessage(context.Background(), 100, 2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pin.Message.ID != 2 || pin.PinnedBy != 100 {
+		t.Errorf("expected message 2 pinned by 100, got message %d by %d", pin.Message.ID, pin.PinnedBy)
+	}
+	if len(publisher.events) != 1 || publisher.events[0].Type != EventMessagePinned {
+		t.Fatalf("expected one %s event, got %v", EventMessagePinned, publisher.events)
+	}
+	if publisher.events[0].Data["message_id"] != int64(2) {
+		t.Errorf("expected event for message 2, got %v", publisher.events[0].Data["message_id"])
+	}
+}
+
+func TestPinMessage_AlreadyPinned(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupPinRoom()
+	publisher := &MockEventPublisher{}
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+	_, _ = uc.PinMessage(context.Background(), 100, 2)
+
+	// Act
+	_, err := uc.PinMessage(context.Background(), 100, 2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(chatRepo.pins) != 1 {
+		t.Errorf("expected 1 pin, got %d", len(chatRepo.pins))
+	}
+	if len(publisher.events) != 1 {
+		t.Errorf("expected no event for a repeated pin, got %d events", len(publisher.events))
+	}
+}
+
+func TestPinMessage_NotModerator(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupPinRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, err := uc.PinMessage(context.Background(), 200, 2)
+
+	// Assert
+	if err != ErrPinNotAllowed {
+		t.Errorf("expected ErrPinNotAllowed, got %v", err)
+	}
+}
+
+func TestPinMessage_DeletedMessage(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupPinRoom()
+	chatRepo.messages[2].IsDeleted = true
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, err := uc.PinMessage(context.Background(), 100, 2)
+
+	// Assert
+	if err != ErrMessageNotFound {
+		t.Errorf("expected ErrMessageNotFound, got %v", err)
+	}
+}
+
+func TestPinMessage_LimitReached(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupPinRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+	uc.maxPinsPerRoom = 2
+	_, _ = uc.PinMessage(context.Background(), 100, 1)
+	_, _ = uc.PinMessage(context.Background(), 100, 2)
+
+	// Act
+	_, err := uc.PinMessage(context.Background(), 100, 3)
+
+	// Assert
+	if err != ErrPinLimitReached {
+		t.Errorf("expected ErrPinLimitReached, got %v", err)
+	}
+}
+
+// ==================== UnpinMessage Tests ====================
+
+func TestUnpinMessage_Success(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupPinRoom()
+	publisher := &MockEventPublisher{}
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+	_, _ = uc.PinMessage(context.Background(), 100, 2)
+
+	// Act
+	err := uc.UnpinMessage(context.Background(), 100, 2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(chatRepo.pins) != 0 {
+		t.Errorf("expected no pins, got %d", len(chatRepo.pins))
+	}
+	if len(publisher.events) != 2 || publisher.events[1].Type != EventMessageUnpinned {
+		t.Errorf("expected a %s event, got %v", EventMessageUnpinned, publisher.events)
+	}
+}
+
+func TestUnpinMessage_NotPinned(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupPinRoom()
+	publisher := &MockEventPublisher{}
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+
+	// Act
+	err := uc.UnpinMessage(context.Background(), 100, 2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(publisher.events) != 0 {
+		t.Errorf("expected no events, got %d", len(publisher.events))
+	}
+}
+
+// ==================== ListPinnedMessages Tests ====================
+
+func TestListPinnedMessages_Success(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupPinRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+	_, _ = uc.PinMessage(context.Background(), 100, 1)
+	_, _ = uc.PinMessage(context.Background(), 100, 3)
+
+	// Act
+	pins, err := uc.ListPinnedMessages(context.Background(), 200, 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(pins) != 2 || pins[0].Message.ID != 3 || pins[1].Message.ID != 1 {
+		t.Errorf("expected pins [3 1], got %v", pins)
+	}
+}
+
+func TestListPinnedMessages_AccessDenied(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupPinRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, err := uc.ListPinnedMessages(context.Background(), 999, 10)
+
+	// Assert
+	if err != ErrRoomAccessDenied {
+		t.Errorf("expected ErrRoomAccessDenied, got %v", err)
+	}
+}
+
+func TestPinMessage_LimitCountsOnlyRoomPins(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupPinRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+	uc.maxPinsPerRoom = 1
+	chatRepo.nextID = 100 // system messages don't replace the fixtures
+	roomRepo.AddRoom(&Room{ID: 11})
+	roomRepo.SetMemberRole(11, 100, "moderator")
+	chatRepo.AddMessage(&Message{ID: 4, RoomID: 11, UserID: 100, Content: "elsewhere", Type: "text"})
+	_, _ = uc.PinMessage(context.Background(), 100, 4)
+
+	// Act
+	pin, err := uc.PinMessage(context.Background(), 100, 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pin.Message.ID != 1 {
+		t.Errorf("expected message 1 pinned, got %d", pin.Message.ID)
+	}
+}
@@ -0,0 +1,344 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+)
+
+var (
+	ErrNotAPoll        = errors.New("message is not a poll")
+	ErrPollClosed      = errors.New("poll is closed")
+	ErrInvalidPoll     = errors.New("a poll needs a question and 2 to 10 distinct options")
+	ErrInvalidVote     = errors.New("invalid poll option")
+	ErrInvalidPollTime = errors.New("poll close time must be in the future and within a year")
+	ErrPollCloseDenied = errors.New("only the poll author, room admins and moderators can close a poll")
+)
+
+// Poll limits
+const (
+	minPollOptions        = 2
+	maxPollOptions        = 10
+	maxPollQuestionLength = 300
+	maxPollOptionLength   = 100
+	maxPollDuration       = 365 * 24 * time.Hour
+)
+
+// Poll is the question and tally of a poll message
+type Poll struct {
+	MessageID      int64
+	Question       string
+	Options        []*PollOption
+	MultipleChoice bool
+	Anonymous      bool
+	ClosesAt       *time.Time // closes automatically at this time (nil = when closed by hand)
+	ClosedAt       *time.Time
+	VoterCount     int32
+}
+
+// PollOption is one answer of a poll with its votes
+type PollOption struct {
+	ID        int32
+	Text      string
+	VoteCount int32
+	VotedByMe bool
+	VoterIDs  []int64 // hidden for anonymous polls
+}
+
+// IsClosed reports whether the poll no longer accepts votes
+func (p *Poll) IsClosed(now time.Time) bool {
+	return p.ClosedAt != nil || (p.ClosesAt != nil && !now.Before(*p.ClosesAt))
+}
+
+// VotePoll replaces the user's votes in a poll and notifies the room.
+// An empty optionIDs retracts the user's votes.
+func (uc *ChatUseCase) VotePoll(ctx context.Context, userID, messageID int64, optionIDs []int32) (*Poll, error) {
+	message, poll, err := uc.getPoll(ctx, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if poll.IsClosed(time.Now()) {
+		return nil, ErrPollClosed
+	}
+	if err := validateVote(poll, optionIDs); err != nil {
+		return nil, err
+	}
+
+	// The repo re-checks the poll under lock, in case it closed meanwhile
+	applied, err := uc.repo.SetPollVotes(ctx, messageID, userID, optionIDs)
+	if err != nil {
+		uc.log.Errorf("Failed to vote in poll %d: %v", messageID, err)
+		return nil, err
+	}
+	if !applied {
+		return nil, ErrPollClosed
+	}
+
+	uc.publishPollUpdated(ctx, message)
+
+	uc.log.Infof("Poll vote: message=%d, user=%d, options=%v", messageID, userID, optionIDs)
+	return uc.loadPoll(ctx, messageID, userID)
+}
+
+// ClosePoll closes a poll to further votes (by the poll author or a room
+// admin/moderator) and notifies the room. Closing a closed poll is a no-op.
+func (uc *ChatUseCase) ClosePoll(ctx context.Context, userID, messageID int64) (*Poll, error) {
+	message, poll, err := uc.getPoll(ctx, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if message.UserID != userID {
+		isModerator, err := uc.isRoomModerator(ctx, message.RoomID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !isModerator {
+			return nil, ErrPollCloseDenied
+		}
+	}
+
+	if poll.ClosedAt != nil {
+		return poll, nil
+	}
+
+	if _, err := uc.repo.ClosePoll(ctx, messageID, time.Now()); err != nil {
+		uc.log.Errorf("Failed to close poll %d: %v", messageID, err)
+		return nil, err
+	}
+
+	uc.publishPollUpdated(ctx, message)
+
+	uc.log.Infof("Poll closed: message=%d, room=%d, by user=%d", messageID, message.RoomID, userID)
+	return uc.loadPoll(ctx, messageID, userID)
+}
+
+// CloseDuePolls closes up to limit polls whose close time has passed and
+// notifies their rooms. It reports how many polls were closed.
+func (uc *ChatUseCase) CloseDuePolls(ctx context.Context, limit int32) (int, error) {
+	messageIDs, err := uc.repo.CloseDuePolls(ctx, time.Now(), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, messageID := range messageIDs {
+		message, err := uc.repo.GetMessage(ctx, messageID)
+		if err != nil || message.IsDeleted {
+			continue
+		}
+		uc.publishPollUpdated(ctx, message)
+	}
+
+	return len(messageIDs), nil
+}
+
+// getPoll loads a live poll message and its poll if the user can read the room
+func (uc *ChatUseCase) getPoll(ctx context.Context, userID, messageID int64) (*Message, *Poll, error) {
+	message, err := uc.repo.GetMessage(ctx, messageID)
+	if err != nil || message.IsDeleted {
+		return nil, nil, ErrMessageNotFound
+	}
+
+	isMember, err := uc.roomRepo.IsUserInRoom(ctx, message.RoomID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isMember {
+		return nil, nil, ErrRoomAccessDenied
+	}
+
+	if message.Type != "poll" {
+		return nil, nil, ErrNotAPoll
+	}
+
+	poll, err := uc.loadPoll(ctx, messageID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return message, poll, nil
+}
+
+// loadPoll loads one poll with its tally relative to the user
+func (uc *ChatUseCase) loadPoll(ctx context.Context, messageID, userID int64) (*Poll, error) {
+	polls, err := uc.repo.ListPolls(ctx, []int64{messageID}, userID)
+	if err != nil {
+		return nil, err
+	}
+	poll := polls[messageID]
+	if poll == nil {
+		return nil, ErrNotAPoll
+	}
+	hidePollVoters(poll)
+	return poll, nil
+}
+
+// publishPollUpdated sends the poll's current tally to its room.
+// The event goes to everyone, so it carries no voted_by_me.
+func (uc *ChatUseCase) publishPollUpdated(ctx context.Context, message *Message) {
+	poll, err := uc.loadPoll(ctx, message.ID, 0)
+	if err != nil {
+		uc.log.Warnf("Failed to load poll %d for its update event: %v", message.ID, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"message_id": message.ID,
+		"poll":       pollEventData(poll),
+	}
+	if message.ParentMessageID != 0 {
+		data["parent_message_id"] = message.ParentMessageID
+	}
+	uc.publishEvent(ctx, &RoomEvent{
+		Type:   EventPollUpdated,
+		RoomID: message.RoomID,
+		Data:   data,
+	})
+}
+
+// attachPolls fills in Poll on each poll message in one repo call
+func (uc *ChatUseCase) attachPolls(ctx context.Context, userID int64, messages []*Message) error {
+	var ids []int64
+	for _, message := range messages {
+		if message.Type == "poll" && !message.IsDeleted {
+			ids = append(ids, message.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	polls, err := uc.repo.ListPolls(ctx, ids, userID)
+	if err != nil {
+		uc.log.Errorf("Failed to load polls: %v", err)
+		return err
+	}
+
+	// Deleted polls weren't loaded, so they lose any poll they carried
+	for _, message := range messages {
+		if message.Type != "poll" {
+			continue
+		}
+		message.Poll = polls[message.ID]
+		if message.Poll != nil {
+			hidePollVoters(message.Poll)
+		}
+	}
+	return nil
+}
+
+// newPoll builds the poll of a new poll message from the request.
+// Options are numbered from 1 in the order given.
+func newPoll(req *chatV1.PollRequest) *Poll {
+	poll := &Poll{
+		Question:       strings.TrimSpace(req.Question),
+		MultipleChoice: req.MultipleChoice,
+		Anonymous:      req.Anonymous,
+	}
+	for i, text := range req.Options {
+		poll.Options = append(poll.Options, &PollOption{ID: int32(i + 1), Text: strings.TrimSpace(text)})
+	}
+	if req.ClosesAt != 0 {
+		closesAt := time.Unix(req.ClosesAt, 0)
+		poll.ClosesAt = &closesAt
+	}
+	return poll
+}
+
+// validatePollRequest checks the question, options and close time of a new poll
+func validatePollRequest(req *chatV1.PollRequest, now time.Time) error {
+	if req == nil {
+		return ErrInvalidPoll
+	}
+
+	question := strings.TrimSpace(req.Question)
+	if question == "" || len(question) > maxPollQuestionLength {
+		return ErrInvalidPoll
+	}
+	if len(req.Options) < minPollOptions || len(req.Options) > maxPollOptions {
+		return ErrInvalidPoll
+	}
+
+	seen := make(map[string]bool, len(req.Options))
+	for _, option := range req.Options {
+		text := strings.TrimSpace(option)
+		key := strings.ToLower(text)
+		if text == "" || len(text) > maxPollOptionLength || seen[key] {
+			return ErrInvalidPoll
+		}
+		seen[key] = true
+	}
+
+	if req.ClosesAt != 0 {
+		closesAt := time.Unix(req.ClosesAt, 0)
+		if !closesAt.After(now) || closesAt.After(now.Add(maxPollDuration)) {
+			return ErrInvalidPollTime
+		}
+	}
+	return nil
+}
+
+// validateVote checks that the options belong to the poll, are distinct,
+// and that a single-choice poll gets at most one
+func validateVote(poll *Poll, optionIDs []int32) error {
+	if !poll.MultipleChoice && len(optionIDs) > 1 {
+		return ErrInvalidVote
+	}
+
+	valid := make(map[int32]bool, len(poll.Options))
+	for _, option := range poll.Options {
+		valid[option.ID] = true
+	}
+	seen := make(map[int32]bool, len(optionIDs))
+	for _, id := range optionIDs {
+		if !valid[id] || seen[id] {
+			return ErrInvalidVote
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// hidePollVoters drops voter IDs from anonymous polls
+func hidePollVoters(poll *Poll) {
+	if !poll.Anonymous {
+		return
+	}
+	for _, option := range poll.Options {
+		option.VoterIDs = nil
+	}
+}
+
+// pollEventData flattens a poll into event data
+func pollEventData(poll *Poll) map[string]interface{} {
+	options := make([]map[string]interface{}, 0, len(poll.Options))
+	for _, option := range poll.Options {
+		data := map[string]interface{}{
+			"id":         option.ID,
+			"text":       option.Text,
+			"vote_count": option.VoteCount,
+		}
+		if !poll.Anonymous {
+			data["voter_ids"] = option.VoterIDs
+		}
+		options = append(options, data)
+	}
+
+	data := map[string]interface{}{
+		"question":        poll.Question,
+		"options":         options,
+		"multiple_choice": poll.MultipleChoice,
+		"anonymous":       poll.Anonymous,
+		"is_closed":       poll.IsClosed(time.Now()),
+		"voter_count":     poll.VoterCount,
+	}
+	if poll.ClosesAt != nil {
+		data["closes_at"] = poll.ClosesAt.Unix()
+	}
+	if poll.ClosedAt != nil {
+		data["closed_at"] = poll.ClosedAt.Unix()
+	}
+	return data
+}
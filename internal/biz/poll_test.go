@@ -0,0 +1,351 @@
+package biz
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+)
+
+// setupPollRoom creates room 10 with author 100, member 200 and moderator 300,
+// and a poll by user 100 asking where to eat
+func setupPollRoom(multipleChoice, anonymous bool) (*ChatUseCase, *MockChatRepo, *MockEventPublisher, *Message) {
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	roomRepo.AddMember(10, 200)
+	roomRepo.SetMemberRole(10, 300, "moderator")
+
+	publisher := &MockEventPublisher{}
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+	message, _ := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{
+		RoomId: 10,
+		Type:   "poll",
+		Poll: &chatV1.PollRequest{
+			Question:       "Lunch?",
+			Options:        []string{"Pizza", "Sushi", "Tacos"},
+			MultipleChoice: multipleChoice,
+			Anonymous:      anonymous,
+		},
+	})
+
+	return uc, chatRepo, publisher, message
+}
+
+// ==================== SendMessage Poll Tests ====================
+
+func TestSendMessage_Poll(t *testing.T) {
+	// Arrange & Act
+	_, _, _, message := setupPollRoom(false, false)
+
+	// Assert
+	if message == nil || message.Type != "poll" {
+		t.Fatalf("expected a poll message, got %+v", message)
+	}
+	if message.Content != "Lunch?" {
+		t.Errorf("expected the question as content, got %q", message.Content)
+	}
+	if len(message.Poll.Options) != 3 || message.Poll.Options[0].ID != 1 || message.Poll.Options[2].Text != "Tacos" {
+		t.Errorf("expected options numbered from 1, got %+v", message.Poll.Options)
+	}
+}
+
+func TestSendMessage_PollTooFewOptions(t *testing.T) {
+	// Arrange
+	uc, _, _, _ := setupPollRoom(false, false)
+
+	// Act
+	_, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{
+		RoomId: 10,
+		Type:   "poll",
+		Poll:   &chatV1.PollRequest{Question: "Yes?", Options: []string{"Yes"}},
+	})
+
+	// Assert
+	if err != ErrInvalidPoll {
+		t.Errorf("expected ErrInvalidPoll, got %v", err)
+	}
+}
+
+func TestSendMessage_PollDuplicateOptions(t *testing.T) {
+	// Arrange
+	uc, _, _, _ := setupPollRoom(false, false)
+
+	// Act
+	_, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{
+		RoomId: 10,
+		Type:   "poll",
+		Poll:   &chatV1.PollRequest{Question: "Tea?", Options: []string{"Yes", " yes "}},
+	})
+
+	// Assert
+	if err != ErrInvalidPoll {
+		t.Errorf("expected ErrInvalidPoll, got %v", err)
+	}
+}
+
+func TestSendMessage_PollCloseTimeInPast(t *testing.T) {
+	// Arrange
+	uc, _, _, _ := setupPollRoom(false, false)
+
+	// Act
+	_, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{
+		RoomId: 10,
+		Type:   "poll",
+		Poll: &chatV1.PollRequest{
+			Question: "Tea?",
+			Options:  []string{"Yes", "No"},
+			ClosesAt: time.Now().Add(-time.Minute).Unix(),
+		},
+	})
+
+	// Assert
+	if err != ErrInvalidPollTime {
+		t.Errorf("expected ErrInvalidPollTime, got %v", err)
+	}
+}
+
+// ==================== VotePoll Tests ====================
+
+func TestVotePoll_Success(t *testing.T) {
+	// Arrange
+	uc, _, publisher, message := setupPollRoom(false, false)
+
+	// Act
+	poll, err := uc.VotePoll(context.Background(), 200, message.ID, []int32{2})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	sushi := poll.Options[1]
+	if sushi.VoteCount != 1 || !sushi.VotedByMe || len(sushi.VoterIDs) != 1 || sushi.VoterIDs[0] != 200 {
+		t.Errorf("expected one vote for Sushi by 200, got %+v", sushi)
+	}
+	if poll.VoterCount != 1 {
+		t.Errorf("expected 1 voter, got %d", poll.VoterCount)
+	}
+	if len(publisher.events) != 1 || publisher.events[0].Type != EventPollUpdated {
+		t.Fatalf("expected one %s event, got %+v", EventPollUpdated, publisher.events)
+	}
+	if publisher.events[0].Data["message_id"] != message.ID {
+		t.Errorf("expected event for message %d, got %v", message.ID, publisher.events[0].Data["message_id"])
+	}
+}
+
+func TestVotePoll_ChangesVote(t *testing.T) {
+	// Arrange
+	uc, _, _, message := setupPollRoom(false, false)
+	_, _ = uc.VotePoll(context.Background(), 200, message.ID, []int32{1})
+
+	// Act
+	poll, err := uc.VotePoll(context.Background(), 200, message.ID, []int32{3})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if poll.Options[0].VoteCount != 0 || poll.Options[2].VoteCount != 1 {
+		t.Errorf("expected the vote moved from Pizza to Tacos, got %+v %+v", poll.Options[0], poll.Options[2])
+	}
+}
+
+func TestVotePoll_SingleChoiceRejectsMultiple(t *testing.T) {
+	// Arrange
+	uc, _, _, message := setupPollRoom(false, false)
+
+	// Act
+	_, err := uc.VotePoll(context.Background(), 200, message.ID, []int32{1, 2})
+
+	// Assert
+	if err != ErrInvalidVote {
+		t.Errorf("expected ErrInvalidVote, got %v", err)
+	}
+}
+
+func TestVotePoll_MultipleChoice(t *testing.T) {
+	// Arrange
+	uc, _, _, message := setupPollRoom(true, false)
+
+	// Act
+	poll, err := uc.VotePoll(context.Background(), 200, message.ID, []int32{1, 3})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if poll.Options[0].VoteCount != 1 || poll.Options[2].VoteCount != 1 || poll.VoterCount != 1 {
+		t.Errorf("expected one voter on Pizza and Tacos, got %+v", poll)
+	}
+}
+
+func TestVotePoll_UnknownOption(t *testing.T) {
+	// Arrange
+	uc, _, _, message := setupPollRoom(true, false)
+
+	// Act
+	_, err := uc.VotePoll(context.Background(), 200, message.ID, []int32{4})
+
+	// Assert
+	if err != ErrInvalidVote {
+		t.Errorf("expected ErrInvalidVote, got %v", err)
+	}
+}
+
+func TestVotePoll_AnonymousHidesVoters(t *testing.T) {
+	// Arrange
+	uc, _, publisher, message := setupPollRoom(false, true)
+
+	// Act
+	poll, err := uc.VotePoll(context.Background(), 200, message.ID, []int32{1})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if poll.Options[0].VoteCount != 1 || !poll.Options[0].VotedByMe || poll.Options[0].VoterIDs != nil {
+		t.Errorf("expected a counted vote without voter IDs, got %+v", poll.Options[0])
+	}
+	options := publisher.events[0].Data["poll"].(map[string]interface{})["options"].([]map[string]interface{})
+	if _, ok := options[0]["voter_ids"]; ok {
+		t.Errorf("expected no voter_ids in the event, got %v", options[0])
+	}
+}
+
+func TestVotePoll_NotMember(t *testing.T) {
+	// Arrange
+	uc, _, _, message := setupPollRoom(false, false)
+
+	// Act
+	_, err := uc.VotePoll(context.Background(), 999, message.ID, []int32{1})
+
+	// Assert
+	if err != ErrRoomAccessDenied {
+		t.Errorf("expected ErrRoomAccessDenied, got %v", err)
+	}
+}
+
+func TestVotePoll_NotAPoll(t *testing.T) {
+	// Arrange
+	uc, _, _, _ := setupPollRoom(false, false)
+	text, _ := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "hi"})
+
+	// Act
+	_, err := uc.VotePoll(context.Background(), 200, text.ID, []int32{1})
+
+	// Assert
+	if err != ErrNotAPoll {
+		t.Errorf("expected ErrNotAPoll, got %v", err)
+	}
+}
+
+func TestVotePoll_Closed(t *testing.T) {
+	// Arrange
+	uc, _, _, message := setupPollRoom(false, false)
+	_, _ = uc.ClosePoll(context.Background(), 100, message.ID)
+
+	// Act
+	_, err := uc.VotePoll(context.Background(), 200, message.ID, []int32{1})
+
+	// Assert
+	if err != ErrPollClosed {
+		t.Errorf("expected ErrPollClosed, got %v", err)
+	}
+}
+
+// ==================== ClosePoll Tests ====================
+
+func TestClosePoll_ByAuthor(t *testing.T) {
+	// Arrange
+	uc, _, publisher, message := setupPollRoom(false, false)
+
+	// Act
+	poll, err := uc.ClosePoll(context.Background(), 100, message.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if poll.ClosedAt == nil || !poll.IsClosed(time.Now()) {
+		t.Error("expected the poll closed")
+	}
+	if len(publisher.events) != 1 || publisher.events[0].Data["poll"].(map[string]interface{})["is_closed"] != true {
+		t.Errorf("expected a poll_updated event for the closed poll, got %+v", publisher.events)
+	}
+}
+
+func TestClosePoll_ByModerator(t *testing.T) {
+	// Arrange
+	uc, _, _, message := setupPollRoom(false, false)
+
+	// Act
+	_, err := uc.ClosePoll(context.Background(), 300, message.ID)
+
+	// Assert
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestClosePoll_Denied(t *testing.T) {
+	// Arrange
+	uc, _, _, message := setupPollRoom(false, false)
+
+	// Act
+	_, err := uc.ClosePoll(context.Background(), 200, message.ID)
+
+	// Assert
+	if err != ErrPollCloseDenied {
+		t.Errorf("expected ErrPollCloseDenied, got %v", err)
+	}
+}
+
+func TestCloseDuePolls(t *testing.T) {
+	// Arrange
+	uc, chatRepo, publisher, message := setupPollRoom(false, false)
+	closesAt := time.Now().Add(-time.Second)
+	chatRepo.polls[message.ID].ClosesAt = &closesAt
+
+	// Act
+	closed, err := uc.CloseDuePolls(context.Background(), 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if closed != 1 || len(publisher.events) != 1 || publisher.events[0].Type != EventPollUpdated {
+		t.Errorf("expected 1 poll closed and announced, got %d closed, events %+v", closed, publisher.events)
+	}
+}
+
+// ==================== Listing Tests ====================
+
+func TestListMessages_AttachesPollTally(t *testing.T) {
+	// Arrange
+	uc, _, _, message := setupPollRoom(false, false)
+	_, _ = uc.VotePoll(context.Background(), 200, message.ID, []int32{2})
+
+	// Act
+	page, err := uc.ListMessages(context.Background(), 100, 10, 20, MessageCursor{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	poll := page.Messages[0].Poll
+	if poll == nil || poll.Options[1].VoteCount != 1 || poll.Options[1].VotedByMe {
+		t.Errorf("expected the tally relative to user 100, got %+v", poll)
+	}
+}
+
+func TestEditMessage_PollRejected(t *testing.T) {
+	// Arrange
+	uc, _, _, message := setupPollRoom(false, false)
+
+	// Act
+	_, err := uc.EditMessage(context.Background(), 100, message.ID, "Dinner?")
+
+	// Assert
+	if err == nil {
+		t.Error("expected editing a poll to fail")
+	}
+}
@@ -0,0 +1,282 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var (
+	ErrTooManyPresenceUsers = errors.New("presence can be requested for at most 100 users at a time")
+)
+
+// Presence statuses, also stored in users.status
+const (
+	PresenceOnline  = "online"
+	PresenceAway    = "away"
+	PresenceOffline = "offline"
+)
+
+const (
+	// PresenceHeartbeatInterval is how often each connection refreshes its presence
+	PresenceHeartbeatInterval = 30 * time.Second
+	// presenceTTL is how long a connection counts without a heartbeat,
+	// so a crashed instance's connections go offline on their own
+	presenceTTL = 3 * PresenceHeartbeatInterval
+	// presenceIdleAfter is how long a user has to be idle on every
+	// connection before turning away
+	presenceIdleAfter = 5 * time.Minute
+	// maxPresenceUsers bounds a GetPresence request
+	maxPresenceUsers = 100
+)
+
+// PresenceConnection is one WebSocket connection of a user, on any instance
+type PresenceConnection struct {
+	UserID    int64
+	ConnID    string
+	ActiveAt  time.Time // last activity the client reported
+	ExpiresAt time.Time // heartbeat deadline
+}
+
+// Presence is a user's status across all of their connections
+type Presence struct {
+	UserID      int64
+	Status      string
+	LastSeen    time.Time
+	Connections int
+}
+
+// PresenceRepo stores live connections across all chat service instances
+type PresenceRepo interface {
+	// FilterOnline returns the users that have at least one live connection
+	FilterOnline(ctx context.Context, userIDs []int64) ([]int64, error)
+	SaveConnection(ctx context.Context, conn *PresenceConnection) error
+	// RemoveConnection reports false if the connection was already removed
+	RemoveConnection(ctx context.Context, userID int64, connID string) (bool, error)
+	GetConnections(ctx context.Context, userIDs []int64) (map[int64][]*PresenceConnection, error)
+	ListExpiredConnections(ctx context.Context, now time.Time, limit int) ([]*PresenceConnection, error)
+	// SwapStatus stores the status last announced for the user and returns
+	// the previous one ("" if none)
+	SwapStatus(ctx context.Context, userID int64, status string) (string, error)
+}
+
+// PresenceUseCase derives users' presence from their WebSocket connections
+type PresenceUseCase struct {
+	repo      PresenceRepo
+	roomRepo  RoomRepo
+	userRepo  UserRepo
+	publisher EventPublisher
+	log       *log.Helper
+}
+
+// NewPresenceUseCase creates a new presence use case
+func NewPresenceUseCase(repo PresenceRepo, roomRepo RoomRepo, userRepo UserRepo, publisher EventPublisher, logger log.Logger) *PresenceUseCase {
+	return &PresenceUseCase{
+		repo:      repo,
+		roomRepo:  roomRepo,
+		userRepo:  userRepo,
+		publisher: publisher,
+		log:       log.NewHelper(log.With(logger, "module", "biz/presence")),
+	}
+}
+
+// Connect registers a new authenticated connection of the user
+func (uc *PresenceUseCase) Connect(ctx context.Context, userID int64, connID string) error {
+	now := time.Now()
+	return uc.touch(ctx, userID, connID, now, now)
+}
+
+// Heartbeat keeps a connection alive; activeAt is the client's last activity
+func (uc *PresenceUseCase) Heartbeat(ctx context.Context, userID int64, connID string, activeAt time.Time) error {
+	return uc.touch(ctx, userID, connID, activeAt, time.Now())
+}
+
+// Disconnect removes a closed connection and records the user as last seen now
+func (uc *PresenceUseCase) Disconnect(ctx context.Context, userID int64, connID string) error {
+	removed, err := uc.repo.RemoveConnection(ctx, userID, connID)
+	if err != nil || !removed {
+		return err
+	}
+
+	now := time.Now()
+	uc.recordLastSeen(ctx, userID, now)
+	return uc.refresh(ctx, userID, now)
+}
+
+// ExpireConnections removes up to limit connections that missed their
+// heartbeats, which happens when an instance dies without closing them.
+// It reports how many connections were removed.
+func (uc *PresenceUseCase) ExpireConnections(ctx context.Context, limit int) (int, error) {
+	now := time.Now()
+	expired, err := uc.repo.ListExpiredConnections(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, conn := range expired {
+		// Another instance may be expiring the same connection
+		removed, err := uc.repo.RemoveConnection(ctx, conn.UserID, conn.ConnID)
+		if err != nil {
+			return count, err
+		}
+		if !removed {
+			continue
+		}
+		count++
+
+		// The user was last seen at the connection's last heartbeat
+		lastSeen := conn.ExpiresAt.Add(-presenceTTL)
+		uc.recordLastSeen(ctx, conn.UserID, lastSeen)
+		if err := uc.refresh(ctx, conn.UserID, lastSeen); err != nil {
+			uc.log.Warnf("Failed to refresh presence of user %d: %v", conn.UserID, err)
+		}
+	}
+	return count, nil
+}
+
+// GetPresence returns the presence of each user in userIDs that the caller
+// can see: themselves and the users sharing a room with them, the same users
+// who receive their presence_changed events. Others are left out.
+func (uc *PresenceUseCase) GetPresence(ctx context.Context, callerID int64, userIDs []int64) ([]*Presence, error) {
+	if len(userIDs) > maxPresenceUsers {
+		return nil, ErrTooManyPresenceUsers
+	}
+
+	roommates, err := uc.roomRepo.ListRoommateIDs(ctx, callerID)
+	if err != nil {
+		return nil, err
+	}
+	visible := make(map[int64]bool, len(roommates)+1)
+	visible[callerID] = true
+	for _, userID := range roommates {
+		visible[userID] = true
+	}
+
+	seen := make(map[int64]bool, len(userIDs))
+	unique := make([]int64, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if visible[userID] && !seen[userID] {
+			seen[userID] = true
+			unique = append(unique, userID)
+		}
+	}
+
+	connections, err := uc.repo.GetConnections(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	presences := make([]*Presence, 0, len(unique))
+	for _, userID := range unique {
+		user, err := uc.userRepo.GetUserByID(ctx, userID)
+		if err != nil || user == nil {
+			continue // Skip users not found
+		}
+
+		presence := &Presence{
+			UserID:   userID,
+			Status:   presenceStatus(connections[userID], now),
+			LastSeen: user.LastSeen,
+		}
+		for _, conn := range connections[userID] {
+			if conn.ExpiresAt.After(now) {
+				presence.Connections++
+			}
+		}
+		if presence.Status != PresenceOffline {
+			presence.LastSeen = now
+		}
+		presences = append(presences, presence)
+	}
+	return presences, nil
+}
+
+// touch saves a live connection and announces any resulting status change
+func (uc *PresenceUseCase) touch(ctx context.Context, userID int64, connID string, activeAt, now time.Time) error {
+	err := uc.repo.SaveConnection(ctx, &PresenceConnection{
+		UserID:    userID,
+		ConnID:    connID,
+		ActiveAt:  activeAt,
+		ExpiresAt: now.Add(presenceTTL),
+	})
+	if err != nil {
+		return err
+	}
+	return uc.refresh(ctx, userID, now)
+}
+
+// refresh recomputes the user's status from their connections. When it
+// differs from the last announced one, it is stored on the user and sent,
+// with seenAt as last seen, to everyone sharing a room with them.
+func (uc *PresenceUseCase) refresh(ctx context.Context, userID int64, seenAt time.Time) error {
+	connections, err := uc.repo.GetConnections(ctx, []int64{userID})
+	if err != nil {
+		return err
+	}
+	status := presenceStatus(connections[userID], time.Now())
+
+	previous, err := uc.repo.SwapStatus(ctx, userID, status)
+	if err != nil {
+		return err
+	}
+	if previous == "" {
+		previous = PresenceOffline
+	}
+	if previous == status {
+		return nil
+	}
+
+	uc.log.Infof("Presence changed: user=%d, %s -> %s", userID, previous, status)
+	if err := uc.userRepo.UpdateUserStatus(ctx, userID, status); err != nil {
+		uc.log.Warnf("Failed to update status of user %d: %v", userID, err)
+	}
+
+	roommates, err := uc.roomRepo.ListRoommateIDs(ctx, userID)
+	if err != nil {
+		uc.log.Warnf("Failed to list roommates of user %d: %v", userID, err)
+		return nil
+	}
+	if len(roommates) == 0 {
+		return nil
+	}
+	err = uc.publisher.PublishRoomEvent(ctx, &RoomEvent{
+		Type: EventPresenceChanged,
+		Data: map[string]interface{}{
+			"user_id":   userID,
+			"status":    status,
+			"last_seen": seenAt.Unix(),
+		},
+		UserIDs: roommates,
+	})
+	if err != nil {
+		uc.log.Warnf("Failed to publish presence of user %d: %v", userID, err)
+	}
+	return nil
+}
+
+// recordLastSeen stores when the user was last connected
+func (uc *PresenceUseCase) recordLastSeen(ctx context.Context, userID int64, at time.Time) {
+	if err := uc.userRepo.UpdateLastSeen(ctx, userID, at); err != nil {
+		uc.log.Warnf("Failed to update last seen of user %d: %v", userID, err)
+	}
+}
+
+// presenceStatus is online if any live connection was recently active,
+// away if all live connections are idle, and offline without any
+func presenceStatus(connections []*PresenceConnection, now time.Time) string {
+	status := PresenceOffline
+	for _, conn := range connections {
+		if !conn.ExpiresAt.After(now) {
+			continue
+		}
+		if now.Sub(conn.ActiveAt) < presenceIdleAfter {
+			return PresenceOnline
+		}
+		status = PresenceAway
+	}
+	return status
+}
@@ -0,0 +1,284 @@
+package biz
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// setupPresence creates room 10 with members 100 (alice) and 200 (bob),
+// and user 300 who shares no room with them
+func setupPresence() (*PresenceUseCase, *MockPresenceRepo, *MockRoomRepo, *MockUserRepo, *MockEventPublisher) {
+	presenceRepo := NewMockPresenceRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddMember(10, 100)
+	roomRepo.AddMember(10, 200)
+	userRepo.usersById[100] = &User{ID: 100, Username: "alice", Status: PresenceOffline}
+	userRepo.usersById[200] = &User{ID: 200, Username: "bob", Status: PresenceOffline}
+	userRepo.usersById[300] = &User{ID: 300, Username: "carol", Status: PresenceOffline}
+
+	publisher := &MockEventPublisher{}
+	uc := NewPresenceUseCase(presenceRepo, roomRepo, userRepo, publisher, log.NewStdLogger(io.Discard))
+	return uc, presenceRepo, roomRepo, userRepo, publisher
+}
+
+// ==================== Connect / Disconnect Tests ====================
+
+func TestPresenceConnect_GoesOnline(t *testing.T) {
+	// Arrange
+	uc, _, _, userRepo, publisher := setupPresence()
+
+	// Act
+	err := uc.Connect(context.Background(), 100, "a")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if userRepo.usersById[100].Status != PresenceOnline {
+		t.Errorf("expected users.status online, got %s", userRepo.usersById[100].Status)
+	}
+	if len(publisher.events) != 1 || publisher.events[0].Type != EventPresenceChanged {
+		t.Fatalf("expected one %s event, got %+v", EventPresenceChanged, publisher.events)
+	}
+	event := publisher.events[0]
+	if event.Data["user_id"] != int64(100) || event.Data["status"] != PresenceOnline {
+		t.Errorf("expected alice online, got %v", event.Data)
+	}
+	if len(event.UserIDs) != 1 || event.UserIDs[0] != 200 {
+		t.Errorf("expected only bob notified, got %v", event.UserIDs)
+	}
+}
+
+func TestPresenceConnect_SecondDeviceIsQuiet(t *testing.T) {
+	// Arrange
+	uc, _, _, _, publisher := setupPresence()
+	_ = uc.Connect(context.Background(), 100, "a")
+
+	// Act
+	err := uc.Connect(context.Background(), 100, "b")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(publisher.events) != 1 {
+		t.Errorf("expected no event for a second device, got %d events", len(publisher.events))
+	}
+}
+
+func TestPresenceDisconnect_OtherDeviceKeepsOnline(t *testing.T) {
+	// Arrange
+	uc, _, _, userRepo, publisher := setupPresence()
+	_ = uc.Connect(context.Background(), 100, "a")
+	_ = uc.Connect(context.Background(), 100, "b")
+
+	// Act
+	err := uc.Disconnect(context.Background(), 100, "a")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(publisher.events) != 1 {
+		t.Errorf("expected alice to stay online, got %d events", len(publisher.events))
+	}
+	if userRepo.usersById[100].LastSeen.IsZero() {
+		t.Error("expected last_seen written on disconnect")
+	}
+}
+
+func TestPresenceDisconnect_LastDeviceGoesOffline(t *testing.T) {
+	// Arrange
+	uc, _, _, userRepo, publisher := setupPresence()
+	_ = uc.Connect(context.Background(), 100, "a")
+	before := time.Now().Add(-time.Second)
+
+	// Act
+	err := uc.Disconnect(context.Background(), 100, "a")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	user := userRepo.usersById[100]
+	if user.Status != PresenceOffline || user.LastSeen.Before(before) {
+		t.Errorf("expected alice offline and last seen now, got %s at %v", user.Status, user.LastSeen)
+	}
+	if len(publisher.events) != 2 || publisher.events[1].Data["status"] != PresenceOffline {
+		t.Errorf("expected an offline event, got %+v", publisher.events)
+	}
+}
+
+func TestPresenceDisconnect_AlreadyRemoved(t *testing.T) {
+	// Arrange
+	uc, _, _, userRepo, publisher := setupPresence()
+
+	// Act
+	err := uc.Disconnect(context.Background(), 100, "gone")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !userRepo.usersById[100].LastSeen.IsZero() || len(publisher.events) != 0 {
+		t.Error("expected nothing recorded for a connection that was already removed")
+	}
+}
+
+// ==================== Heartbeat Tests ====================
+
+func TestPresenceHeartbeat_IdleGoesAway(t *testing.T) {
+	// Arrange
+	uc, _, _, userRepo, publisher := setupPresence()
+	_ = uc.Connect(context.Background(), 100, "a")
+
+	// Act
+	err := uc.Heartbeat(context.Background(), 100, "a", time.Now().Add(-presenceIdleAfter-time.Minute))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if userRepo.usersById[100].Status != PresenceAway {
+		t.Errorf("expected alice away, got %s", userRepo.usersById[100].Status)
+	}
+	if len(publisher.events) != 2 || publisher.events[1].Data["status"] != PresenceAway {
+		t.Errorf("expected an away event, got %+v", publisher.events)
+	}
+}
+
+func TestPresenceHeartbeat_ActiveDeviceKeepsOnline(t *testing.T) {
+	// Arrange
+	uc, _, _, userRepo, _ := setupPresence()
+	_ = uc.Connect(context.Background(), 100, "a")
+	_ = uc.Connect(context.Background(), 100, "b")
+
+	// Act
+	err := uc.Heartbeat(context.Background(), 100, "a", time.Now().Add(-presenceIdleAfter-time.Minute))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if userRepo.usersById[100].Status != PresenceOnline {
+		t.Errorf("expected alice online while another device is active, got %s", userRepo.usersById[100].Status)
+	}
+}
+
+// ==================== ExpireConnections Tests ====================
+
+func TestExpireConnections_CrashedInstance(t *testing.T) {
+	// Arrange
+	uc, presenceRepo, _, userRepo, publisher := setupPresence()
+	_ = uc.Connect(context.Background(), 100, "a")
+	lastHeartbeat := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	presenceRepo.connections[100]["a"].ExpiresAt = lastHeartbeat.Add(presenceTTL)
+
+	// Act
+	expired, err := uc.ExpireConnections(context.Background(), 100)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expired != 1 {
+		t.Errorf("expected 1 expired connection, got %d", expired)
+	}
+	user := userRepo.usersById[100]
+	if user.Status != PresenceOffline || !user.LastSeen.Equal(lastHeartbeat) {
+		t.Errorf("expected alice offline, last seen at her last heartbeat, got %s at %v", user.Status, user.LastSeen)
+	}
+	if len(publisher.events) != 2 || publisher.events[1].Data["status"] != PresenceOffline {
+		t.Errorf("expected an offline event, got %+v", publisher.events)
+	}
+}
+
+func TestExpireConnections_NoneStale(t *testing.T) {
+	// Arrange
+	uc, _, _, _, _ := setupPresence()
+	_ = uc.Connect(context.Background(), 100, "a")
+
+	// Act
+	expired, err := uc.ExpireConnections(context.Background(), 100)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expired != 0 {
+		t.Errorf("expected a live connection kept, got %d expired", expired)
+	}
+}
+
+// ==================== GetPresence Tests ====================
+
+func TestGetPresence_Batch(t *testing.T) {
+	// Arrange
+	uc, _, roomRepo, userRepo, _ := setupPresence()
+	_ = uc.Connect(context.Background(), 100, "a")
+	_ = uc.Connect(context.Background(), 100, "b")
+	_ = uc.Connect(context.Background(), 200, "c")
+	_ = uc.Heartbeat(context.Background(), 200, "c", time.Now().Add(-presenceIdleAfter-time.Minute))
+	lastSeen := time.Unix(1714554000, 0)
+	userRepo.usersById[300].LastSeen = lastSeen
+	roomRepo.AddRoom(&Room{ID: 20})
+	roomRepo.AddMember(20, 100)
+	roomRepo.AddMember(20, 300)
+
+	// Act
+	presences, err := uc.GetPresence(context.Background(), 100, []int64{100, 200, 300, 999, 100})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(presences) != 3 {
+		t.Fatalf("expected unknown and repeated users left out, got %d", len(presences))
+	}
+	if presences[0].Status != PresenceOnline || presences[0].Connections != 2 {
+		t.Errorf("expected alice online on 2 devices, got %+v", presences[0])
+	}
+	if presences[1].Status != PresenceAway {
+		t.Errorf("expected bob away, got %+v", presences[1])
+	}
+	if presences[2].Status != PresenceOffline || !presences[2].LastSeen.Equal(lastSeen) {
+		t.Errorf("expected carol offline with her last_seen, got %+v", presences[2])
+	}
+}
+
+func TestGetPresence_TooManyUsers(t *testing.T) {
+	// Arrange
+	uc, _, _, _, _ := setupPresence()
+	userIDs := make([]int64, maxPresenceUsers+1)
+
+	// Act
+	_, err := uc.GetPresence(context.Background(), 100, userIDs)
+
+	// Assert
+	if err != ErrTooManyPresenceUsers {
+		t.Errorf("expected ErrTooManyPresenceUsers, got %v", err)
+	}
+}
+
+func TestGetPresence_LeavesOutStrangers(t *testing.T) {
+	// Arrange
+	uc, _, _, _, _ := setupPresence()
+	_ = uc.Connect(context.Background(), 300, "a")
+
+	// Act
+	presences, err := uc.GetPresence(context.Background(), 100, []int64{200, 300})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(presences) != 1 || presences[0].UserID != 200 {
+		t.Errorf("expected only roommate bob, got %+v", presences)
+	}
+}
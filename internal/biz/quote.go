@@ -0,0 +1,109 @@
+package biz
+
+import (
+	"context"
+	"time"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+)
+
+// QuotedMessage is a copy of a quoted or forwarded message taken when the
+// quote is sent. It does not follow later edits or deletion of the original.
+type QuotedMessage struct {
+	MessageID int64
+	RoomID    int64
+	UserID    int64
+	Username  string
+	Content   string
+	Type      string
+	FileURL   string
+	FileName  string
+	FileSize  int64
+	MimeType  string
+	CreatedAt time.Time
+	// ExpiresAt is carried so the quote never outlives an ephemeral original
+	ExpiresAt *time.Time
+}
+
+// ForwardMessage re-sends a message into another room, with an optional comment
+func (uc *ChatUseCase) ForwardMessage(ctx context.Context, userID, messageID, roomID int64, comment string) (*Message, error) {
+	message, err := uc.sendMessage(ctx, userID, &chatV1.SendMessageRequest{
+		RoomId:          roomID,
+		Content:         comment,
+		Type:            "text",
+		QuotedMessageId: messageID,
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.publishMessage(ctx, message)
+	return message, nil
+}
+
+// quoteMessage snapshots a message for quoting or forwarding.
+// The caller must be able to read the room the original was posted in.
+func (uc *ChatUseCase) quoteMessage(ctx context.Context, userID, messageID int64) (*QuotedMessage, error) {
+	message, err := uc.repo.GetMessage(ctx, messageID)
+	if err != nil || message.IsDeleted {
+		return nil, ErrMessageNotFound
+	}
+	if message.ExpiresAt != nil && !message.ExpiresAt.After(time.Now()) {
+		return nil, ErrMessageNotFound
+	}
+
+	isMember, err := uc.roomRepo.IsUserInRoom(ctx, message.RoomID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, ErrRoomAccessDenied
+	}
+
+	return &QuotedMessage{
+		MessageID: message.ID,
+		RoomID:    message.RoomID,
+		UserID:    message.UserID,
+		Username:  message.Username,
+		Content:   message.Content,
+		Type:      message.Type,
+		FileURL:   message.FileURL,
+		FileName:  message.FileName,
+		FileSize:  message.FileSize,
+		MimeType:  message.MimeType,
+		CreatedAt: message.CreatedAt,
+		ExpiresAt: message.ExpiresAt,
+	}, nil
+}
+
+// quotedMessageEventData flattens a quote snapshot into event data
+func quotedMessageEventData(quoted *QuotedMessage) map[string]interface{} {
+	data := map[string]interface{}{
+		"message_id": quoted.MessageID,
+		"room_id":    quoted.RoomID,
+		"user_id":    quoted.UserID,
+		"username":   quoted.Username,
+		"content":    quoted.Content,
+		"type":       quoted.Type,
+		"created_at": quoted.CreatedAt.Unix(),
+	}
+	if quoted.FileURL != "" {
+		data["file_url"] = quoted.FileURL
+		data["file_name"] = quoted.FileName
+		data["file_size"] = quoted.FileSize
+		data["mime_type"] = quoted.MimeType
+	}
+	return data
+}
+
+// quoteExpiry caps a message's expiry at that of the message it quotes,
+// since the reaper removes the original's attachment with it
+func quoteExpiry(expiresAt *time.Time, quoted *QuotedMessage) *time.Time {
+	if quoted == nil || quoted.ExpiresAt == nil {
+		return expiresAt
+	}
+	if expiresAt == nil || quoted.ExpiresAt.Before(*expiresAt) {
+		return quoted.ExpiresAt
+	}
+	return expiresAt
+}
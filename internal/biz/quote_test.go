@@ -0,0 +1,209 @@
+package biz
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+)
+
+// setupQuoteRooms creates source room 10 holding message 500 by user 200,
+// and destination room 20. User 100 belongs to both rooms.
+func setupQuoteRooms() (*MockChatRepo, *MockRoomRepo, *MockUserRepo) {
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	roomRepo.AddRoom(&Room{ID: 20})
+	roomRepo.AddMember(10, 200)
+	roomRepo.AddMember(20, 100)
+
+	chatRepo.AddMessage(&Message{
+		ID:        500,
+		RoomID:    10,
+		UserID:    200,
+		Username:  "author",
+		Content:   "see attached",
+		Type:      "file",
+		FileURL:   "http://files/report.pdf",
+		FileName:  "report.pdf",
+		FileSize:  1024,
+		MimeType:  "application/pdf",
+		CreatedAt: time.Now().Add(-time.Hour),
+	})
+
+	return chatRepo, roomRepo, userRepo
+}
+
+// ==================== Quote Tests ====================
+
+func TestSendMessage_QuoteSnapshot(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupQuoteRooms()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	message, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{
+		RoomId:          20,
+		Content:         "look at this",
+		QuotedMessageId: 500,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	quoted := message.QuotedMessage
+	if quoted == nil {
+		t.Fatal("expected a quoted message snapshot")
+	}
+	if quoted.MessageID != 500 || quoted.RoomID != 10 || quoted.Username != "author" {
+		t.Errorf("expected snapshot of message 500 in room 10 by author, got %+v", quoted)
+	}
+	if quoted.FileURL != "http://files/report.pdf" || quoted.FileName != "report.pdf" {
+		t.Errorf("expected attachment in snapshot, got %+v", quoted)
+	}
+	if message.IsForwarded {
+		t.Error("expected a quote not to be marked forwarded")
+	}
+}
+
+func TestSendMessage_QuoteWithoutContent(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupQuoteRooms()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	message, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{
+		RoomId:          20,
+		QuotedMessageId: 500,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if message.QuotedMessage == nil {
+		t.Error("expected a quoted message snapshot")
+	}
+}
+
+func TestSendMessage_QuoteSourceRoomAccessDenied(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupQuoteRooms()
+	roomRepo.AddMember(20, 300)
+	userRepo.usersById[300] = &User{ID: 300, Username: "outsider"}
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, err := uc.SendMessage(context.Background(), 300, &chatV1.SendMessageRequest{
+		RoomId:          20,
+		Content:         "leak",
+		QuotedMessageId: 500,
+	})
+
+	// Assert
+	if err != ErrRoomAccessDenied {
+		t.Errorf("expected ErrRoomAccessDenied, got %v", err)
+	}
+}
+
+func TestSendMessage_QuoteDeletedMessage(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupQuoteRooms()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+	_ = chatRepo.DeleteMessage(context.Background(), 500, 200)
+
+	// Act
+	_, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{
+		RoomId:          20,
+		QuotedMessageId: 500,
+	})
+
+	// Assert
+	if err != ErrMessageNotFound {
+		t.Errorf("expected ErrMessageNotFound, got %v", err)
+	}
+}
+
+func TestSendMessage_QuoteSurvivesEditAndDelete(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupQuoteRooms()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+	message, _ := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{
+		RoomId:          20,
+		QuotedMessageId: 500,
+	})
+
+	// Act
+	_ = chatRepo.EditMessage(context.Background(), 500, "changed", "")
+	_ = chatRepo.DeleteMessage(context.Background(), 500, 200)
+
+	// Assert
+	stored, _ := chatRepo.GetMessage(context.Background(), message.ID)
+	if stored.QuotedMessage.Content != "see attached" || stored.QuotedMessage.FileURL == "" {
+		t.Errorf("expected original content and attachment in snapshot, got %+v", stored.QuotedMessage)
+	}
+}
+
+func TestSendMessage_QuoteInheritsEarlierExpiry(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupQuoteRooms()
+	expiresAt := time.Now().Add(time.Minute)
+	chatRepo.messages[500].ExpiresAt = &expiresAt
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	message, err := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{
+		RoomId:          20,
+		QuotedMessageId: 500,
+		ExpiresIn:       3600,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if message.ExpiresAt == nil || !message.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("expected quote to expire with the original at %v, got %v", expiresAt, message.ExpiresAt)
+	}
+}
+
+// ==================== ForwardMessage Tests ====================
+
+func TestForwardMessage_Success(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupQuoteRooms()
+	publisher := &MockEventPublisher{}
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+
+	// Act
+	message, err := uc.ForwardMessage(context.Background(), 100, 500, 20, "fyi")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !message.IsForwarded || message.RoomID != 20 || message.Content != "fyi" {
+		t.Errorf("expected forwarded message in room 20 with comment, got %+v", message)
+	}
+	if message.QuotedMessage == nil || message.QuotedMessage.MessageID != 500 {
+		t.Errorf("expected snapshot of message 500, got %+v", message.QuotedMessage)
+	}
+	if len(publisher.messages) != 1 || publisher.messages[0].ID != message.ID {
+		t.Errorf("expected forwarded message to be published, got %d messages", len(publisher.messages))
+	}
+}
+
+func TestForwardMessage_NotMemberOfDestination(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupQuoteRooms()
+	userRepo.usersById[200] = &User{ID: 200, Username: "author"}
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, err := uc.ForwardMessage(context.Background(), 200, 500, 20, "")
+
+	// Assert
+	if err != ErrCannotSendMessage {
+		t.Errorf("expected ErrCannotSendMessage, got %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrInvalidReaction = errors.New("invalid reaction emoji")
+)
+
+// maxEmojiLength matches message_reactions.emoji
+const maxEmojiLength = 64
+
+// Reaction is the aggregate of one emoji on a message
+type Reaction struct {
+	Emoji       string
+	Count       int32
+	ReactedByMe bool
+}
+
+// AddReaction adds the user's emoji reaction to a message and notifies the room.
+// Adding a reaction the user already has is a no-op.
+func (uc *ChatUseCase) AddReaction(ctx context.Context, userID, messageID int64, emoji string) ([]*Reaction, error) {
+	message, err := uc.getReactableMessage(ctx, userID, messageID, emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	added, err := uc.repo.AddReaction(ctx, messageID, userID, emoji)
+	if err != nil {
+		uc.log.Errorf("Failed to add reaction to message %d: %v", messageID, err)
+		return nil, err
+	}
+
+	return uc.reactionsChanged(ctx, userID, message, emoji, added, EventReactionAdded)
+}
+
+// RemoveReaction removes the user's emoji reaction from a message and notifies the room.
+// Removing a reaction the user doesn't have is a no-op.
+func (uc *ChatUseCase) RemoveReaction(ctx context.Context, userID, messageID int64, emoji string) ([]*Reaction, error) {
+	message, err := uc.getReactableMessage(ctx, userID, messageID, emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := uc.repo.RemoveReaction(ctx, messageID, userID, emoji)
+	if err != nil {
+		uc.log.Errorf("Failed to remove reaction from message %d: %v", messageID, err)
+		return nil, err
+	}
+
+	return uc.reactionsChanged(ctx, userID, message, emoji, removed, EventReactionRemoved)
+}
+
+// getReactableMessage validates the emoji and checks the user can see the message
+func (uc *ChatUseCase) getReactableMessage(ctx context.Context, userID, messageID int64, emoji string) (*Message, error) {
+	if !isValidEmoji(emoji) {
+		return nil, ErrInvalidReaction
+	}
+
+	message, err := uc.repo.GetMessage(ctx, messageID)
+	if err != nil || message.IsDeleted {
+		return nil, ErrMessageNotFound
+	}
+
+	// Check if user has access to the room
+	isMember, err := uc.roomRepo.IsUserInRoom(ctx, message.RoomID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, ErrRoomAccessDenied
+	}
+
+	return message, nil
+}
+
+// reactionsChanged reloads the message's reactions and, if the change took
+// effect, publishes it with the emoji's new count
+func (uc *ChatUseCase) reactionsChanged(ctx context.Context, userID int64, message *Message, emoji string, changed bool, eventType string) ([]*Reaction, error) {
+	reactions, err := uc.repo.ListReactions(ctx, []int64{message.ID}, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if changed {
+		count := int32(0)
+		for _, reaction := range reactions[message.ID] {
+			if reaction.Emoji == emoji {
+				count = reaction.Count
+			}
+		}
+		uc.publishEvent(ctx, &RoomEvent{
+			Type:   eventType,
+			RoomID: message.RoomID,
+			Data: map[string]interface{}{
+				"message_id": message.ID,
+				"user_id":    userID,
+				"emoji":      emoji,
+				"count":      count,
+			},
+		})
+	}
+
+	return reactions[message.ID], nil
+}
+
+// attachReactions fills in Reactions on each message in one repo call
+func (uc *ChatUseCase) attachReactions(ctx context.Context, userID int64, messages []*Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(messages))
+	for _, message := range messages {
+		ids = append(ids, message.ID)
+	}
+
+	reactions, err := uc.repo.ListReactions(ctx, ids, userID)
+	if err != nil {
+		uc.log.Errorf("Failed to load reactions: %v", err)
+		return err
+	}
+
+	for _, message := range messages {
+		message.Reactions = reactions[message.ID]
+	}
+	return nil
+}
+
+// isValidEmoji accepts a short, non-empty token without whitespace
+// (a unicode emoji or a :shortcode:)
+func isValidEmoji(emoji string) bool {
+	if emoji == "" || len(emoji) > maxEmojiLength || !utf8.ValidString(emoji) {
+		return false
+	}
+	return !strings.ContainsAny(emoji, " \t\r\n")
+}
@@ -0,0 +1,240 @@
+package biz
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// ==================== AddReaction Tests ====================
+
+func TestAddReaction_Success(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	publisher := &MockEventPublisher{}
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10, UserID: 200})
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddMember(10, 100)
+	roomRepo.AddMember(10, 200)
+
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+	_, _ = chatRepo.AddReaction(context.Background(), 1, 200, "👍")
+
+	// Act
+	reactions, err := uc.AddReaction(context.Background(), 100, 1, "👍")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(reactions) != 1 {
+		t.Fatalf("expected 1 reaction, got %d", len(reactions))
+	}
+	if reactions[0].Count != 2 {
+		t.Errorf("expected count 2, got %d", reactions[0].Count)
+	}
+	if !reactions[0].ReactedByMe {
+		t.Error("expected reacted by me")
+	}
+	if len(publisher.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(publisher.events))
+	}
+	event := publisher.events[0]
+	if event.Type != EventReactionAdded {
+		t.Errorf("expected event type '%s', got '%s'", EventReactionAdded, event.Type)
+	}
+	if event.Data["count"] != int32(2) {
+		t.Errorf("expected count 2 in event, got %v", event.Data["count"])
+	}
+}
+
+func TestAddReaction_Duplicate(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	publisher := &MockEventPublisher{}
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10})
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddMember(10, 100)
+
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+	_, _ = uc.AddReaction(context.Background(), 100, 1, "🎉")
+
+	// Act
+	reactions, err := uc.AddReaction(context.Background(), 100, 1, "🎉")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if reactions[0].Count != 1 {
+		t.Errorf("expected count 1, got %d", reactions[0].Count)
+	}
+	if len(publisher.events) != 1 {
+		t.Errorf("expected only the first add to publish, got %d events", len(publisher.events))
+	}
+}
+
+func TestAddReaction_NotMember(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10})
+	roomRepo.AddRoom(&Room{ID: 10})
+	// User 100 is NOT a member
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, err := uc.AddReaction(context.Background(), 100, 1, "👍")
+
+	// Assert
+	if err != ErrRoomAccessDenied {
+		t.Fatalf("expected ErrRoomAccessDenied, got %v", err)
+	}
+}
+
+func TestAddReaction_InvalidEmoji(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10})
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddMember(10, 100)
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, err := uc.AddReaction(context.Background(), 100, 1, "thumbs up")
+
+	// Assert
+	if err != ErrInvalidReaction {
+		t.Fatalf("expected ErrInvalidReaction, got %v", err)
+	}
+}
+
+func TestAddReaction_DeletedMessage(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+
+	deletedAt := time.Now()
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10, IsDeleted: true, DeletedAt: &deletedAt})
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddMember(10, 100)
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, err := uc.AddReaction(context.Background(), 100, 1, "👍")
+
+	// Assert
+	if err != ErrMessageNotFound {
+		t.Fatalf("expected ErrMessageNotFound, got %v", err)
+	}
+}
+
+// ==================== RemoveReaction Tests ====================
+
+func TestRemoveReaction_Success(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	publisher := &MockEventPublisher{}
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10})
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddMember(10, 100)
+	_, _ = chatRepo.AddReaction(context.Background(), 1, 100, "👍")
+
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+
+	// Act
+	reactions, err := uc.RemoveReaction(context.Background(), 100, 1, "👍")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(reactions) != 0 {
+		t.Errorf("expected no reactions, got %d", len(reactions))
+	}
+	if len(publisher.events) != 1 || publisher.events[0].Type != EventReactionRemoved {
+		t.Fatalf("expected a %s event, got %v", EventReactionRemoved, publisher.events)
+	}
+	if publisher.events[0].Data["count"] != int32(0) {
+		t.Errorf("expected count 0 in event, got %v", publisher.events[0].Data["count"])
+	}
+}
+
+func TestRemoveReaction_NotReacted(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	publisher := &MockEventPublisher{}
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10})
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddMember(10, 100)
+
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+
+	// Act
+	_, err := uc.RemoveReaction(context.Background(), 100, 1, "👍")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(publisher.events) != 0 {
+		t.Errorf("expected no events, got %d", len(publisher.events))
+	}
+}
+
+// ==================== ListMessages Reactions Tests ====================
+
+func TestListMessages_IncludesReactions(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10})
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.AddMember(10, 100)
+	_, _ = chatRepo.AddReaction(context.Background(), 1, 200, "❤️")
+	_, _ = chatRepo.AddReaction(context.Background(), 1, 300, "❤️")
+	_, _ = chatRepo.AddReaction(context.Background(), 1, 100, "😂")
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	page, err := uc.ListMessages(context.Background(), 100, 10, 50, MessageCursor{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	reactions := page.Messages[0].Reactions
+	if len(reactions) != 2 {
+		t.Fatalf("expected 2 reactions, got %d", len(reactions))
+	}
+	if reactions[0].Emoji != "❤️" || reactions[0].Count != 2 || reactions[0].ReactedByMe {
+		t.Errorf("unexpected first reaction: %+v", reactions[0])
+	}
+	if reactions[1].Emoji != "😂" || reactions[1].Count != 1 || !reactions[1].ReactedByMe {
+		t.Errorf("unexpected second reaction: %+v", reactions[1])
+	}
+}
@@ -0,0 +1,167 @@
+package biz
+
+import (
+	"context"
+	"time"
+)
+
+// ReadCursor is a member's read position in a room: every message up to
+// LastReadMessageID has been seen
+type ReadCursor struct {
+	UserID            int64
+	Username          string
+	LastReadMessageID int64
+	ReadAt            *time.Time // nil if the member has never read the room
+}
+
+// maxSummaryUnreadCount is where the summary stops counting a room's unread
+// messages; clients show it as "99+"
+const maxSummaryUnreadCount = 100
+
+// RoomUnread is a user's unread state in one room
+type RoomUnread struct {
+	RoomID               int64
+	UnreadCount          int32
+	MentionCount         int32 // unread mentions of the user
+	FirstUnreadMessageID int64 // 0 if nothing is unread
+}
+
+// MarkRoomRead moves the user's read cursor in a room up to a message
+// (0 = the latest message) and reports the cursor and how many messages
+// remain unread. Cursors never move back; when one moves, the room gets a
+// read_receipt event.
+func (uc *ChatUseCase) MarkRoomRead(ctx context.Context, userID, roomID, upToMessageID int64) (int64, int32, error) {
+	// Check if user has access to the room
+	isMember, err := uc.roomRepo.IsUserInRoom(ctx, roomID, userID)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !isMember {
+		return 0, 0, ErrRoomAccessDenied
+	}
+
+	if upToMessageID == 0 {
+		latest, _, err := uc.repo.ListMessages(ctx, roomID, 1, 0, 0)
+		if err != nil {
+			return 0, 0, err
+		}
+		if len(latest) > 0 {
+			upToMessageID = latest[0].ID
+		}
+	} else {
+		message, err := uc.repo.GetMessage(ctx, upToMessageID)
+		if err != nil || message.RoomID != roomID {
+			return 0, 0, ErrMessageNotFound
+		}
+	}
+
+	if upToMessageID != 0 {
+		readAt := time.Now()
+		advanced, err := uc.repo.MarkRoomRead(ctx, roomID, userID, upToMessageID, readAt)
+		if err != nil {
+			uc.log.Errorf("Failed to mark room %d read for user %d: %v", roomID, userID, err)
+			return 0, 0, err
+		}
+		if advanced {
+			uc.publishReadReceipt(ctx, roomID, userID, upToMessageID, readAt)
+		}
+	}
+
+	lastRead, err := uc.repo.GetLastReadMessageID(ctx, roomID, userID)
+	if err != nil {
+		return 0, 0, err
+	}
+	unread, err := uc.repo.CountUnread(ctx, roomID, userID)
+	if err != nil {
+		return 0, 0, err
+	}
+	return lastRead, unread, nil
+}
+
+// ListReadCursors lists every member's read cursor in a room
+func (uc *ChatUseCase) ListReadCursors(ctx context.Context, userID, roomID int64) ([]*ReadCursor, error) {
+	// Check if user has access to the room
+	isMember, err := uc.roomRepo.IsUserInRoom(ctx, roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, ErrRoomAccessDenied
+	}
+
+	return uc.repo.ListReadCursors(ctx, roomID)
+}
+
+// GetMessageReaders lists the members other than the author who have seen a message
+func (uc *ChatUseCase) GetMessageReaders(ctx context.Context, userID, messageID int64) ([]*ReadCursor, error) {
+	message, err := uc.GetMessage(ctx, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	cursors, err := uc.repo.ListReadCursors(ctx, message.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	var readers []*ReadCursor
+	for _, cursor := range cursors {
+		if cursor.UserID != message.UserID && cursor.LastReadMessageID >= messageID {
+			readers = append(readers, cursor)
+		}
+	}
+	return readers, nil
+}
+
+// GetUnreadMessages lists the room timeline messages past the user's read
+// cursor, oldest first. Own, deleted and system messages don't count as unread.
+func (uc *ChatUseCase) GetUnreadMessages(ctx context.Context, userID, roomID int64, limit int32) ([]*Message, bool, error) {
+	// Check if user has access to the room
+	isMember, err := uc.roomRepo.IsUserInRoom(ctx, roomID, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	if !isMember {
+		return nil, false, ErrRoomAccessDenied
+	}
+
+	messages, hasMore, err := uc.repo.ListUnreadMessages(ctx, roomID, userID, limit)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := uc.attachReactions(ctx, userID, messages); err != nil {
+		return nil, false, err
+	}
+	if err := uc.attachLinkPreviews(ctx, messages); err != nil {
+		return nil, false, err
+	}
+	if err := uc.attachPolls(ctx, userID, messages); err != nil {
+		return nil, false, err
+	}
+
+	return messages, hasMore, nil
+}
+
+// GetUnreadSummary reports the unread state of every room the user belongs
+// to. Unread counts stop at maxSummaryUnreadCount.
+func (uc *ChatUseCase) GetUnreadSummary(ctx context.Context, userID int64) ([]*RoomUnread, error) {
+	return uc.repo.ListRoomUnreads(ctx, userID, maxSummaryUnreadCount)
+}
+
+// publishReadReceipt tells the room how far a member has read
+func (uc *ChatUseCase) publishReadReceipt(ctx context.Context, roomID, userID, lastReadMessageID int64, readAt time.Time) {
+	data := map[string]interface{}{
+		"user_id":              userID,
+		"last_read_message_id": lastReadMessageID,
+		"read_at":              readAt.Unix(),
+	}
+	if user, err := uc.userRepo.GetUserByID(ctx, userID); err == nil {
+		data["username"] = user.Username
+	}
+	uc.publishEvent(ctx, &RoomEvent{
+		Type:   EventReadReceipt,
+		RoomID: roomID,
+		Data:   data,
+	})
+}
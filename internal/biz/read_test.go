@@ -0,0 +1,262 @@
+package biz
+
+import (
+	"context"
+	"testing"
+)
+
+// setupReadRoom creates room 10 with members 100 (sender) and 200, and
+// messages 1-3 from user 200 followed by message 4 from user 100
+func setupReadRoom() (*ChatUseCase, *MockChatRepo, *MockRoomRepo, *MockEventPublisher) {
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	roomRepo.AddMember(10, 200)
+	for id := int64(1); id <= 3; id++ {
+		chatRepo.AddMessage(&Message{ID: id, RoomID: 10, UserID: 200, Content: "hi"})
+	}
+	chatRepo.AddMessage(&Message{ID: 4, RoomID: 10, UserID: 100, Content: "hello"})
+
+	publisher := &MockEventPublisher{}
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+	return uc, chatRepo, roomRepo, publisher
+}
+
+// ==================== MarkRoomRead Tests ====================
+
+func TestMarkRoomRead_Success(t *testing.T) {
+	// Arrange
+	uc, _, _, publisher := setupReadRoom()
+
+	// Act
+	lastRead, unread, err := uc.MarkRoomRead(context.Background(), 100, 10, 2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if lastRead != 2 || unread != 1 {
+		t.Errorf("expected cursor at 2 with 1 unread, got %d with %d", lastRead, unread)
+	}
+	if len(publisher.events) != 1 || publisher.events[0].Type != EventReadReceipt {
+		t.Fatalf("expected one %s event, got %+v", EventReadReceipt, publisher.events)
+	}
+	data := publisher.events[0].Data
+	if data["user_id"] != int64(100) || data["last_read_message_id"] != int64(2) || data["username"] != "sender" {
+		t.Errorf("expected a receipt for alice up to 2, got %v", data)
+	}
+}
+
+func TestMarkRoomRead_Latest(t *testing.T) {
+	// Arrange
+	uc, _, _, _ := setupReadRoom()
+
+	// Act
+	lastRead, unread, err := uc.MarkRoomRead(context.Background(), 200, 10, 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if lastRead != 4 || unread != 0 {
+		t.Errorf("expected cursor at the latest message with nothing unread, got %d with %d", lastRead, unread)
+	}
+}
+
+func TestMarkRoomRead_NeverMovesBack(t *testing.T) {
+	// Arrange
+	uc, _, _, publisher := setupReadRoom()
+	_, _, _ = uc.MarkRoomRead(context.Background(), 100, 10, 3)
+
+	// Act
+	lastRead, _, err := uc.MarkRoomRead(context.Background(), 100, 10, 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if lastRead != 3 {
+		t.Errorf("expected the cursor to stay at 3, got %d", lastRead)
+	}
+	if len(publisher.events) != 1 {
+		t.Errorf("expected only the first move to be announced, got %d events", len(publisher.events))
+	}
+}
+
+func TestMarkRoomRead_MessageInOtherRoom(t *testing.T) {
+	// Arrange
+	uc, chatRepo, _, _ := setupReadRoom()
+	chatRepo.AddMessage(&Message{ID: 5, RoomID: 20, UserID: 200})
+
+	// Act
+	_, _, err := uc.MarkRoomRead(context.Background(), 100, 10, 5)
+
+	// Assert
+	if err != ErrMessageNotFound {
+		t.Errorf("expected ErrMessageNotFound, got %v", err)
+	}
+}
+
+func TestMarkRoomRead_NotMember(t *testing.T) {
+	// Arrange
+	uc, _, _, _ := setupReadRoom()
+
+	// Act
+	_, _, err := uc.MarkRoomRead(context.Background(), 999, 10, 1)
+
+	// Assert
+	if err != ErrRoomAccessDenied {
+		t.Errorf("expected ErrRoomAccessDenied, got %v", err)
+	}
+}
+
+func TestMarkRoomRead_ClearsMentions(t *testing.T) {
+	// Arrange
+	uc, chatRepo, _, _ := setupReadRoom()
+	_ = chatRepo.CreateMentions(context.Background(), []*Mention{{MessageID: 2, RoomID: 10, UserID: 100, Type: MentionTypeUser}})
+
+	// Act
+	_, _, _ = uc.MarkRoomRead(context.Background(), 100, 10, 3)
+
+	// Assert
+	mentions, _, _ := chatRepo.ListUnreadMentions(context.Background(), 100, 10, 0)
+	if len(mentions) != 0 {
+		t.Errorf("expected the mention read, got %d unread", len(mentions))
+	}
+}
+
+// ==================== Read Cursor Tests ====================
+
+func TestGetMessageReaders(t *testing.T) {
+	// Arrange
+	uc, _, roomRepo, _ := setupReadRoom()
+	roomRepo.AddMember(10, 300)
+	_, _, _ = uc.MarkRoomRead(context.Background(), 100, 10, 3)
+	_, _, _ = uc.MarkRoomRead(context.Background(), 300, 10, 1)
+
+	// Act
+	readers, err := uc.GetMessageReaders(context.Background(), 100, 2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(readers) != 1 || readers[0].UserID != 100 {
+		t.Errorf("expected only user 100 to have seen message 2, got %+v", readers)
+	}
+}
+
+func TestGetMessageReaders_ExcludesAuthor(t *testing.T) {
+	// Arrange
+	uc, _, _, _ := setupReadRoom()
+	_, _, _ = uc.MarkRoomRead(context.Background(), 200, 10, 4)
+
+	// Act
+	readers, err := uc.GetMessageReaders(context.Background(), 100, 2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(readers) != 0 {
+		t.Errorf("expected the author not to count as a reader, got %+v", readers)
+	}
+}
+
+func TestListReadCursors_NotMember(t *testing.T) {
+	// Arrange
+	uc, _, _, _ := setupReadRoom()
+
+	// Act
+	_, err := uc.ListReadCursors(context.Background(), 999, 10)
+
+	// Assert
+	if err != ErrRoomAccessDenied {
+		t.Errorf("expected ErrRoomAccessDenied, got %v", err)
+	}
+}
+
+// ==================== Unread Tests ====================
+
+func TestGetUnreadMessages_PastCursor(t *testing.T) {
+	// Arrange
+	uc, chatRepo, _, _ := setupReadRoom()
+	chatRepo.AddMessage(&Message{ID: 5, RoomID: 10, UserID: 200, Type: "system"})
+	chatRepo.AddMessage(&Message{ID: 6, RoomID: 10, UserID: 200, ParentMessageID: 2})
+	_, _, _ = uc.MarkRoomRead(context.Background(), 100, 10, 1)
+
+	// Act
+	messages, hasMore, err := uc.GetUnreadMessages(context.Background(), 100, 10, 50)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(messages) != 2 || messages[0].ID != 2 || messages[1].ID != 3 || hasMore {
+		t.Errorf("expected messages 2 and 3 oldest first, got %d messages (has_more=%v)", len(messages), hasMore)
+	}
+}
+
+func TestGetUnreadMessages_HasMore(t *testing.T) {
+	// Arrange
+	uc, _, _, _ := setupReadRoom()
+
+	// Act
+	messages, hasMore, err := uc.GetUnreadMessages(context.Background(), 100, 10, 2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(messages) != 2 || messages[0].ID != 1 || !hasMore {
+		t.Errorf("expected the first 2 of 3 unread with more to come, got %d (has_more=%v)", len(messages), hasMore)
+	}
+}
+
+func TestGetUnreadSummary(t *testing.T) {
+	// Arrange
+	uc, chatRepo, roomRepo, _ := setupReadRoom()
+	roomRepo.AddRoom(&Room{ID: 20})
+	roomRepo.AddMember(20, 100)
+	chatRepo.AddMessage(&Message{ID: 7, RoomID: 20, UserID: 200})
+	_ = chatRepo.CreateMentions(context.Background(), []*Mention{
+		{MessageID: 1, RoomID: 10, UserID: 100, Type: MentionTypeUser},
+		{MessageID: 3, RoomID: 10, UserID: 100, Type: MentionTypeUser},
+	})
+	_, _, _ = uc.MarkRoomRead(context.Background(), 100, 10, 2)
+	_, _, _ = uc.MarkRoomRead(context.Background(), 100, 20, 7)
+
+	// Act
+	rooms, err := uc.GetUnreadSummary(context.Background(), 100)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(rooms) != 2 {
+		t.Fatalf("expected 2 rooms, got %d", len(rooms))
+	}
+	if rooms[0].RoomID != 10 || rooms[0].UnreadCount != 1 || rooms[0].MentionCount != 1 || rooms[0].FirstUnreadMessageID != 3 {
+		t.Errorf("expected room 10 with 1 unread and 1 mention from message 3, got %+v", rooms[0])
+	}
+	if rooms[1].RoomID != 20 || rooms[1].UnreadCount != 0 || rooms[1].FirstUnreadMessageID != 0 {
+		t.Errorf("expected room 20 fully read, got %+v", rooms[1])
+	}
+}
+
+func TestGetUnreadSummary_CapsCount(t *testing.T) {
+	// Arrange
+	uc, chatRepo, _, _ := setupReadRoom()
+	for id := int64(5); id < 5+maxSummaryUnreadCount; id++ {
+		chatRepo.AddMessage(&Message{ID: id, RoomID: 10, UserID: 200, Content: "hi"})
+	}
+
+	// Act
+	rooms, err := uc.GetUnreadSummary(context.Background(), 100)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(rooms) != 1 || rooms[0].UnreadCount != maxSummaryUnreadCount || rooms[0].FirstUnreadMessageID != 1 {
+		t.Errorf("expected %d unread from message 1, got %+v", maxSummaryUnreadCount, rooms)
+	}
+}
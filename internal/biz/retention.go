@@ -0,0 +1,106 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidRetention = errors.New("retention must be default, forever, 1 to 3650 days or the last 1 to 1000000 messages")
+	ErrRetentionDenied  = errors.New("only room admins can change the retention policy")
+)
+
+// Retention modes
+const (
+	RetentionDefault  = "default"  // follow the server's policy
+	RetentionForever  = "forever"  // never purge
+	RetentionDays     = "days"     // purge messages older than Value days
+	RetentionMessages = "messages" // keep only the newest Value messages
+)
+
+// Retention limits
+const (
+	maxRetentionDays     = 3650
+	maxRetentionMessages = 1000000
+)
+
+// RetentionPolicy is how long a room keeps its messages
+type RetentionPolicy struct {
+	Mode  string
+	Value int32 // days for RetentionDays, messages for RetentionMessages
+}
+
+// Validate checks the mode and that Value is in range for it
+func (p RetentionPolicy) Validate() error {
+	switch p.Mode {
+	case RetentionDefault, RetentionForever:
+		if p.Value != 0 {
+			return ErrInvalidRetention
+		}
+	case RetentionDays:
+		if p.Value < 1 || p.Value > maxRetentionDays {
+			return ErrInvalidRetention
+		}
+	case RetentionMessages:
+		if p.Value < 1 || p.Value > maxRetentionMessages {
+			return ErrInvalidRetention
+		}
+	default:
+		return ErrInvalidRetention
+	}
+	return nil
+}
+
+// SetRetentionPolicy sets how long a room keeps its messages (room admins only).
+// The purger applies it to existing messages on its next round.
+func (uc *RoomUseCase) SetRetentionPolicy(ctx context.Context, userID, roomID int64, policy RetentionPolicy) (*Room, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	role, err := uc.repo.GetMemberRole(ctx, roomID, userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotInRoom) {
+			return nil, ErrRoomAccessDenied
+		}
+		return nil, err
+	}
+	if role != "admin" {
+		return nil, ErrRetentionDenied
+	}
+
+	if err := uc.repo.SetRetentionPolicy(ctx, roomID, policy); err != nil {
+		uc.log.Errorf("Failed to set retention policy for room %d: %v", roomID, err)
+		return nil, err
+	}
+
+	room, err := uc.repo.GetRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, ErrRoomNotFound
+	}
+
+	uc.log.Infof("Room %d retention set to %s/%d by user %d", roomID, policy.Mode, policy.Value, userID)
+	return room, nil
+}
+
+// PurgeRetainedMessages hard-deletes up to limit messages that their room's
+// retention policy no longer keeps, with their orphaned attachments and the
+// replies of purged thread roots, and tells each room to drop them. Rooms on
+// RetentionDefault follow defaultPolicy. It reports how many messages were deleted.
+func (uc *ChatUseCase) PurgeRetainedMessages(ctx context.Context, defaultPolicy RetentionPolicy, limit int32) (int, error) {
+	if defaultPolicy.Mode == RetentionDefault {
+		return 0, ErrInvalidRetention
+	}
+	if err := defaultPolicy.Validate(); err != nil {
+		return 0, err
+	}
+
+	deleted, err := uc.repo.DeleteRetainedMessages(ctx, time.Now(), defaultPolicy, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	uc.publishRemoved(ctx, EventMessagePurged, deleted)
+	return len(deleted), nil
+}
@@ -0,0 +1,156 @@
+package biz
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// setupRetentionRoom creates room 10 with admin 100 and member 200, and
+// timeline messages 1-4 sent 3.5, 2.5, 1.5 and 0.5 days ago, plus a new reply 5 to message 1
+func setupRetentionRoom() (*ChatUseCase, *MockChatRepo, *MockEventPublisher) {
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	roomRepo.SetMemberRole(10, 100, "admin")
+	roomRepo.AddMember(10, 200)
+
+	now := time.Now()
+	for id := int64(1); id <= 4; id++ {
+		chatRepo.AddMessage(&Message{ID: id, RoomID: 10, UserID: 200, CreatedAt: now.AddDate(0, 0, -int(4-id)).Add(-12 * time.Hour)})
+	}
+	chatRepo.AddMessage(&Message{ID: 5, RoomID: 10, UserID: 200, ParentMessageID: 1, CreatedAt: now})
+
+	publisher := &MockEventPublisher{}
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+	return uc, chatRepo, publisher
+}
+
+// ==================== SetRetentionPolicy Tests ====================
+
+func TestSetRetentionPolicy_Success(t *testing.T) {
+	// Arrange
+	roomRepo := NewMockRoomRepo()
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.SetMemberRole(10, 100, "admin")
+	uc := NewRoomUseCase(roomRepo, NewMockUserRepo(), nil, log.NewStdLogger(io.Discard))
+
+	// Act
+	room, err := uc.SetRetentionPolicy(context.Background(), 100, 10, RetentionPolicy{Mode: RetentionDays, Value: 30})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if room.Retention.Mode != RetentionDays || room.Retention.Value != 30 {
+		t.Errorf("expected 30 days retention, got %+v", room.Retention)
+	}
+}
+
+func TestSetRetentionPolicy_ModeratorDenied(t *testing.T) {
+	// Arrange
+	roomRepo := NewMockRoomRepo()
+	roomRepo.AddRoom(&Room{ID: 10})
+	roomRepo.SetMemberRole(10, 100, "moderator")
+	uc := NewRoomUseCase(roomRepo, NewMockUserRepo(), nil, log.NewStdLogger(io.Discard))
+
+	// Act
+	_, err := uc.SetRetentionPolicy(context.Background(), 100, 10, RetentionPolicy{Mode: RetentionForever})
+
+	// Assert
+	if err != ErrRetentionDenied {
+		t.Errorf("expected ErrRetentionDenied, got %v", err)
+	}
+}
+
+func TestRetentionPolicy_Validate(t *testing.T) {
+	// Arrange
+	invalid := []RetentionPolicy{
+		{Mode: RetentionDays},
+		{Mode: RetentionMessages, Value: maxRetentionMessages + 1},
+		{Mode: RetentionForever, Value: 7},
+		{Mode: "weeks", Value: 2},
+	}
+
+	// Act & Assert
+	for _, policy := range invalid {
+		if err := policy.Validate(); err != ErrInvalidRetention {
+			t.Errorf("expected ErrInvalidRetention for %+v, got %v", policy, err)
+		}
+	}
+	if err := (RetentionPolicy{Mode: RetentionDefault}).Validate(); err != nil {
+		t.Errorf("expected the default mode to be valid, got %v", err)
+	}
+}
+
+// ==================== PurgeRetainedMessages Tests ====================
+
+func TestPurgeRetainedMessages_KeepLastMessages(t *testing.T) {
+	// Arrange
+	uc, chatRepo, publisher := setupRetentionRoom()
+	chatRepo.retention = map[int64]RetentionPolicy{10: {Mode: RetentionMessages, Value: 2}}
+
+	// Act
+	deleted, err := uc.PurgeRetainedMessages(context.Background(), RetentionPolicy{Mode: RetentionForever}, 100)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if deleted != 3 {
+		t.Errorf("expected messages 1, 2 and the reply to 1 purged, got %d", deleted)
+	}
+	if _, ok := chatRepo.messages[3]; !ok || len(chatRepo.messages) != 2 {
+		t.Errorf("expected messages 3 and 4 kept, got %d messages", len(chatRepo.messages))
+	}
+	if len(publisher.events) != 3 || publisher.events[0].Type != EventMessagePurged {
+		t.Errorf("expected 3 %s events, got %+v", EventMessagePurged, publisher.events)
+	}
+}
+
+func TestPurgeRetainedMessages_ServerDefaultDays(t *testing.T) {
+	// Arrange
+	uc, chatRepo, _ := setupRetentionRoom()
+
+	// Act
+	deleted, err := uc.PurgeRetainedMessages(context.Background(), RetentionPolicy{Mode: RetentionDays, Value: 2}, 100)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if deleted != 3 || len(chatRepo.messages) != 2 {
+		t.Errorf("expected messages older than 2 days and their replies purged, got %d deleted", deleted)
+	}
+}
+
+func TestPurgeRetainedMessages_RoomOverridesDefault(t *testing.T) {
+	// Arrange
+	uc, chatRepo, _ := setupRetentionRoom()
+	chatRepo.retention = map[int64]RetentionPolicy{10: {Mode: RetentionForever}}
+
+	// Act
+	deleted, err := uc.PurgeRetainedMessages(context.Background(), RetentionPolicy{Mode: RetentionDays, Value: 1}, 100)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if deleted != 0 {
+		t.Errorf("expected a room kept forever to lose nothing, got %d deleted", deleted)
+	}
+}
+
+func TestPurgeRetainedMessages_DefaultModeRejected(t *testing.T) {
+	// Arrange
+	uc, _, _ := setupRetentionRoom()
+
+	// Act
+	_, err := uc.PurgeRetainedMessages(context.Background(), RetentionPolicy{Mode: RetentionDefault}, 100)
+
+	// Assert
+	if err != ErrInvalidRetention {
+		t.Errorf("expected ErrInvalidRetention, got %v", err)
+	}
+}
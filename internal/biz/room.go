@@ -15,6 +15,9 @@ var (
 	ErrUserAlreadyInRoom     = errors.New("user already in room")
 	ErrUserNotInRoom         = errors.New("user not in room")
 	ErrCannotJoinPrivateRoom = errors.New("cannot join private room without invitation")
+	ErrRoomRenameDenied      = errors.New("only room admins and moderators can rename a room")
+	ErrRoleChangeDenied      = errors.New("only room admins can change member roles")
+	ErrInvalidRole           = errors.New("role must be 'admin', 'moderator' or 'member'")
 )
 
 // Room represents the room business entity
@@ -24,6 +27,8 @@ type Room struct {
 	Description string
 	Type        string // public, private, direct
 	CreatedBy   int64
+	MessageTTL  time.Duration // default message lifetime, 0 = messages never expire
+	Retention   RetentionPolicy
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -33,6 +38,7 @@ type RoomMember struct {
 	ID       int64
 	RoomID   int64
 	UserID   int64
+	Username string
 	Role     string // admin, moderator, member
 	JoinedAt time.Time
 }
@@ -46,20 +52,29 @@ type RoomRepo interface {
 	JoinRoom(ctx context.Context, roomID, userID int64, role string) error
 	LeaveRoom(ctx context.Context, roomID, userID int64) error
 	GetRoomMembers(ctx context.Context, roomID int64) ([]*RoomMember, error)
+	GetMemberRole(ctx context.Context, roomID, userID int64) (string, error)
+	GetMessageTTL(ctx context.Context, roomID int64) (time.Duration, error)
+	SetMessageTTL(ctx context.Context, roomID int64, ttl time.Duration) error
+	SetRetentionPolicy(ctx context.Context, roomID int64, policy RetentionPolicy) error
+	RenameRoom(ctx context.Context, roomID int64, name string) error
+	UpdateMemberRole(ctx context.Context, roomID, userID int64, role string) error
+	ListRoommateIDs(ctx context.Context, userID int64) ([]int64, error)
 }
 
 // RoomUseCase contains room business logic
 type RoomUseCase struct {
 	repo     RoomRepo
 	userRepo UserRepo
+	system   *SystemMessenger
 	log      *log.Helper
 }
 
 // NewRoomUseCase creates a new room use case
-func NewRoomUseCase(repo RoomRepo, userRepo UserRepo, logger log.Logger) *RoomUseCase {
+func NewRoomUseCase(repo RoomRepo, userRepo UserRepo, system *SystemMessenger, logger log.Logger) *RoomUseCase {
 	return &RoomUseCase{
 		repo:     repo,
 		userRepo: userRepo,
+		system:   system,
 		log:      log.NewHelper(log.With(logger, "module", "biz/room")),
 	}
 }
@@ -85,6 +100,7 @@ func (uc *RoomUseCase) CreateRoom(ctx context.Context, userID int64, req *chatV1
 		Description: req.Description,
 		Type:        req.Type,
 		CreatedBy:   userID,
+		MessageTTL:  time.Duration(req.MessageTtl) * time.Second,
 	}
 
 	createdRoom, err := uc.repo.CreateRoom(ctx, room)
@@ -163,6 +179,7 @@ func (uc *RoomUseCase) JoinRoom(ctx context.Context, userID, roomID int64) (*Roo
 		uc.log.Errorf("Failed to join room: %v", err)
 		return nil, err
 	}
+	uc.system.Post(ctx, roomID, &SystemEvent{Kind: SystemMemberJoined, ActorID: userID})
 
 	uc.log.Infof("User %d joined room %d successfully", userID, roomID)
 	return room, nil
@@ -184,11 +201,136 @@ func (uc *RoomUseCase) LeaveRoom(ctx context.Context, userID, roomID int64) erro
 		uc.log.Errorf("Failed to leave room: %v", err)
 		return err
 	}
+	uc.system.Post(ctx, roomID, &SystemEvent{Kind: SystemMemberLeft, ActorID: userID})
 
 	uc.log.Infof("User %d left room %d", userID, roomID)
 	return nil
 }
 
+// SetMessageTTL sets the room's default message lifetime (room admins and moderators only).
+// It applies to messages sent from now on.
+func (uc *RoomUseCase) SetMessageTTL(ctx context.Context, userID, roomID int64, ttl time.Duration) (*Room, error) {
+	if err := validateMessageTTL(ttl); err != nil {
+		return nil, err
+	}
+
+	role, err := uc.repo.GetMemberRole(ctx, roomID, userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotInRoom) {
+			return nil, ErrRoomAccessDenied
+		}
+		return nil, err
+	}
+	if role != "admin" && role != "moderator" {
+		return nil, errors.New("only room admins and moderators can change the message lifetime")
+	}
+
+	if err := uc.repo.SetMessageTTL(ctx, roomID, ttl); err != nil {
+		uc.log.Errorf("Failed to set message ttl for room %d: %v", roomID, err)
+		return nil, err
+	}
+
+	room, err := uc.repo.GetRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, ErrRoomNotFound
+	}
+
+	uc.log.Infof("Room %d message ttl set to %s by user %d", roomID, ttl, userID)
+	return room, nil
+}
+
+// RenameRoom renames a room (room admins and moderators only) and records the change in its history
+func (uc *RoomUseCase) RenameRoom(ctx context.Context, userID, roomID int64, name string) (*Room, error) {
+	if err := validateRoomName(name); err != nil {
+		return nil, err
+	}
+
+	role, err := uc.repo.GetMemberRole(ctx, roomID, userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotInRoom) {
+			return nil, ErrRoomAccessDenied
+		}
+		return nil, err
+	}
+	if role != "admin" && role != "moderator" {
+		return nil, ErrRoomRenameDenied
+	}
+
+	room, err := uc.repo.GetRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, ErrRoomNotFound
+	}
+	if room.Name == name {
+		return room, nil
+	}
+	oldName := room.Name
+
+	if err := uc.repo.RenameRoom(ctx, roomID, name); err != nil {
+		uc.log.Errorf("Failed to rename room %d: %v", roomID, err)
+		return nil, err
+	}
+	room.Name = name
+
+	uc.system.Post(ctx, roomID, &SystemEvent{
+		Kind:    SystemRoomRenamed,
+		ActorID: userID,
+		Details: map[string]string{"old_name": oldName, "new_name": name},
+	})
+
+	uc.log.Infof("Room %d renamed from %q to %q by user %d", roomID, oldName, name, userID)
+	return room, nil
+}
+
+// SetMemberRole changes a member's role (room admins only) and records the
+// change in the room's history. Admins can't change their own role, so a
+// room always keeps at least one admin.
+func (uc *RoomUseCase) SetMemberRole(ctx context.Context, userID, roomID, targetID int64, role string) (*RoomMember, error) {
+	if role != "admin" && role != "moderator" && role != "member" {
+		return nil, ErrInvalidRole
+	}
+
+	actorRole, err := uc.repo.GetMemberRole(ctx, roomID, userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotInRoom) {
+			return nil, ErrRoomAccessDenied
+		}
+		return nil, err
+	}
+	if actorRole != "admin" || targetID == userID {
+		return nil, ErrRoleChangeDenied
+	}
+
+	currentRole, err := uc.repo.GetMemberRole(ctx, roomID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if currentRole != role {
+		if err := uc.repo.UpdateMemberRole(ctx, roomID, targetID, role); err != nil {
+			uc.log.Errorf("Failed to set role of user %d in room %d: %v", targetID, roomID, err)
+			return nil, err
+		}
+		uc.system.Post(ctx, roomID, &SystemEvent{
+			Kind:     SystemRoleChanged,
+			ActorID:  userID,
+			TargetID: targetID,
+			Details:  map[string]string{"role": role},
+		})
+		uc.log.Infof("User %d made user %d %s in room %d", userID, targetID, role, roomID)
+	}
+
+	members, err := uc.repo.GetRoomMembers(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		if member.UserID == targetID {
+			return member, nil
+		}
+	}
+	return nil, ErrUserNotInRoom
+}
+
 // GetRoomMembers retrieves all members of a room
 func (uc *RoomUseCase) GetRoomMembers(ctx context.Context, userID, roomID int64) ([]*RoomMember, error) {
 	// Check if user has access to the room
@@ -205,11 +347,8 @@ func (uc *RoomUseCase) GetRoomMembers(ctx context.Context, userID, roomID int64)
 
 // validateCreateRoomRequest validates room creation input
 func (uc *RoomUseCase) validateCreateRoomRequest(req *chatV1.CreateRoomRequest) error {
-	if req.Name == "" {
-		return errors.New("room name is required")
-	}
-	if len(req.Name) > 100 {
-		return errors.New("room name must be less than 100 characters")
+	if err := validateRoomName(req.Name); err != nil {
+		return err
 	}
 	if req.Type == "" {
 		req.Type = "public" // Default to public
@@ -220,5 +359,19 @@ func (uc *RoomUseCase) validateCreateRoomRequest(req *chatV1.CreateRoomRequest)
 	if len(req.Description) > 500 {
 		return errors.New("room description must be less than 500 characters")
 	}
+	if err := validateMessageTTL(time.Duration(req.MessageTtl) * time.Second); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateRoomName checks a room name's length
+func validateRoomName(name string) error {
+	if name == "" {
+		return errors.New("room name is required")
+	}
+	if len(name) > 100 {
+		return errors.New("room name must be less than 100 characters")
+	}
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
@@ -14,6 +15,8 @@ import (
 type MockRoomRepo struct {
 	rooms      map[int64]*Room
 	members    map[int64]map[int64]bool // roomID -> userID -> isMember
+	roles      map[int64]map[int64]string // roomID -> userID -> role
+	usernames  map[int64]string           // userID -> username
 	nextID     int64
 	createErr  error
 	joinErr    error
@@ -24,6 +27,8 @@ func NewMockRoomRepo() *MockRoomRepo {
 	return &MockRoomRepo{
 		rooms:   make(map[int64]*Room),
 		members: make(map[int64]map[int64]bool),
+		roles:     make(map[int64]map[int64]string),
+		usernames: make(map[int64]string),
 		nextID:  1,
 	}
 }
@@ -95,15 +100,86 @@ func (m *MockRoomRepo) GetRoomMembers(ctx context.Context, roomID int64) ([]*Roo
 	if roomMembers, ok := m.members[roomID]; ok {
 		for userID := range roomMembers {
 			members = append(members, &RoomMember{
-				RoomID: roomID,
-				UserID: userID,
-				Role:   "member",
+				RoomID:   roomID,
+				UserID:   userID,
+				Username: m.usernames[userID],
+				Role:     m.role(roomID, userID),
 			})
 		}
 	}
 	return members, nil
 }
 
+func (m *MockRoomRepo) GetMemberRole(ctx context.Context, roomID, userID int64) (string, error) {
+	if !m.members[roomID][userID] {
+		return "", ErrUserNotInRoom
+	}
+	return m.role(roomID, userID), nil
+}
+
+func (m *MockRoomRepo) GetMessageTTL(ctx context.Context, roomID int64) (time.Duration, error) {
+	if room, ok := m.rooms[roomID]; ok {
+		return room.MessageTTL, nil
+	}
+	return 0, nil
+}
+
+func (m *MockRoomRepo) SetMessageTTL(ctx context.Context, roomID int64, ttl time.Duration) error {
+	if room, ok := m.rooms[roomID]; ok {
+		room.MessageTTL = ttl
+		return nil
+	}
+	return ErrRoomNotFound
+}
+
+func (m *MockRoomRepo) SetRetentionPolicy(ctx context.Context, roomID int64, policy RetentionPolicy) error {
+	if room, ok := m.rooms[roomID]; ok {
+		room.Retention = policy
+		return nil
+	}
+	return ErrRoomNotFound
+}
+
+func (m *MockRoomRepo) RenameRoom(ctx context.Context, roomID int64, name string) error {
+	if room, ok := m.rooms[roomID]; ok {
+		room.Name = name
+		return nil
+	}
+	return ErrRoomNotFound
+}
+
+func (m *MockRoomRepo) UpdateMemberRole(ctx context.Context, roomID, userID int64, role string) error {
+	if !m.members[roomID][userID] {
+		return ErrUserNotInRoom
+	}
+	m.SetMemberRole(roomID, userID, role)
+	return nil
+}
+
+func (m *MockRoomRepo) ListRoommateIDs(ctx context.Context, userID int64) ([]int64, error) {
+	seen := make(map[int64]bool)
+	var userIDs []int64
+	for _, members := range m.members {
+		if !members[userID] {
+			continue
+		}
+		for memberID := range members {
+			if memberID != userID && !seen[memberID] {
+				seen[memberID] = true
+				userIDs = append(userIDs, memberID)
+			}
+		}
+	}
+	return userIDs, nil
+}
+
+func (m *MockRoomRepo) role(roomID, userID int64) string {
+	if role, ok := m.roles[roomID][userID]; ok {
+		return role
+	}
+	return "member"
+}
+
 // Helper to add a room directly for testing
 func (m *MockRoomRepo) AddRoom(room *Room) {
 	m.rooms[room.ID] = room
@@ -120,11 +196,26 @@ func (m *MockRoomRepo) AddMember(roomID, userID int64) {
 	m.members[roomID][userID] = true
 }
 
+// Helper to add member with a role directly for testing
+func (m *MockRoomRepo) SetMemberRole(roomID, userID int64, role string) {
+	m.AddMember(roomID, userID)
+	if m.roles[roomID] == nil {
+		m.roles[roomID] = make(map[int64]string)
+	}
+	m.roles[roomID][userID] = role
+}
+
+// Helper to add member with a username directly for testing
+func (m *MockRoomRepo) AddNamedMember(roomID, userID int64, username string) {
+	m.AddMember(roomID, userID)
+	m.usernames[userID] = username
+}
+
 // ==================== Helper ====================
 
 func newTestRoomUseCase(roomRepo *MockRoomRepo, userRepo *MockUserRepo) *RoomUseCase {
 	logger := log.NewStdLogger(io.Discard)
-	return NewRoomUseCase(roomRepo, userRepo, logger)
+	return NewRoomUseCase(roomRepo, userRepo, nil, logger)
 }
 
 // ==================== CreateRoom Tests ====================
@@ -570,3 +661,65 @@ func TestListUserRooms_Empty(t *testing.T) {
 		t.Errorf("expected total 0, got %d", total)
 	}
 }
+
+// ==================== SetMessageTTL Tests ====================
+
+func TestSetMessageTTL_Success(t *testing.T) {
+	// Arrange
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	uc := newTestRoomUseCase(roomRepo, userRepo)
+
+	roomRepo.AddRoom(&Room{ID: 1, Name: "Incidents"})
+	roomRepo.SetMemberRole(1, 100, "admin")
+
+	// Act
+	room, err := uc.SetMessageTTL(context.Background(), 100, 1, time.Hour)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if room.MessageTTL != time.Hour {
+		t.Errorf("expected message ttl 1h, got %s", room.MessageTTL)
+	}
+}
+
+func TestSetMessageTTL_NotModerator(t *testing.T) {
+	// Arrange
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	uc := newTestRoomUseCase(roomRepo, userRepo)
+
+	roomRepo.AddRoom(&Room{ID: 1, Name: "Incidents"})
+	roomRepo.AddMember(1, 200)
+
+	// Act
+	_, err := uc.SetMessageTTL(context.Background(), 200, 1, time.Hour)
+
+	// Assert
+	if err == nil {
+		t.Error("expected error for plain member, got nil")
+	}
+	if roomRepo.rooms[1].MessageTTL != 0 {
+		t.Errorf("expected message ttl unchanged, got %s", roomRepo.rooms[1].MessageTTL)
+	}
+}
+
+func TestSetMessageTTL_TooLong(t *testing.T) {
+	// Arrange
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	uc := newTestRoomUseCase(roomRepo, userRepo)
+
+	roomRepo.AddRoom(&Room{ID: 1, Name: "Incidents"})
+	roomRepo.SetMemberRole(1, 100, "admin")
+
+	// Act
+	_, err := uc.SetMessageTTL(context.Background(), 100, 1, maxMessageTTL+time.Second)
+
+	// Assert
+	if err != ErrInvalidMessageTTL {
+		t.Errorf("expected ErrInvalidMessageTTL, got %v", err)
+	}
+}
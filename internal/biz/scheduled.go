@@ -0,0 +1,183 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+)
+
+var (
+	ErrScheduledMessageNotFound = errors.New("scheduled message not found")
+	ErrInvalidSendTime          = errors.New("send time must be in the future and within a year")
+)
+
+// Scheduled message statuses
+const (
+	ScheduledStatusPending   = "pending"
+	ScheduledStatusSending   = "sending"
+	ScheduledStatusSent      = "sent"
+	ScheduledStatusFailed    = "failed"
+	ScheduledStatusCancelled = "cancelled"
+)
+
+// maxScheduleAhead bounds how far in the future a message can be scheduled
+const maxScheduleAhead = 365 * 24 * time.Hour
+
+// scheduledClaimLease is how long a claimed message may stay unsent before
+// a dispatcher claims it again, e.g. after a crash in the middle of a batch
+const scheduledClaimLease = 5 * time.Minute
+
+// ScheduledMessage is a message composed now and sent at SendAt
+type ScheduledMessage struct {
+	ID        int64
+	UserID    int64
+	Request   *chatV1.SendMessageRequest
+	SendAt    time.Time
+	Status    string
+	MessageID int64     // set once sent
+	ClaimedAt time.Time // when a dispatcher last claimed it
+	CreatedAt time.Time
+}
+
+// ScheduleMessage validates a message and stores it to be sent at sendAt
+func (uc *ChatUseCase) ScheduleMessage(ctx context.Context, userID int64, req *chatV1.SendMessageRequest, sendAt time.Time) (*ScheduledMessage, error) {
+	if err := uc.validateSendMessageRequest(req); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !sendAt.After(now) || sendAt.After(now.Add(maxScheduleAhead)) {
+		return nil, ErrInvalidSendTime
+	}
+	// A scheduled poll must still be open when it is sent
+	if req.Poll != nil && req.Poll.ClosesAt != 0 && req.Poll.ClosesAt <= sendAt.Unix() {
+		return nil, ErrInvalidPollTime
+	}
+
+	// Check if user is a member of the room
+	isMember, err := uc.roomRepo.IsUserInRoom(ctx, req.RoomId, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, ErrCannotSendMessage
+	}
+
+	// Replies must target a live root message in the same room
+	if req.ParentMessageId != 0 {
+		parent, err := uc.repo.GetMessage(ctx, req.ParentMessageId)
+		if err != nil || !isThreadRoot(parent, req.RoomId) {
+			return nil, ErrInvalidThreadRoot
+		}
+	}
+
+	scheduled, err := uc.repo.CreateScheduledMessage(ctx, &ScheduledMessage{
+		UserID:  userID,
+		Request: req,
+		SendAt:  sendAt,
+		Status:  ScheduledStatusPending,
+	})
+	if err != nil {
+		uc.log.Errorf("Failed to schedule message: %v", err)
+		return nil, err
+	}
+
+	uc.log.Infof("Message scheduled: id=%d, room=%d, user=%d, send_at=%s", scheduled.ID, req.RoomId, userID, sendAt.Format(time.RFC3339))
+	return scheduled, nil
+}
+
+// ListScheduledMessages lists the user's pending scheduled messages, soonest first.
+// roomID 0 lists every room.
+func (uc *ChatUseCase) ListScheduledMessages(ctx context.Context, userID, roomID int64) ([]*ScheduledMessage, error) {
+	return uc.repo.ListPendingScheduledMessages(ctx, userID, roomID)
+}
+
+// CancelScheduledMessage cancels one of the user's pending scheduled messages
+func (uc *ChatUseCase) CancelScheduledMessage(ctx context.Context, userID, id int64) error {
+	cancelled, err := uc.repo.CancelScheduledMessage(ctx, id, userID)
+	if err != nil {
+		uc.log.Errorf("Failed to cancel scheduled message %d: %v", id, err)
+		return err
+	}
+	if !cancelled {
+		return ErrScheduledMessageNotFound
+	}
+
+	uc.log.Infof("Scheduled message cancelled: id=%d, user=%d", id, userID)
+	return nil
+}
+
+// DispatchScheduledMessages sends up to limit due scheduled messages and
+// reports how many were sent. Each message is claimed before it is sent, so
+// concurrent dispatchers don't send it together; a claim that is never
+// completed lapses after scheduledClaimLease and the message is sent again.
+// Resends carry the same client_msg_id, so the message is stored only once.
+// A send that can never succeed marks the message failed; any other error
+// leaves the claim to lapse, so the send is retried.
+func (uc *ChatUseCase) DispatchScheduledMessages(ctx context.Context, limit int32) (int, error) {
+	now := time.Now()
+	due, err := uc.repo.ClaimDueScheduledMessages(ctx, now, now.Add(-scheduledClaimLease), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, scheduled := range due {
+		scheduled.Request.ClientMsgId = fmt.Sprintf("scheduled:%d", scheduled.ID)
+		// Validation errors aren't sentinels, so they are told apart up front
+		terminal := false
+		err := uc.validateSendMessageRequest(scheduled.Request)
+		var message *Message
+		if err != nil {
+			terminal = true
+		} else {
+			message, err = uc.SendMessage(ctx, scheduled.UserID, scheduled.Request)
+			terminal = isTerminalSendError(err)
+		}
+		if err != nil {
+			// Stopping: the rest keep their claims, which lapse and are retried
+			if ctx.Err() != nil {
+				return sent, ctx.Err()
+			}
+			if !terminal {
+				uc.log.Warnf("Failed to send scheduled message %d, will retry: %v", scheduled.ID, err)
+				continue
+			}
+			uc.log.Warnf("Failed to send scheduled message %d: %v", scheduled.ID, err)
+			if err := uc.repo.CompleteScheduledMessage(ctx, scheduled.ID, 0, err.Error()); err != nil {
+				uc.log.Errorf("Failed to mark scheduled message %d failed: %v", scheduled.ID, err)
+			}
+			continue
+		}
+
+		if err := uc.repo.CompleteScheduledMessage(ctx, scheduled.ID, message.ID, ""); err != nil {
+			uc.log.Errorf("Failed to mark scheduled message %d sent: %v", scheduled.ID, err)
+		}
+
+		// Thread replies are published by SendMessage as thread_reply, and
+		// a replayed send is one an earlier, lapsed claim already stored
+		if message.ParentMessageID == 0 && !message.Replayed {
+			uc.publishMessage(ctx, message)
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// isTerminalSendError reports whether a scheduled send failed for good: the
+// sender left the room, or what it quotes or replies to is gone
+func isTerminalSendError(err error) bool {
+	for _, terminal := range []error{
+		ErrCannotSendMessage, ErrRoomAccessDenied, ErrInvalidMessage, ErrMessageNotFound,
+		ErrInvalidThreadRoot, ErrUserNotFound, ErrClientMsgIDConflict,
+	} {
+		if errors.Is(err, terminal) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+)
+
+// ==================== ScheduleMessage Tests ====================
+
+func TestScheduleMessage_Success(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+	sendAt := time.Now().Add(time.Hour)
+
+	// Act
+	scheduled, err := uc.ScheduleMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "later"}, sendAt)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if scheduled.Status != ScheduledStatusPending || !scheduled.SendAt.Equal(sendAt) {
+		t.Errorf("expected pending message at %v, got %s at %v", sendAt, scheduled.Status, scheduled.SendAt)
+	}
+	if len(chatRepo.messages) != 0 {
+		t.Errorf("expected nothing sent yet, got %d messages", len(chatRepo.messages))
+	}
+}
+
+func TestScheduleMessage_PastSendTime(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, err := uc.ScheduleMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "late"}, time.Now().Add(-time.Minute))
+
+	// Assert
+	if err != ErrInvalidSendTime {
+		t.Errorf("expected ErrInvalidSendTime, got %v", err)
+	}
+}
+
+func TestScheduleMessage_NotMember(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, err := uc.ScheduleMessage(context.Background(), 999, &chatV1.SendMessageRequest{RoomId: 10, Content: "later"}, time.Now().Add(time.Hour))
+
+	// Assert
+	if err != ErrCannotSendMessage {
+		t.Errorf("expected ErrCannotSendMessage, got %v", err)
+	}
+}
+
+// ==================== CancelScheduledMessage Tests ====================
+
+func TestCancelScheduledMessage_Success(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+	scheduled, _ := uc.ScheduleMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "later"}, time.Now().Add(time.Hour))
+
+	// Act
+	err := uc.CancelScheduledMessage(context.Background(), 100, scheduled.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	pending, _ := uc.ListScheduledMessages(context.Background(), 100, 0)
+	if len(pending) != 0 {
+		t.Errorf("expected no pending messages, got %d", len(pending))
+	}
+}
+
+func TestCancelScheduledMessage_NotOwner(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+	scheduled, _ := uc.ScheduleMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "later"}, time.Now().Add(time.Hour))
+
+	// Act
+	err := uc.CancelScheduledMessage(context.Background(), 200, scheduled.ID)
+
+	// Assert
+	if err != ErrScheduledMessageNotFound {
+		t.Errorf("expected ErrScheduledMessageNotFound, got %v", err)
+	}
+}
+
+// ==================== DispatchScheduledMessages Tests ====================
+
+func TestDispatchScheduledMessages_SendsDueOnce(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	publisher := &MockEventPublisher{}
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+	due, _ := uc.ScheduleMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "due"}, time.Now().Add(time.Hour))
+	_, _ = uc.ScheduleMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "not yet"}, time.Now().Add(2*time.Hour))
+	due.SendAt = time.Now().Add(-time.Second)
+
+	// Act
+	sent, err := uc.DispatchScheduledMessages(context.Background(), 10)
+	sentAgain, _ := uc.DispatchScheduledMessages(context.Background(), 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sent != 1 || sentAgain != 0 {
+		t.Errorf("expected 1 then 0 sent, got %d then %d", sent, sentAgain)
+	}
+	if due.Status != ScheduledStatusSent || due.MessageID == 0 {
+		t.Errorf("expected due message sent with a message ID, got %s (%d)", due.Status, due.MessageID)
+	}
+	if len(publisher.messages) != 1 || publisher.messages[0].Content != "due" {
+		t.Errorf("expected the due message published once, got %v", publisher.messages)
+	}
+}
+
+func TestDispatchScheduledMessages_MemberLeft(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	publisher := &MockEventPublisher{}
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+	scheduled, _ := uc.ScheduleMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "due"}, time.Now().Add(time.Hour))
+	scheduled.SendAt = time.Now().Add(-time.Second)
+	_ = roomRepo.LeaveRoom(context.Background(), 10, 100)
+
+	// Act
+	sent, err := uc.DispatchScheduledMessages(context.Background(), 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sent != 0 || scheduled.Status != ScheduledStatusFailed {
+		t.Errorf("expected message to fail, got %d sent, status %s", sent, scheduled.Status)
+	}
+	if len(publisher.messages) != 0 {
+		t.Errorf("expected nothing published, got %d", len(publisher.messages))
+	}
+}
+
+func TestDispatchScheduledMessages_ReclaimsLapsedClaim(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+	scheduled, _ := uc.ScheduleMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "due"}, time.Now().Add(time.Hour))
+	scheduled.SendAt = time.Now().Add(-time.Hour)
+	scheduled.Status = ScheduledStatusSending
+	scheduled.ClaimedAt = time.Now().Add(-time.Minute)
+
+	// Act
+	sentDuringLease, _ := uc.DispatchScheduledMessages(context.Background(), 10)
+	scheduled.ClaimedAt = time.Now().Add(-scheduledClaimLease - time.Minute)
+	sent, err := uc.DispatchScheduledMessages(context.Background(), 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sentDuringLease != 0 || sent != 1 {
+		t.Errorf("expected 0 sent during the lease then 1, got %d then %d", sentDuringLease, sent)
+	}
+	if scheduled.Status != ScheduledStatusSent {
+		t.Errorf("expected message sent, got %s", scheduled.Status)
+	}
+}
+
+func TestDispatchScheduledMessages_ResendIsIdempotent(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+	scheduled, _ := uc.ScheduleMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "due"}, time.Now().Add(time.Hour))
+	scheduled.SendAt = time.Now().Add(-time.Hour)
+	// A dispatcher stored the message and crashed before marking it sent
+	first, _ := uc.SendMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "due", ClientMsgId: fmt.Sprintf("scheduled:%d", scheduled.ID)})
+	scheduled.Status = ScheduledStatusSending
+	scheduled.ClaimedAt = time.Now().Add(-scheduledClaimLease - time.Minute)
+
+	// Act
+	sent, err := uc.DispatchScheduledMessages(context.Background(), 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sent != 1 || scheduled.MessageID != first.ID {
+		t.Errorf("expected the stored message %d to be reused, got %d sent with message %d", first.ID, sent, scheduled.MessageID)
+	}
+	if len(chatRepo.messages) != 1 {
+		t.Errorf("expected 1 stored message, got %d", len(chatRepo.messages))
+	}
+}
+
+func TestDispatchScheduledMessages_TransientErrorKeepsClaim(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+	scheduled, _ := uc.ScheduleMessage(context.Background(), 100, &chatV1.SendMessageRequest{RoomId: 10, Content: "due"}, time.Now().Add(time.Hour))
+	scheduled.SendAt = time.Now().Add(-time.Second)
+	chatRepo.sendErr = errors.New("connection reset")
+
+	// Act
+	sent, err := uc.DispatchScheduledMessages(context.Background(), 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sent != 0 || scheduled.Status != ScheduledStatusSending {
+		t.Errorf("expected the message left claimed for a retry, got %d sent, status %s", sent, scheduled.Status)
+	}
+}
@@ -0,0 +1,78 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSearch = errors.New("search needs a query or a file name")
+)
+
+// maxSearchQueryLength bounds the text handed to the full-text parser
+const maxSearchQueryLength = 200
+
+// SearchFilter narrows a message search. Query and FileName may not both be empty.
+type SearchFilter struct {
+	Query    string
+	RoomID   int64
+	AuthorID int64
+	From     *time.Time // inclusive
+	To       *time.Time // exclusive
+	Type     string     // text, image, file
+	FileName string     // attachment file name contains this
+	BeforeID int64      // results older than this message ID
+}
+
+// SearchResult is a matching message with its highlighted snippet
+type SearchResult struct {
+	Message *Message
+	Snippet string
+}
+
+// SearchMessages searches messages in the rooms the user belongs to, newest first
+func (uc *ChatUseCase) SearchMessages(ctx context.Context, userID int64, filter *SearchFilter, limit int32) ([]*SearchResult, bool, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	filter.FileName = strings.TrimSpace(filter.FileName)
+	if err := validateSearchFilter(filter); err != nil {
+		return nil, false, err
+	}
+
+	// A room filter must name a room the user can read
+	if filter.RoomID != 0 {
+		isMember, err := uc.roomRepo.IsUserInRoom(ctx, filter.RoomID, userID)
+		if err != nil {
+			return nil, false, err
+		}
+		if !isMember {
+			return nil, false, ErrRoomAccessDenied
+		}
+	}
+
+	results, hasMore, err := uc.repo.SearchMessages(ctx, userID, filter, limit)
+	if err != nil {
+		uc.log.Errorf("Failed to search messages for user %d: %v", userID, err)
+		return nil, false, err
+	}
+
+	return results, hasMore, nil
+}
+
+// validateSearchFilter validates message search input
+func validateSearchFilter(filter *SearchFilter) error {
+	if filter.Query == "" && filter.FileName == "" {
+		return ErrInvalidSearch
+	}
+	if len(filter.Query) > maxSearchQueryLength || len(filter.FileName) > maxSearchQueryLength {
+		return errors.New("search query too long")
+	}
+	if filter.Type != "" && filter.Type != "text" && filter.Type != "image" && filter.Type != "file" {
+		return errors.New("invalid message type")
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return errors.New("invalid date range")
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package biz
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// ==================== SearchMessages Tests ====================
+
+// setupSearchRoom creates room 10 with member 100 and a few messages
+func setupSearchRoom() (*MockChatRepo, *MockRoomRepo, *MockUserRepo) {
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	chatRepo.AddMessage(&Message{ID: 1, RoomID: 10, UserID: 100, Content: "deploy is done", Type: "text"})
+	chatRepo.AddMessage(&Message{ID: 2, RoomID: 10, UserID: 200, Content: "lunch?", Type: "text"})
+	chatRepo.AddMessage(&Message{ID: 3, RoomID: 10, UserID: 200, Content: "Deploy notes", Type: "file", FileName: "deploy.pdf"})
+
+	return chatRepo, roomRepo, userRepo
+}
+
+func TestSearchMessages_Success(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupSearchRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	results, hasMore, err := uc.SearchMessages(context.Background(), 100, &SearchFilter{Query: "  deploy ", RoomID: 10}, 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 1 || results[0].Message.ID != 3 {
+		t.Fatalf("expected newest match (3), got %v", results)
+	}
+	if !hasMore {
+		t.Error("expected hasMore to be true")
+	}
+}
+
+func TestSearchMessages_FileNameOnly(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupSearchRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	results, _, err := uc.SearchMessages(context.Background(), 100, &SearchFilter{FileName: "deploy", Type: "file"}, 20)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 1 || results[0].Message.ID != 3 {
+		t.Fatalf("expected only the attachment (3), got %v", results)
+	}
+}
+
+func TestSearchMessages_RoomAccessDenied(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupSearchRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, _, err := uc.SearchMessages(context.Background(), 999, &SearchFilter{Query: "deploy", RoomID: 10}, 20)
+
+	// Assert
+	if err != ErrRoomAccessDenied {
+		t.Errorf("expected ErrRoomAccessDenied, got %v", err)
+	}
+}
+
+func TestSearchMessages_EmptyQuery(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupSearchRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, _, err := uc.SearchMessages(context.Background(), 100, &SearchFilter{Query: "   "}, 20)
+
+	// Assert
+	if err != ErrInvalidSearch {
+		t.Errorf("expected ErrInvalidSearch, got %v", err)
+	}
+}
+
+func TestSearchMessages_InvalidType(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupSearchRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	_, _, err := uc.SearchMessages(context.Background(), 100, &SearchFilter{Query: "deploy", Type: "video"}, 20)
+
+	// Assert
+	if err == nil {
+		t.Error("expected error for invalid type, got nil")
+	}
+}
+
+func TestSearchMessages_InvalidDateRange(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupSearchRoom()
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+	from := time.Now()
+	to := from.Add(-time.Hour)
+
+	// Act
+	_, _, err := uc.SearchMessages(context.Background(), 100, &SearchFilter{Query: "deploy", From: &from, To: &to}, 20)
+
+	// Assert
+	if err == nil {
+		t.Error("expected error for invalid date range, got nil")
+	}
+}
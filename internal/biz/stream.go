@@ -0,0 +1,81 @@
+package biz
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrStreamInterrupted = errors.New("message stream interrupted, reconnect with after_message_id to resume")
+)
+
+// streamReplayPage is how many messages are replayed per query
+const streamReplayPage = 100
+
+// StreamMessages sends the room's messages newer than afterID, oldest first,
+// then each new message as it is published, until ctx ends or send fails.
+// afterID 0 skips the replay. send may block; a receiver that falls too far
+// behind gets ErrStreamInterrupted and can resume from its last message.
+func (uc *ChatUseCase) StreamMessages(ctx context.Context, userID, roomID, afterID int64, send func(*Message) error) error {
+	isMember, err := uc.roomRepo.IsUserInRoom(ctx, roomID, userID)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return ErrRoomAccessDenied
+	}
+
+	// Subscribe before replaying so nothing sent in between is missed
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	live, err := uc.publisher.SubscribeMessages(ctx, roomID)
+	if err != nil {
+		return err
+	}
+
+	// Instances may commit concurrent messages out of ID order, so a message
+	// below the replay's last ID can still arrive live; only the messages the
+	// replay actually sent are duplicates
+	replayed := make(map[int64]bool)
+	lastID := afterID
+	for afterID > 0 {
+		page, err := uc.ListMessages(ctx, userID, roomID, streamReplayPage, MessageCursor{AfterID: lastID})
+		if err != nil {
+			return err
+		}
+		// Pages are newest first
+		for i := len(page.Messages) - 1; i >= 0; i-- {
+			if err := send(page.Messages[i]); err != nil {
+				return err
+			}
+			lastID = page.Messages[i].ID
+			replayed[lastID] = true
+		}
+		if !page.HasMore {
+			break
+		}
+	}
+
+	uc.log.Infof("Streaming room %d to user %d from message %d", roomID, userID, lastID)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case message, ok := <-live:
+			if !ok {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return ErrStreamInterrupted
+			}
+			// Skip what the replay already sent; each message is published once
+			if replayed[message.ID] {
+				delete(replayed, message.ID)
+				continue
+			}
+			if err := send(message); err != nil {
+				return err
+			}
+		}
+	}
+}
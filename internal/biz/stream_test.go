@@ -0,0 +1,173 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// ==================== StreamMessages Tests ====================
+
+func TestStreamMessages_AccessDenied(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	addRoomHistory(chatRepo, roomRepo, 3)
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+
+	// Act
+	err := uc.StreamMessages(context.Background(), 200, 10, 0, func(*Message) error { return nil })
+
+	// Assert
+	if err != ErrRoomAccessDenied {
+		t.Errorf("expected ErrRoomAccessDenied, got %v", err)
+	}
+}
+
+func TestStreamMessages_ReplayThenLive(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	addRoomHistory(chatRepo, roomRepo, streamReplayPage+5)
+
+	publisher := &MockEventPublisher{live: make(chan *Message, 3)}
+	// Published while the replay ran: the first is a duplicate
+	publisher.live <- &Message{ID: streamReplayPage + 5, RoomID: 10}
+	publisher.live <- &Message{ID: streamReplayPage + 6, RoomID: 10}
+	close(publisher.live)
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+
+	var sent []int64
+	send := func(message *Message) error {
+		sent = append(sent, message.ID)
+		return nil
+	}
+
+	// Act
+	err := uc.StreamMessages(context.Background(), 100, 10, 2, send)
+
+	// Assert
+	if err != ErrStreamInterrupted {
+		t.Errorf("expected ErrStreamInterrupted once the subscription closes, got %v", err)
+	}
+	if len(sent) != streamReplayPage+4 {
+		t.Fatalf("expected %d messages, got %d: %v", streamReplayPage+4, len(sent), sent)
+	}
+	for i, id := range sent {
+		if id != int64(i)+3 {
+			t.Fatalf("expected messages 3..%d in order without gaps, got %v", streamReplayPage+6, sent)
+		}
+	}
+}
+
+func TestStreamMessages_LiveOnly(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	addRoomHistory(chatRepo, roomRepo, 5)
+
+	publisher := &MockEventPublisher{live: make(chan *Message, 1)}
+	publisher.live <- &Message{ID: 6, RoomID: 10}
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var sent []int64
+	send := func(message *Message) error {
+		sent = append(sent, message.ID)
+		cancel()
+		return nil
+	}
+
+	// Act
+	err := uc.StreamMessages(ctx, 100, 10, 0, send)
+
+	// Assert
+	if err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if len(sent) != 1 || sent[0] != 6 {
+		t.Errorf("expected only the live message 6, got %v", sent)
+	}
+}
+
+func TestStreamMessages_SendFails(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	addRoomHistory(chatRepo, roomRepo, 5)
+
+	uc := newTestChatUseCase(chatRepo, roomRepo, userRepo)
+	sendErr := errors.New("stream closed")
+
+	// Act
+	err := uc.StreamMessages(context.Background(), 100, 10, 1, func(*Message) error { return sendErr })
+
+	// Assert
+	if err != sendErr {
+		t.Errorf("expected the send error, got %v", err)
+	}
+}
+
+func TestStreamMessages_LiveOutOfOrder(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	addRoomHistory(chatRepo, roomRepo, 5)
+
+	publisher := &MockEventPublisher{live: make(chan *Message, 2)}
+	// Two instances published 7 before 6
+	publisher.live <- &Message{ID: 7, RoomID: 10}
+	publisher.live <- &Message{ID: 6, RoomID: 10}
+	close(publisher.live)
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+
+	var sent []int64
+	send := func(message *Message) error {
+		sent = append(sent, message.ID)
+		return nil
+	}
+
+	// Act
+	_ = uc.StreamMessages(context.Background(), 100, 10, 5, send)
+
+	// Assert
+	if len(sent) != 2 || sent[0] != 7 || sent[1] != 6 {
+		t.Errorf("expected both live messages delivered, got %v", sent)
+	}
+}
+
+func TestStreamMessages_LateLowerIDAfterReplay(t *testing.T) {
+	// Arrange
+	chatRepo := NewMockChatRepo()
+	roomRepo := NewMockRoomRepo()
+	userRepo := NewMockUserRepo()
+	addRoomHistory(chatRepo, roomRepo, 5)
+	// 4 commits after the replay read 5
+	delete(chatRepo.messages, 4)
+
+	publisher := &MockEventPublisher{live: make(chan *Message, 2)}
+	publisher.live <- &Message{ID: 5, RoomID: 10}
+	publisher.live <- &Message{ID: 4, RoomID: 10}
+	close(publisher.live)
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+
+	var sent []int64
+	send := func(message *Message) error {
+		sent = append(sent, message.ID)
+		return nil
+	}
+
+	// Act
+	_ = uc.StreamMessages(context.Background(), 100, 10, 2, send)
+
+	// Assert
+	if len(sent) != 3 || sent[0] != 3 || sent[1] != 5 || sent[2] != 4 {
+		t.Errorf("expected 3 and 5 replayed, then 4 live, got %v", sent)
+	}
+}
@@ -0,0 +1,125 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// System message kinds
+const (
+	SystemMemberJoined    = "member_joined"
+	SystemMemberLeft      = "member_left"
+	SystemRoomRenamed     = "room_renamed"
+	SystemRoleChanged     = "role_changed"
+	SystemMessagePinned   = "message_pinned"
+	SystemMessageUnpinned = "message_unpinned"
+)
+
+// SystemEvent is the structured record of a room lifecycle event carried by a system message
+type SystemEvent struct {
+	Kind     string
+	ActorID  int64             // user who made the change
+	TargetID int64             // user or message the change applies to (0 = the room)
+	Details  map[string]string // kind-specific fields, e.g. old_name/new_name or role
+}
+
+// SystemMessenger writes system messages into room history and
+// broadcasts them like any other new message
+type SystemMessenger struct {
+	repo      ChatRepo
+	userRepo  UserRepo
+	publisher EventPublisher
+	log       *log.Helper
+}
+
+// NewSystemMessenger creates a system message writer
+func NewSystemMessenger(repo ChatRepo, userRepo UserRepo, publisher EventPublisher, logger log.Logger) *SystemMessenger {
+	return &SystemMessenger{
+		repo:      repo,
+		userRepo:  userRepo,
+		publisher: publisher,
+		log:       log.NewHelper(log.With(logger, "module", "biz/system")),
+	}
+}
+
+// Post stores a system message in the room, authored by the actor, and
+// publishes it. The change it records is already made, so failures are
+// only logged. A nil messenger posts nothing.
+func (m *SystemMessenger) Post(ctx context.Context, roomID int64, event *SystemEvent) *Message {
+	if m == nil {
+		return nil
+	}
+
+	actor := m.username(ctx, event.ActorID)
+	var target string
+	if event.Kind == SystemRoleChanged {
+		target = m.username(ctx, event.TargetID)
+	}
+	message, err := m.repo.SendMessage(ctx, &Message{
+		RoomID:      roomID,
+		UserID:      event.ActorID,
+		Username:    actor,
+		Content:     systemMessageContent(event, actor, target),
+		Type:        "system",
+		Format:      MessageFormatPlain,
+		SystemEvent: event,
+	})
+	if err != nil {
+		m.log.Errorf("Failed to post %s system message to room %d: %v", event.Kind, roomID, err)
+		return nil
+	}
+
+	if m.publisher != nil {
+		if err := m.publisher.PublishMessage(ctx, message); err != nil {
+			m.log.Warnf("Failed to publish system message %d to room %d: %v", message.ID, roomID, err)
+		}
+	}
+	return message
+}
+
+// username looks up a user's name for the message text, falling back to a placeholder
+func (m *SystemMessenger) username(ctx context.Context, userID int64) string {
+	user, err := m.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return "someone"
+	}
+	return user.Username
+}
+
+// systemMessageContent renders the readable text of a system message.
+// Clients that understand the event can render their own from the fields.
+func systemMessageContent(event *SystemEvent, actor, target string) string {
+	switch event.Kind {
+	case SystemMemberJoined:
+		return fmt.Sprintf("%s joined the room", actor)
+	case SystemMemberLeft:
+		return fmt.Sprintf("%s left the room", actor)
+	case SystemRoomRenamed:
+		return fmt.Sprintf("%s renamed the room to %q", actor, event.Details["new_name"])
+	case SystemRoleChanged:
+		return fmt.Sprintf("%s made %s %s", actor, target, event.Details["role"])
+	case SystemMessagePinned:
+		return fmt.Sprintf("%s pinned a message", actor)
+	case SystemMessageUnpinned:
+		return fmt.Sprintf("%s unpinned a message", actor)
+	default:
+		return fmt.Sprintf("%s updated the room", actor)
+	}
+}
+
+// systemEventData flattens a system event into event data
+func systemEventData(event *SystemEvent) map[string]interface{} {
+	data := map[string]interface{}{
+		"kind":     event.Kind,
+		"actor_id": event.ActorID,
+	}
+	if event.TargetID != 0 {
+		data["target_id"] = event.TargetID
+	}
+	if len(event.Details) > 0 {
+		data["details"] = event.Details
+	}
+	return data
+}
@@ -0,0 +1,254 @@
+package biz
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// setupSystemRoom creates public room 10 with admin 100 (sender) and member 200 (bob),
+// and a room use case whose system messages land in the returned repo and publisher
+func setupSystemRoom() (*RoomUseCase, *MockRoomRepo, *MockChatRepo, *MockEventPublisher) {
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	roomRepo.AddRoom(&Room{ID: 10, Name: "general", Type: "public"})
+	roomRepo.SetMemberRole(10, 100, "admin")
+	roomRepo.AddMember(10, 200)
+	userRepo.usersById[200] = &User{ID: 200, Username: "bob"}
+	userRepo.usersById[300] = &User{ID: 300, Username: "carol"}
+
+	logger := log.NewStdLogger(io.Discard)
+	publisher := &MockEventPublisher{}
+	system := NewSystemMessenger(chatRepo, userRepo, publisher, logger)
+	return NewRoomUseCase(roomRepo, userRepo, system, logger), roomRepo, chatRepo, publisher
+}
+
+// lastSystemMessage returns the most recently published system message, failing if there is none
+func lastSystemMessage(t *testing.T, publisher *MockEventPublisher) *Message {
+	t.Helper()
+	if len(publisher.messages) == 0 {
+		t.Fatal("expected a system message to be published")
+	}
+	message := publisher.messages[len(publisher.messages)-1]
+	if message.Type != "system" || message.SystemEvent == nil {
+		t.Fatalf("expected a system message, got %+v", message)
+	}
+	return message
+}
+
+// ==================== Membership Tests ====================
+
+func TestJoinRoom_PostsSystemMessage(t *testing.T) {
+	// Arrange
+	uc, _, chatRepo, publisher := setupSystemRoom()
+
+	// Act
+	_, err := uc.JoinRoom(context.Background(), 300, 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	message := lastSystemMessage(t, publisher)
+	if message.SystemEvent.Kind != SystemMemberJoined || message.SystemEvent.ActorID != 300 || message.RoomID != 10 {
+		t.Errorf("expected member_joined by 300 in room 10, got %+v", message.SystemEvent)
+	}
+	if message.Content != "carol joined the room" {
+		t.Errorf("expected readable content, got %q", message.Content)
+	}
+	if _, ok := chatRepo.messages[message.ID]; !ok {
+		t.Error("expected the system message stored in history")
+	}
+}
+
+func TestLeaveRoom_PostsSystemMessage(t *testing.T) {
+	// Arrange
+	uc, _, _, publisher := setupSystemRoom()
+
+	// Act
+	err := uc.LeaveRoom(context.Background(), 200, 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	message := lastSystemMessage(t, publisher)
+	if message.SystemEvent.Kind != SystemMemberLeft || message.SystemEvent.ActorID != 200 {
+		t.Errorf("expected member_left by 200, got %+v", message.SystemEvent)
+	}
+}
+
+func TestJoinRoom_FailurePostsNothing(t *testing.T) {
+	// Arrange
+	uc, _, _, publisher := setupSystemRoom()
+
+	// Act
+	_, err := uc.JoinRoom(context.Background(), 200, 10)
+
+	// Assert
+	if err != ErrUserAlreadyInRoom {
+		t.Errorf("expected ErrUserAlreadyInRoom, got %v", err)
+	}
+	if len(publisher.messages) != 0 {
+		t.Errorf("expected no system message, got %d", len(publisher.messages))
+	}
+}
+
+// ==================== RenameRoom Tests ====================
+
+func TestRenameRoom_Success(t *testing.T) {
+	// Arrange
+	uc, roomRepo, _, publisher := setupSystemRoom()
+
+	// Act
+	room, err := uc.RenameRoom(context.Background(), 100, 10, "random")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if room.Name != "random" || roomRepo.rooms[10].Name != "random" {
+		t.Errorf("expected the room renamed, got %q", room.Name)
+	}
+	event := lastSystemMessage(t, publisher).SystemEvent
+	if event.Kind != SystemRoomRenamed || event.Details["old_name"] != "general" || event.Details["new_name"] != "random" {
+		t.Errorf("expected room_renamed from general to random, got %+v", event)
+	}
+}
+
+func TestRenameRoom_MemberDenied(t *testing.T) {
+	// Arrange
+	uc, _, _, publisher := setupSystemRoom()
+
+	// Act
+	_, err := uc.RenameRoom(context.Background(), 200, 10, "random")
+
+	// Assert
+	if err != ErrRoomRenameDenied {
+		t.Errorf("expected ErrRoomRenameDenied, got %v", err)
+	}
+	if len(publisher.messages) != 0 {
+		t.Errorf("expected no system message, got %d", len(publisher.messages))
+	}
+}
+
+func TestRenameRoom_SameNameIsNoop(t *testing.T) {
+	// Arrange
+	uc, _, _, publisher := setupSystemRoom()
+
+	// Act
+	_, err := uc.RenameRoom(context.Background(), 100, 10, "general")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(publisher.messages) != 0 {
+		t.Errorf("expected no system message, got %d", len(publisher.messages))
+	}
+}
+
+// ==================== SetMemberRole Tests ====================
+
+func TestSetMemberRole_Success(t *testing.T) {
+	// Arrange
+	uc, _, _, publisher := setupSystemRoom()
+
+	// Act
+	member, err := uc.SetMemberRole(context.Background(), 100, 10, 200, "moderator")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if member.UserID != 200 || member.Role != "moderator" {
+		t.Errorf("expected 200 to be a moderator, got %+v", member)
+	}
+	message := lastSystemMessage(t, publisher)
+	if message.SystemEvent.Kind != SystemRoleChanged || message.SystemEvent.TargetID != 200 || message.SystemEvent.Details["role"] != "moderator" {
+		t.Errorf("expected role_changed for 200 to moderator, got %+v", message.SystemEvent)
+	}
+	if message.Content != "sender made bob moderator" {
+		t.Errorf("expected readable content, got %q", message.Content)
+	}
+}
+
+func TestSetMemberRole_NonAdminDenied(t *testing.T) {
+	// Arrange
+	uc, roomRepo, _, _ := setupSystemRoom()
+	roomRepo.SetMemberRole(10, 300, "moderator")
+
+	// Act
+	_, err := uc.SetMemberRole(context.Background(), 300, 10, 200, "moderator")
+
+	// Assert
+	if err != ErrRoleChangeDenied {
+		t.Errorf("expected ErrRoleChangeDenied, got %v", err)
+	}
+}
+
+func TestSetMemberRole_OwnRoleDenied(t *testing.T) {
+	// Arrange
+	uc, _, _, _ := setupSystemRoom()
+
+	// Act
+	_, err := uc.SetMemberRole(context.Background(), 100, 10, 100, "member")
+
+	// Assert
+	if err != ErrRoleChangeDenied {
+		t.Errorf("expected ErrRoleChangeDenied, got %v", err)
+	}
+}
+
+func TestSetMemberRole_InvalidRole(t *testing.T) {
+	// Arrange
+	uc, _, _, _ := setupSystemRoom()
+
+	// Act
+	_, err := uc.SetMemberRole(context.Background(), 100, 10, 200, "owner")
+
+	// Assert
+	if err != ErrInvalidRole {
+		t.Errorf("expected ErrInvalidRole, got %v", err)
+	}
+}
+
+// ==================== Pin Tests ====================
+
+func TestPinMessage_PostsSystemMessage(t *testing.T) {
+	// Arrange
+	chatRepo, roomRepo, userRepo := setupTestRoom()
+	roomRepo.SetMemberRole(10, 100, "admin")
+	publisher := &MockEventPublisher{}
+	uc := newTestChatUseCaseWithPublisher(chatRepo, roomRepo, userRepo, publisher)
+	target, _ := chatRepo.SendMessage(context.Background(), &Message{RoomID: 10, UserID: 100, Content: "read me"})
+
+	// Act
+	_, err := uc.PinMessage(context.Background(), 100, target.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	message := lastSystemMessage(t, publisher)
+	if message.SystemEvent.Kind != SystemMessagePinned || message.SystemEvent.TargetID != target.ID {
+		t.Errorf("expected message_pinned for %d, got %+v", target.ID, message.SystemEvent)
+	}
+}
+
+func TestEditMessage_SystemRejected(t *testing.T) {
+	// Arrange
+	uc, roomRepo, chatRepo, publisher := setupSystemRoom()
+	_, _ = uc.JoinRoom(context.Background(), 300, 10)
+	message := lastSystemMessage(t, publisher)
+	chatUC := newTestChatUseCase(chatRepo, roomRepo, NewMockUserRepo())
+
+	// Act
+	_, err := chatUC.EditMessage(context.Background(), 300, message.ID, "carol did not join")
+
+	// Assert
+	if err == nil {
+		t.Error("expected editing a system message to fail")
+	}
+}
@@ -34,6 +34,7 @@ type UserRepo interface {
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
 	GetUserByUsername(ctx context.Context, username string) (*User, error)
 	UpdateUserStatus(ctx context.Context, id int64, status string) error
+	UpdateLastSeen(ctx context.Context, id int64, at time.Time) error
 	VerifyPassword(ctx context.Context, email, password string) (*User, error)
 }
 
@@ -131,11 +132,6 @@ func (uc *UserUseCase) Login(ctx context.Context, req *userV1.LoginRequest) (*Us
 		return nil, "", ErrInvalidCredentials
 	}
 
-	// Update user status to online
-	if err := uc.repo.UpdateUserStatus(ctx, user.ID, "online"); err != nil {
-		uc.log.Warnf("Failed to update user status: %v", err)
-	}
-
 	// Generate token
 	token, err := uc.tokenManager.GenerateToken(user.ID, user.Username)
 	if err != nil {
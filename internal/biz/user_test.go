@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	userV1 "github.com/yourusername/chat-app/api/user/v1"
@@ -75,6 +76,14 @@ func (m *MockUserRepo) UpdateUserStatus(ctx context.Context, id int64, status st
 	return ErrUserNotFound
 }
 
+func (m *MockUserRepo) UpdateLastSeen(ctx context.Context, id int64, at time.Time) error {
+	if user, ok := m.usersById[id]; ok {
+		user.LastSeen = at
+		return nil
+	}
+	return ErrUserNotFound
+}
+
 func (m *MockUserRepo) VerifyPassword(ctx context.Context, email, password string) (*User, error) {
 	if m.verifyError != nil {
 		return nil, m.verifyError
@@ -2,13 +2,16 @@ package data
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"io"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
 	userV1 "github.com/yourusername/chat-app/api/user/v1"
 	"github.com/yourusername/chat-app/internal/biz"
+	"github.com/yourusername/chat-app/internal/metrics"
+	"github.com/yourusername/chat-app/internal/storage"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -96,9 +99,21 @@ func (a *UserRepoAdapter) GetUserByEmail(ctx context.Context, email string) (*bi
 
 // GetUserByUsername retrieves a user by username
 func (a *UserRepoAdapter) GetUserByUsername(ctx context.Context, username string) (*biz.User, error) {
-	// This method needs to be added to the data layer UserRepo interface
-	// For now, return not found error
-	return nil, biz.ErrUserNotFound
+	user, _, err := a.repo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return &biz.User{
+		ID:        user.Id,
+		Username:  user.Username,
+		Email:     user.Email,
+		AvatarURL: user.AvatarUrl,
+		Status:    user.Status,
+		LastSeen:  time.Unix(user.LastSeen, 0),
+		CreatedAt: time.Unix(user.CreatedAt, 0),
+		UpdatedAt: time.Now(),
+	}, nil
 }
 
 // UpdateUserStatus updates user's online status
@@ -106,6 +121,11 @@ func (a *UserRepoAdapter) UpdateUserStatus(ctx context.Context, id int64, status
 	return a.repo.UpdateUserStatus(ctx, id, status)
 }
 
+// UpdateLastSeen records when the user was last connected
+func (a *UserRepoAdapter) UpdateLastSeen(ctx context.Context, id int64, at time.Time) error {
+	return a.repo.UpdateLastSeen(ctx, id, at)
+}
+
 // VerifyPassword verifies user password and returns user if valid
 func (a *UserRepoAdapter) VerifyPassword(ctx context.Context, email, password string) (*biz.User, error) {
 	user, passwordHash, err := a.repo.GetUserByEmail(ctx, email)
@@ -152,6 +172,7 @@ func (a *RoomRepoAdapter) CreateRoom(ctx context.Context, room *biz.Room) (*biz.
 		Description: room.Description,
 		Type:        room.Type,
 		CreatedBy:   room.CreatedBy,
+		MessageTtl:  int32(room.MessageTTL / time.Second),
 	}
 
 	createdRoom, err := a.repo.CreateRoom(ctx, dataRoom)
@@ -165,6 +186,7 @@ func (a *RoomRepoAdapter) CreateRoom(ctx context.Context, room *biz.Room) (*biz.
 		Description: createdRoom.Description,
 		Type:        createdRoom.Type,
 		CreatedBy:   createdRoom.CreatedBy,
+		MessageTTL:  time.Duration(createdRoom.MessageTtl) * time.Second,
 		CreatedAt:   time.Unix(createdRoom.CreatedAt, 0),
 		UpdatedAt:   time.Now(),
 	}, nil
@@ -183,6 +205,8 @@ func (a *RoomRepoAdapter) GetRoomByID(ctx context.Context, id int64) (*biz.Room,
 		Description: room.Description,
 		Type:        room.Type,
 		CreatedBy:   room.CreatedBy,
+		MessageTTL:  time.Duration(room.MessageTtl) * time.Second,
+		Retention:   toBizRetentionPolicy(room.Retention),
 		CreatedAt:   time.Unix(room.CreatedAt, 0),
 		UpdatedAt:   time.Now(),
 	}, nil
@@ -228,22 +252,93 @@ func (a *RoomRepoAdapter) LeaveRoom(ctx context.Context, roomID, userID int64) e
 
 // GetRoomMembers retrieves all members of a room
 func (a *RoomRepoAdapter) GetRoomMembers(ctx context.Context, roomID int64) ([]*biz.RoomMember, error) {
-	// This method needs to be implemented in the data layer
-	// For now, return empty slice
-	return []*biz.RoomMember{}, nil
+	members, err := a.repo.GetRoomMembers(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	bizMembers := make([]*biz.RoomMember, 0, len(members))
+	for _, member := range members {
+		bizMembers = append(bizMembers, &biz.RoomMember{
+			RoomID:   roomID,
+			UserID:   member.UserId,
+			Username: member.Username,
+			Role:     member.Role,
+			JoinedAt: time.Unix(member.JoinedAt, 0),
+		})
+	}
+
+	return bizMembers, nil
+}
+
+// GetMemberRole returns the user's role in the room
+func (a *RoomRepoAdapter) GetMemberRole(ctx context.Context, roomID, userID int64) (string, error) {
+	role, err := a.repo.GetMemberRole(ctx, roomID, userID)
+	if err != nil {
+		return "", err
+	}
+	if role == "" {
+		return "", biz.ErrUserNotInRoom
+	}
+	return role, nil
+}
+
+// GetMessageTTL returns the room's default message lifetime
+func (a *RoomRepoAdapter) GetMessageTTL(ctx context.Context, roomID int64) (time.Duration, error) {
+	ttlSeconds, err := a.repo.GetMessageTTL(ctx, roomID)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(ttlSeconds) * time.Second, nil
+}
+
+// SetMessageTTL sets the room's default message lifetime
+func (a *RoomRepoAdapter) SetMessageTTL(ctx context.Context, roomID int64, ttl time.Duration) error {
+	return a.repo.SetMessageTTL(ctx, roomID, int32(ttl/time.Second))
+}
+
+// SetRetentionPolicy sets how long the room keeps its messages
+func (a *RoomRepoAdapter) SetRetentionPolicy(ctx context.Context, roomID int64, policy biz.RetentionPolicy) error {
+	return a.repo.SetRetentionPolicy(ctx, roomID, &chatV1.RetentionPolicy{Mode: policy.Mode, Value: policy.Value})
+}
+
+// toBizRetentionPolicy converts a data layer retention policy, treating a missing one as the default
+func toBizRetentionPolicy(policy *chatV1.RetentionPolicy) biz.RetentionPolicy {
+	if policy == nil {
+		return biz.RetentionPolicy{Mode: biz.RetentionDefault}
+	}
+	return biz.RetentionPolicy{Mode: policy.Mode, Value: policy.Value}
+}
+
+// RenameRoom changes the room's name
+func (a *RoomRepoAdapter) RenameRoom(ctx context.Context, roomID int64, name string) error {
+	return a.repo.RenameRoom(ctx, roomID, name)
+}
+
+// UpdateMemberRole changes a member's role in the room
+func (a *RoomRepoAdapter) UpdateMemberRole(ctx context.Context, roomID, userID int64, role string) error {
+	return a.repo.UpdateMemberRole(ctx, roomID, userID, role)
+}
+
+// ListRoommateIDs returns the users sharing at least one room with the user
+func (a *RoomRepoAdapter) ListRoommateIDs(ctx context.Context, userID int64) ([]int64, error) {
+	return a.repo.ListRoommateIDs(ctx, userID)
 }
 
 // ChatRepoAdapter adapts the data layer MessageRepo to biz layer ChatRepo interface
 type ChatRepoAdapter struct {
-	repo MessageRepo
-	log  *log.Helper
+	repo    MessageRepo
+	storage *storage.MinioStorage
+	log     *log.Helper
 }
 
-// NewChatRepoAdapter creates a new chat repository adapter
-func NewChatRepoAdapter(repo MessageRepo, logger log.Logger) biz.ChatRepo {
+// NewChatRepoAdapter creates a new chat repository adapter.
+// store may be nil, in which case attachments are left in place on delete.
+func NewChatRepoAdapter(repo MessageRepo, store *storage.MinioStorage, logger log.Logger) biz.ChatRepo {
 	return &ChatRepoAdapter{
-		repo: repo,
-		log:  log.NewHelper(log.With(logger, "module", "data/chat_adapter")),
+		repo:    repo,
+		storage: store,
+		log:     log.NewHelper(log.With(logger, "module", "data/chat_adapter")),
 	}
 }
 
@@ -256,24 +351,34 @@ func (a *ChatRepoAdapter) SendMessage(ctx context.Context, message *biz.Message)
 		Username: message.Username,
 		Content:  message.Content,
 		Type:     message.Type,
+		FileUrl:  message.FileURL,
+		FileName: message.FileName,
+		FileSize: message.FileSize,
+		MimeType: message.MimeType,
+
+		ParentMessageId: message.ParentMessageID,
 	}
+	if message.ExpiresAt != nil {
+		dataMessage.ExpiresAt = message.ExpiresAt.Unix()
+	}
+	dataMessage.QuotedMessage = toProtoQuotedMessage(message.QuotedMessage)
+	dataMessage.IsForwarded = message.IsForwarded
+	dataMessage.Format = message.Format
+	dataMessage.ContentHtml = message.ContentHTML
+	dataMessage.Poll = toProtoPoll(message.Poll)
+	dataMessage.SystemEvent = toProtoSystemEvent(message.SystemEvent)
+	dataMessage.ClientMsgId = message.ClientMsgID
 
 	sentMessage, err := a.repo.CreateMessage(ctx, dataMessage)
+	if errors.Is(err, errDuplicateClientMsgID) {
+		return nil, biz.ErrDuplicateClientMsgID
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	// Convert back to biz.Message
-	return &biz.Message{
-		ID:        sentMessage.Id,
-		RoomID:    sentMessage.RoomId,
-		UserID:    sentMessage.UserId,
-		Username:  sentMessage.Username,
-		Content:   sentMessage.Content,
-		Type:      sentMessage.Type,
-		IsEdited:  sentMessage.IsEdited,
-		CreatedAt: time.Unix(sentMessage.CreatedAt, 0),
-	}, nil
+	return toBizMessage(sentMessage), nil
 }
 
 // GetMessage retrieves a message by ID
@@ -283,7 +388,558 @@ func (a *ChatRepoAdapter) GetMessage(ctx context.Context, messageID int64) (*biz
 		return nil, err
 	}
 
-	return &biz.Message{
+	return toBizMessage(message), nil
+}
+
+// GetMessageByClientMsgID retrieves the user's message sent with the client message ID
+func (a *ChatRepoAdapter) GetMessageByClientMsgID(ctx context.Context, userID int64, clientMsgID string) (*biz.Message, error) {
+	message, err := a.repo.GetMessageByClientMsgID(ctx, userID, clientMsgID)
+	if err != nil {
+		return nil, err
+	}
+	if message == nil {
+		return nil, biz.ErrMessageNotFound
+	}
+
+	return toBizMessage(message), nil
+}
+
+// ListRoomHistory lists a batch of a room's messages, thread replies included, oldest first
+func (a *ChatRepoAdapter) ListRoomHistory(ctx context.Context, roomID, afterID int64, limit int32) ([]*biz.Message, error) {
+	messages, err := a.repo.GetRoomHistory(ctx, roomID, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	bizMessages := make([]*biz.Message, 0, len(messages))
+	for _, message := range messages {
+		bizMessages = append(bizMessages, toBizMessage(message))
+	}
+	return bizMessages, nil
+}
+
+// ListMessages lists a page of messages in a room, newest first
+func (a *ChatRepoAdapter) ListMessages(ctx context.Context, roomID int64, limit int32, beforeID, afterID int64) ([]*biz.Message, bool, error) {
+	messages, hasMore, err := a.repo.GetMessages(ctx, roomID, limit, beforeID, afterID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	bizMessages := make([]*biz.Message, 0, len(messages))
+	for _, msg := range messages {
+		bizMessages = append(bizMessages, toBizMessage(msg))
+	}
+
+	return bizMessages, hasMore, nil
+}
+
+// ListThreadReplies lists replies to a thread root, oldest first
+func (a *ChatRepoAdapter) ListThreadReplies(ctx context.Context, parentID int64, limit int32, afterID int64) ([]*biz.Message, bool, error) {
+	replies, hasMore, err := a.repo.GetThreadReplies(ctx, parentID, limit, afterID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	bizReplies := make([]*biz.Message, 0, len(replies))
+	for _, reply := range replies {
+		bizReplies = append(bizReplies, toBizMessage(reply))
+	}
+
+	return bizReplies, hasMore, nil
+}
+
+// SearchMessages searches messages in the user's rooms
+func (a *ChatRepoAdapter) SearchMessages(ctx context.Context, userID int64, filter *biz.SearchFilter, limit int32) ([]*biz.SearchResult, bool, error) {
+	dataFilter := &MessageSearchFilter{
+		Query:    filter.Query,
+		RoomID:   filter.RoomID,
+		AuthorID: filter.AuthorID,
+		Type:     filter.Type,
+		FileName: filter.FileName,
+		BeforeID: filter.BeforeID,
+	}
+	if filter.From != nil {
+		dataFilter.From = *filter.From
+	}
+	if filter.To != nil {
+		dataFilter.To = *filter.To
+	}
+
+	results, hasMore, err := a.repo.SearchMessages(ctx, userID, dataFilter, limit)
+	if err != nil {
+		return nil, false, err
+	}
+
+	bizResults := make([]*biz.SearchResult, 0, len(results))
+	for _, result := range results {
+		bizResults = append(bizResults, &biz.SearchResult{
+			Message: toBizMessage(result.Message),
+			Snippet: result.Snippet,
+		})
+	}
+
+	return bizResults, hasMore, nil
+}
+
+// EditMessage edits a message content and records the previous version
+func (a *ChatRepoAdapter) EditMessage(ctx context.Context, messageID int64, content, contentHTML string) error {
+	_, err := a.repo.UpdateMessageContent(ctx, messageID, content, contentHTML)
+	return err
+}
+
+// DeleteMessage turns a message into a tombstone and removes its attachment
+func (a *ChatRepoAdapter) DeleteMessage(ctx context.Context, messageID, deletedBy int64) error {
+	fileURL, err := a.repo.SoftDeleteMessage(ctx, messageID, deletedBy)
+	if err != nil {
+		return err
+	}
+
+	// The tombstone is already committed, so a failed cleanup only leaves an orphaned object
+	if fileURL != "" && a.storage != nil {
+		if err := a.storage.DeleteFile(ctx, fileURL); err != nil {
+			a.log.Warnf("failed to delete attachment of message %d: %v", messageID, err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteExpiredMessages hard-deletes expired messages and their attachments
+func (a *ChatRepoAdapter) DeleteExpiredMessages(ctx context.Context, now time.Time, limit int32) ([]*biz.Message, error) {
+	deleted, err := a.repo.DeleteExpiredMessages(ctx, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	bizMessages := make([]*biz.Message, 0, len(deleted))
+	for _, message := range deleted {
+		// The rows are already gone, so a failed cleanup only leaves an orphaned object
+		if message.FileUrl != "" && a.storage != nil {
+			if err := a.storage.DeleteFile(ctx, message.FileUrl); err != nil {
+				a.log.Warnf("failed to delete attachment of expired message %d: %v", message.Id, err)
+			}
+		}
+		bizMessages = append(bizMessages, toBizMessage(message))
+	}
+
+	return bizMessages, nil
+}
+
+// DeleteRetainedMessages hard-deletes messages past their room's retention
+// policy and the attachments no surviving message refers to
+func (a *ChatRepoAdapter) DeleteRetainedMessages(ctx context.Context, now time.Time, defaultPolicy biz.RetentionPolicy, limit int32) ([]*biz.Message, error) {
+	deleted, err := a.repo.DeleteRetainedMessages(ctx, now, &chatV1.RetentionPolicy{Mode: defaultPolicy.Mode, Value: defaultPolicy.Value}, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	bizMessages := make([]*biz.Message, 0, len(deleted))
+	for _, message := range deleted {
+		// The rows are already gone, so a failed cleanup only leaves an orphaned object
+		if message.FileUrl != "" && a.storage != nil {
+			if err := a.storage.DeleteFile(ctx, message.FileUrl); err != nil {
+				a.log.Warnf("failed to delete attachment of purged message %d: %v", message.Id, err)
+			} else {
+				metrics.RecordAttachmentPurged()
+			}
+		}
+		bizMessages = append(bizMessages, toBizMessage(message))
+	}
+
+	return bizMessages, nil
+}
+
+// AddReaction adds a user's emoji reaction to a message
+func (a *ChatRepoAdapter) AddReaction(ctx context.Context, messageID, userID int64, emoji string) (bool, error) {
+	return a.repo.AddReaction(ctx, messageID, userID, emoji)
+}
+
+// RemoveReaction removes a user's emoji reaction from a message
+func (a *ChatRepoAdapter) RemoveReaction(ctx context.Context, messageID, userID int64, emoji string) (bool, error) {
+	return a.repo.RemoveReaction(ctx, messageID, userID, emoji)
+}
+
+// ListReactions returns aggregated reactions keyed by message ID
+func (a *ChatRepoAdapter) ListReactions(ctx context.Context, messageIDs []int64, userID int64) (map[int64][]*biz.Reaction, error) {
+	reactions, err := a.repo.GetReactions(ctx, messageIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	bizReactions := make(map[int64][]*biz.Reaction, len(reactions))
+	for messageID, messageReactions := range reactions {
+		for _, reaction := range messageReactions {
+			bizReactions[messageID] = append(bizReactions[messageID], &biz.Reaction{
+				Emoji:       reaction.Emoji,
+				Count:       reaction.Count,
+				ReactedByMe: reaction.ReactedByMe,
+			})
+		}
+	}
+
+	return bizReactions, nil
+}
+
+// QueueLinkPreviews queues a message for the unfurler
+func (a *ChatRepoAdapter) QueueLinkPreviews(ctx context.Context, messageID int64) error {
+	return a.repo.QueueLinkPreviews(ctx, messageID)
+}
+
+// ClaimLinkPreviewJobs claims queued messages for unfurling
+func (a *ChatRepoAdapter) ClaimLinkPreviewJobs(ctx context.Context, now, reclaimBefore time.Time, limit int32) ([]int64, error) {
+	return a.repo.ClaimLinkPreviewJobs(ctx, now, reclaimBefore, limit)
+}
+
+// CompleteLinkPreviewJob removes an unfurled message from the queue
+func (a *ChatRepoAdapter) CompleteLinkPreviewJob(ctx context.Context, messageID int64, claimedAt time.Time) error {
+	return a.repo.CompleteLinkPreviewJob(ctx, messageID, claimedAt)
+}
+
+// SaveLinkPreviews replaces a message's link previews
+func (a *ChatRepoAdapter) SaveLinkPreviews(ctx context.Context, messageID int64, previews []*biz.LinkPreview) error {
+	dataPreviews := make([]*chatV1.LinkPreview, 0, len(previews))
+	for _, preview := range previews {
+		dataPreviews = append(dataPreviews, &chatV1.LinkPreview{
+			Url:         preview.URL,
+			Title:       preview.Title,
+			Description: preview.Description,
+			ImageUrl:    preview.ImageURL,
+			SiteName:    preview.SiteName,
+		})
+	}
+	return a.repo.SaveLinkPreviews(ctx, messageID, dataPreviews)
+}
+
+// ListLinkPreviews returns link previews keyed by message ID
+func (a *ChatRepoAdapter) ListLinkPreviews(ctx context.Context, messageIDs []int64) (map[int64][]*biz.LinkPreview, error) {
+	previews, err := a.repo.GetLinkPreviews(ctx, messageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	bizPreviews := make(map[int64][]*biz.LinkPreview, len(previews))
+	for messageID, messagePreviews := range previews {
+		for _, preview := range messagePreviews {
+			bizPreviews[messageID] = append(bizPreviews[messageID], &biz.LinkPreview{
+				URL:         preview.Url,
+				Title:       preview.Title,
+				Description: preview.Description,
+				ImageURL:    preview.ImageUrl,
+				SiteName:    preview.SiteName,
+			})
+		}
+	}
+
+	return bizPreviews, nil
+}
+
+// ListPolls returns polls with their tallies keyed by message ID
+func (a *ChatRepoAdapter) ListPolls(ctx context.Context, messageIDs []int64, userID int64) (map[int64]*biz.Poll, error) {
+	polls, err := a.repo.GetPolls(ctx, messageIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	bizPolls := make(map[int64]*biz.Poll, len(polls))
+	for messageID, poll := range polls {
+		bizPoll := toBizPoll(poll)
+		bizPoll.MessageID = messageID
+		bizPolls[messageID] = bizPoll
+	}
+
+	return bizPolls, nil
+}
+
+// SetPollVotes replaces a user's votes in an open poll
+func (a *ChatRepoAdapter) SetPollVotes(ctx context.Context, messageID, userID int64, optionIDs []int32) (bool, error) {
+	return a.repo.SetPollVotes(ctx, messageID, userID, optionIDs)
+}
+
+// ClosePoll closes a poll
+func (a *ChatRepoAdapter) ClosePoll(ctx context.Context, messageID int64, closedAt time.Time) (bool, error) {
+	return a.repo.ClosePoll(ctx, messageID, closedAt)
+}
+
+// CloseDuePolls closes polls whose close time has passed
+func (a *ChatRepoAdapter) CloseDuePolls(ctx context.Context, now time.Time, limit int32) ([]int64, error) {
+	return a.repo.CloseDuePolls(ctx, now, limit)
+}
+
+// CreateMentions stores the mentions of a message
+func (a *ChatRepoAdapter) CreateMentions(ctx context.Context, mentions []*biz.Mention) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	mentionTypes := make(map[int64]string, len(mentions))
+	for _, mention := range mentions {
+		mentionTypes[mention.UserID] = mention.Type
+	}
+
+	return a.repo.CreateMentions(ctx, mentions[0].MessageID, mentions[0].RoomID, mentionTypes)
+}
+
+// ListUnreadMentions lists a user's unread mentions with their messages
+func (a *ChatRepoAdapter) ListUnreadMentions(ctx context.Context, userID int64, limit int32, beforeID int64) ([]*biz.Mention, bool, error) {
+	mentions, hasMore, err := a.repo.GetUnreadMentions(ctx, userID, limit, beforeID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	bizMentions := make([]*biz.Mention, 0, len(mentions))
+	for _, mention := range mentions {
+		message := toBizMessage(mention.Message)
+		bizMentions = append(bizMentions, &biz.Mention{
+			ID:        mention.Id,
+			MessageID: message.ID,
+			RoomID:    message.RoomID,
+			UserID:    userID,
+			Type:      mention.MentionType,
+			CreatedAt: time.Unix(mention.CreatedAt, 0),
+			Message:   message,
+		})
+	}
+
+	return bizMentions, hasMore, nil
+}
+
+// PinMessage pins a message to its room
+func (a *ChatRepoAdapter) PinMessage(ctx context.Context, roomID, messageID, pinnedBy int64, maxPins int) (bool, error) {
+	pinned, err := a.repo.PinMessage(ctx, roomID, messageID, pinnedBy, maxPins)
+	if errors.Is(err, errPinLimitReached) {
+		return false, biz.ErrPinLimitReached
+	}
+	return pinned, err
+}
+
+// UnpinMessage removes a message's pin
+func (a *ChatRepoAdapter) UnpinMessage(ctx context.Context, roomID, messageID int64) (bool, error) {
+	return a.repo.UnpinMessage(ctx, roomID, messageID)
+}
+
+// ListPins lists a room's pins with their messages
+func (a *ChatRepoAdapter) ListPins(ctx context.Context, roomID int64) ([]*biz.Pin, error) {
+	pins, err := a.repo.GetPins(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	bizPins := make([]*biz.Pin, 0, len(pins))
+	for _, pin := range pins {
+		bizPins = append(bizPins, &biz.Pin{
+			Message:          toBizMessage(pin.Message),
+			PinnedBy:         pin.PinnedBy,
+			PinnedByUsername: pin.PinnedByUsername,
+			PinnedAt:         time.Unix(pin.PinnedAt, 0),
+		})
+	}
+
+	return bizPins, nil
+}
+
+// CreateScheduledMessage stores a pending scheduled message
+func (a *ChatRepoAdapter) CreateScheduledMessage(ctx context.Context, scheduled *biz.ScheduledMessage) (*biz.ScheduledMessage, error) {
+	created, err := a.repo.CreateScheduledMessage(ctx, &chatV1.ScheduledMessage{
+		UserId:  scheduled.UserID,
+		Message: scheduled.Request,
+		SendAt:  scheduled.SendAt.Unix(),
+		Status:  scheduled.Status,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toBizScheduledMessage(created), nil
+}
+
+// ListPendingScheduledMessages lists a user's pending scheduled messages
+func (a *ChatRepoAdapter) ListPendingScheduledMessages(ctx context.Context, userID, roomID int64) ([]*biz.ScheduledMessage, error) {
+	scheduled, err := a.repo.GetPendingScheduledMessages(ctx, userID, roomID)
+	if err != nil {
+		return nil, err
+	}
+	return toBizScheduledMessages(scheduled), nil
+}
+
+// CancelScheduledMessage cancels a user's pending scheduled message
+func (a *ChatRepoAdapter) CancelScheduledMessage(ctx context.Context, id, userID int64) (bool, error) {
+	return a.repo.CancelScheduledMessage(ctx, id, userID)
+}
+
+// ClaimDueScheduledMessages claims due scheduled messages for sending
+func (a *ChatRepoAdapter) ClaimDueScheduledMessages(ctx context.Context, now, reclaimBefore time.Time, limit int32) ([]*biz.ScheduledMessage, error) {
+	claimed, err := a.repo.ClaimDueScheduledMessages(ctx, now, reclaimBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toBizScheduledMessages(claimed), nil
+}
+
+// CompleteScheduledMessage records whether a claimed message was sent
+func (a *ChatRepoAdapter) CompleteScheduledMessage(ctx context.Context, id, messageID int64, failure string) error {
+	return a.repo.CompleteScheduledMessage(ctx, id, messageID, failure)
+}
+
+// MarkRoomRead moves a member's read cursor forward to a message
+func (a *ChatRepoAdapter) MarkRoomRead(ctx context.Context, roomID, userID, messageID int64, readAt time.Time) (bool, error) {
+	return a.repo.MarkRoomRead(ctx, roomID, userID, messageID, readAt)
+}
+
+// GetLastReadMessageID returns a member's read cursor in a room
+func (a *ChatRepoAdapter) GetLastReadMessageID(ctx context.Context, roomID, userID int64) (int64, error) {
+	return a.repo.GetLastReadMessageID(ctx, roomID, userID)
+}
+
+// CountUnread counts the messages past a member's read cursor
+func (a *ChatRepoAdapter) CountUnread(ctx context.Context, roomID, userID int64) (int32, error) {
+	return a.repo.CountUnread(ctx, roomID, userID)
+}
+
+// ListReadCursors lists every member's read cursor in a room
+func (a *ChatRepoAdapter) ListReadCursors(ctx context.Context, roomID int64) ([]*biz.ReadCursor, error) {
+	cursors, err := a.repo.GetReadCursors(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	bizCursors := make([]*biz.ReadCursor, 0, len(cursors))
+	for _, cursor := range cursors {
+		bizCursor := &biz.ReadCursor{
+			UserID:            cursor.UserId,
+			Username:          cursor.Username,
+			LastReadMessageID: cursor.LastReadMessageId,
+		}
+		if cursor.ReadAt != 0 {
+			readAt := time.Unix(cursor.ReadAt, 0)
+			bizCursor.ReadAt = &readAt
+		}
+		bizCursors = append(bizCursors, bizCursor)
+	}
+	return bizCursors, nil
+}
+
+// ListUnreadMessages lists the messages past a member's read cursor, oldest first
+func (a *ChatRepoAdapter) ListUnreadMessages(ctx context.Context, roomID, userID int64, limit int32) ([]*biz.Message, bool, error) {
+	messages, hasMore, err := a.repo.GetUnreadMessages(ctx, roomID, userID, limit)
+	if err != nil {
+		return nil, false, err
+	}
+
+	bizMessages := make([]*biz.Message, 0, len(messages))
+	for _, message := range messages {
+		bizMessages = append(bizMessages, toBizMessage(message))
+	}
+	return bizMessages, hasMore, nil
+}
+
+// ListRoomUnreads lists a user's unread state in each of their rooms
+func (a *ChatRepoAdapter) ListRoomUnreads(ctx context.Context, userID int64, maxCount int32) ([]*biz.RoomUnread, error) {
+	rooms, err := a.repo.GetRoomUnreads(ctx, userID, maxCount)
+	if err != nil {
+		return nil, err
+	}
+
+	bizRooms := make([]*biz.RoomUnread, 0, len(rooms))
+	for _, room := range rooms {
+		bizRooms = append(bizRooms, &biz.RoomUnread{
+			RoomID:               room.RoomId,
+			UnreadCount:          room.UnreadCount,
+			MentionCount:         room.MentionCount,
+			FirstUnreadMessageID: room.FirstUnreadMessageId,
+		})
+	}
+	return bizRooms, nil
+}
+
+// ImportRepoAdapter adapts the data layer ImportRepo to biz layer ImportRepo interface
+type ImportRepoAdapter struct {
+	repo    ImportRepo
+	storage *storage.MinioStorage
+	log     *log.Helper
+}
+
+// NewImportRepoAdapter creates a new import repository adapter.
+// store may be nil, in which case attachments can't be imported.
+func NewImportRepoAdapter(repo ImportRepo, store *storage.MinioStorage, logger log.Logger) biz.ImportRepo {
+	return &ImportRepoAdapter{
+		repo:    repo,
+		storage: store,
+		log:     log.NewHelper(log.With(logger, "module", "data/import_adapter")),
+	}
+}
+
+// UpsertImportedRoom creates or finds the room imported under key
+func (a *ImportRepoAdapter) UpsertImportedRoom(ctx context.Context, key string, room *biz.Room) (int64, error) {
+	return a.repo.UpsertImportedRoom(ctx, key, &chatV1.Room{
+		Name:        room.Name,
+		Description: room.Description,
+		Type:        room.Type,
+		CreatedBy:   room.CreatedBy,
+	})
+}
+
+// AddImportedMembers adds users to an imported room
+func (a *ImportRepoAdapter) AddImportedMembers(ctx context.Context, roomID int64, userIDs []int64, role string) error {
+	return a.repo.AddImportedMembers(ctx, roomID, userIDs, role)
+}
+
+// GetImportedMessageIDs maps imported keys to message IDs
+func (a *ImportRepoAdapter) GetImportedMessageIDs(ctx context.Context, keys []string) (map[string]int64, error) {
+	return a.repo.GetImportedMessageIDs(ctx, keys)
+}
+
+// CreateImportedMessages stores imported messages under their keys
+func (a *ImportRepoAdapter) CreateImportedMessages(ctx context.Context, messages []*biz.ImportedMessage) (int, error) {
+	keys := make([]string, 0, len(messages))
+	dataMessages := make([]*chatV1.Message, 0, len(messages))
+	for _, imported := range messages {
+		message := imported.Message
+		keys = append(keys, imported.Key)
+		dataMessages = append(dataMessages, &chatV1.Message{
+			RoomId:          message.RoomID,
+			UserId:          message.UserID,
+			Content:         message.Content,
+			Type:            message.Type,
+			FileUrl:         message.FileURL,
+			FileName:        message.FileName,
+			FileSize:        message.FileSize,
+			MimeType:        message.MimeType,
+			ParentMessageId: message.ParentMessageID,
+			CreatedAt:       message.CreatedAt.Unix(),
+		})
+	}
+	return a.repo.CreateImportedMessages(ctx, keys, dataMessages)
+}
+
+// UploadImportedFile uploads an imported attachment, counting its bytes
+// when the source didn't say how big it is
+func (a *ImportRepoAdapter) UploadImportedFile(ctx context.Context, reader io.Reader, fileName string, fileSize int64, mimeType string) (string, int64, error) {
+	if a.storage == nil {
+		return "", 0, errors.New("file storage is not configured")
+	}
+
+	counter := &countingReader{reader: reader}
+	info, err := a.storage.UploadFile(ctx, counter, fileName, fileSize, mimeType)
+	if err != nil {
+		return "", 0, err
+	}
+	return info.URL, counter.n, nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	reader io.Reader
+	n      int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// toBizMessage converts a data layer message to the biz entity
+func toBizMessage(message *chatV1.Message) *biz.Message {
+	bizMessage := &biz.Message{
 		ID:        message.Id,
 		RoomID:    message.RoomId,
 		UserID:    message.UserId,
@@ -292,66 +948,186 @@ func (a *ChatRepoAdapter) GetMessage(ctx context.Context, messageID int64) (*biz
 		Type:      message.Type,
 		IsEdited:  message.IsEdited,
 		CreatedAt: time.Unix(message.CreatedAt, 0),
-	}, nil
-}
+		FileURL:   message.FileUrl,
+		FileName:  message.FileName,
+		FileSize:  message.FileSize,
+		MimeType:  message.MimeType,
 
-// ListMessages lists messages in a room
-func (a *ChatRepoAdapter) ListMessages(ctx context.Context, roomID int64, limit, offset int32) ([]*biz.Message, int32, error) {
-	// Use GetMessages with beforeID = 0 to get latest messages
-	messages, hasMore, err := a.repo.GetMessages(ctx, roomID, limit, 0)
-	if err != nil {
-		return nil, 0, err
+		ParentMessageID: message.ParentMessageId,
+		ReplyCount:      message.ReplyCount,
+		QuotedMessage:   toBizQuotedMessage(message.QuotedMessage),
+		IsForwarded:     message.IsForwarded,
+		Format:          message.Format,
+		ContentHTML:     message.ContentHtml,
+		Poll:            toBizPoll(message.Poll),
+		SystemEvent:     toBizSystemEvent(message.SystemEvent),
+		ClientMsgID:     message.ClientMsgId,
+	}
+	if bizMessage.Poll != nil {
+		bizMessage.Poll.MessageID = message.Id
+	}
+	if message.EditedAt != 0 {
+		editedAt := time.Unix(message.EditedAt, 0)
+		bizMessage.EditedAt = &editedAt
+	}
+	if message.IsDeleted {
+		deletedAt := time.Unix(message.DeletedAt, 0)
+		bizMessage.IsDeleted = true
+		bizMessage.DeletedAt = &deletedAt
+	}
+	if message.LastReplyAt != 0 {
+		lastReplyAt := time.Unix(message.LastReplyAt, 0)
+		bizMessage.LastReplyAt = &lastReplyAt
+	}
+	if message.ExpiresAt != 0 {
+		expiresAt := time.Unix(message.ExpiresAt, 0)
+		bizMessage.ExpiresAt = &expiresAt
 	}
+	return bizMessage
+}
 
-	var bizMessages []*biz.Message
-	for _, msg := range messages {
-		bizMessages = append(bizMessages, &biz.Message{
-			ID:        msg.Id,
-			RoomID:    msg.RoomId,
-			UserID:    msg.UserId,
-			Username:  msg.Username,
-			Content:   msg.Content,
-			Type:      msg.Type,
-			IsEdited:  msg.IsEdited,
-			CreatedAt: time.Unix(msg.CreatedAt, 0),
-		})
+// toBizQuotedMessage converts a stored quote snapshot to the biz entity
+func toBizQuotedMessage(quoted *chatV1.QuotedMessage) *biz.QuotedMessage {
+	if quoted == nil {
+		return nil
 	}
+	return &biz.QuotedMessage{
+		MessageID: quoted.MessageId,
+		RoomID:    quoted.RoomId,
+		UserID:    quoted.UserId,
+		Username:  quoted.Username,
+		Content:   quoted.Content,
+		Type:      quoted.Type,
+		FileURL:   quoted.FileUrl,
+		FileName:  quoted.FileName,
+		FileSize:  quoted.FileSize,
+		MimeType:  quoted.MimeType,
+		CreatedAt: time.Unix(quoted.CreatedAt, 0),
+	}
+}
 
-	// For now, return length as total (could be improved with separate count query)
-	total := int32(len(bizMessages))
-	if hasMore {
-		total += 1 // Indicate there are more
+// toProtoQuotedMessage converts a biz quote snapshot for storage and publishing
+func toProtoQuotedMessage(quoted *biz.QuotedMessage) *chatV1.QuotedMessage {
+	if quoted == nil {
+		return nil
 	}
+	return &chatV1.QuotedMessage{
+		MessageId: quoted.MessageID,
+		RoomId:    quoted.RoomID,
+		UserId:    quoted.UserID,
+		Username:  quoted.Username,
+		Content:   quoted.Content,
+		Type:      quoted.Type,
+		FileUrl:   quoted.FileURL,
+		FileName:  quoted.FileName,
+		FileSize:  quoted.FileSize,
+		MimeType:  quoted.MimeType,
+		CreatedAt: quoted.CreatedAt.Unix(),
+	}
+}
 
-	return bizMessages, total, nil
+// toBizPoll converts a data layer poll to the biz entity
+func toBizPoll(poll *chatV1.Poll) *biz.Poll {
+	if poll == nil {
+		return nil
+	}
+	bizPoll := &biz.Poll{
+		Question:       poll.Question,
+		MultipleChoice: poll.MultipleChoice,
+		Anonymous:      poll.Anonymous,
+		VoterCount:     poll.VoterCount,
+	}
+	for _, option := range poll.Options {
+		bizPoll.Options = append(bizPoll.Options, &biz.PollOption{
+			ID:        option.Id,
+			Text:      option.Text,
+			VoteCount: option.VoteCount,
+			VotedByMe: option.VotedByMe,
+			VoterIDs:  option.VoterIds,
+		})
+	}
+	if poll.ClosesAt != 0 {
+		closesAt := time.Unix(poll.ClosesAt, 0)
+		bizPoll.ClosesAt = &closesAt
+	}
+	if poll.ClosedAt != 0 {
+		closedAt := time.Unix(poll.ClosedAt, 0)
+		bizPoll.ClosedAt = &closedAt
+	}
+	return bizPoll
 }
 
-// EditMessage edits a message content
-func (a *ChatRepoAdapter) EditMessage(ctx context.Context, messageID int64, content string) error {
-	// This method doesn't exist in MessageRepo, return not implemented
-	return fmt.Errorf("edit message not implemented in data layer")
+// toProtoPoll converts a biz poll for storage
+func toProtoPoll(poll *biz.Poll) *chatV1.Poll {
+	if poll == nil {
+		return nil
+	}
+	protoPoll := &chatV1.Poll{
+		Question:       poll.Question,
+		MultipleChoice: poll.MultipleChoice,
+		Anonymous:      poll.Anonymous,
+		VoterCount:     poll.VoterCount,
+	}
+	for _, option := range poll.Options {
+		protoPoll.Options = append(protoPoll.Options, &chatV1.PollOption{
+			Id:        option.ID,
+			Text:      option.Text,
+			VoteCount: option.VoteCount,
+		})
+	}
+	if poll.ClosesAt != nil {
+		protoPoll.ClosesAt = poll.ClosesAt.Unix()
+	}
+	if poll.ClosedAt != nil {
+		protoPoll.ClosedAt = poll.ClosedAt.Unix()
+	}
+	return protoPoll
 }
 
-// DeleteMessage deletes a message
-func (a *ChatRepoAdapter) DeleteMessage(ctx context.Context, messageID int64) error {
-	// This method doesn't exist in MessageRepo, return not implemented
-	return fmt.Errorf("delete message not implemented in data layer")
+// toBizSystemEvent converts a stored system event to the biz entity
+func toBizSystemEvent(event *chatV1.SystemEvent) *biz.SystemEvent {
+	if event == nil {
+		return nil
+	}
+	return &biz.SystemEvent{
+		Kind:     event.Kind,
+		ActorID:  event.ActorId,
+		TargetID: event.TargetId,
+		Details:  event.Details,
+	}
 }
 
-// MarkMessageAsRead marks a message as read by a user
-func (a *ChatRepoAdapter) MarkMessageAsRead(ctx context.Context, messageID, userID int64) error {
-	return a.repo.MarkAsRead(ctx, messageID, userID)
+// toProtoSystemEvent converts a biz system event for storage
+func toProtoSystemEvent(event *biz.SystemEvent) *chatV1.SystemEvent {
+	if event == nil {
+		return nil
+	}
+	return &chatV1.SystemEvent{
+		Kind:     event.Kind,
+		ActorId:  event.ActorID,
+		TargetId: event.TargetID,
+		Details:  event.Details,
+	}
 }
 
-// GetUnreadMessages gets unread messages for a user in a room
-func (a *ChatRepoAdapter) GetUnreadMessages(ctx context.Context, roomID, userID int64) ([]*biz.Message, error) {
-	// The current MessageRepo doesn't have this method, let's use GetUnreadCount for now
-	// and return empty slice - this needs to be implemented in the data layer
-	_, err := a.repo.GetUnreadCount(ctx, userID, roomID)
-	if err != nil {
-		return nil, err
+// toBizScheduledMessage converts a data layer scheduled message to the biz entity
+func toBizScheduledMessage(scheduled *chatV1.ScheduledMessage) *biz.ScheduledMessage {
+	return &biz.ScheduledMessage{
+		ID:        scheduled.Id,
+		UserID:    scheduled.UserId,
+		Request:   scheduled.Message,
+		SendAt:    time.Unix(scheduled.SendAt, 0),
+		Status:    scheduled.Status,
+		MessageID: scheduled.MessageId,
+		CreatedAt: time.Unix(scheduled.CreatedAt, 0),
 	}
+}
 
-	// Return empty slice for now - this method needs proper implementation
-	return []*biz.Message{}, nil
+// toBizScheduledMessages converts a list of data layer scheduled messages
+func toBizScheduledMessages(scheduled []*chatV1.ScheduledMessage) []*biz.ScheduledMessage {
+	bizScheduled := make([]*biz.ScheduledMessage, 0, len(scheduled))
+	for _, message := range scheduled {
+		bizScheduled = append(bizScheduled, toBizScheduledMessage(message))
+	}
+	return bizScheduled
 }
@@ -20,6 +20,9 @@ var ProviderSet = wire.NewSet(
 	NewUserRepo,
 	NewRoomRepo,
 	NewMessageRepo,
+	NewEventPublisher,
+	NewPresenceRepo,
+	NewLinkUnfurler,
 	// Biz adapters
 	NewUserRepoAdapter,
 	NewRoomRepoAdapter,
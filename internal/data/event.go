@@ -0,0 +1,224 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/biz"
+	"github.com/yourusername/chat-app/internal/metrics"
+)
+
+// roomEventPayload is published on the room:%d channel.
+// The WebSocket hub decodes it as a RedisMessage with Event set.
+type roomEventPayload struct {
+	Event   string                 `json:"event"`
+	RoomID  int64                  `json:"room_id"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+	UserIDs []int64                `json:"user_ids,omitempty"`
+}
+
+// newMessagePayload is published on the room:%d channel for new messages.
+// It matches the RedisMessage the WebSocket send path publishes.
+type newMessagePayload struct {
+	RoomID    int64  `json:"room_id"`
+	MessageID int64  `json:"message_id,omitempty"`
+	UserID    int64  `json:"user_id"`
+	Username  string `json:"username"`
+	Content   string `json:"content"`
+	CreatedAt int64  `json:"created_at,omitempty"`
+	Type      string `json:"type,omitempty"`
+	FileURL   string `json:"file_url,omitempty"`
+	FileName  string `json:"file_name,omitempty"`
+	FileSize  int64  `json:"file_size,omitempty"`
+	MimeType  string `json:"mime_type,omitempty"`
+	ExpiresAt int64  `json:"expires_at,omitempty"`
+
+	QuotedMessage *chatV1.QuotedMessage `json:"quoted_message,omitempty"`
+	IsForwarded   bool                  `json:"is_forwarded,omitempty"`
+	Format        string                `json:"format,omitempty"`
+	ContentHTML   string                `json:"content_html,omitempty"`
+	Poll          *chatV1.Poll          `json:"poll,omitempty"`
+	SystemEvent   *chatV1.SystemEvent   `json:"system_event,omitempty"`
+	ClientMsgID   string                `json:"client_msg_id,omitempty"`
+}
+
+type eventPublisher struct {
+	data *Data
+	log  *log.Helper
+}
+
+// NewEventPublisher creates a Redis Pub/Sub backed room event publisher
+func NewEventPublisher(data *Data, logger log.Logger) biz.EventPublisher {
+	return &eventPublisher{
+		data: data,
+		log:  log.NewHelper(log.With(logger, "module", "data/event")),
+	}
+}
+
+// PublishRoomEvent publishes an event to every chat instance subscribed to the room
+func (p *eventPublisher) PublishRoomEvent(ctx context.Context, event *biz.RoomEvent) error {
+	if p.data.redis == nil {
+		return fmt.Errorf("redis not available")
+	}
+
+	payload, err := json.Marshal(roomEventPayload{
+		Event:   event.Type,
+		RoomID:  event.RoomID,
+		Data:    event.Data,
+		UserIDs: event.UserIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode room event: %w", err)
+	}
+
+	redisStart := time.Now()
+	channel := fmt.Sprintf("room:%d", event.RoomID)
+	if err := p.data.redis.Publish(ctx, channel, payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish room event: %w", err)
+	}
+	metrics.RecordRedisOperation("publish_event", redisStart)
+
+	p.log.Infof("published %s event to %s", event.Type, channel)
+	return nil
+}
+
+// PublishMessage publishes a new message to every chat instance subscribed to the room
+func (p *eventPublisher) PublishMessage(ctx context.Context, message *biz.Message) error {
+	if p.data.redis == nil {
+		return fmt.Errorf("redis not available")
+	}
+
+	var expiresAt int64
+	if message.ExpiresAt != nil {
+		expiresAt = message.ExpiresAt.Unix()
+	}
+
+	payload, err := json.Marshal(newMessagePayload{
+		RoomID:    message.RoomID,
+		MessageID: message.ID,
+		UserID:    message.UserID,
+		Username:  message.Username,
+		Content:   message.Content,
+		CreatedAt: message.CreatedAt.Unix(),
+		Type:      message.Type,
+		FileURL:   message.FileURL,
+		FileName:  message.FileName,
+		FileSize:  message.FileSize,
+		MimeType:  message.MimeType,
+		ExpiresAt: expiresAt,
+
+		QuotedMessage: toProtoQuotedMessage(message.QuotedMessage),
+		IsForwarded:   message.IsForwarded,
+		Format:        message.Format,
+		ContentHTML:   message.ContentHTML,
+		Poll:          toProtoPoll(message.Poll),
+		SystemEvent:   toProtoSystemEvent(message.SystemEvent),
+		ClientMsgID:   message.ClientMsgID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	redisStart := time.Now()
+	channel := fmt.Sprintf("room:%d", message.RoomID)
+	if err := p.data.redis.Publish(ctx, channel, payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+	metrics.RecordRedisOperation("publish_message", redisStart)
+
+	p.log.Infof("published message %d to %s", message.ID, channel)
+	return nil
+}
+
+// messageSubscriptionBuffer is how many new messages a subscriber may fall
+// behind before its subscription is dropped
+const messageSubscriptionBuffer = 256
+
+// subscribedPayload is anything published on a room channel; only new
+// messages (no event, with an ID) are passed to subscribers
+type subscribedPayload struct {
+	newMessagePayload
+	Event   string  `json:"event,omitempty"`
+	UserIDs []int64 `json:"user_ids,omitempty"`
+}
+
+// SubscribeMessages subscribes to the room's channel on a dedicated Redis
+// connection, held until ctx ends or the subscriber falls behind
+func (p *eventPublisher) SubscribeMessages(ctx context.Context, roomID int64) (<-chan *biz.Message, error) {
+	if p.data.redis == nil {
+		return nil, fmt.Errorf("redis not available")
+	}
+
+	channel := fmt.Sprintf("room:%d", roomID)
+	pubsub := p.data.redis.Subscribe(ctx, channel)
+	// Wait for the subscription, so anything published from now on is received
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", channel, err)
+	}
+
+	messages := make(chan *biz.Message, messageSubscriptionBuffer)
+	go func() {
+		defer close(messages)
+		defer func() { _ = pubsub.Close() }()
+
+		received := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-received:
+				if !ok {
+					return
+				}
+
+				var payload subscribedPayload
+				if err := json.Unmarshal([]byte(msg.Payload), &payload); err != nil {
+					p.log.Warnf("Skipping undecodable payload on %s: %v", channel, err)
+					continue
+				}
+				if payload.Event != "" || len(payload.UserIDs) > 0 || payload.MessageID == 0 {
+					continue
+				}
+
+				select {
+				case messages <- toBizMessage(payload.toProto()):
+				default:
+					p.log.Warnf("Dropping subscriber of %s that fell %d messages behind", channel, messageSubscriptionBuffer)
+					return
+				}
+			}
+		}
+	}()
+
+	return messages, nil
+}
+
+// toProto converts a published new message back to the stored message form
+func (m *newMessagePayload) toProto() *chatV1.Message {
+	return &chatV1.Message{
+		Id:            m.MessageID,
+		RoomId:        m.RoomID,
+		UserId:        m.UserID,
+		Username:      m.Username,
+		Content:       m.Content,
+		Type:          m.Type,
+		CreatedAt:     m.CreatedAt,
+		FileUrl:       m.FileURL,
+		FileName:      m.FileName,
+		FileSize:      m.FileSize,
+		MimeType:      m.MimeType,
+		ExpiresAt:     m.ExpiresAt,
+		QuotedMessage: m.QuotedMessage,
+		IsForwarded:   m.IsForwarded,
+		Format:        m.Format,
+		ContentHtml:   m.ContentHTML,
+		Poll:          m.Poll,
+		SystemEvent:   m.SystemEvent,
+		ClientMsgId:   m.ClientMsgID,
+	}
+}
@@ -0,0 +1,158 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/metrics"
+)
+
+// DeleteExpiredMessages hard-deletes up to limit messages whose expiry has
+// passed, along with the replies of expired thread roots, and returns them.
+// SKIP LOCKED lets replicas reap disjoint batches.
+func (r *messageRepo) DeleteExpiredMessages(ctx context.Context, now time.Time, limit int32) ([]*chatV1.Message, error) {
+	query := `
+		WITH expired AS (
+			SELECT id FROM messages
+			WHERE expires_at <= $1
+			ORDER BY expires_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		DELETE FROM messages
+		WHERE id IN (SELECT id FROM expired) OR parent_message_id IN (SELECT id FROM expired)
+		RETURNING id, room_id, COALESCE(parent_message_id, 0), COALESCE(file_url, '')`
+
+	return r.hardDeleteMessages(ctx, "delete_expired_messages", query, now, limit)
+}
+
+// hardDeleteMessages runs a DELETE ... RETURNING id, room_id, parent_message_id,
+// file_url and returns the deleted messages. It keeps surviving thread roots'
+// reply counts and the message cache in step, and blanks the file URL of any
+// attachment a surviving message still shows, so the caller only deletes
+// orphaned objects.
+func (r *messageRepo) hardDeleteMessages(ctx context.Context, operation, query string, args ...interface{}) ([]*chatV1.Message, error) {
+	dbStart := time.Now()
+
+	tx, err := r.data.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete messages: %w", err)
+	}
+
+	var deleted []*chatV1.Message
+	deletedIDs := make(map[int64]bool)
+	for rows.Next() {
+		message := &chatV1.Message{}
+		if err := rows.Scan(&message.Id, &message.RoomId, &message.ParentMessageId, &message.FileUrl); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan deleted message: %w", err)
+		}
+		deleted = append(deleted, message)
+		deletedIDs[message.Id] = true
+	}
+	_ = rows.Close()
+
+	// Deleted replies no longer count towards their surviving roots
+	roots := make(map[int64]bool)
+	for _, message := range deleted {
+		if message.ParentMessageId == 0 || deletedIDs[message.ParentMessageId] {
+			continue
+		}
+		rootQuery := `UPDATE messages SET reply_count = GREATEST(reply_count - 1, 0) WHERE id = $1`
+		if _, err := tx.ExecContext(ctx, rootQuery, message.ParentMessageId); err != nil {
+			return nil, fmt.Errorf("failed to update thread root: %w", err)
+		}
+		roots[message.ParentMessageId] = true
+	}
+
+	// Quotes and forwards keep showing the attachment, so leave the object in place
+	sharedQuery := `
+		SELECT EXISTS(
+			SELECT 1 FROM messages
+			WHERE file_url = $1 OR (quoted_message IS NOT NULL AND quoted_message->>'file_url' = $1)
+		)`
+	seen := make(map[string]bool)
+	for _, message := range deleted {
+		if message.FileUrl == "" {
+			continue
+		}
+		if seen[message.FileUrl] {
+			message.FileUrl = "" // already returned with another deleted message
+			continue
+		}
+		seen[message.FileUrl] = true
+		var shared bool
+		if err := tx.QueryRowContext(ctx, sharedQuery, message.FileUrl).Scan(&shared); err != nil {
+			return nil, fmt.Errorf("failed to check shared attachment: %w", err)
+		}
+		if shared {
+			message.FileUrl = ""
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit deleted messages: %w", err)
+	}
+	metrics.RecordDBQuery(operation, dbStart)
+
+	if r.data.redis != nil && len(deleted) > 0 {
+		redisStart := time.Now()
+		r.evictCachedMessages(ctx, deleted)
+		for rootID := range roots {
+			if root, err := r.GetMessageByID(ctx, rootID); err == nil {
+				r.updateCachedMessage(ctx, root)
+			}
+		}
+		metrics.RecordRedisOperation("evict_messages", redisStart)
+	}
+
+	return deleted, nil
+}
+
+// evictCachedMessages removes messages from their rooms' recent message lists
+func (r *messageRepo) evictCachedMessages(ctx context.Context, messages []*chatV1.Message) {
+	byRoom := make(map[int64]map[int64]bool)
+	for _, message := range messages {
+		if byRoom[message.RoomId] == nil {
+			byRoom[message.RoomId] = make(map[int64]bool)
+		}
+		byRoom[message.RoomId][message.Id] = true
+	}
+
+	for roomID, ids := range byRoom {
+		key := fmt.Sprintf("room:%d:messages", roomID)
+
+		err := r.data.redis.Watch(ctx, func(tx *redis.Tx) error {
+			cached, err := tx.LRange(ctx, key, 0, -1).Result()
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				for _, data := range cached {
+					cachedMessage := r.deserializeMessage(data)
+					if cachedMessage != nil && ids[cachedMessage.Id] {
+						pipe.LRem(ctx, key, 1, data)
+					}
+				}
+				return nil
+			})
+			return err
+		}, key)
+
+		if err != nil {
+			r.log.Warnf("failed to evict deleted messages from room %d, invalidating cache: %v", roomID, err)
+			r.data.redis.Del(ctx, key)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/metrics"
+)
+
+// GetRoomHistory returns up to limit of the room's messages after afterID,
+// thread replies included and deleted messages left out, oldest first.
+// It bypasses the cache, which only holds the latest timeline page.
+func (r *messageRepo) GetRoomHistory(ctx context.Context, roomID, afterID int64, limit int32) ([]*chatV1.Message, error) {
+	dbStart := time.Now()
+
+	query := `
+		SELECT ` + messageColumns + `
+		FROM messages m
+		JOIN users u ON m.user_id = u.id
+		WHERE m.room_id = $1 AND m.id > $2 AND m.deleted_at IS NULL
+		ORDER BY m.id ASC
+		LIMIT $3`
+
+	rows, err := r.data.db.QueryContext(ctx, query, roomID, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room history: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var messages []*chatV1.Message
+	for rows.Next() {
+		message, err := scanMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan history message: %w", err)
+		}
+		messages = append(messages, message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get room history: %w", err)
+	}
+	metrics.RecordDBQuery("get_room_history", dbStart)
+
+	return messages, nil
+}
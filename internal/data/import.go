@@ -0,0 +1,207 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/lib/pq"
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/metrics"
+)
+
+// ImportRepo defines the data layer interface for imported history
+type ImportRepo interface {
+	UpsertImportedRoom(ctx context.Context, key string, room *chatV1.Room) (int64, error)
+	AddImportedMembers(ctx context.Context, roomID int64, userIDs []int64, role string) error
+	GetImportedMessageIDs(ctx context.Context, keys []string) (map[string]int64, error)
+	CreateImportedMessages(ctx context.Context, keys []string, messages []*chatV1.Message) (int, error)
+}
+
+type importRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// NewImportRepo creates a new import repository
+func NewImportRepo(data *Data, logger log.Logger) ImportRepo {
+	return &importRepo{
+		data: data,
+		log:  log.NewHelper(log.With(logger, "module", "data/import")),
+	}
+}
+
+// UpsertImportedRoom creates the room imported under key, or returns the
+// existing one's ID untouched so renames made since the last run stick
+func (r *importRepo) UpsertImportedRoom(ctx context.Context, key string, room *chatV1.Room) (int64, error) {
+	dbStart := time.Now()
+
+	query := `
+		INSERT INTO rooms (name, description, type, created_by, import_key, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		ON CONFLICT (import_key) WHERE import_key IS NOT NULL DO UPDATE SET import_key = EXCLUDED.import_key
+		RETURNING id`
+
+	var roomID int64
+	err := r.data.db.QueryRowContext(ctx, query, room.Name, room.Description, room.Type,
+		room.CreatedBy, key, time.Now()).Scan(&roomID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to import room: %w", err)
+	}
+	metrics.RecordDBQuery("upsert_imported_room", dbStart)
+
+	return roomID, nil
+}
+
+// AddImportedMembers adds users to a room with a role, leaving members who
+// are already there as they are
+func (r *importRepo) AddImportedMembers(ctx context.Context, roomID int64, userIDs []int64, role string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	dbStart := time.Now()
+
+	query := `
+		INSERT INTO room_members (room_id, user_id, role, joined_at, last_read_message_id)
+		SELECT $1, user_id, $3, $4, (SELECT COALESCE(MAX(id), 0) FROM messages WHERE room_id = $1)
+		FROM UNNEST($2::BIGINT[]) AS user_id
+		ON CONFLICT (room_id, user_id) DO NOTHING`
+
+	if _, err := r.data.db.ExecContext(ctx, query, roomID, pq.Array(userIDs), role, time.Now()); err != nil {
+		return fmt.Errorf("failed to add imported members: %w", err)
+	}
+	metrics.RecordDBQuery("add_imported_members", dbStart)
+
+	return nil
+}
+
+// GetImportedMessageIDs maps the given import keys that exist to their message IDs
+func (r *importRepo) GetImportedMessageIDs(ctx context.Context, keys []string) (map[string]int64, error) {
+	ids := make(map[string]int64)
+	if len(keys) == 0 {
+		return ids, nil
+	}
+
+	dbStart := time.Now()
+
+	query := `SELECT import_key, id FROM messages WHERE import_key = ANY($1)`
+
+	rows, err := r.data.db.QueryContext(ctx, query, pq.Array(keys))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get imported messages: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var key string
+		var id int64
+		if err := rows.Scan(&key, &id); err != nil {
+			return nil, fmt.Errorf("failed to scan imported message: %w", err)
+		}
+		ids[key] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get imported messages: %w", err)
+	}
+	metrics.RecordDBQuery("get_imported_message_ids", dbStart)
+
+	return ids, nil
+}
+
+// CreateImportedMessages inserts messages under their import keys with
+// their original timestamps in one transaction, skipping keys that are
+// already taken, and brings the reply stats of the threads they join up
+// to date. It returns how many messages were inserted.
+func (r *importRepo) CreateImportedMessages(ctx context.Context, keys []string, messages []*chatV1.Message) (int, error) {
+	if len(messages) == 0 {
+		return 0, nil
+	}
+
+	dbStart := time.Now()
+
+	tx, err := r.data.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	const columns = 11
+	placeholders := make([]string, 0, len(messages))
+	args := make([]interface{}, 0, len(messages)*columns)
+	for i, message := range messages {
+		base := i * columns
+		placeholders = append(placeholders, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			base+1, base+2, base+3, base+4, base+5, base+6, base+7, base+8, base+9, base+10, base+11))
+		args = append(args,
+			message.RoomId,
+			message.UserId,
+			message.Content,
+			message.Type,
+			nullString(message.FileUrl),
+			nullString(message.FileName),
+			nullInt64(message.FileSize),
+			nullString(message.MimeType),
+			nullInt64(message.ParentMessageId),
+			time.Unix(message.CreatedAt, 0),
+			keys[i],
+		)
+	}
+
+	query := `
+		INSERT INTO messages (room_id, user_id, content, type, file_url, file_name, file_size, mime_type,
+		                      parent_message_id, created_at, import_key)
+		VALUES ` + strings.Join(placeholders, ", ") + `
+		ON CONFLICT (import_key) WHERE import_key IS NOT NULL DO NOTHING
+		RETURNING id`
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to import messages: %w", err)
+	}
+	created := 0
+	for rows.Next() {
+		created++
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to import messages: %w", err)
+	}
+
+	var rootIDs []int64
+	for _, message := range messages {
+		if message.ParentMessageId != 0 {
+			rootIDs = append(rootIDs, message.ParentMessageId)
+		}
+	}
+	if len(rootIDs) > 0 {
+		rootQuery := `
+			UPDATE messages p
+			SET reply_count = s.replies, last_reply_at = s.last_reply_at
+			FROM (
+				SELECT parent_message_id, COUNT(*) AS replies, MAX(created_at) AS last_reply_at
+				FROM messages WHERE parent_message_id = ANY($1)
+				GROUP BY parent_message_id
+			) s
+			WHERE p.id = s.parent_message_id`
+		if _, err := tx.ExecContext(ctx, rootQuery, pq.Array(rootIDs)); err != nil {
+			return 0, fmt.Errorf("failed to update imported threads: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit imported messages: %w", err)
+	}
+	metrics.RecordDBQuery("create_imported_messages", dbStart)
+
+	// The recent messages cache no longer matches the room
+	if created > 0 && r.data.redis != nil {
+		redisStart := time.Now()
+		r.data.redis.Del(ctx, fmt.Sprintf("room:%d:messages", messages[0].RoomId))
+		metrics.RecordRedisOperation("invalidate_messages", redisStart)
+	}
+
+	return created, nil
+}
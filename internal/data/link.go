@@ -0,0 +1,150 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/metrics"
+)
+
+// QueueLinkPreviews queues a message for the unfurler. Queueing a message
+// that is being unfurled releases its claim, so the latest content is unfurled again.
+func (r *messageRepo) QueueLinkPreviews(ctx context.Context, messageID int64) error {
+	dbStart := time.Now()
+
+	query := `
+		INSERT INTO link_preview_jobs (message_id, created_at)
+		VALUES ($1, $2)
+		ON CONFLICT (message_id) DO UPDATE SET claimed_at = NULL`
+
+	if _, err := r.data.db.ExecContext(ctx, query, messageID, time.Now()); err != nil {
+		return fmt.Errorf("failed to queue link previews: %w", err)
+	}
+	metrics.RecordDBQuery("queue_link_previews", dbStart)
+
+	return nil
+}
+
+// ClaimLinkPreviewJobs claims up to limit unclaimed queued messages, along
+// with those whose claim was taken before reclaimBefore and never completed,
+// and returns their IDs. Rows locked by another replica are skipped.
+func (r *messageRepo) ClaimLinkPreviewJobs(ctx context.Context, now, reclaimBefore time.Time, limit int32) ([]int64, error) {
+	dbStart := time.Now()
+
+	query := `
+		UPDATE link_preview_jobs SET claimed_at = $1
+		WHERE message_id IN (
+			SELECT message_id FROM link_preview_jobs
+			WHERE claimed_at IS NULL OR claimed_at < $2
+			ORDER BY created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING message_id`
+
+	rows, err := r.data.db.QueryContext(ctx, query, now, reclaimBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim link preview jobs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var messageIDs []int64
+	for rows.Next() {
+		var messageID int64
+		if err := rows.Scan(&messageID); err != nil {
+			return nil, fmt.Errorf("failed to scan link preview job: %w", err)
+		}
+		messageIDs = append(messageIDs, messageID)
+	}
+	metrics.RecordDBQuery("claim_link_preview_jobs", dbStart)
+
+	return messageIDs, nil
+}
+
+// CompleteLinkPreviewJob deletes a job claimed at claimedAt. A job queued
+// again since the claim is kept, so the newer content is still unfurled.
+func (r *messageRepo) CompleteLinkPreviewJob(ctx context.Context, messageID int64, claimedAt time.Time) error {
+	dbStart := time.Now()
+
+	query := `DELETE FROM link_preview_jobs WHERE message_id = $1 AND claimed_at = $2`
+
+	if _, err := r.data.db.ExecContext(ctx, query, messageID, claimedAt); err != nil {
+		return fmt.Errorf("failed to complete link preview job: %w", err)
+	}
+	metrics.RecordDBQuery("complete_link_preview_job", dbStart)
+
+	return nil
+}
+
+// SaveLinkPreviews replaces a message's link previews
+func (r *messageRepo) SaveLinkPreviews(ctx context.Context, messageID int64, previews []*chatV1.LinkPreview) error {
+	dbStart := time.Now()
+
+	tx, err := r.data.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM link_previews WHERE message_id = $1`, messageID); err != nil {
+		return fmt.Errorf("failed to clear link previews: %w", err)
+	}
+
+	query := `
+		INSERT INTO link_previews (message_id, position, url, title, description, image_url, site_name, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (message_id, url) DO NOTHING`
+
+	now := time.Now()
+	for i, preview := range previews {
+		if _, err := tx.ExecContext(ctx, query, messageID, i, preview.Url, preview.Title,
+			preview.Description, preview.ImageUrl, preview.SiteName, now); err != nil {
+			return fmt.Errorf("failed to save link preview: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit link previews: %w", err)
+	}
+	metrics.RecordDBQuery("save_link_previews", dbStart)
+
+	return nil
+}
+
+// GetLinkPreviews returns the link previews of each message, in content order
+func (r *messageRepo) GetLinkPreviews(ctx context.Context, messageIDs []int64) (map[int64][]*chatV1.LinkPreview, error) {
+	previews := make(map[int64][]*chatV1.LinkPreview)
+	if len(messageIDs) == 0 {
+		return previews, nil
+	}
+
+	dbStart := time.Now()
+
+	query := `
+		SELECT message_id, url, title, description, image_url, site_name
+		FROM link_previews
+		WHERE message_id = ANY($1)
+		ORDER BY message_id, position`
+
+	rows, err := r.data.db.QueryContext(ctx, query, pq.Array(messageIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link previews: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var messageID int64
+		preview := &chatV1.LinkPreview{}
+		if err := rows.Scan(&messageID, &preview.Url, &preview.Title, &preview.Description,
+			&preview.ImageUrl, &preview.SiteName); err != nil {
+			return nil, fmt.Errorf("failed to scan link preview: %w", err)
+		}
+		previews[messageID] = append(previews[messageID], preview)
+	}
+	metrics.RecordDBQuery("get_link_previews", dbStart)
+
+	return previews, nil
+}
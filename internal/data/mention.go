@@ -0,0 +1,90 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/metrics"
+)
+
+// CreateMentions stores who a message mentions, keyed by user ID with the mention type
+func (r *messageRepo) CreateMentions(ctx context.Context, messageID, roomID int64, mentionTypes map[int64]string) error {
+	if len(mentionTypes) == 0 {
+		return nil
+	}
+
+	dbStart := time.Now()
+
+	tx, err := r.data.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+		INSERT INTO message_mentions (message_id, room_id, user_id, mention_type, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (message_id, user_id) DO NOTHING`
+
+	now := time.Now()
+	for userID, mentionType := range mentionTypes {
+		if _, err := tx.ExecContext(ctx, query, messageID, roomID, userID, mentionType, now); err != nil {
+			return fmt.Errorf("failed to create mention: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit mentions: %w", err)
+	}
+	metrics.RecordDBQuery("create_mentions", dbStart)
+
+	return nil
+}
+
+// GetUnreadMentions returns the user's unread mentions across rooms, newest first.
+// Mentions in deleted messages are skipped.
+func (r *messageRepo) GetUnreadMentions(ctx context.Context, userID int64, limit int32, beforeID int64) ([]*chatV1.Mention, bool, error) {
+	dbStart := time.Now()
+
+	// Fetch one extra row to know whether another page exists
+	query := `
+		SELECT ` + messageColumns + `, mm.id, mm.mention_type, mm.created_at
+		FROM message_mentions mm
+		JOIN messages m ON mm.message_id = m.id
+		JOIN users u ON m.user_id = u.id
+		WHERE mm.user_id = $1 AND mm.read_at IS NULL AND m.deleted_at IS NULL
+		  AND ($2 = 0 OR mm.id < $2)
+		ORDER BY mm.id DESC
+		LIMIT $3`
+
+	rows, err := r.data.db.QueryContext(ctx, query, userID, beforeID, limit+1)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get mentions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var mentions []*chatV1.Mention
+	for rows.Next() {
+		mention := &chatV1.Mention{}
+		var createdAt time.Time
+
+		message, err := scanMessage(rows, &mention.Id, &mention.MentionType, &createdAt)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to scan mention: %w", err)
+		}
+
+		mention.Message = message
+		mention.CreatedAt = createdAt.Unix()
+		mentions = append(mentions, mention)
+	}
+	metrics.RecordDBQuery("get_mentions", dbStart)
+
+	hasMore := len(mentions) > int(limit)
+	if hasMore {
+		mentions = mentions[:limit]
+	}
+
+	return mentions, hasMore, nil
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
 	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
 	"github.com/yourusername/chat-app/internal/metrics"
 )
@@ -15,12 +17,62 @@ import (
 // MessageRepo defines the interface for message data operations
 type MessageRepo interface {
 	CreateMessage(ctx context.Context, message *chatV1.Message) (*chatV1.Message, error)
-	GetMessages(ctx context.Context, roomID int64, limit int32, beforeID int64) ([]*chatV1.Message, bool, error)
+	GetMessages(ctx context.Context, roomID int64, limit int32, beforeID, afterID int64) ([]*chatV1.Message, bool, error)
 	GetMessageByID(ctx context.Context, id int64) (*chatV1.Message, error)
-	MarkAsRead(ctx context.Context, messageID, userID int64) error
-	GetUnreadCount(ctx context.Context, userID, roomID int64) (int32, error)
+	GetMessageByClientMsgID(ctx context.Context, userID int64, clientMsgID string) (*chatV1.Message, error)
+	GetThreadReplies(ctx context.Context, parentID int64, limit int32, afterID int64) ([]*chatV1.Message, bool, error)
+	SearchMessages(ctx context.Context, userID int64, filter *MessageSearchFilter, limit int32) ([]*chatV1.SearchResult, bool, error)
+	UpdateMessageContent(ctx context.Context, id int64, content, contentHTML string) (*chatV1.Message, error)
+	SoftDeleteMessage(ctx context.Context, id, deletedBy int64) (string, error)
+	AddReaction(ctx context.Context, messageID, userID int64, emoji string) (bool, error)
+	RemoveReaction(ctx context.Context, messageID, userID int64, emoji string) (bool, error)
+	GetReactions(ctx context.Context, messageIDs []int64, userID int64) (map[int64][]*chatV1.Reaction, error)
+	CreateMentions(ctx context.Context, messageID, roomID int64, mentionTypes map[int64]string) error
+	GetUnreadMentions(ctx context.Context, userID int64, limit int32, beforeID int64) ([]*chatV1.Mention, bool, error)
+	PinMessage(ctx context.Context, roomID, messageID, pinnedBy int64, maxPins int) (bool, error)
+	UnpinMessage(ctx context.Context, roomID, messageID int64) (bool, error)
+	GetPins(ctx context.Context, roomID int64) ([]*chatV1.Pin, error)
+	CreateScheduledMessage(ctx context.Context, scheduled *chatV1.ScheduledMessage) (*chatV1.ScheduledMessage, error)
+	GetPendingScheduledMessages(ctx context.Context, userID, roomID int64) ([]*chatV1.ScheduledMessage, error)
+	CancelScheduledMessage(ctx context.Context, id, userID int64) (bool, error)
+	ClaimDueScheduledMessages(ctx context.Context, now, reclaimBefore time.Time, limit int32) ([]*chatV1.ScheduledMessage, error)
+	CompleteScheduledMessage(ctx context.Context, id, messageID int64, failure string) error
+	DeleteExpiredMessages(ctx context.Context, now time.Time, limit int32) ([]*chatV1.Message, error)
+	DeleteRetainedMessages(ctx context.Context, now time.Time, defaultPolicy *chatV1.RetentionPolicy, limit int32) ([]*chatV1.Message, error)
+	QueueLinkPreviews(ctx context.Context, messageID int64) error
+	ClaimLinkPreviewJobs(ctx context.Context, now, reclaimBefore time.Time, limit int32) ([]int64, error)
+	CompleteLinkPreviewJob(ctx context.Context, messageID int64, claimedAt time.Time) error
+	SaveLinkPreviews(ctx context.Context, messageID int64, previews []*chatV1.LinkPreview) error
+	GetLinkPreviews(ctx context.Context, messageIDs []int64) (map[int64][]*chatV1.LinkPreview, error)
+	GetPolls(ctx context.Context, messageIDs []int64, userID int64) (map[int64]*chatV1.Poll, error)
+	SetPollVotes(ctx context.Context, messageID, userID int64, optionIDs []int32) (bool, error)
+	ClosePoll(ctx context.Context, messageID int64, closedAt time.Time) (bool, error)
+	CloseDuePolls(ctx context.Context, now time.Time, limit int32) ([]int64, error)
+	MarkRoomRead(ctx context.Context, roomID, userID, messageID int64, readAt time.Time) (bool, error)
+	GetLastReadMessageID(ctx context.Context, roomID, userID int64) (int64, error)
+	CountUnread(ctx context.Context, roomID, userID int64) (int32, error)
+	GetReadCursors(ctx context.Context, roomID int64) ([]*chatV1.ReadCursor, error)
+	GetUnreadMessages(ctx context.Context, roomID, userID int64, limit int32) ([]*chatV1.Message, bool, error)
+	GetRoomUnreads(ctx context.Context, userID int64, maxCount int32) ([]*chatV1.RoomUnread, error)
+	GetRoomHistory(ctx context.Context, roomID, afterID int64, limit int32) ([]*chatV1.Message, error)
 }
 
+// errDuplicateClientMsgID is returned by CreateMessage when the sender
+// already has a message with the same client message ID
+var errDuplicateClientMsgID = errors.New("duplicate client_msg_id")
+
+// messageCacheSize is how many recent messages are cached per room:
+// the largest page (100) plus one so cached reads know if there are more
+const messageCacheSize = 101
+
+// messageColumns is the select list shared by message queries, read with scanMessage
+const messageColumns = `m.id, m.room_id, m.user_id, u.username, m.content, m.type,
+		       m.is_edited, m.edited_at, m.created_at,
+		       m.file_url, m.file_name, m.file_size, m.mime_type,
+		       m.deleted_at, m.parent_message_id, m.reply_count, m.last_reply_at,
+		       m.expires_at, m.quoted_message, m.is_forwarded, m.format, m.content_html, m.system_event,
+		       m.client_msg_id`
+
 type messageRepo struct {
 	data *Data
 	log  *log.Helper
@@ -45,18 +97,47 @@ func (r *messageRepo) CreateMessage(ctx context.Context, message *chatV1.Message
 		return nil, fmt.Errorf("failed to get username: %w", err)
 	}
 
+	tx, err := r.data.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
 	// Insert message into database
 	query := `
-		INSERT INTO messages (room_id, user_id, content, type, file_url, file_name, file_size, mime_type, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO messages (room_id, user_id, content, type, file_url, file_name, file_size, mime_type, parent_message_id, expires_at,
+		                      quoted_message_id, quoted_message, is_forwarded, format, content_html, system_event, client_msg_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		ON CONFLICT (user_id, client_msg_id) WHERE client_msg_id IS NOT NULL DO NOTHING
 		RETURNING id, created_at`
 
 	now := time.Now()
 	message.CreatedAt = now.Unix()
 	message.Username = username
 
+	var expiresAt sql.NullTime
+	if message.ExpiresAt != 0 {
+		expiresAt = sql.NullTime{Time: time.Unix(message.ExpiresAt, 0), Valid: true}
+	}
+
+	var quotedID sql.NullInt64
+	var quoted []byte
+	if message.QuotedMessage != nil {
+		quotedID = nullInt64(message.QuotedMessage.MessageId)
+		if quoted, err = json.Marshal(message.QuotedMessage); err != nil {
+			return nil, fmt.Errorf("failed to encode quoted message: %w", err)
+		}
+	}
+
+	var systemEvent []byte
+	if message.SystemEvent != nil {
+		if systemEvent, err = json.Marshal(message.SystemEvent); err != nil {
+			return nil, fmt.Errorf("failed to encode system event: %w", err)
+		}
+	}
+
 	var createdAt time.Time
-	err = r.data.db.QueryRowContext(ctx, query,
+	err = tx.QueryRowContext(ctx, query,
 		message.RoomId,
 		message.UserId,
 		message.Content,
@@ -65,21 +146,60 @@ func (r *messageRepo) CreateMessage(ctx context.Context, message *chatV1.Message
 		nullString(message.FileName),
 		nullInt64(message.FileSize),
 		nullString(message.MimeType),
+		nullInt64(message.ParentMessageId),
+		expiresAt,
+		quotedID,
+		quoted,
+		message.IsForwarded,
+		message.Format,
+		nullString(message.ContentHtml),
+		systemEvent,
+		nullString(message.ClientMsgId),
 		now,
 	).Scan(&message.Id, &createdAt)
 
+	// No row comes back when the client message ID is already taken
+	if err == sql.ErrNoRows {
+		return nil, errDuplicateClientMsgID
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create message: %w", err)
 	}
 
+	if message.Poll != nil {
+		if err := createPoll(ctx, tx, message.Id, message.Poll); err != nil {
+			return nil, err
+		}
+	}
+
+	// Keep the thread root's reply stats in step with its replies
+	if message.ParentMessageId != 0 {
+		rootQuery := `UPDATE messages SET reply_count = reply_count + 1, last_reply_at = $2 WHERE id = $1`
+		if _, err := tx.ExecContext(ctx, rootQuery, message.ParentMessageId, createdAt); err != nil {
+			return nil, fmt.Errorf("failed to update thread root: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit message: %w", err)
+	}
+
 	message.CreatedAt = createdAt.Unix()
 	metrics.RecordDBQuery("create_message", dbStart)
 
-	// Cache recent messages in Redis for fast access
+	// Cache recent messages in Redis for fast access.
+	// Replies stay out of the room timeline; only the root's stats change.
 	if r.data.redis != nil {
 		redisStart := time.Now()
-		r.cacheMessage(ctx, message)
-		r.updateUnreadCounts(ctx, message)
+		if message.ParentMessageId != 0 {
+			if root, err := r.GetMessageByID(ctx, message.ParentMessageId); err == nil {
+				r.updateCachedMessage(ctx, root)
+			} else {
+				r.log.Warnf("failed to reload thread root %d: %v", message.ParentMessageId, err)
+			}
+		} else {
+			r.cacheMessage(ctx, message)
+		}
 		metrics.RecordRedisOperation("cache_message", redisStart)
 	}
 
@@ -87,42 +207,54 @@ func (r *messageRepo) CreateMessage(ctx context.Context, message *chatV1.Message
 	return message, nil
 }
 
-func (r *messageRepo) GetMessages(ctx context.Context, roomID int64, limit int32, beforeID int64) ([]*chatV1.Message, bool, error) {
-	// Try Redis cache first for recent messages (if no beforeID specified)
-	if beforeID == 0 && r.data.redis != nil {
-		if messages, ok := r.getCachedMessages(ctx, roomID, limit); ok {
-			return messages, len(messages) == int(limit), nil
+// GetMessages returns a page of room timeline messages, newest first.
+// beforeID pages back, afterID pages forward, neither returns the latest page.
+// hasMore reports whether another page exists in the paging direction.
+func (r *messageRepo) GetMessages(ctx context.Context, roomID int64, limit int32, beforeID, afterID int64) ([]*chatV1.Message, bool, error) {
+	latest := beforeID == 0 && afterID == 0
+
+	// Try Redis cache first for the latest page
+	if latest && r.data.redis != nil {
+		if messages, hasMore, ok := r.getCachedMessages(ctx, roomID, limit); ok {
+			return messages, hasMore, nil
 		}
 	}
 
-	// Build query with optional beforeID filter
+	// Keyset pagination on id; one extra row tells whether another page exists
 	var query string
 	var args []interface{}
 
-	if beforeID > 0 {
+	switch {
+	case afterID > 0:
+		query = `
+			SELECT ` + messageColumns + `
+			FROM messages m
+			JOIN users u ON m.user_id = u.id
+			WHERE m.room_id = $1 AND m.id > $2 AND m.parent_message_id IS NULL
+			ORDER BY m.id ASC
+			LIMIT $3`
+		args = []interface{}{roomID, afterID, limit + 1}
+	case beforeID > 0:
 		query = `
-			SELECT m.id, m.room_id, m.user_id, u.username, m.content, m.type,
-				   m.is_edited, m.edited_at, m.created_at,
-				   m.file_url, m.file_name, m.file_size, m.mime_type
+			SELECT ` + messageColumns + `
 			FROM messages m
 			JOIN users u ON m.user_id = u.id
-			WHERE m.room_id = $1 AND m.id < $2
-			ORDER BY m.created_at DESC
+			WHERE m.room_id = $1 AND m.id < $2 AND m.parent_message_id IS NULL
+			ORDER BY m.id DESC
 			LIMIT $3`
-		args = []interface{}{roomID, beforeID, limit}
-	} else {
+		args = []interface{}{roomID, beforeID, limit + 1}
+	default:
 		query = `
-			SELECT m.id, m.room_id, m.user_id, u.username, m.content, m.type,
-				   m.is_edited, m.edited_at, m.created_at,
-				   m.file_url, m.file_name, m.file_size, m.mime_type
+			SELECT ` + messageColumns + `
 			FROM messages m
 			JOIN users u ON m.user_id = u.id
-			WHERE m.room_id = $1
-			ORDER BY m.created_at DESC
+			WHERE m.room_id = $1 AND m.parent_message_id IS NULL
+			ORDER BY m.id DESC
 			LIMIT $2`
-		args = []interface{}{roomID, limit}
+		args = []interface{}{roomID, limit + 1}
 	}
 
+	dbStart := time.Now()
 	rows, err := r.data.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, false, fmt.Errorf("failed to get messages: %w", err)
@@ -131,103 +263,120 @@ func (r *messageRepo) GetMessages(ctx context.Context, roomID int64, limit int32
 
 	var messages []*chatV1.Message
 	for rows.Next() {
-		message := &chatV1.Message{}
-		var createdAt time.Time
-		var editedAt sql.NullTime
-		var fileURL, fileName, mimeType sql.NullString
-		var fileSize sql.NullInt64
-
-		err := rows.Scan(
-			&message.Id,
-			&message.RoomId,
-			&message.UserId,
-			&message.Username,
-			&message.Content,
-			&message.Type,
-			&message.IsEdited,
-			&editedAt,
-			&createdAt,
-			&fileURL,
-			&fileName,
-			&fileSize,
-			&mimeType,
-		)
+		message, err := scanMessage(rows)
 		if err != nil {
 			return nil, false, fmt.Errorf("failed to scan message: %w", err)
 		}
-
-		message.CreatedAt = createdAt.Unix()
-		if editedAt.Valid {
-			message.EditedAt = editedAt.Time.Unix()
-		}
-		if fileURL.Valid {
-			message.FileUrl = fileURL.String
-		}
-		if fileName.Valid {
-			message.FileName = fileName.String
-		}
-		if fileSize.Valid {
-			message.FileSize = fileSize.Int64
-		}
-		if mimeType.Valid {
-			message.MimeType = mimeType.String
-		}
-
 		messages = append(messages, message)
 	}
+	metrics.RecordDBQuery("get_messages", dbStart)
 
-	// Check if there are more messages
-	hasMore := len(messages) == int(limit)
-	if hasMore && len(messages) > 0 {
-		// Check if there's actually a next message
-		var count int
-		nextQuery := `SELECT COUNT(*) FROM messages WHERE room_id = $1 AND id < $2`
-		lastID := messages[len(messages)-1].Id
-		if err := r.data.db.QueryRowContext(ctx, nextQuery, roomID, lastID).Scan(&count); err != nil {
-			r.log.Warnf("failed to check for more messages: %v", err)
-		}
-		hasMore = count > 0
+	// Cache the latest page, including the extra row so cached reads can compute hasMore
+	if latest && r.data.redis != nil && len(messages) > 0 {
+		r.cacheMessages(ctx, roomID, messages)
 	}
 
-	// Cache recent messages if this was a recent query
-	if beforeID == 0 && r.data.redis != nil && len(messages) > 0 {
-		r.cacheMessages(ctx, roomID, messages)
+	hasMore := len(messages) > int(limit)
+	if hasMore {
+		messages = messages[:limit]
+	}
+
+	// Forward pages are read oldest first; return them newest first like the others
+	if afterID > 0 {
+		for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+			messages[i], messages[j] = messages[j], messages[i]
+		}
 	}
 
 	return messages, hasMore, nil
 }
 
 func (r *messageRepo) GetMessageByID(ctx context.Context, id int64) (*chatV1.Message, error) {
-	message := &chatV1.Message{}
-	var createdAt time.Time
-	var editedAt sql.NullTime
-	var fileURL, fileName, mimeType sql.NullString
-	var fileSize sql.NullInt64
-
 	query := `
-		SELECT m.id, m.room_id, m.user_id, u.username, m.content, m.type,
-		       m.is_edited, m.edited_at, m.created_at,
-		       m.file_url, m.file_name, m.file_size, m.mime_type
+		SELECT ` + messageColumns + `
 		FROM messages m
 		JOIN users u ON m.user_id = u.id
 		WHERE m.id = $1`
 
-	err := r.data.db.QueryRowContext(ctx, query, id).Scan(
-		&message.Id,
-		&message.RoomId,
-		&message.UserId,
-		&message.Username,
-		&message.Content,
-		&message.Type,
-		&message.IsEdited,
-		&editedAt,
-		&createdAt,
-		&fileURL,
-		&fileName,
-		&fileSize,
-		&mimeType,
-	)
+	message, err := scanMessage(r.data.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("message not found")
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	return message, nil
+}
+
+// GetMessageByClientMsgID returns the user's message sent with the client message ID, or nil if there is none
+func (r *messageRepo) GetMessageByClientMsgID(ctx context.Context, userID int64, clientMsgID string) (*chatV1.Message, error) {
+	query := `
+		SELECT ` + messageColumns + `
+		FROM messages m
+		JOIN users u ON m.user_id = u.id
+		WHERE m.user_id = $1 AND m.client_msg_id = $2`
+
+	message, err := scanMessage(r.data.db.QueryRowContext(ctx, query, userID, clientMsgID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	return message, nil
+}
+
+// GetThreadReplies returns replies to a root message, oldest first
+func (r *messageRepo) GetThreadReplies(ctx context.Context, parentID int64, limit int32, afterID int64) ([]*chatV1.Message, bool, error) {
+	dbStart := time.Now()
+
+	// Fetch one extra row to know whether another page exists
+	query := `
+		SELECT ` + messageColumns + `
+		FROM messages m
+		JOIN users u ON m.user_id = u.id
+		WHERE m.parent_message_id = $1 AND m.id > $2
+		ORDER BY m.id ASC
+		LIMIT $3`
+
+	rows, err := r.data.db.QueryContext(ctx, query, parentID, afterID, limit+1)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get thread replies: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var replies []*chatV1.Message
+	for rows.Next() {
+		reply, err := scanMessage(rows)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to scan thread reply: %w", err)
+		}
+		replies = append(replies, reply)
+	}
+	metrics.RecordDBQuery("get_thread_replies", dbStart)
 
+	hasMore := len(replies) > int(limit)
+	if hasMore {
+		replies = replies[:limit]
+	}
+
+	return replies, hasMore, nil
+}
+
+func (r *messageRepo) UpdateMessageContent(ctx context.Context, id int64, content, contentHTML string) (*chatV1.Message, error) {
+	dbStart := time.Now()
+
+	tx, err := r.data.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Lock the row so concurrent edits are recorded in order
+	var previousContent string
+	err = tx.QueryRowContext(ctx, `SELECT content FROM messages WHERE id = $1 FOR UPDATE`, id).Scan(&previousContent)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("message not found")
@@ -235,71 +384,122 @@ func (r *messageRepo) GetMessageByID(ctx context.Context, id int64) (*chatV1.Mes
 		return nil, fmt.Errorf("failed to get message: %w", err)
 	}
 
-	message.CreatedAt = createdAt.Unix()
-	if editedAt.Valid {
-		message.EditedAt = editedAt.Time.Unix()
+	now := time.Now()
+
+	// Keep the old content in the edit history
+	historyQuery := `
+		INSERT INTO message_edits (message_id, previous_content, edited_at)
+		VALUES ($1, $2, $3)`
+	if _, err := tx.ExecContext(ctx, historyQuery, id, previousContent, now); err != nil {
+		return nil, fmt.Errorf("failed to record message edit: %w", err)
 	}
-	if fileURL.Valid {
-		message.FileUrl = fileURL.String
+
+	updateQuery := `
+		UPDATE messages
+		SET content = $2, content_html = $3, is_edited = TRUE, edited_at = $4
+		WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, updateQuery, id, content, nullString(contentHTML), now); err != nil {
+		return nil, fmt.Errorf("failed to update message: %w", err)
 	}
-	if fileName.Valid {
-		message.FileName = fileName.String
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit message edit: %w", err)
 	}
-	if fileSize.Valid {
-		message.FileSize = fileSize.Int64
+	metrics.RecordDBQuery("edit_message", dbStart)
+
+	message, err := r.GetMessageByID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	if mimeType.Valid {
-		message.MimeType = mimeType.String
+
+	// Replace the stale copy in the recent messages cache
+	if r.data.redis != nil {
+		redisStart := time.Now()
+		r.updateCachedMessage(ctx, message)
+		metrics.RecordRedisOperation("update_cached_message", redisStart)
 	}
 
+	r.log.Infof("edited message: id=%d, room_id=%d", message.Id, message.RoomId)
 	return message, nil
 }
 
-func (r *messageRepo) MarkAsRead(ctx context.Context, messageID, userID int64) error {
-	// Insert or ignore if already exists
-	query := `
-		INSERT INTO message_reads (message_id, user_id, read_at)
-		VALUES ($1, $2, $3)
-		ON CONFLICT (message_id, user_id) DO NOTHING`
+func (r *messageRepo) SoftDeleteMessage(ctx context.Context, id, deletedBy int64) (string, error) {
+	dbStart := time.Now()
 
-	_, err := r.data.db.ExecContext(ctx, query, messageID, userID, time.Now())
+	tx, err := r.data.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to mark message as read: %w", err)
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer func() { _ = tx.Rollback() }()
 
-	return nil
-}
-
-func (r *messageRepo) GetUnreadCount(ctx context.Context, userID, roomID int64) (int32, error) {
-	// Try Redis cache first
-	if r.data.redis != nil {
-		key := fmt.Sprintf("unread:%d:%d", userID, roomID)
-		count, err := r.data.redis.Get(ctx, key).Int()
-		if err == nil {
-			return int32(count), nil
+	var fileURL sql.NullString
+	var deletedAt sql.NullTime
+	err = tx.QueryRowContext(ctx, `SELECT file_url, deleted_at FROM messages WHERE id = $1 FOR UPDATE`, id).Scan(&fileURL, &deletedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("message not found")
 		}
+		return "", fmt.Errorf("failed to get message: %w", err)
+	}
+	if deletedAt.Valid {
+		return "", fmt.Errorf("message already deleted")
 	}
 
-	// Fall back to database
-	var count int32
-	query := `
-		SELECT COUNT(*)
-		FROM messages m
-		LEFT JOIN message_reads mr ON m.id = mr.message_id AND mr.user_id = $1
-		WHERE m.room_id = $2 AND m.user_id != $1 AND mr.message_id IS NULL`
+	// Scrub content, attachment and any quoted snapshot so only the tombstone remains
+	deleteQuery := `
+		UPDATE messages
+		SET content = '', content_html = NULL, file_url = NULL, file_name = NULL, file_size = NULL, mime_type = NULL,
+		    quoted_message = NULL, deleted_at = $2, deleted_by = $3
+		WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, deleteQuery, id, time.Now(), deletedBy); err != nil {
+		return "", fmt.Errorf("failed to delete message: %w", err)
+	}
 
-	err := r.data.db.QueryRowContext(ctx, query, userID, roomID).Scan(&count)
-	if err != nil {
-		return 0, fmt.Errorf("failed to get unread count: %w", err)
+	// Edit history would still hold the old content
+	if _, err := tx.ExecContext(ctx, `DELETE FROM message_edits WHERE message_id = $1`, id); err != nil {
+		return "", fmt.Errorf("failed to delete message edit history: %w", err)
+	}
+
+	// Previews describe links in the scrubbed content
+	if _, err := tx.ExecContext(ctx, `DELETE FROM link_previews WHERE message_id = $1`, id); err != nil {
+		return "", fmt.Errorf("failed to delete link previews: %w", err)
+	}
+
+	// The poll goes with its question, along with everyone's votes
+	if _, err := tx.ExecContext(ctx, `DELETE FROM polls WHERE message_id = $1`, id); err != nil {
+		return "", fmt.Errorf("failed to delete poll: %w", err)
+	}
+
+	// Quotes and forwards keep showing the attachment, so leave the object in place
+	if fileURL.Valid {
+		var quoted bool
+		quotedQuery := `SELECT EXISTS(SELECT 1 FROM messages WHERE quoted_message IS NOT NULL AND quoted_message->>'file_url' = $1)`
+		if err := tx.QueryRowContext(ctx, quotedQuery, fileURL.String).Scan(&quoted); err != nil {
+			return "", fmt.Errorf("failed to check quoted attachment: %w", err)
+		}
+		if quoted {
+			fileURL = sql.NullString{}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit message delete: %w", err)
 	}
+	metrics.RecordDBQuery("delete_message", dbStart)
 
-	// Cache the result
 	if r.data.redis != nil {
-		key := fmt.Sprintf("unread:%d:%d", userID, roomID)
-		r.data.redis.Set(ctx, key, count, 30*time.Minute)
+		message, err := r.GetMessageByID(ctx, id)
+		if err != nil {
+			r.log.Warnf("failed to reload deleted message %d: %v", id, err)
+		} else {
+			redisStart := time.Now()
+			r.updateCachedMessage(ctx, message)
+			metrics.RecordRedisOperation("update_cached_message", redisStart)
+		}
 	}
 
-	return count, nil
+	r.log.Infof("deleted message: id=%d, deleted_by=%d", id, deletedBy)
+	return fileURL.String, nil
 }
 
 // Helper functions for Redis caching
@@ -309,13 +509,15 @@ func (r *messageRepo) cacheMessage(ctx context.Context, message *chatV1.Message)
 	// Add to list (most recent first)
 	r.data.redis.LPush(ctx, key, r.serializeMessage(message))
 
-	// Keep only last 100 messages
-	r.data.redis.LTrim(ctx, key, 0, 99)
+	// Keep only the most recent messages
+	r.data.redis.LTrim(ctx, key, 0, messageCacheSize-1)
 
 	// Set expiration
 	r.data.redis.Expire(ctx, key, time.Hour)
 }
 
+// cacheMessages replaces the cached list with messages (newest first).
+// It runs as one transaction so a concurrent cacheMessage can't interleave.
 func (r *messageRepo) cacheMessages(ctx context.Context, roomID int64, messages []*chatV1.Message) {
 	if len(messages) == 0 {
 		return
@@ -323,48 +525,76 @@ func (r *messageRepo) cacheMessages(ctx context.Context, roomID int64, messages
 
 	key := fmt.Sprintf("room:%d:messages", roomID)
 
-	// Clear existing cache
-	r.data.redis.Del(ctx, key)
-
-	// Add all messages (reverse order since LPush adds to front)
-	for i := len(messages) - 1; i >= 0; i-- {
-		r.data.redis.LPush(ctx, key, r.serializeMessage(messages[i]))
+	values := make([]interface{}, 0, len(messages))
+	for _, message := range messages {
+		values = append(values, r.serializeMessage(message))
 	}
 
-	r.data.redis.Expire(ctx, key, time.Hour)
+	_, err := r.data.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.RPush(ctx, key, values...)
+		pipe.LTrim(ctx, key, 0, messageCacheSize-1)
+		pipe.Expire(ctx, key, time.Hour)
+		return nil
+	})
+	if err != nil {
+		r.log.Warnf("failed to cache messages for room %d: %v", roomID, err)
+	}
 }
 
-func (r *messageRepo) getCachedMessages(ctx context.Context, roomID int64, limit int32) ([]*chatV1.Message, bool) {
-	key := fmt.Sprintf("room:%d:messages", roomID)
+// updateCachedMessage replaces a message in the room's cached list in place.
+// The list is watched so a concurrent LPush can't shift the index under us;
+// if that happens the cache is dropped and rebuilt on the next read.
+func (r *messageRepo) updateCachedMessage(ctx context.Context, message *chatV1.Message) {
+	key := fmt.Sprintf("room:%d:messages", message.RoomId)
 
-	cached := r.data.redis.LRange(ctx, key, 0, int64(limit-1)).Val()
-	if len(cached) == 0 {
-		return nil, false
-	}
+	err := r.data.redis.Watch(ctx, func(tx *redis.Tx) error {
+		cached, err := tx.LRange(ctx, key, 0, -1).Result()
+		if err != nil {
+			return err
+		}
 
-	var messages []*chatV1.Message
-	for _, data := range cached {
-		if message := r.deserializeMessage(data); message != nil {
-			messages = append(messages, message)
+		for i, data := range cached {
+			cachedMessage := r.deserializeMessage(data)
+			if cachedMessage == nil || cachedMessage.Id != message.Id {
+				continue
+			}
+			_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.LSet(ctx, key, int64(i), r.serializeMessage(message))
+				return nil
+			})
+			return err
 		}
-	}
+		return nil
+	}, key)
 
-	return messages, true
+	if err != nil {
+		r.log.Warnf("failed to update cached message %d, invalidating cache: %v", message.Id, err)
+		r.data.redis.Del(ctx, key)
+	}
 }
 
-func (r *messageRepo) updateUnreadCounts(ctx context.Context, message *chatV1.Message) {
-	// Get room members from Redis
-	membersKey := fmt.Sprintf("room:%d:members", message.RoomId)
-	members := r.data.redis.SMembers(ctx, membersKey).Val()
-
-	// Update unread count for each member (except sender)
-	for _, memberIDStr := range members {
-		if memberIDStr != fmt.Sprintf("%d", message.UserId) {
-			unreadKey := fmt.Sprintf("unread:%s:%d", memberIDStr, message.RoomId)
-			r.data.redis.Incr(ctx, unreadKey)
-			r.data.redis.Expire(ctx, unreadKey, 24*time.Hour)
+// getCachedMessages serves the latest page from the cache. It only answers
+// when the cache holds more than limit messages, so hasMore is exact; smaller
+// lists may be missing older history and fall through to the database.
+func (r *messageRepo) getCachedMessages(ctx context.Context, roomID int64, limit int32) ([]*chatV1.Message, bool, bool) {
+	key := fmt.Sprintf("room:%d:messages", roomID)
+
+	cached := r.data.redis.LRange(ctx, key, 0, int64(limit)).Val()
+	if len(cached) <= int(limit) {
+		return nil, false, false
+	}
+
+	messages := make([]*chatV1.Message, 0, limit)
+	for _, data := range cached[:limit] {
+		message := r.deserializeMessage(data)
+		if message == nil {
+			return nil, false, false
 		}
+		messages = append(messages, message)
 	}
+
+	return messages, true, true
 }
 
 // Serialization helpers using JSON for proper handling of special characters
@@ -386,6 +616,102 @@ func (r *messageRepo) deserializeMessage(data string) *chatV1.Message {
 	return message
 }
 
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanMessage reads a row selected with messageColumns.
+// Columns selected after messageColumns are scanned into extra.
+func scanMessage(row rowScanner, extra ...interface{}) (*chatV1.Message, error) {
+	message := &chatV1.Message{}
+	var createdAt time.Time
+	var editedAt, deletedAt, lastReplyAt, expiresAt sql.NullTime
+	var fileURL, fileName, mimeType, contentHTML, clientMsgID sql.NullString
+	var fileSize, parentID sql.NullInt64
+	var quoted, systemEvent []byte
+
+	dest := []interface{}{
+		&message.Id,
+		&message.RoomId,
+		&message.UserId,
+		&message.Username,
+		&message.Content,
+		&message.Type,
+		&message.IsEdited,
+		&editedAt,
+		&createdAt,
+		&fileURL,
+		&fileName,
+		&fileSize,
+		&mimeType,
+		&deletedAt,
+		&parentID,
+		&message.ReplyCount,
+		&lastReplyAt,
+		&expiresAt,
+		&quoted,
+		&message.IsForwarded,
+		&message.Format,
+		&contentHTML,
+		&systemEvent,
+		&clientMsgID,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	message.CreatedAt = createdAt.Unix()
+	if editedAt.Valid {
+		message.EditedAt = editedAt.Time.Unix()
+	}
+	if fileURL.Valid {
+		message.FileUrl = fileURL.String
+	}
+	if fileName.Valid {
+		message.FileName = fileName.String
+	}
+	if fileSize.Valid {
+		message.FileSize = fileSize.Int64
+	}
+	if mimeType.Valid {
+		message.MimeType = mimeType.String
+	}
+	if deletedAt.Valid {
+		message.IsDeleted = true
+		message.DeletedAt = deletedAt.Time.Unix()
+	}
+	if parentID.Valid {
+		message.ParentMessageId = parentID.Int64
+	}
+	if lastReplyAt.Valid {
+		message.LastReplyAt = lastReplyAt.Time.Unix()
+	}
+	if expiresAt.Valid {
+		message.ExpiresAt = expiresAt.Time.Unix()
+	}
+	if contentHTML.Valid {
+		message.ContentHtml = contentHTML.String
+	}
+	if clientMsgID.Valid {
+		message.ClientMsgId = clientMsgID.String
+	}
+	if len(quoted) > 0 {
+		message.QuotedMessage = &chatV1.QuotedMessage{}
+		if err := json.Unmarshal(quoted, message.QuotedMessage); err != nil {
+			return nil, fmt.Errorf("failed to decode quoted message: %w", err)
+		}
+	}
+	if len(systemEvent) > 0 {
+		message.SystemEvent = &chatV1.SystemEvent{}
+		if err := json.Unmarshal(systemEvent, message.SystemEvent); err != nil {
+			return nil, fmt.Errorf("failed to decode system event: %w", err)
+		}
+	}
+
+	return message, nil
+}
+
 // Helper functions for nullable database values
 func nullString(s string) sql.NullString {
 	if s == "" {
@@ -0,0 +1,124 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/metrics"
+)
+
+// errPinLimitReached is returned by PinMessage when the room already has
+// maxPins pins
+var errPinLimitReached = errors.New("room pin limit reached")
+
+// PinMessage pins a message to its room, reporting false if it was already
+// pinned. The room row is locked while the pins are counted, so concurrent
+// pins can't both pass the limit.
+func (r *messageRepo) PinMessage(ctx context.Context, roomID, messageID, pinnedBy int64, maxPins int) (bool, error) {
+	dbStart := time.Now()
+
+	tx, err := r.data.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM rooms WHERE id = $1 FOR UPDATE`, roomID); err != nil {
+		return false, fmt.Errorf("failed to lock room: %w", err)
+	}
+
+	var pinned bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM room_pins WHERE room_id = $1 AND message_id = $2)`, roomID, messageID).Scan(&pinned)
+	if err != nil {
+		return false, fmt.Errorf("failed to check pin: %w", err)
+	}
+	if pinned {
+		return false, nil
+	}
+
+	// Pins of deleted messages are not listed, so they don't count
+	query := `
+		INSERT INTO room_pins (room_id, message_id, pinned_by, pinned_at)
+		SELECT $1, $2, $3, $4
+		WHERE (
+			SELECT COUNT(*) FROM room_pins rp
+			JOIN messages m ON rp.message_id = m.id
+			WHERE rp.room_id = $1 AND m.deleted_at IS NULL
+		) < $5`
+
+	result, err := tx.ExecContext(ctx, query, roomID, messageID, pinnedBy, time.Now(), maxPins)
+	if err != nil {
+		return false, fmt.Errorf("failed to pin message: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return false, errPinLimitReached
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit pin: %w", err)
+	}
+	metrics.RecordDBQuery("pin_message", dbStart)
+
+	return true, nil
+}
+
+// UnpinMessage removes a pin, reporting false if the message wasn't pinned
+func (r *messageRepo) UnpinMessage(ctx context.Context, roomID, messageID int64) (bool, error) {
+	dbStart := time.Now()
+
+	query := `DELETE FROM room_pins WHERE room_id = $1 AND message_id = $2`
+
+	result, err := r.data.db.ExecContext(ctx, query, roomID, messageID)
+	if err != nil {
+		return false, fmt.Errorf("failed to unpin message: %w", err)
+	}
+	metrics.RecordDBQuery("unpin_message", dbStart)
+
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+// GetPins returns a room's pinned messages, most recently pinned first.
+// Pins of deleted messages are skipped.
+func (r *messageRepo) GetPins(ctx context.Context, roomID int64) ([]*chatV1.Pin, error) {
+	dbStart := time.Now()
+
+	query := `
+		SELECT ` + messageColumns + `, rp.pinned_by, COALESCE(pu.username, ''), rp.pinned_at
+		FROM room_pins rp
+		JOIN messages m ON rp.message_id = m.id
+		JOIN users u ON m.user_id = u.id
+		LEFT JOIN users pu ON rp.pinned_by = pu.id
+		WHERE rp.room_id = $1 AND m.deleted_at IS NULL
+		ORDER BY rp.pinned_at DESC`
+
+	rows, err := r.data.db.QueryContext(ctx, query, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pins: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var pins []*chatV1.Pin
+	for rows.Next() {
+		pin := &chatV1.Pin{}
+		var pinnedBy sql.NullInt64
+		var pinnedAt time.Time
+
+		message, err := scanMessage(rows, &pinnedBy, &pin.PinnedByUsername, &pinnedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pin: %w", err)
+		}
+
+		pin.Message = message
+		pin.PinnedBy = pinnedBy.Int64
+		pin.PinnedAt = pinnedAt.Unix()
+		pins = append(pins, pin)
+	}
+	metrics.RecordDBQuery("get_pins", dbStart)
+
+	return pins, nil
+}
@@ -0,0 +1,225 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/metrics"
+)
+
+// createPoll stores a new poll message's question and options in the message's transaction
+func createPoll(ctx context.Context, tx *sql.Tx, messageID int64, poll *chatV1.Poll) error {
+	var closesAt sql.NullTime
+	if poll.ClosesAt != 0 {
+		closesAt = sql.NullTime{Time: time.Unix(poll.ClosesAt, 0), Valid: true}
+	}
+
+	pollQuery := `
+		INSERT INTO polls (message_id, question, multiple_choice, anonymous, closes_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	if _, err := tx.ExecContext(ctx, pollQuery, messageID, poll.Question, poll.MultipleChoice,
+		poll.Anonymous, closesAt, time.Now()); err != nil {
+		return fmt.Errorf("failed to create poll: %w", err)
+	}
+
+	optionQuery := `INSERT INTO poll_options (message_id, option_id, text) VALUES ($1, $2, $3)`
+	for _, option := range poll.Options {
+		if _, err := tx.ExecContext(ctx, optionQuery, messageID, option.Id, option.Text); err != nil {
+			return fmt.Errorf("failed to create poll option: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetPolls returns the poll of each poll message with its tally.
+// voted_by_me is relative to userID; voter IDs are left out of anonymous polls.
+func (r *messageRepo) GetPolls(ctx context.Context, messageIDs []int64, userID int64) (map[int64]*chatV1.Poll, error) {
+	polls := make(map[int64]*chatV1.Poll)
+	if len(messageIDs) == 0 {
+		return polls, nil
+	}
+
+	dbStart := time.Now()
+
+	pollQuery := `
+		SELECT p.message_id, p.question, p.multiple_choice, p.anonymous, p.closes_at, p.closed_at,
+		       (SELECT COUNT(DISTINCT v.user_id) FROM poll_votes v WHERE v.message_id = p.message_id)
+		FROM polls p
+		WHERE p.message_id = ANY($1)`
+
+	rows, err := r.data.db.QueryContext(ctx, pollQuery, pq.Array(messageIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get polls: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var messageID int64
+		var closesAt, closedAt sql.NullTime
+		poll := &chatV1.Poll{}
+		if err := rows.Scan(&messageID, &poll.Question, &poll.MultipleChoice, &poll.Anonymous,
+			&closesAt, &closedAt, &poll.VoterCount); err != nil {
+			return nil, fmt.Errorf("failed to scan poll: %w", err)
+		}
+		if closesAt.Valid {
+			poll.ClosesAt = closesAt.Time.Unix()
+		}
+		if closedAt.Valid {
+			poll.ClosedAt = closedAt.Time.Unix()
+		}
+		polls[messageID] = poll
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get polls: %w", err)
+	}
+
+	optionQuery := `
+		SELECT o.message_id, o.option_id, o.text,
+		       COUNT(v.user_id),
+		       COALESCE(BOOL_OR(v.user_id = $2), FALSE),
+		       COALESCE(ARRAY_AGG(v.user_id ORDER BY v.created_at) FILTER (WHERE v.user_id IS NOT NULL AND NOT p.anonymous), '{}')
+		FROM poll_options o
+		JOIN polls p ON p.message_id = o.message_id
+		LEFT JOIN poll_votes v ON v.message_id = o.message_id AND v.option_id = o.option_id
+		WHERE o.message_id = ANY($1)
+		GROUP BY o.message_id, o.option_id, o.text
+		ORDER BY o.message_id, o.option_id`
+
+	optionRows, err := r.data.db.QueryContext(ctx, optionQuery, pq.Array(messageIDs), userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get poll options: %w", err)
+	}
+	defer func() { _ = optionRows.Close() }()
+
+	for optionRows.Next() {
+		var messageID int64
+		var voterIDs pq.Int64Array
+		option := &chatV1.PollOption{}
+		if err := optionRows.Scan(&messageID, &option.Id, &option.Text, &option.VoteCount,
+			&option.VotedByMe, &voterIDs); err != nil {
+			return nil, fmt.Errorf("failed to scan poll option: %w", err)
+		}
+		option.VoterIds = voterIDs
+		if poll, ok := polls[messageID]; ok {
+			poll.Options = append(poll.Options, option)
+		}
+	}
+	metrics.RecordDBQuery("get_polls", dbStart)
+
+	return polls, nil
+}
+
+// SetPollVotes replaces a user's votes in a poll, reporting false if the
+// poll doesn't exist or is closed. The poll row is share-locked so a
+// concurrent close can't slip in between the check and the votes, and the
+// user's votes are serialized by an advisory lock so two concurrent requests
+// can't both clear and then each add a vote.
+func (r *messageRepo) SetPollVotes(ctx context.Context, messageID, userID int64, optionIDs []int32) (bool, error) {
+	dbStart := time.Now()
+
+	tx, err := r.data.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Held until the transaction ends; a hash collision only serializes more
+	lockQuery := `SELECT pg_advisory_xact_lock(hashtextextended(format('poll_votes:%s:%s', $1::bigint, $2::bigint), 0))`
+	if _, err := tx.ExecContext(ctx, lockQuery, messageID, userID); err != nil {
+		return false, fmt.Errorf("failed to lock poll votes: %w", err)
+	}
+
+	var open bool
+	openQuery := `
+		SELECT closed_at IS NULL AND (closes_at IS NULL OR closes_at > $2)
+		FROM polls WHERE message_id = $1
+		FOR SHARE`
+	err = tx.QueryRowContext(ctx, openQuery, messageID, time.Now()).Scan(&open)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check poll: %w", err)
+	}
+	if !open {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM poll_votes WHERE message_id = $1 AND user_id = $2`, messageID, userID); err != nil {
+		return false, fmt.Errorf("failed to clear poll votes: %w", err)
+	}
+
+	voteQuery := `
+		INSERT INTO poll_votes (message_id, option_id, user_id, created_at)
+		VALUES ($1, $2, $3, $4)`
+	now := time.Now()
+	for _, optionID := range optionIDs {
+		if _, err := tx.ExecContext(ctx, voteQuery, messageID, optionID, userID, now); err != nil {
+			return false, fmt.Errorf("failed to save poll vote: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit poll votes: %w", err)
+	}
+	metrics.RecordDBQuery("set_poll_votes", dbStart)
+
+	return true, nil
+}
+
+// ClosePoll closes a poll, reporting false if it was already closed
+func (r *messageRepo) ClosePoll(ctx context.Context, messageID int64, closedAt time.Time) (bool, error) {
+	dbStart := time.Now()
+
+	query := `UPDATE polls SET closed_at = $2 WHERE message_id = $1 AND closed_at IS NULL`
+
+	result, err := r.data.db.ExecContext(ctx, query, messageID, closedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to close poll: %w", err)
+	}
+	metrics.RecordDBQuery("close_poll", dbStart)
+
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+// CloseDuePolls closes up to limit open polls whose close time has passed
+// and returns their message IDs. SKIP LOCKED lets each replica close a
+// disjoint batch, so every poll is announced once.
+func (r *messageRepo) CloseDuePolls(ctx context.Context, now time.Time, limit int32) ([]int64, error) {
+	dbStart := time.Now()
+
+	query := `
+		UPDATE polls SET closed_at = closes_at
+		WHERE message_id IN (
+			SELECT message_id FROM polls
+			WHERE closed_at IS NULL AND closes_at <= $1
+			ORDER BY closes_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING message_id`
+
+	rows, err := r.data.db.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to close due polls: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var messageIDs []int64
+	for rows.Next() {
+		var messageID int64
+		if err := rows.Scan(&messageID); err != nil {
+			return nil, fmt.Errorf("failed to scan closed poll: %w", err)
+		}
+		messageIDs = append(messageIDs, messageID)
+	}
+	metrics.RecordDBQuery("close_due_polls", dbStart)
+
+	return messageIDs, nil
+}
@@ -0,0 +1,208 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"github.com/yourusername/chat-app/internal/biz"
+	"github.com/yourusername/chat-app/internal/metrics"
+)
+
+const (
+	// presenceKeyFormat is a hash of the user's live WebSocket connections,
+	// connection ID -> "<active unix> <expires unix>". Every chat instance
+	// refreshes its own connections' fields with heartbeats.
+	presenceKeyFormat = "presence:%d"
+	// presenceExpiryKey orders every connection ("<user ID>:<connection ID>")
+	// by heartbeat deadline, so any instance can reap connections whose
+	// instance died without closing them
+	presenceExpiryKey = "presence:expiry"
+	// presenceStatusKeyFormat holds the status last announced for the user
+	presenceStatusKeyFormat = "presence:status:%d"
+)
+
+type presenceRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// NewPresenceRepo creates a Redis backed presence repository
+func NewPresenceRepo(data *Data, logger log.Logger) biz.PresenceRepo {
+	return &presenceRepo{
+		data: data,
+		log:  log.NewHelper(log.With(logger, "module", "data/presence")),
+	}
+}
+
+// FilterOnline returns the users that have at least one live connection
+func (r *presenceRepo) FilterOnline(ctx context.Context, userIDs []int64) ([]int64, error) {
+	connections, err := r.GetConnections(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var online []int64
+	for _, userID := range userIDs {
+		for _, conn := range connections[userID] {
+			if conn.ExpiresAt.After(now) {
+				online = append(online, userID)
+				break
+			}
+		}
+	}
+	return online, nil
+}
+
+// SaveConnection adds or refreshes a connection until its ExpiresAt
+func (r *presenceRepo) SaveConnection(ctx context.Context, conn *biz.PresenceConnection) error {
+	if r.data.redis == nil {
+		return fmt.Errorf("redis not available")
+	}
+
+	redisStart := time.Now()
+	key := fmt.Sprintf(presenceKeyFormat, conn.UserID)
+	value := fmt.Sprintf("%d %d", conn.ActiveAt.Unix(), conn.ExpiresAt.Unix())
+
+	pipe := r.data.redis.TxPipeline()
+	pipe.HSet(ctx, key, conn.ConnID, value)
+	// The newest heartbeat has the latest deadline, so the hash outlives its fields
+	pipe.ExpireAt(ctx, key, conn.ExpiresAt)
+	pipe.ZAdd(ctx, presenceExpiryKey, redis.Z{
+		Score:  float64(conn.ExpiresAt.Unix()),
+		Member: presenceMember(conn.UserID, conn.ConnID),
+	})
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save connection: %w", err)
+	}
+	metrics.RecordRedisOperation("save_presence", redisStart)
+	return nil
+}
+
+// RemoveConnection drops a connection. It reports false when the
+// connection was already gone, e.g. reaped by another instance.
+func (r *presenceRepo) RemoveConnection(ctx context.Context, userID int64, connID string) (bool, error) {
+	if r.data.redis == nil {
+		return false, fmt.Errorf("redis not available")
+	}
+
+	redisStart := time.Now()
+	pipe := r.data.redis.TxPipeline()
+	removed := pipe.ZRem(ctx, presenceExpiryKey, presenceMember(userID, connID))
+	pipe.HDel(ctx, fmt.Sprintf(presenceKeyFormat, userID), connID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, fmt.Errorf("failed to remove connection: %w", err)
+	}
+	metrics.RecordRedisOperation("remove_presence", redisStart)
+	return removed.Val() > 0, nil
+}
+
+// GetConnections returns the users' connections, including expired ones
+// that haven't been reaped yet
+func (r *presenceRepo) GetConnections(ctx context.Context, userIDs []int64) (map[int64][]*biz.PresenceConnection, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	if r.data.redis == nil {
+		return nil, fmt.Errorf("redis not available")
+	}
+
+	redisStart := time.Now()
+	pipe := r.data.redis.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(userIDs))
+	for i, userID := range userIDs {
+		cmds[i] = pipe.HGetAll(ctx, fmt.Sprintf(presenceKeyFormat, userID))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get connections: %w", err)
+	}
+	metrics.RecordRedisOperation("get_presence", redisStart)
+
+	connections := make(map[int64][]*biz.PresenceConnection)
+	for i, userID := range userIDs {
+		for connID, value := range cmds[i].Val() {
+			var activeAt, expiresAt int64
+			if _, err := fmt.Sscanf(value, "%d %d", &activeAt, &expiresAt); err != nil {
+				r.log.Warnf("Skipping malformed presence of user %d: %q", userID, value)
+				continue
+			}
+			connections[userID] = append(connections[userID], &biz.PresenceConnection{
+				UserID:    userID,
+				ConnID:    connID,
+				ActiveAt:  time.Unix(activeAt, 0),
+				ExpiresAt: time.Unix(expiresAt, 0),
+			})
+		}
+	}
+	return connections, nil
+}
+
+// ListExpiredConnections returns up to limit connections whose heartbeat
+// deadline passed before now. ActiveAt is not set.
+func (r *presenceRepo) ListExpiredConnections(ctx context.Context, now time.Time, limit int) ([]*biz.PresenceConnection, error) {
+	if r.data.redis == nil {
+		return nil, fmt.Errorf("redis not available")
+	}
+
+	redisStart := time.Now()
+	members, err := r.data.redis.ZRangeByScoreWithScores(ctx, presenceExpiryKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   fmt.Sprintf("(%d", now.Unix()),
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired connections: %w", err)
+	}
+	metrics.RecordRedisOperation("list_expired_presence", redisStart)
+
+	var expired []*biz.PresenceConnection
+	for _, member := range members {
+		name, _ := member.Member.(string)
+		userIDPart, connID, ok := strings.Cut(name, ":")
+		userID, err := strconv.ParseInt(userIDPart, 10, 64)
+		if !ok || err != nil {
+			r.log.Warnf("Skipping malformed presence member %q", name)
+			continue
+		}
+		expired = append(expired, &biz.PresenceConnection{
+			UserID:    userID,
+			ConnID:    connID,
+			ExpiresAt: time.Unix(int64(member.Score), 0),
+		})
+	}
+	return expired, nil
+}
+
+// SwapStatus stores the status announced for the user and returns the
+// previous one, or "" if none was stored. Offline isn't stored.
+func (r *presenceRepo) SwapStatus(ctx context.Context, userID int64, status string) (string, error) {
+	if r.data.redis == nil {
+		return "", fmt.Errorf("redis not available")
+	}
+
+	redisStart := time.Now()
+	key := fmt.Sprintf(presenceStatusKeyFormat, userID)
+	var previous string
+	var err error
+	if status == biz.PresenceOffline {
+		previous, err = r.data.redis.GetDel(ctx, key).Result()
+	} else {
+		previous, err = r.data.redis.SetArgs(ctx, key, status, redis.SetArgs{Get: true}).Result()
+	}
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return "", fmt.Errorf("failed to swap presence status: %w", err)
+	}
+	metrics.RecordRedisOperation("swap_presence_status", redisStart)
+	return previous, nil
+}
+
+// presenceMember names a connection in the expiry set
+func presenceMember(userID int64, connID string) string {
+	return fmt.Sprintf("%d:%s", userID, connID)
+}
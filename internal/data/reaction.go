@@ -0,0 +1,82 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/metrics"
+)
+
+// AddReaction records a user's emoji reaction, reporting false if it already existed
+func (r *messageRepo) AddReaction(ctx context.Context, messageID, userID int64, emoji string) (bool, error) {
+	dbStart := time.Now()
+
+	query := `
+		INSERT INTO message_reactions (message_id, user_id, emoji, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (message_id, user_id, emoji) DO NOTHING`
+
+	result, err := r.data.db.ExecContext(ctx, query, messageID, userID, emoji, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("failed to add reaction: %w", err)
+	}
+	metrics.RecordDBQuery("add_reaction", dbStart)
+
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+// RemoveReaction deletes a user's emoji reaction, reporting false if there was none
+func (r *messageRepo) RemoveReaction(ctx context.Context, messageID, userID int64, emoji string) (bool, error) {
+	dbStart := time.Now()
+
+	query := `DELETE FROM message_reactions WHERE message_id = $1 AND user_id = $2 AND emoji = $3`
+
+	result, err := r.data.db.ExecContext(ctx, query, messageID, userID, emoji)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove reaction: %w", err)
+	}
+	metrics.RecordDBQuery("remove_reaction", dbStart)
+
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+// GetReactions aggregates reactions per emoji for each message, in the order
+// each emoji was first used. ReactedByMe is relative to userID.
+func (r *messageRepo) GetReactions(ctx context.Context, messageIDs []int64, userID int64) (map[int64][]*chatV1.Reaction, error) {
+	reactions := make(map[int64][]*chatV1.Reaction)
+	if len(messageIDs) == 0 {
+		return reactions, nil
+	}
+
+	dbStart := time.Now()
+
+	query := `
+		SELECT message_id, emoji, COUNT(*), BOOL_OR(user_id = $2)
+		FROM message_reactions
+		WHERE message_id = ANY($1)
+		GROUP BY message_id, emoji
+		ORDER BY message_id, MIN(created_at)`
+
+	rows, err := r.data.db.QueryContext(ctx, query, pq.Array(messageIDs), userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reactions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var messageID int64
+		reaction := &chatV1.Reaction{}
+		if err := rows.Scan(&messageID, &reaction.Emoji, &reaction.Count, &reaction.ReactedByMe); err != nil {
+			return nil, fmt.Errorf("failed to scan reaction: %w", err)
+		}
+		reactions[messageID] = append(reactions[messageID], reaction)
+	}
+	metrics.RecordDBQuery("get_reactions", dbStart)
+
+	return reactions, nil
+}
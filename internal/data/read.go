@@ -0,0 +1,209 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/metrics"
+)
+
+// MarkRoomRead moves the member's read cursor forward to messageID and
+// clears their mentions up to it, reporting false if the cursor was
+// already there or past it
+func (r *messageRepo) MarkRoomRead(ctx context.Context, roomID, userID, messageID int64, readAt time.Time) (bool, error) {
+	dbStart := time.Now()
+
+	tx, err := r.data.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	cursorQuery := `
+		UPDATE room_members SET last_read_message_id = $3, last_read_at = $4
+		WHERE room_id = $1 AND user_id = $2 AND last_read_message_id < $3`
+
+	result, err := tx.ExecContext(ctx, cursorQuery, roomID, userID, messageID, readAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to move read cursor: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+
+	// Reading a message clears any mention of the reader in it
+	mentionQuery := `
+		UPDATE message_mentions SET read_at = $4
+		WHERE room_id = $1 AND user_id = $2 AND message_id <= $3 AND read_at IS NULL`
+	if _, err := tx.ExecContext(ctx, mentionQuery, roomID, userID, messageID, readAt); err != nil {
+		return false, fmt.Errorf("failed to mark mentions as read: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit read cursor: %w", err)
+	}
+	metrics.RecordDBQuery("mark_room_read", dbStart)
+
+	return rowsAffected > 0, nil
+}
+
+// GetLastReadMessageID returns the member's read cursor in the room (0 = nothing read)
+func (r *messageRepo) GetLastReadMessageID(ctx context.Context, roomID, userID int64) (int64, error) {
+	var lastRead int64
+	query := `SELECT last_read_message_id FROM room_members WHERE room_id = $1 AND user_id = $2`
+
+	err := r.data.db.QueryRowContext(ctx, query, roomID, userID).Scan(&lastRead)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get read cursor: %w", err)
+	}
+
+	return lastRead, nil
+}
+
+// CountUnread counts the room timeline messages past the member's read
+// cursor, leaving out their own, deleted and system messages
+func (r *messageRepo) CountUnread(ctx context.Context, roomID, userID int64) (int32, error) {
+	dbStart := time.Now()
+
+	var count int32
+	query := `
+		SELECT COUNT(*)
+		FROM room_members rm
+		JOIN messages m ON m.room_id = rm.room_id AND m.id > rm.last_read_message_id
+		WHERE rm.room_id = $1 AND rm.user_id = $2
+		  AND m.user_id != $2 AND m.parent_message_id IS NULL
+		  AND m.deleted_at IS NULL AND m.type != 'system'`
+
+	err := r.data.db.QueryRowContext(ctx, query, roomID, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get unread count: %w", err)
+	}
+	metrics.RecordDBQuery("count_unread", dbStart)
+
+	return count, nil
+}
+
+// GetReadCursors returns every member's read cursor in the room
+func (r *messageRepo) GetReadCursors(ctx context.Context, roomID int64) ([]*chatV1.ReadCursor, error) {
+	query := `
+		SELECT rm.user_id, u.username, rm.last_read_message_id, rm.last_read_at
+		FROM room_members rm
+		JOIN users u ON rm.user_id = u.id
+		WHERE rm.room_id = $1
+		ORDER BY rm.last_read_message_id DESC, rm.user_id`
+
+	rows, err := r.data.db.QueryContext(ctx, query, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get read cursors: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var cursors []*chatV1.ReadCursor
+	for rows.Next() {
+		cursor := &chatV1.ReadCursor{}
+		var readAt sql.NullTime
+		if err := rows.Scan(&cursor.UserId, &cursor.Username, &cursor.LastReadMessageId, &readAt); err != nil {
+			return nil, fmt.Errorf("failed to scan read cursor: %w", err)
+		}
+		if readAt.Valid {
+			cursor.ReadAt = readAt.Time.Unix()
+		}
+		cursors = append(cursors, cursor)
+	}
+
+	return cursors, nil
+}
+
+// GetUnreadMessages returns the room timeline messages past the member's
+// read cursor, oldest first, with the same exclusions as CountUnread
+func (r *messageRepo) GetUnreadMessages(ctx context.Context, roomID, userID int64, limit int32) ([]*chatV1.Message, bool, error) {
+	dbStart := time.Now()
+
+	// Fetch one extra row to know whether another page exists
+	query := `
+		SELECT ` + messageColumns + `
+		FROM room_members rm
+		JOIN messages m ON m.room_id = rm.room_id AND m.id > rm.last_read_message_id
+		JOIN users u ON m.user_id = u.id
+		WHERE rm.room_id = $1 AND rm.user_id = $2
+		  AND m.user_id != $2 AND m.parent_message_id IS NULL
+		  AND m.deleted_at IS NULL AND m.type != 'system'
+		ORDER BY m.id ASC
+		LIMIT $3`
+
+	rows, err := r.data.db.QueryContext(ctx, query, roomID, userID, limit+1)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get unread messages: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var messages []*chatV1.Message
+	for rows.Next() {
+		message, err := scanMessage(rows)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to scan unread message: %w", err)
+		}
+		messages = append(messages, message)
+	}
+	metrics.RecordDBQuery("get_unread_messages", dbStart)
+
+	hasMore := len(messages) > int(limit)
+	if hasMore {
+		messages = messages[:limit]
+	}
+
+	return messages, hasMore, nil
+}
+
+// GetRoomUnreads returns the user's unread and unread mention counts in
+// every room they belong to, in one query. Each room's unread count stops
+// at maxCount, so a room far behind costs no more than maxCount rows.
+func (r *messageRepo) GetRoomUnreads(ctx context.Context, userID int64, maxCount int32) ([]*chatV1.RoomUnread, error) {
+	dbStart := time.Now()
+
+	query := `
+		SELECT rm.room_id, u.unread, COALESCE(mc.mentions, 0), u.first_id
+		FROM room_members rm
+		CROSS JOIN LATERAL (
+			SELECT COUNT(*) AS unread, COALESCE(MIN(um.id), 0) AS first_id
+			FROM (
+				SELECT m.id FROM messages m
+				WHERE m.room_id = rm.room_id AND m.id > rm.last_read_message_id
+				  AND m.user_id != rm.user_id AND m.parent_message_id IS NULL
+				  AND m.deleted_at IS NULL AND m.type != 'system'
+				ORDER BY m.id
+				LIMIT $2
+			) um
+		) u
+		LEFT JOIN (
+			SELECT mm.room_id, COUNT(*) AS mentions
+			FROM message_mentions mm
+			JOIN messages mm_m ON mm.message_id = mm_m.id
+			WHERE mm.user_id = $1 AND mm.read_at IS NULL AND mm_m.deleted_at IS NULL
+			GROUP BY mm.room_id
+		) mc ON mc.room_id = rm.room_id
+		WHERE rm.user_id = $1
+		ORDER BY rm.room_id`
+
+	rows, err := r.data.db.QueryContext(ctx, query, userID, maxCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unread summary: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var rooms []*chatV1.RoomUnread
+	for rows.Next() {
+		room := &chatV1.RoomUnread{}
+		if err := rows.Scan(&room.RoomId, &room.UnreadCount, &room.MentionCount, &room.FirstUnreadMessageId); err != nil {
+			return nil, fmt.Errorf("failed to scan unread summary: %w", err)
+		}
+		rooms = append(rooms, room)
+	}
+	metrics.RecordDBQuery("get_room_unreads", dbStart)
+
+	return rooms, nil
+}
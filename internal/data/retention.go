@@ -0,0 +1,56 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/metrics"
+)
+
+// DeleteRetainedMessages hard-deletes up to limit messages that their room's
+// retention policy no longer keeps, along with the replies of purged thread
+// roots, and returns them. Rooms without a policy follow defaultPolicy.
+// A "days" policy purges any message older than the cutoff; a "messages"
+// policy counts timeline messages only, so replies go with their root.
+// SKIP LOCKED lets replicas purge disjoint batches.
+func (r *messageRepo) DeleteRetainedMessages(ctx context.Context, now time.Time, defaultPolicy *chatV1.RetentionPolicy, limit int32) ([]*chatV1.Message, error) {
+	query := `
+		WITH policies AS (
+			SELECT id AS room_id,
+			       COALESCE(retention_mode, $2) AS mode,
+			       CASE WHEN retention_mode IS NULL THEN $3 ELSE retention_value END AS value
+			FROM rooms
+		),
+		cutoffs AS (
+			SELECT p.room_id, p.mode,
+			       $1::timestamp - make_interval(days => p.value) AS min_created_at,
+			       (SELECT m.id FROM messages m
+			        WHERE m.room_id = p.room_id AND m.parent_message_id IS NULL
+			        ORDER BY m.id DESC
+			        OFFSET p.value - 1 LIMIT 1) AS min_id
+			FROM policies p
+			WHERE p.mode IN ('days', 'messages') AND p.value > 0
+		),
+		purged AS (
+			SELECT m.id FROM messages m
+			JOIN cutoffs c ON c.room_id = m.room_id
+			WHERE (c.mode = 'days' AND m.created_at < c.min_created_at)
+			   OR (c.mode = 'messages' AND m.parent_message_id IS NULL AND m.id < c.min_id)
+			ORDER BY m.id
+			LIMIT $4
+			FOR UPDATE OF m SKIP LOCKED
+		)
+		DELETE FROM messages
+		WHERE id IN (SELECT id FROM purged) OR parent_message_id IN (SELECT id FROM purged)
+		RETURNING id, room_id, COALESCE(parent_message_id, 0), COALESCE(file_url, '')`
+
+	deleted, err := r.hardDeleteMessages(ctx, "delete_retained_messages", query,
+		now, defaultPolicy.Mode, defaultPolicy.Value, limit)
+	if err != nil {
+		return nil, err
+	}
+	metrics.RecordMessagesPurged(len(deleted))
+
+	return deleted, nil
+}
@@ -19,6 +19,13 @@ type RoomRepo interface {
 	LeaveRoom(ctx context.Context, roomID, userID int64) error
 	GetRoomMembers(ctx context.Context, roomID int64) ([]*chatV1.RoomMember, error)
 	IsUserInRoom(ctx context.Context, roomID, userID int64) (bool, error)
+	GetMemberRole(ctx context.Context, roomID, userID int64) (string, error)
+	GetMessageTTL(ctx context.Context, roomID int64) (int32, error)
+	SetMessageTTL(ctx context.Context, roomID int64, ttlSeconds int32) error
+	SetRetentionPolicy(ctx context.Context, roomID int64, policy *chatV1.RetentionPolicy) error
+	RenameRoom(ctx context.Context, roomID int64, name string) error
+	UpdateMemberRole(ctx context.Context, roomID, userID int64, role string) error
+	ListRoommateIDs(ctx context.Context, userID int64) ([]int64, error)
 }
 
 type roomRepo struct {
@@ -36,8 +43,8 @@ func NewRoomRepo(data *Data, logger log.Logger) RoomRepo {
 
 func (r *roomRepo) CreateRoom(ctx context.Context, room *chatV1.Room) (*chatV1.Room, error) {
 	query := `
-		INSERT INTO rooms (name, description, type, created_by, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO rooms (name, description, type, created_by, message_ttl_seconds, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING id`
 
 	now := time.Now()
@@ -48,6 +55,7 @@ func (r *roomRepo) CreateRoom(ctx context.Context, room *chatV1.Room) (*chatV1.R
 		room.Description,
 		room.Type,
 		room.CreatedBy,
+		room.MessageTtl,
 		now,
 		now,
 	).Scan(&room.Id)
@@ -68,9 +76,12 @@ func (r *roomRepo) CreateRoom(ctx context.Context, room *chatV1.Room) (*chatV1.R
 func (r *roomRepo) GetRoomByID(ctx context.Context, id int64) (*chatV1.Room, error) {
 	room := &chatV1.Room{}
 	var createdAt time.Time
+	var retentionMode sql.NullString
+	retention := &chatV1.RetentionPolicy{}
 
 	query := `
-		SELECT id, name, description, type, created_by, created_at,
+		SELECT id, name, description, type, created_by, created_at, message_ttl_seconds,
+		       retention_mode, retention_value,
 		       (SELECT COUNT(*) FROM room_members WHERE room_id = $1) as member_count
 		FROM rooms
 		WHERE id = $1`
@@ -82,6 +93,9 @@ func (r *roomRepo) GetRoomByID(ctx context.Context, id int64) (*chatV1.Room, err
 		&room.Type,
 		&room.CreatedBy,
 		&createdAt,
+		&room.MessageTtl,
+		&retentionMode,
+		&retention.Value,
 		&room.MemberCount,
 	)
 
@@ -93,6 +107,11 @@ func (r *roomRepo) GetRoomByID(ctx context.Context, id int64) (*chatV1.Room, err
 	}
 
 	room.CreatedAt = createdAt.Unix()
+	retention.Mode = retentionMode.String
+	if !retentionMode.Valid {
+		retention.Mode = "default"
+	}
+	room.Retention = retention
 
 	// Get room members
 	members, err := r.GetRoomMembers(ctx, id)
@@ -168,22 +187,16 @@ func (r *roomRepo) JoinRoom(ctx context.Context, roomID, userID int64, role stri
 		return fmt.Errorf("user already in room")
 	}
 
+	// New members start caught up rather than with the whole history unread
 	query := `
-		INSERT INTO room_members (room_id, user_id, role, joined_at)
-		VALUES ($1, $2, $3, $4)`
+		INSERT INTO room_members (room_id, user_id, role, joined_at, last_read_message_id)
+		VALUES ($1, $2, $3, $4, (SELECT COALESCE(MAX(id), 0) FROM messages WHERE room_id = $1))`
 
 	_, err = r.data.db.ExecContext(ctx, query, roomID, userID, role, time.Now())
 	if err != nil {
 		return fmt.Errorf("failed to join room: %w", err)
 	}
 
-	// Update Redis set of room members for fast access
-	if r.data.redis != nil {
-		key := fmt.Sprintf("room:%d:members", roomID)
-		r.data.redis.SAdd(ctx, key, userID)
-		r.data.redis.Expire(ctx, key, time.Hour) // Cache for 1 hour
-	}
-
 	r.log.Infof("user joined room: user_id=%d, room_id=%d, role=%s", userID, roomID, role)
 	return nil
 }
@@ -201,12 +214,6 @@ func (r *roomRepo) LeaveRoom(ctx context.Context, roomID, userID int64) error {
 		return fmt.Errorf("user not in room")
 	}
 
-	// Remove from Redis set
-	if r.data.redis != nil {
-		key := fmt.Sprintf("room:%d:members", roomID)
-		r.data.redis.SRem(ctx, key, userID)
-	}
-
 	r.log.Infof("user left room: user_id=%d, room_id=%d", userID, roomID)
 	return nil
 }
@@ -262,3 +269,123 @@ func (r *roomRepo) IsUserInRoom(ctx context.Context, roomID, userID int64) (bool
 
 	return exists, nil
 }
+
+// GetMemberRole returns the member's role, or an empty string if the user is not in the room
+func (r *roomRepo) GetMemberRole(ctx context.Context, roomID, userID int64) (string, error) {
+	var role string
+	query := `SELECT role FROM room_members WHERE room_id = $1 AND user_id = $2`
+
+	err := r.data.db.QueryRowContext(ctx, query, roomID, userID).Scan(&role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get member role: %w", err)
+	}
+
+	return role, nil
+}
+
+// GetMessageTTL returns the room's default message lifetime in seconds (0 = never expire)
+func (r *roomRepo) GetMessageTTL(ctx context.Context, roomID int64) (int32, error) {
+	var ttlSeconds int32
+	query := `SELECT message_ttl_seconds FROM rooms WHERE id = $1`
+
+	err := r.data.db.QueryRowContext(ctx, query, roomID).Scan(&ttlSeconds)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("room not found")
+		}
+		return 0, fmt.Errorf("failed to get message ttl: %w", err)
+	}
+
+	return ttlSeconds, nil
+}
+
+// SetMessageTTL sets the room's default message lifetime in seconds
+func (r *roomRepo) SetMessageTTL(ctx context.Context, roomID int64, ttlSeconds int32) error {
+	query := `UPDATE rooms SET message_ttl_seconds = $2, updated_at = $3 WHERE id = $1`
+
+	if _, err := r.data.db.ExecContext(ctx, query, roomID, ttlSeconds, time.Now()); err != nil {
+		return fmt.Errorf("failed to set message ttl: %w", err)
+	}
+
+	r.log.Infof("set message ttl: room_id=%d, ttl=%ds", roomID, ttlSeconds)
+	return nil
+}
+
+// SetRetentionPolicy sets how long the room keeps its messages.
+// The "default" mode is stored as NULL so the room follows the server's policy.
+func (r *roomRepo) SetRetentionPolicy(ctx context.Context, roomID int64, policy *chatV1.RetentionPolicy) error {
+	mode := sql.NullString{String: policy.Mode, Valid: policy.Mode != "default"}
+	query := `UPDATE rooms SET retention_mode = $2, retention_value = $3, updated_at = $4 WHERE id = $1`
+
+	if _, err := r.data.db.ExecContext(ctx, query, roomID, mode, policy.Value, time.Now()); err != nil {
+		return fmt.Errorf("failed to set retention policy: %w", err)
+	}
+
+	r.log.Infof("set retention policy: room_id=%d, mode=%s, value=%d", roomID, policy.Mode, policy.Value)
+	return nil
+}
+
+// RenameRoom changes the room's name
+func (r *roomRepo) RenameRoom(ctx context.Context, roomID int64, name string) error {
+	query := `UPDATE rooms SET name = $2, updated_at = $3 WHERE id = $1`
+
+	result, err := r.data.db.ExecContext(ctx, query, roomID, name, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to rename room: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("room not found")
+	}
+
+	r.log.Infof("renamed room: room_id=%d, name=%s", roomID, name)
+	return nil
+}
+
+// UpdateMemberRole changes a member's role in the room
+func (r *roomRepo) UpdateMemberRole(ctx context.Context, roomID, userID int64, role string) error {
+	query := `UPDATE room_members SET role = $3 WHERE room_id = $1 AND user_id = $2`
+
+	result, err := r.data.db.ExecContext(ctx, query, roomID, userID, role)
+	if err != nil {
+		return fmt.Errorf("failed to update member role: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not in room")
+	}
+
+	r.log.Infof("updated member role: user_id=%d, room_id=%d, role=%s", userID, roomID, role)
+	return nil
+}
+
+// ListRoommateIDs returns the users sharing at least one room with the user
+func (r *roomRepo) ListRoommateIDs(ctx context.Context, userID int64) ([]int64, error) {
+	query := `
+		SELECT DISTINCT other.user_id
+		FROM room_members mine
+		JOIN room_members other ON other.room_id = mine.room_id
+		WHERE mine.user_id = $1 AND other.user_id <> $1`
+
+	rows, err := r.data.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roommates: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var userIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan roommate: %w", err)
+		}
+		userIDs = append(userIDs, id)
+	}
+
+	return userIDs, rows.Err()
+}
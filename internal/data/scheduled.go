@@ -0,0 +1,208 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/metrics"
+)
+
+// scheduledMessageColumns is the select list shared by scheduled message queries
+const scheduledMessageColumns = `id, room_id, user_id, content, type,
+		       file_url, file_name, file_size, mime_type, parent_message_id,
+		       expires_in, quoted_message_id, format, poll, send_at, status, message_id, created_at`
+
+// CreateScheduledMessage stores a pending scheduled message
+func (r *messageRepo) CreateScheduledMessage(ctx context.Context, scheduled *chatV1.ScheduledMessage) (*chatV1.ScheduledMessage, error) {
+	dbStart := time.Now()
+
+	req := scheduled.Message
+	var parentID sql.NullInt64
+	if req.ParentMessageId != 0 {
+		parentID = sql.NullInt64{Int64: req.ParentMessageId, Valid: true}
+	}
+
+	var poll []byte
+	if req.Poll != nil {
+		var err error
+		if poll, err = json.Marshal(req.Poll); err != nil {
+			return nil, fmt.Errorf("failed to encode poll: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO scheduled_messages (room_id, user_id, content, type, file_url, file_name, file_size, mime_type,
+		                                parent_message_id, expires_in, quoted_message_id, format, poll, send_at, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, 'pending', $15)
+		RETURNING ` + scheduledMessageColumns
+
+	row := r.data.db.QueryRowContext(ctx, query,
+		req.RoomId, scheduled.UserId, req.Content, req.Type, req.FileUrl, req.FileName, req.FileSize, req.MimeType,
+		parentID, req.ExpiresIn, req.QuotedMessageId, req.Format, poll, time.Unix(scheduled.SendAt, 0), time.Now(),
+	)
+	created, err := scanScheduledMessage(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scheduled message: %w", err)
+	}
+	metrics.RecordDBQuery("create_scheduled_message", dbStart)
+
+	return created, nil
+}
+
+// GetPendingScheduledMessages lists a user's pending scheduled messages, soonest first.
+// roomID 0 lists every room.
+func (r *messageRepo) GetPendingScheduledMessages(ctx context.Context, userID, roomID int64) ([]*chatV1.ScheduledMessage, error) {
+	dbStart := time.Now()
+
+	query := `
+		SELECT ` + scheduledMessageColumns + `
+		FROM scheduled_messages
+		WHERE user_id = $1 AND status = 'pending' AND ($2 = 0 OR room_id = $2)
+		ORDER BY send_at, id`
+
+	rows, err := r.data.db.QueryContext(ctx, query, userID, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduled messages: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var scheduled []*chatV1.ScheduledMessage
+	for rows.Next() {
+		message, err := scanScheduledMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled message: %w", err)
+		}
+		scheduled = append(scheduled, message)
+	}
+	metrics.RecordDBQuery("get_scheduled_messages", dbStart)
+
+	return scheduled, nil
+}
+
+// CancelScheduledMessage cancels a user's pending scheduled message,
+// reporting false if it doesn't exist or is no longer pending
+func (r *messageRepo) CancelScheduledMessage(ctx context.Context, id, userID int64) (bool, error) {
+	dbStart := time.Now()
+
+	query := `
+		UPDATE scheduled_messages SET status = 'cancelled'
+		WHERE id = $1 AND user_id = $2 AND status = 'pending'`
+
+	result, err := r.data.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel scheduled message: %w", err)
+	}
+	metrics.RecordDBQuery("cancel_scheduled_message", dbStart)
+
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+// ClaimDueScheduledMessages moves up to limit due pending messages to sending
+// and returns them, along with messages whose claim was taken before
+// reclaimBefore and never completed (the dispatcher crashed or was stopped
+// mid-batch). SKIP LOCKED lets each replica claim a disjoint batch.
+func (r *messageRepo) ClaimDueScheduledMessages(ctx context.Context, now, reclaimBefore time.Time, limit int32) ([]*chatV1.ScheduledMessage, error) {
+	dbStart := time.Now()
+
+	query := `
+		UPDATE scheduled_messages SET status = 'sending', claimed_at = $1
+		WHERE id IN (
+			SELECT id FROM scheduled_messages
+			WHERE (status = 'pending' AND send_at <= $1)
+			   OR (status = 'sending' AND (claimed_at IS NULL OR claimed_at < $2))
+			ORDER BY send_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + scheduledMessageColumns
+
+	rows, err := r.data.db.QueryContext(ctx, query, now, reclaimBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim scheduled messages: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var claimed []*chatV1.ScheduledMessage
+	for rows.Next() {
+		message, err := scanScheduledMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled message: %w", err)
+		}
+		claimed = append(claimed, message)
+	}
+	metrics.RecordDBQuery("claim_scheduled_messages", dbStart)
+
+	return claimed, nil
+}
+
+// CompleteScheduledMessage records the outcome of a claimed message:
+// sent with its message ID, or failed with the reason
+func (r *messageRepo) CompleteScheduledMessage(ctx context.Context, id, messageID int64, failure string) error {
+	dbStart := time.Now()
+
+	var query string
+	var args []interface{}
+	if failure != "" {
+		query = `UPDATE scheduled_messages SET status = 'failed', error = $2 WHERE id = $1`
+		args = []interface{}{id, failure}
+	} else {
+		query = `UPDATE scheduled_messages SET status = 'sent', message_id = $2 WHERE id = $1`
+		args = []interface{}{id, messageID}
+	}
+
+	if _, err := r.data.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to complete scheduled message: %w", err)
+	}
+	metrics.RecordDBQuery("complete_scheduled_message", dbStart)
+
+	return nil
+}
+
+// scanScheduledMessage reads a row selected with scheduledMessageColumns
+func scanScheduledMessage(row rowScanner) (*chatV1.ScheduledMessage, error) {
+	scheduled := &chatV1.ScheduledMessage{Message: &chatV1.SendMessageRequest{}}
+	req := scheduled.Message
+	var parentID, messageID sql.NullInt64
+	var poll []byte
+	var sendAt, createdAt time.Time
+
+	if err := row.Scan(
+		&scheduled.Id,
+		&req.RoomId,
+		&scheduled.UserId,
+		&req.Content,
+		&req.Type,
+		&req.FileUrl,
+		&req.FileName,
+		&req.FileSize,
+		&req.MimeType,
+		&parentID,
+		&req.ExpiresIn,
+		&req.QuotedMessageId,
+		&req.Format,
+		&poll,
+		&sendAt,
+		&scheduled.Status,
+		&messageID,
+		&createdAt,
+	); err != nil {
+		return nil, err
+	}
+
+	req.ParentMessageId = parentID.Int64
+	if poll != nil {
+		req.Poll = &chatV1.PollRequest{}
+		if err := json.Unmarshal(poll, req.Poll); err != nil {
+			return nil, fmt.Errorf("failed to decode poll: %w", err)
+		}
+	}
+	scheduled.MessageId = messageID.Int64
+	scheduled.SendAt = sendAt.Unix()
+	scheduled.CreatedAt = createdAt.Unix()
+	return scheduled, nil
+}
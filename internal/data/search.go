@@ -0,0 +1,117 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/metrics"
+)
+
+// searchConfig is the text search configuration behind messages.search_vector
+const searchConfig = "english"
+
+// searchHeadlineOptions wraps matched terms in <mark> and keeps snippets short
+const searchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5"
+
+// MessageSearchFilter narrows SearchMessages; zero fields are ignored
+type MessageSearchFilter struct {
+	Query    string
+	RoomID   int64
+	AuthorID int64
+	From     time.Time
+	To       time.Time
+	Type     string
+	FileName string
+	BeforeID int64
+}
+
+// SearchMessages runs a full-text search over messages in rooms the user
+// belongs to, newest first. Deleted messages are never returned.
+func (r *messageRepo) SearchMessages(ctx context.Context, userID int64, filter *MessageSearchFilter, limit int32) ([]*chatV1.SearchResult, bool, error) {
+	dbStart := time.Now()
+
+	args := []interface{}{userID}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions := []string{"m.deleted_at IS NULL"}
+	// Content is HTML-escaped, so the only markup in a snippet is <mark>
+	snippet := escapeHTMLSQL("left(m.content, 200)")
+
+	if filter.Query != "" {
+		tsQuery := fmt.Sprintf("websearch_to_tsquery('%s', %s)", searchConfig, arg(filter.Query))
+		conditions = append(conditions, "m.search_vector @@ "+tsQuery)
+		snippet = fmt.Sprintf("ts_headline('%s', %s, %s, '%s')", searchConfig, escapeHTMLSQL("m.content"), tsQuery, searchHeadlineOptions)
+	}
+	if filter.RoomID != 0 {
+		conditions = append(conditions, "m.room_id = "+arg(filter.RoomID))
+	}
+	if filter.AuthorID != 0 {
+		conditions = append(conditions, "m.user_id = "+arg(filter.AuthorID))
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "m.created_at >= "+arg(filter.From))
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "m.created_at < "+arg(filter.To))
+	}
+	if filter.Type != "" {
+		conditions = append(conditions, "m.type = "+arg(filter.Type))
+	}
+	if filter.FileName != "" {
+		conditions = append(conditions, "m.file_name ILIKE "+arg("%"+escapeLike(filter.FileName)+"%"))
+	}
+	if filter.BeforeID != 0 {
+		conditions = append(conditions, "m.id < "+arg(filter.BeforeID))
+	}
+
+	// Fetch one extra row to know whether another page exists
+	query := `
+		SELECT ` + messageColumns + `, ` + snippet + `
+		FROM messages m
+		JOIN users u ON m.user_id = u.id
+		JOIN room_members rm ON rm.room_id = m.room_id AND rm.user_id = $1
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY m.id DESC
+		LIMIT ` + arg(limit+1)
+
+	rows, err := r.data.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to search messages: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []*chatV1.SearchResult
+	for rows.Next() {
+		result := &chatV1.SearchResult{}
+		message, err := scanMessage(rows, &result.Snippet)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		result.Message = message
+		results = append(results, result)
+	}
+	metrics.RecordDBQuery("search_messages", dbStart)
+
+	hasMore := len(results) > int(limit)
+	if hasMore {
+		results = results[:limit]
+	}
+
+	return results, hasMore, nil
+}
+
+// escapeHTMLSQL wraps a SQL text expression so it is HTML-escaped
+func escapeHTMLSQL(expr string) string {
+	return "replace(replace(replace(" + expr + ", '&', '&amp;'), '<', '&lt;'), '>', '&gt;')"
+}
+
+// escapeLike escapes LIKE wildcards so user input matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,112 @@
+package data
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"github.com/yourusername/chat-app/internal/biz"
+	"github.com/yourusername/chat-app/internal/metrics"
+	"github.com/yourusername/chat-app/internal/unfurl"
+)
+
+const (
+	// linkPreviewKeyFormat caches a URL's preview, keyed by the URL's SHA-256
+	linkPreviewKeyFormat = "link_preview:%x"
+	// linkPreviewTTL is how long a fetched preview is reused
+	linkPreviewTTL = 24 * time.Hour
+	// linkPreviewMissTTL is how long a URL without a preview is not refetched
+	linkPreviewMissTTL = time.Hour
+)
+
+type linkUnfurler struct {
+	data    *Data
+	fetcher *unfurl.Fetcher
+	log     *log.Helper
+}
+
+// NewLinkUnfurler creates a link unfurler that caches previews in Redis
+func NewLinkUnfurler(data *Data, logger log.Logger) biz.LinkUnfurler {
+	return &linkUnfurler{
+		data:    data,
+		fetcher: unfurl.NewFetcher(nil),
+		log:     log.NewHelper(log.With(logger, "module", "data/unfurl")),
+	}
+}
+
+// Unfurl returns the preview of url, or nil if the page has none or
+// can't be fetched. Both outcomes are cached.
+func (u *linkUnfurler) Unfurl(ctx context.Context, url string) (*biz.LinkPreview, error) {
+	key := fmt.Sprintf(linkPreviewKeyFormat, sha256.Sum256([]byte(url)))
+
+	if u.data.redis != nil {
+		redisStart := time.Now()
+		cached, err := u.data.redis.Get(ctx, key).Bytes()
+		if err == nil {
+			metrics.RecordRedisOperation("get_link_preview", redisStart)
+			return decodeLinkPreview(cached)
+		}
+		if !errors.Is(err, redis.Nil) {
+			u.log.Warnf("Failed to read cached preview of %s: %v", url, err)
+		}
+	}
+
+	preview, err := u.fetcher.Fetch(ctx, url)
+	if err != nil {
+		u.log.Infof("No preview for %s: %v", url, err)
+		preview = nil
+	}
+	// Don't cache a miss caused by our own shutdown or deadline
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if u.data.redis != nil {
+		payload, ttl := []byte("{}"), linkPreviewMissTTL
+		if preview != nil {
+			if payload, err = json.Marshal(preview); err != nil {
+				return nil, fmt.Errorf("failed to encode link preview: %w", err)
+			}
+			ttl = linkPreviewTTL
+		}
+
+		redisStart := time.Now()
+		if err := u.data.redis.Set(ctx, key, payload, ttl).Err(); err != nil {
+			u.log.Warnf("Failed to cache preview of %s: %v", url, err)
+		} else {
+			metrics.RecordRedisOperation("set_link_preview", redisStart)
+		}
+	}
+
+	if preview == nil {
+		return nil, nil
+	}
+	return toBizLinkPreview(preview), nil
+}
+
+// decodeLinkPreview reads a cached preview; an empty object is a cached miss
+func decodeLinkPreview(cached []byte) (*biz.LinkPreview, error) {
+	preview := &unfurl.Preview{}
+	if err := json.Unmarshal(cached, preview); err != nil {
+		return nil, fmt.Errorf("failed to decode link preview: %w", err)
+	}
+	if preview.URL == "" {
+		return nil, nil
+	}
+	return toBizLinkPreview(preview), nil
+}
+
+func toBizLinkPreview(preview *unfurl.Preview) *biz.LinkPreview {
+	return &biz.LinkPreview{
+		URL:         preview.URL,
+		Title:       preview.Title,
+		Description: preview.Description,
+		ImageURL:    preview.ImageURL,
+		SiteName:    preview.SiteName,
+	}
+}
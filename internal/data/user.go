@@ -17,7 +17,7 @@ type UserRepo interface {
 	GetUserByUsername(ctx context.Context, username string) (*userV1.User, string, error) // returns user and password hash
 	GetUserByID(ctx context.Context, id int64) (*userV1.User, error)
 	UpdateUserStatus(ctx context.Context, userID int64, status string) error
-	UpdateLastSeen(ctx context.Context, userID int64) error
+	UpdateLastSeen(ctx context.Context, userID int64, at time.Time) error
 }
 
 type userRepo struct {
@@ -197,10 +197,11 @@ func (r *userRepo) UpdateUserStatus(ctx context.Context, userID int64, status st
 	return nil
 }
 
-func (r *userRepo) UpdateLastSeen(ctx context.Context, userID int64) error {
-	query := `UPDATE users SET last_seen = $1 WHERE id = $2`
+// UpdateLastSeen moves last_seen forward to at; an older time is ignored
+func (r *userRepo) UpdateLastSeen(ctx context.Context, userID int64, at time.Time) error {
+	query := `UPDATE users SET last_seen = GREATEST(last_seen, $1) WHERE id = $2`
 
-	_, err := r.data.db.ExecContext(ctx, query, time.Now(), userID)
+	_, err := r.data.db.ExecContext(ctx, query, at, userID)
 	if err != nil {
 		return fmt.Errorf("failed to update last seen: %w", err)
 	}
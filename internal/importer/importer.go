@@ -0,0 +1,159 @@
+// Package importer reads history exported from other chat systems as a
+// biz.ImportSource. Two formats are supported:
+//
+//   - slack: a Slack workspace export zip (users.json, channels.json,
+//     groups.json and one JSON file per channel and day). Direct messages
+//     and messages without a user, such as most bot posts, are left out.
+//
+//   - jsonl: a JSON-lines file, one object per line, with a "type" of
+//     user, room or message:
+//
+//     {"type":"user","id":"u1","email":"ada@example.com","username":"ada"}
+//     {"type":"room","id":"r1","name":"general","description":"","private":false,"members":["u1"]}
+//     {"type":"message","id":"m1","room":"r1","user":"u1","text":"hi","created_at":"2024-05-01T09:30:00Z",
+//     "thread_id":"","files":[{"name":"a.png","mime_type":"image/png","url":"https://files.example.com/a.png"}]}
+//
+//     Each object is a single line; the message above is wrapped to fit.
+//     IDs must stay the same between runs, and room and message IDs must be
+//     unique across every JSON-lines export imported into the server. A
+//     room's messages are imported in file order, so thread roots must come
+//     before their replies.
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/yourusername/chat-app/internal/biz"
+	"github.com/yourusername/chat-app/internal/unfurl"
+)
+
+// Supported export formats
+const (
+	FormatSlack = "slack"
+	FormatJSONL = "jsonl"
+)
+
+var (
+	ErrUnknownFormat  = errors.New("importer: unknown format, expected slack or jsonl")
+	ErrExportTooLarge = errors.New("importer: export is larger than 2 GiB")
+)
+
+// batchSize is how many messages a source hands over at a time
+const batchSize = 500
+
+// downloadTimeout bounds a single export or attachment download
+const downloadTimeout = 10 * time.Minute
+
+// maxExportBytes is the largest export Download accepts
+const maxExportBytes = 2 << 30
+
+// Open reads an export file in the given format. slackToken, if set, is
+// sent when downloading Slack attachments, which are private, and only to
+// slack.com hosts.
+func Open(format string, file *os.File, slackToken string) (biz.ImportSource, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("importer: failed to stat export: %w", err)
+	}
+
+	client := newHTTPClient()
+	switch format {
+	case FormatSlack:
+		return NewSlackSource(file, info.Size(), client, slackToken)
+	case FormatJSONL:
+		return NewJSONLSource(file, info.Size(), client), nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// Download copies the export at url into a temporary file. The caller
+// closes and removes it.
+func Download(ctx context.Context, url string) (*os.File, error) {
+	reader, size, err := fetch(ctx, newHTTPClient(), url, "")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+	if size > maxExportBytes {
+		return nil, ErrExportTooLarge
+	}
+
+	file, err := os.CreateTemp("", "chat-import-*")
+	if err != nil {
+		return nil, fmt.Errorf("importer: failed to create temporary file: %w", err)
+	}
+	written, err := io.Copy(file, io.LimitReader(reader, maxExportBytes+1))
+	if err == nil && written > maxExportBytes {
+		err = ErrExportTooLarge
+	}
+	if err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		if errors.Is(err, ErrExportTooLarge) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("importer: failed to download export: %w", err)
+	}
+	return file, nil
+}
+
+// newHTTPClient creates the client for exports and attachments. Their URLs
+// come from untrusted export data, so only public addresses are dialed.
+func newHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+		Control: unfurl.PublicAddressOnly,
+	}
+	return &http.Client{
+		Timeout: downloadTimeout,
+		Transport: &http.Transport{
+			// No proxy: the address check must see the real destination
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   30 * time.Second,
+			ResponseHeaderTimeout: time.Minute,
+			IdleConnTimeout:       90 * time.Second,
+		},
+	}
+}
+
+// remoteFile is an attachment fetched over HTTP when the import copies it
+func remoteFile(client *http.Client, name, mimeType, url, token string) *biz.ImportFile {
+	return &biz.ImportFile{
+		Name:     name,
+		MimeType: mimeType,
+		Open: func(ctx context.Context) (io.ReadCloser, int64, error) {
+			return fetch(ctx, client, url, token)
+		},
+	}
+}
+
+// fetch GETs url, sending token as a bearer token when set, and returns
+// the body with its length (-1 if unknown)
+func fetch(ctx context.Context, client *http.Client, url, token string) (io.ReadCloser, int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("importer: invalid url: %w", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("importer: failed to fetch %s: %w", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, 0, fmt.Errorf("importer: fetching %s returned %s", url, resp.Status)
+	}
+	return resp.Body, resp.ContentLength, nil
+}
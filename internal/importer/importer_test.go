@@ -0,0 +1,27 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yourusername/chat-app/internal/unfurl"
+)
+
+func TestDownload_BlocksPrivateAddress(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"type":"user","id":"u1"}`))
+	}))
+	defer server.Close()
+
+	// Act
+	_, err := Download(context.Background(), server.URL)
+
+	// Assert
+	if !errors.Is(err, unfurl.ErrBlockedAddress) {
+		t.Errorf("expected a loopback export URL blocked, got %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package importer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/yourusername/chat-app/internal/biz"
+)
+
+// maxJSONLLine bounds a single line of a JSON-lines export
+const maxJSONLLine = 1024 * 1024
+
+// jsonlRecord is one line of a JSON-lines export; the fields used depend on the type
+type jsonlRecord struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+
+	// user
+	Email    string `json:"email"`
+	Username string `json:"username"`
+
+	// room
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Private     bool     `json:"private"`
+	Members     []string `json:"members"`
+
+	// message
+	Room      string      `json:"room"`
+	User      string      `json:"user"`
+	Text      string      `json:"text"`
+	CreatedAt time.Time   `json:"created_at"`
+	ThreadID  string      `json:"thread_id"`
+	Files     []jsonlFile `json:"files"`
+}
+
+type jsonlFile struct {
+	Name     string `json:"name"`
+	MimeType string `json:"mime_type"`
+	URL      string `json:"url"`
+}
+
+// JSONLSource reads a JSON-lines export. Users and rooms are read up
+// front; each room's messages are read by scanning the file again, so
+// exports of any size are never held in memory.
+type JSONLSource struct {
+	r      io.ReaderAt
+	size   int64
+	client *http.Client
+}
+
+// NewJSONLSource reads a JSON-lines export of size bytes
+func NewJSONLSource(r io.ReaderAt, size int64, client *http.Client) *JSONLSource {
+	return &JSONLSource{r: r, size: size, client: client}
+}
+
+// scan decodes every line of the file in order
+func (s *JSONLSource) scan(ctx context.Context, fn func(*jsonlRecord) error) error {
+	scanner := bufio.NewScanner(io.NewSectionReader(s.r, 0, s.size))
+	scanner.Buffer(make([]byte, 64*1024), maxJSONLLine)
+
+	for line := 1; scanner.Scan(); line++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		record := &jsonlRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return fmt.Errorf("importer: line %d: %w", line, err)
+		}
+		if record.ID == "" {
+			return fmt.Errorf("importer: line %d: missing id", line)
+		}
+		if err := fn(record); err != nil {
+			return fmt.Errorf("importer: line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("importer: failed to read export: %w", err)
+	}
+	return nil
+}
+
+// Users lists the export's users
+func (s *JSONLSource) Users(ctx context.Context) ([]*biz.ImportUser, error) {
+	var users []*biz.ImportUser
+	err := s.scan(ctx, func(record *jsonlRecord) error {
+		if record.Type == "user" {
+			users = append(users, &biz.ImportUser{
+				Key:      jsonlKey(record.ID),
+				Email:    record.Email,
+				Username: record.Username,
+			})
+		}
+		return nil
+	})
+	return users, err
+}
+
+// Rooms lists the export's rooms
+func (s *JSONLSource) Rooms(ctx context.Context) ([]*biz.ImportRoom, error) {
+	var rooms []*biz.ImportRoom
+	err := s.scan(ctx, func(record *jsonlRecord) error {
+		if record.Type != "room" {
+			return nil
+		}
+		members := make([]string, 0, len(record.Members))
+		for _, id := range record.Members {
+			members = append(members, jsonlKey(id))
+		}
+		rooms = append(rooms, &biz.ImportRoom{
+			Key:         jsonlKey(record.ID),
+			Name:        record.Name,
+			Description: record.Description,
+			Private:     record.Private,
+			MemberKeys:  members,
+		})
+		return nil
+	})
+	return rooms, err
+}
+
+// Messages passes a room's messages to fn in file order, in batches
+func (s *JSONLSource) Messages(ctx context.Context, room *biz.ImportRoom, fn func([]*biz.ImportMessage) error) error {
+	batch := make([]*biz.ImportMessage, 0, batchSize)
+	err := s.scan(ctx, func(record *jsonlRecord) error {
+		if record.Type != "message" || jsonlKey(record.Room) != room.Key {
+			return nil
+		}
+		if record.CreatedAt.IsZero() {
+			return fmt.Errorf("message %s has no created_at", record.ID)
+		}
+
+		batch = append(batch, s.toImportMessage(record))
+		if len(batch) < batchSize {
+			return nil
+		}
+		err := fn(batch)
+		batch = make([]*biz.ImportMessage, 0, batchSize)
+		return err
+	})
+	if err != nil || len(batch) == 0 {
+		return err
+	}
+	return fn(batch)
+}
+
+// toImportMessage converts a message line
+func (s *JSONLSource) toImportMessage(record *jsonlRecord) *biz.ImportMessage {
+	message := &biz.ImportMessage{
+		Key:       jsonlKey(record.ID),
+		UserKey:   jsonlKey(record.User),
+		Text:      record.Text,
+		CreatedAt: record.CreatedAt,
+	}
+	if record.ThreadID != "" {
+		message.ThreadKey = jsonlKey(record.ThreadID)
+	}
+	for _, file := range record.Files {
+		if file.URL != "" {
+			message.Files = append(message.Files, remoteFile(s.client, file.Name, file.MimeType, file.URL, ""))
+		}
+	}
+	return message
+}
+
+// jsonlKey is the import key of an ID in a JSON-lines export
+func jsonlKey(id string) string {
+	return "jsonl:" + id
+}
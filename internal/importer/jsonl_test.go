@@ -0,0 +1,98 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/chat-app/internal/biz"
+)
+
+// newJSONLExport builds a JSON-lines export from its lines
+func newJSONLExport(lines ...string) *JSONLSource {
+	content := strings.Join(lines, "\n")
+	return NewJSONLSource(strings.NewReader(content), int64(len(content)), http.DefaultClient)
+}
+
+func TestJSONLSource_Export(t *testing.T) {
+	// Arrange
+	src := newJSONLExport(
+		`{"type":"user","id":"u1","email":"ada@example.com","username":"ada"}`,
+		`{"type":"room","id":"r1","name":"general","private":true,"members":["u1"]}`,
+		`{"type":"room","id":"r2","name":"random"}`,
+		`{"type":"message","id":"m1","room":"r1","user":"u1","text":"hi","created_at":"2024-05-01T09:30:00Z"}`,
+		``,
+		`{"type":"message","id":"m2","room":"r2","user":"u1","text":"elsewhere","created_at":"2024-05-01T09:31:00Z"}`,
+		`{"type":"message","id":"m3","room":"r1","user":"u1","text":"reply","created_at":"2024-05-01T09:32:00Z","thread_id":"m1","files":[{"name":"a.png","mime_type":"image/png","url":"https://files.example.com/a.png"}]}`,
+	)
+
+	// Act
+	users, _ := src.Users(context.Background())
+	rooms, _ := src.Rooms(context.Background())
+	var messages []*biz.ImportMessage
+	err := src.Messages(context.Background(), rooms[0], func(batch []*biz.ImportMessage) error {
+		messages = append(messages, batch...)
+		return nil
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(users) != 1 || users[0].Key != "jsonl:u1" || users[0].Email != "ada@example.com" {
+		t.Errorf("unexpected users %+v", users)
+	}
+	if len(rooms) != 2 || rooms[0].Key != "jsonl:r1" || !rooms[0].Private || rooms[0].MemberKeys[0] != "jsonl:u1" {
+		t.Errorf("unexpected rooms %+v", rooms)
+	}
+	if len(messages) != 2 || messages[0].Key != "jsonl:m1" || messages[1].ThreadKey != "jsonl:m1" {
+		t.Fatalf("expected r1's root and reply, got %+v", messages)
+	}
+	if messages[0].CreatedAt.Unix() != 1714555800 || len(messages[1].Files) != 1 {
+		t.Errorf("unexpected messages %+v %+v", messages[0], messages[1])
+	}
+}
+
+func TestJSONLSource_Batches(t *testing.T) {
+	// Arrange
+	lines := []string{`{"type":"room","id":"r1","name":"general"}`}
+	for i := 0; i < batchSize+1; i++ {
+		lines = append(lines, fmt.Sprintf(`{"type":"message","id":"m%d","room":"r1","user":"u1","text":"hi","created_at":"2024-05-01T09:30:00Z"}`, i))
+	}
+	src := newJSONLExport(lines...)
+	rooms, _ := src.Rooms(context.Background())
+	var sizes []int
+
+	// Act
+	err := src.Messages(context.Background(), rooms[0], func(batch []*biz.ImportMessage) error {
+		sizes = append(sizes, len(batch))
+		return nil
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(sizes) != 2 || sizes[0] != batchSize || sizes[1] != 1 {
+		t.Errorf("expected a full batch and a remainder, got %v", sizes)
+	}
+}
+
+func TestJSONLSource_InvalidLine(t *testing.T) {
+	// Arrange
+	src := newJSONLExport(
+		`{"type":"room","id":"r1","name":"general"}`,
+		`{"type":"message","id":"m1","room":"r1","user":"u1","text":"no time"}`,
+	)
+	rooms, _ := src.Rooms(context.Background())
+
+	// Act
+	err := src.Messages(context.Background(), rooms[0], func(batch []*biz.ImportMessage) error { return nil })
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected an error pointing at line 2, got %v", err)
+	}
+}
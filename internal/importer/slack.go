@@ -0,0 +1,317 @@
+package importer
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/chat-app/internal/biz"
+)
+
+// slackSubtypes are the message subtypes carrying something a person said.
+// The rest (joins, topic changes, bot posts...) are skipped.
+var slackSubtypes = map[string]bool{
+	"":                 true,
+	"thread_broadcast": true,
+	"file_share":       true,
+	"me_message":       true,
+}
+
+// slackEntity matches Slack's <...> markup for mentions, channels and links
+var slackEntity = regexp.MustCompile(`<([^<>]+)>`)
+
+type slackUser struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Profile struct {
+		Email       string `json:"email"`
+		DisplayName string `json:"display_name"`
+	} `json:"profile"`
+}
+
+type slackChannel struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+	Purpose struct {
+		Value string `json:"value"`
+	} `json:"purpose"`
+	private bool
+}
+
+type slackMessage struct {
+	Type     string      `json:"type"`
+	Subtype  string      `json:"subtype"`
+	User     string      `json:"user"`
+	Text     string      `json:"text"`
+	TS       string      `json:"ts"`
+	ThreadTS string      `json:"thread_ts"`
+	Files    []slackFile `json:"files"`
+}
+
+type slackFile struct {
+	Name        string `json:"name"`
+	Mimetype    string `json:"mimetype"`
+	URLDownload string `json:"url_private_download"`
+	URLPrivate  string `json:"url_private"`
+}
+
+// SlackSource reads a Slack workspace export zip
+type SlackSource struct {
+	zip          *zip.Reader
+	client       *http.Client
+	token        string
+	users        []*slackUser
+	userNames    map[string]string // user ID -> name, for mentions
+	channels     []*slackChannel
+	channelNames map[string]string // channel ID -> name, for channel links
+}
+
+// NewSlackSource opens a Slack export zip and reads its users and channels
+func NewSlackSource(r io.ReaderAt, size int64, client *http.Client, token string) (*SlackSource, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("importer: not a zip file: %w", err)
+	}
+
+	s := &SlackSource{
+		zip:          archive,
+		client:       client,
+		token:        token,
+		userNames:    make(map[string]string),
+		channelNames: make(map[string]string),
+	}
+	if err := s.readJSON("users.json", &s.users); err != nil {
+		return nil, err
+	}
+	for _, user := range s.users {
+		s.userNames[user.ID] = slackUsername(user)
+	}
+
+	var public, private []*slackChannel
+	if err := s.readJSON("channels.json", &public); err != nil {
+		return nil, err
+	}
+	if err := s.readJSON("groups.json", &private); err != nil && !isMissing(err) {
+		return nil, err
+	}
+	for _, channel := range private {
+		channel.private = true
+	}
+	s.channels = append(public, private...)
+	for _, channel := range s.channels {
+		s.channelNames[channel.ID] = channel.Name
+	}
+
+	return s, nil
+}
+
+// missingFileError reports a file the export doesn't contain
+type missingFileError struct{ name string }
+
+func (e *missingFileError) Error() string {
+	return fmt.Sprintf("importer: export has no %s", e.name)
+}
+
+func isMissing(err error) bool {
+	_, ok := err.(*missingFileError)
+	return ok
+}
+
+// readJSON decodes a JSON file in the export
+func (s *SlackSource) readJSON(name string, v interface{}) error {
+	file, err := s.zip.Open(name)
+	if err != nil {
+		return &missingFileError{name: name}
+	}
+	defer func() { _ = file.Close() }()
+
+	if err := json.NewDecoder(file).Decode(v); err != nil {
+		return fmt.Errorf("importer: invalid %s: %w", name, err)
+	}
+	return nil
+}
+
+// Users lists the workspace's users
+func (s *SlackSource) Users(ctx context.Context) ([]*biz.ImportUser, error) {
+	users := make([]*biz.ImportUser, 0, len(s.users))
+	for _, user := range s.users {
+		users = append(users, &biz.ImportUser{
+			Key:      slackUserKey(user.ID),
+			Email:    user.Profile.Email,
+			Username: slackUsername(user),
+		})
+	}
+	return users, nil
+}
+
+// Rooms lists the public and private channels
+func (s *SlackSource) Rooms(ctx context.Context) ([]*biz.ImportRoom, error) {
+	rooms := make([]*biz.ImportRoom, 0, len(s.channels))
+	for _, channel := range s.channels {
+		members := make([]string, 0, len(channel.Members))
+		for _, id := range channel.Members {
+			members = append(members, slackUserKey(id))
+		}
+		rooms = append(rooms, &biz.ImportRoom{
+			Key:         "slack:" + channel.ID,
+			Name:        channel.Name,
+			Description: channel.Purpose.Value,
+			Private:     channel.private,
+			MemberKeys:  members,
+		})
+	}
+	return rooms, nil
+}
+
+// Messages passes a channel's messages to fn one day file at a time, in
+// batches of at most batchSize
+func (s *SlackSource) Messages(ctx context.Context, room *biz.ImportRoom, fn func([]*biz.ImportMessage) error) error {
+	channelID := strings.TrimPrefix(room.Key, "slack:")
+	dir := s.channelNames[channelID]
+
+	// Day files are named YYYY-MM-DD.json, so name order is date order
+	var days []string
+	for _, file := range s.zip.File {
+		if path.Dir(file.Name) == dir && strings.HasSuffix(file.Name, ".json") {
+			days = append(days, file.Name)
+		}
+	}
+	sort.Strings(days)
+
+	for _, day := range days {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var raw []*slackMessage
+		if err := s.readJSON(day, &raw); err != nil {
+			return err
+		}
+		sort.SliceStable(raw, func(i, j int) bool { return slackTime(raw[i].TS).Before(slackTime(raw[j].TS)) })
+
+		batch := make([]*biz.ImportMessage, 0, len(raw))
+		for _, message := range raw {
+			if message.Type != "message" || !slackSubtypes[message.Subtype] || message.User == "" {
+				continue
+			}
+			batch = append(batch, s.toImportMessage(channelID, message))
+		}
+		// A busy day is split so each batch stays within one insert
+		for len(batch) > 0 {
+			n := min(len(batch), batchSize)
+			if err := fn(batch[:n]); err != nil {
+				return err
+			}
+			batch = batch[n:]
+		}
+	}
+	return nil
+}
+
+// toImportMessage converts a Slack message, rewriting its markup as plain text
+func (s *SlackSource) toImportMessage(channelID string, message *slackMessage) *biz.ImportMessage {
+	imported := &biz.ImportMessage{
+		Key:       fmt.Sprintf("slack:%s:%s", channelID, message.TS),
+		UserKey:   slackUserKey(message.User),
+		Text:      s.plainText(message.Text),
+		CreatedAt: slackTime(message.TS),
+	}
+	if message.ThreadTS != "" {
+		imported.ThreadKey = fmt.Sprintf("slack:%s:%s", channelID, message.ThreadTS)
+	}
+
+	for _, file := range message.Files {
+		fileURL := file.URLDownload
+		if fileURL == "" {
+			fileURL = file.URLPrivate
+		}
+		// Files Slack no longer keeps have no URL
+		if fileURL == "" {
+			continue
+		}
+		// The export may have been tampered with; the token only goes to Slack
+		token := ""
+		if isSlackURL(fileURL) {
+			token = s.token
+		}
+		imported.Files = append(imported.Files, remoteFile(s.client, file.Name, file.Mimetype, fileURL, token))
+	}
+	return imported
+}
+
+// isSlackURL reports whether rawURL is an HTTPS URL on slack.com
+func isSlackURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	return host == "slack.com" || strings.HasSuffix(host, ".slack.com")
+}
+
+// plainText rewrites Slack's <@U123>, <#C123|name> and <url|label> markup
+// and unescapes the text
+func (s *SlackSource) plainText(text string) string {
+	text = slackEntity.ReplaceAllStringFunc(text, func(entity string) string {
+		inner := entity[1 : len(entity)-1]
+		target, label, _ := strings.Cut(inner, "|")
+		switch {
+		case strings.HasPrefix(target, "@"):
+			if name, ok := s.userNames[target[1:]]; ok {
+				return "@" + name
+			}
+			if label != "" {
+				return "@" + strings.TrimPrefix(label, "@")
+			}
+			return target
+		case strings.HasPrefix(target, "#"):
+			if label == "" {
+				label = s.channelNames[target[1:]]
+			}
+			return "#" + label
+		case strings.HasPrefix(target, "!"):
+			if label != "" {
+				return label
+			}
+			return "@" + strings.TrimPrefix(target, "!")
+		case label == "" || label == target || "mailto:"+label == target:
+			return strings.TrimPrefix(target, "mailto:")
+		default:
+			return fmt.Sprintf("%s (%s)", label, target)
+		}
+	})
+
+	return strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&").Replace(text)
+}
+
+// slackUserKey is the import key of a Slack user
+func slackUserKey(id string) string {
+	return "slack:" + id
+}
+
+// slackUsername prefers the user's handle, as mentions show it
+func slackUsername(user *slackUser) string {
+	if user.Name != "" {
+		return user.Name
+	}
+	return user.Profile.DisplayName
+}
+
+// slackTime parses a Slack timestamp such as "1512085950.000216"
+func slackTime(ts string) time.Time {
+	seconds, fraction, _ := strings.Cut(ts, ".")
+	sec, _ := strconv.ParseInt(seconds, 10, 64)
+	micro, _ := strconv.ParseInt((fraction + "000000")[:6], 10, 64)
+	return time.Unix(sec, micro*1000)
+}
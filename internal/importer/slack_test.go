@@ -0,0 +1,182 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/chat-app/internal/biz"
+)
+
+// newSlackExport builds a Slack export zip from file names and contents
+func newSlackExport(t *testing.T, files map[string]string) *SlackSource {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = w.Write([]byte(content))
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := NewSlackSource(bytes.NewReader(buf.Bytes()), int64(buf.Len()), http.DefaultClient, "")
+	if err != nil {
+		t.Fatalf("expected the export to open, got %v", err)
+	}
+	return src
+}
+
+// testSlackExport has users ada and bob, public #general and private #ops
+func testSlackExport(t *testing.T) *SlackSource {
+	return newSlackExport(t, map[string]string{
+		"users.json": `[
+			{"id": "U1", "name": "ada", "profile": {"email": "ada@example.com"}},
+			{"id": "U2", "name": "bob", "profile": {}}
+		]`,
+		"channels.json": `[{"id": "C1", "name": "general", "members": ["U1", "U2"], "purpose": {"value": "Company-wide"}}]`,
+		"groups.json":   `[{"id": "G1", "name": "ops", "members": ["U1"]}]`,
+		"general/2024-05-02.json": `[
+			{"type": "message", "user": "U2", "text": "second day", "ts": "1714640400.000100"}
+		]`,
+		"general/2024-05-01.json": `[
+			{"type": "message", "user": "U2", "text": "reply", "ts": "1714554060.000200", "thread_ts": "1714554000.000100"},
+			{"type": "message", "subtype": "channel_join", "user": "U2", "text": "<@U2> has joined the channel", "ts": "1714553000.000100"},
+			{"type": "message", "user": "U1", "text": "hi <@U2>, see <#C1> and <https://example.com|the docs> &amp; more", "ts": "1714554000.000100", "thread_ts": "1714554000.000100",
+			 "files": [{"name": "a.png", "mimetype": "image/png", "url_private_download": "https://files.slack.com/a.png"}]}
+		]`,
+	})
+}
+
+func TestSlackSource_UsersAndRooms(t *testing.T) {
+	// Arrange
+	src := testSlackExport(t)
+
+	// Act
+	users, _ := src.Users(context.Background())
+	rooms, _ := src.Rooms(context.Background())
+
+	// Assert
+	if len(users) != 2 || users[0].Key != "slack:U1" || users[0].Email != "ada@example.com" || users[1].Username != "bob" {
+		t.Errorf("unexpected users %+v", users)
+	}
+	if len(rooms) != 2 {
+		t.Fatalf("expected 2 rooms, got %d", len(rooms))
+	}
+	if rooms[0].Key != "slack:C1" || rooms[0].Private || rooms[0].Description != "Company-wide" || len(rooms[0].MemberKeys) != 2 {
+		t.Errorf("unexpected public room %+v", rooms[0])
+	}
+	if rooms[1].Name != "ops" || !rooms[1].Private {
+		t.Errorf("expected #ops private, got %+v", rooms[1])
+	}
+}
+
+func TestSlackSource_Messages(t *testing.T) {
+	// Arrange
+	src := testSlackExport(t)
+	rooms, _ := src.Rooms(context.Background())
+	var messages []*biz.ImportMessage
+
+	// Act
+	err := src.Messages(context.Background(), rooms[0], func(batch []*biz.ImportMessage) error {
+		messages = append(messages, batch...)
+		return nil
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(messages) != 3 {
+		t.Fatalf("expected the join skipped and 3 messages left, got %d", len(messages))
+	}
+	root, reply := messages[0], messages[1]
+	if root.Key != "slack:C1:1714554000.000100" || root.UserKey != "slack:U1" || root.CreatedAt.Unix() != 1714554000 {
+		t.Errorf("expected the root first, got %+v", root)
+	}
+	if root.Text != "hi @bob, see #general and the docs (https://example.com) & more" {
+		t.Errorf("expected markup rewritten, got %q", root.Text)
+	}
+	if len(root.Files) != 1 || root.Files[0].Name != "a.png" {
+		t.Errorf("expected the attachment, got %+v", root.Files)
+	}
+	if reply.ThreadKey != root.Key {
+		t.Errorf("expected the reply in the root's thread, got %q", reply.ThreadKey)
+	}
+	if messages[2].Text != "second day" {
+		t.Errorf("expected days in date order, got %q last", messages[2].Text)
+	}
+}
+
+func TestSlackSource_MessagesSplitsBusyDay(t *testing.T) {
+	// Arrange
+	var day strings.Builder
+	day.WriteString("[")
+	for i := 0; i < batchSize+1; i++ {
+		if i > 0 {
+			day.WriteString(",")
+		}
+		fmt.Fprintf(&day, `{"type": "message", "user": "U1", "text": "hi", "ts": "1714554000.%06d"}`, i)
+	}
+	day.WriteString("]")
+	src := newSlackExport(t, map[string]string{
+		"users.json":              `[{"id": "U1", "name": "ada"}]`,
+		"channels.json":           `[{"id": "C1", "name": "general", "members": ["U1"]}]`,
+		"general/2024-05-01.json": day.String(),
+	})
+	rooms, _ := src.Rooms(context.Background())
+	var sizes []int
+
+	// Act
+	err := src.Messages(context.Background(), rooms[0], func(batch []*biz.ImportMessage) error {
+		sizes = append(sizes, len(batch))
+		return nil
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(sizes) != 2 || sizes[0] != batchSize || sizes[1] != 1 {
+		t.Errorf("expected a full batch then 1 message, got %v", sizes)
+	}
+}
+
+func TestNewSlackSource_NotAZip(t *testing.T) {
+	// Arrange
+	data := []byte("not a zip")
+
+	// Act
+	_, err := NewSlackSource(bytes.NewReader(data), int64(len(data)), http.DefaultClient, "")
+
+	// Assert
+	if err == nil {
+		t.Error("expected an error for a file that isn't a zip")
+	}
+}
+
+func TestIsSlackURL(t *testing.T) {
+	// Arrange
+	trusted := []string{"https://files.slack.com/a.png", "https://acme.slack.com/files/a.png"}
+	untrusted := []string{"https://files.slack.com.evil.example/a.png", "https://evilslack.com/a.png", "http://files.slack.com/a.png", "::"}
+
+	// Act & Assert
+	for _, rawURL := range trusted {
+		if !isSlackURL(rawURL) {
+			t.Errorf("expected %s trusted", rawURL)
+		}
+	}
+	for _, rawURL := range untrusted {
+		if isSlackURL(rawURL) {
+			t.Errorf("expected %s not trusted", rawURL)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+// Package markdown renders the chat markdown subset to safe HTML.
+//
+// Raw HTML in the source is always escaped, link targets are limited to
+// http, https and mailto, and images are not supported, so the output can be
+// shown without further sanitizing.
+//
+// Supported: paragraphs (single newlines become <br>), # headings, > quotes,
+// - and 1. lists, --- rules, fenced code blocks, `code`, **strong**,
+// *emphasis*, ~~strikethrough~~, [links](https://...) and bare URLs.
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxQuoteDepth bounds how deeply > quotes nest
+const maxQuoteDepth = 5
+
+var (
+	headingPattern     = regexp.MustCompile(`^(#{1,6})[ \t]+(.*?)[ \t#]*$`)
+	quotePattern       = regexp.MustCompile(`^[ \t]{0,3}>[ \t]?(.*)$`)
+	bulletPattern      = regexp.MustCompile(`^[ \t]{0,3}[-*+][ \t]+(.*)$`)
+	orderedPattern     = regexp.MustCompile(`^[ \t]{0,3}\d{1,9}[.)][ \t]+(.*)$`)
+	rulePattern        = regexp.MustCompile(`^[ \t]{0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	fencePattern       = regexp.MustCompile("^[ \\t]{0,3}(```+|~~~+)[ \\t]*([^`\\s]*)")
+	languagePattern    = regexp.MustCompile(`^[A-Za-z0-9_+#.-]{1,32}$`)
+	codeSpanPattern    = regexp.MustCompile("``(.+?)``|`([^`]+)`")
+	linkPattern        = regexp.MustCompile(`\[([^\[\]]+)\]\(([^()\s]+)\)`)
+	autolinkPattern    = regexp.MustCompile(`https?://[^\s<>"'` + "`" + `]+`)
+	strongPattern      = regexp.MustCompile(`\*\*([^\s*<](?:[^<]*?[^\s<])?)\*\*|__([^\s_<](?:[^<]*?[^\s<])?)__`)
+	emphasisPattern    = regexp.MustCompile(`\*([^\s*<](?:[^*<]*?[^\s*<])?)\*`)
+	underscorePattern  = regexp.MustCompile(`(^|[^\p{L}\p{N}_])_([^\s_<](?:[^_<]*?[^\s_<])?)_($|[^\p{L}\p{N}_])`)
+	strikePattern      = regexp.MustCompile(`~~([^\s~<](?:[^<]*?[^\s<])?)~~`)
+	placeholderPattern = regexp.MustCompile("\x00(\\d+)\x00")
+	escapablePattern   = regexp.MustCompile("\\\\([!-/:-@\\[-`{-~])")
+)
+
+// Render converts markdown source to sanitized HTML
+func Render(source string) string {
+	source = strings.ReplaceAll(source, "\x00", "�")
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+
+	var out strings.Builder
+	renderBlocks(&out, strings.Split(source, "\n"), 0)
+	return out.String()
+}
+
+// renderBlocks renders lines as a sequence of block elements
+func renderBlocks(out *strings.Builder, lines []string, depth int) {
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		rendered := make([]string, len(paragraph))
+		for i, line := range paragraph {
+			rendered[i] = renderInline(strings.TrimSpace(line))
+		}
+		out.WriteString("<p>" + strings.Join(rendered, "<br>") + "</p>")
+		paragraph = nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+
+		case fencePattern.MatchString(line):
+			flush()
+			match := fencePattern.FindStringSubmatch(line)
+			fence := match[1]
+			var code []string
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) && strings.Trim(strings.TrimSpace(lines[i]), fence[:1]) == "" {
+					break
+				}
+				code = append(code, lines[i])
+			}
+			class := ""
+			if languagePattern.MatchString(match[2]) {
+				class = fmt.Sprintf(` class="language-%s"`, html.EscapeString(match[2]))
+			}
+			out.WriteString("<pre><code" + class + ">" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>")
+
+		case headingPattern.MatchString(line):
+			flush()
+			match := headingPattern.FindStringSubmatch(line)
+			level := len(match[1])
+			fmt.Fprintf(out, "<h%d>%s</h%d>", level, renderInline(match[2]), level)
+
+		case rulePattern.MatchString(line):
+			flush()
+			out.WriteString("<hr>")
+
+		case quotePattern.MatchString(line):
+			flush()
+			var quoted []string
+			for ; i < len(lines) && quotePattern.MatchString(lines[i]); i++ {
+				quoted = append(quoted, quotePattern.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			out.WriteString("<blockquote>")
+			if depth < maxQuoteDepth {
+				renderBlocks(out, quoted, depth+1)
+			} else {
+				out.WriteString("<p>" + renderInline(strings.Join(quoted, " ")) + "</p>")
+			}
+			out.WriteString("</blockquote>")
+
+		case bulletPattern.MatchString(line):
+			flush()
+			i = renderList(out, lines, i, bulletPattern, "ul")
+
+		case orderedPattern.MatchString(line):
+			flush()
+			i = renderList(out, lines, i, orderedPattern, "ol")
+
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+}
+
+// renderList renders consecutive list items starting at lines[start]
+// and returns the index of the last item
+func renderList(out *strings.Builder, lines []string, start int, pattern *regexp.Regexp, tag string) int {
+	out.WriteString("<" + tag + ">")
+	i := start
+	for ; i < len(lines) && pattern.MatchString(lines[i]) && !rulePattern.MatchString(lines[i]); i++ {
+		out.WriteString("<li>" + renderInline(strings.TrimSpace(pattern.FindStringSubmatch(lines[i])[1])) + "</li>")
+	}
+	out.WriteString("</" + tag + ">")
+	return i - 1
+}
+
+// renderInline renders the inline markup of one line of text
+func renderInline(text string) string {
+	// Rendered fragments are swapped for placeholders so later passes leave them alone
+	var fragments []string
+	hold := func(fragment string) string {
+		fragments = append(fragments, fragment)
+		return fmt.Sprintf("\x00%d\x00", len(fragments)-1)
+	}
+
+	text = codeSpanPattern.ReplaceAllStringFunc(text, func(span string) string {
+		match := codeSpanPattern.FindStringSubmatch(span)
+		return hold("<code>" + html.EscapeString(strings.TrimSpace(match[1]+match[2])) + "</code>")
+	})
+	text = escapablePattern.ReplaceAllStringFunc(text, func(escaped string) string {
+		return hold(html.EscapeString(escaped[1:]))
+	})
+	text = linkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		match := linkPattern.FindStringSubmatch(link)
+		label := renderEmphasis(html.EscapeString(match[1]))
+		href, ok := safeURL(match[2])
+		if !ok {
+			return hold(label)
+		}
+		return hold(`<a href="` + html.EscapeString(href) + `" rel="nofollow noopener noreferrer" target="_blank">` + label + `</a>`)
+	})
+	text = autolinkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		trimmed := strings.TrimRight(link, ".,;:!?)]}*_~")
+		href, ok := safeURL(trimmed)
+		if !ok {
+			return link
+		}
+		anchor := `<a href="` + html.EscapeString(href) + `" rel="nofollow noopener noreferrer" target="_blank">` + html.EscapeString(trimmed) + `</a>`
+		return hold(anchor) + link[len(trimmed):]
+	})
+
+	text = renderEmphasis(html.EscapeString(text))
+
+	// Fragments may hold other placeholders (a code span in a link label)
+	for placeholderPattern.MatchString(text) {
+		text = placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+			index, _ := strconv.Atoi(strings.Trim(placeholder, "\x00"))
+			return fragments[index]
+		})
+	}
+	return text
+}
+
+// renderEmphasis applies strong, emphasis and strikethrough to escaped text
+func renderEmphasis(text string) string {
+	text = strongPattern.ReplaceAllString(text, "<strong>$1$2</strong>")
+	text = emphasisPattern.ReplaceAllString(text, "<em>$1</em>")
+	text = underscorePattern.ReplaceAllString(text, "$1<em>$2</em>$3")
+	text = strikePattern.ReplaceAllString(text, "<del>$1</del>")
+	return text
+}
+
+// safeURL reports whether a link target may be rendered,
+// returning it normalized
+func safeURL(raw string) (string, bool) {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "http", "https":
+		if parsed.Host == "" {
+			return "", false
+		}
+	case "mailto":
+		if parsed.Opaque == "" {
+			return "", false
+		}
+	default:
+		return "", false
+	}
+	return parsed.String(), true
+}
@@ -0,0 +1,132 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender_Inline(t *testing.T) {
+	// Act
+	out := Render("**bold** *em* _also em_ ~~gone~~ `a < b`")
+
+	// Assert
+	want := "<p><strong>bold</strong> <em>em</em> <em>also em</em> <del>gone</del> <code>a &lt; b</code></p>"
+	if out != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+}
+
+func TestRender_EscapesRawHTML(t *testing.T) {
+	// Act
+	out := Render(`<script>alert("x")</script><img src=x onerror=alert(1)>`)
+
+	// Assert
+	if strings.Contains(out, "<script") || strings.Contains(out, "<img") {
+		t.Errorf("expected raw HTML escaped, got %q", out)
+	}
+	if !strings.Contains(out, "&lt;script&gt;") {
+		t.Errorf("expected escaped script tag, got %q", out)
+	}
+}
+
+func TestRender_Links(t *testing.T) {
+	// Act
+	out := Render(`[docs](https://go.dev/doc?a=1&b="2") and https://example.com/x.`)
+
+	// Assert
+	if !strings.Contains(out, `<a href="https://go.dev/doc?a=1&amp;b=&#34;2&#34;" rel="nofollow noopener noreferrer" target="_blank">docs</a>`) {
+		t.Errorf("expected escaped markdown link, got %q", out)
+	}
+	if !strings.Contains(out, `>https://example.com/x</a>.`) {
+		t.Errorf("expected bare URL linked without trailing period, got %q", out)
+	}
+}
+
+func TestRender_StripsUnsafeLinks(t *testing.T) {
+	// Arrange
+	sources := []string{
+		"[click](javascript:alert(1))",
+		"[click](JavaScript:alert)",
+		"[click](data:text/html;base64,PHNjcmlwdD4=)",
+		"[click](vbscript:msgbox)",
+		"[click](//evil.example.com)",
+	}
+
+	for _, source := range sources {
+		// Act
+		out := Render(source)
+
+		// Assert
+		if strings.Contains(out, "href") {
+			t.Errorf("expected no link for %q, got %q", source, out)
+		}
+	}
+}
+
+func TestRender_Blocks(t *testing.T) {
+	// Act
+	out := Render("# Title\n\n- one\n- two\n\n1. first\n\n> quoted **text**\n\n---\nline one\nline two")
+
+	// Assert
+	want := "<h1>Title</h1>" +
+		"<ul><li>one</li><li>two</li></ul>" +
+		"<ol><li>first</li></ol>" +
+		"<blockquote><p>quoted <strong>text</strong></p></blockquote>" +
+		"<hr>" +
+		"<p>line one<br>line two</p>"
+	if out != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+}
+
+func TestRender_CodeBlock(t *testing.T) {
+	// Act
+	out := Render("```go\nif a < b && **x** {\n}\n```")
+
+	// Assert
+	want := "<pre><code class=\"language-go\">if a &lt; b &amp;&amp; **x** {\n}</code></pre>"
+	if out != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+}
+
+func TestRender_CodeBlockUnsafeLanguage(t *testing.T) {
+	// Act
+	out := Render("```\"onmouseover=alert(1)\nx\n```")
+
+	// Assert
+	if strings.Contains(out, "class=") || strings.Contains(out, "onmouseover=alert(1)\"") {
+		t.Errorf("expected language dropped, got %q", out)
+	}
+}
+
+func TestRender_BackslashEscapes(t *testing.T) {
+	// Act
+	out := Render(`\*not em\* \<b\>`)
+
+	// Assert
+	want := "<p>*not em* &lt;b&gt;</p>"
+	if out != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+}
+
+func TestRender_SnakeCaseNotEmphasis(t *testing.T) {
+	// Act
+	out := Render("call snake_case_name now")
+
+	// Assert
+	if strings.Contains(out, "<em>") {
+		t.Errorf("expected no emphasis inside words, got %q", out)
+	}
+}
+
+func TestRender_NullBytes(t *testing.T) {
+	// Act
+	out := Render("a\x000\x00b")
+
+	// Assert
+	if strings.Contains(out, "\x00") {
+		t.Errorf("expected NUL bytes replaced, got %q", out)
+	}
+}
@@ -112,6 +112,18 @@ var (
 		Name: "room_leaves_total",
 		Help: "Total number of room leaves",
 	})
+
+	// Messages purged by retention policies (counter)
+	MessagesPurgedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "messages_purged_total",
+		Help: "Total number of messages purged by room retention policies",
+	})
+
+	// Attachments purged with their messages (counter)
+	AttachmentsPurgedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "attachments_purged_total",
+		Help: "Total number of attachments deleted with purged messages",
+	})
 )
 
 // Helper functions
@@ -195,6 +207,16 @@ func RecordRoomLeave() {
 	RoomLeavesTotal.Inc()
 }
 
+// RecordMessagesPurged records messages purged by retention policies
+func RecordMessagesPurged(count int) {
+	MessagesPurgedTotal.Add(float64(count))
+}
+
+// RecordAttachmentPurged records an attachment deleted with a purged message
+func RecordAttachmentPurged() {
+	AttachmentsPurgedTotal.Inc()
+}
+
 // StartMetricsUpdater starts a goroutine that periodically updates system metrics
 func StartMetricsUpdater() {
 	go func() {
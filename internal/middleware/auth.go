@@ -10,6 +10,10 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/yourusername/chat-app/internal/client"
 	"github.com/yourusername/chat-app/internal/conf"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 // Context key types to avoid collisions
@@ -124,3 +128,45 @@ func JWTAuthWithUserClient(userClient *client.UserClient) middleware.Middleware
 		}
 	}
 }
+
+// StreamAuthWithUserClient authenticates gRPC streams via User Service.
+// Kratos middleware only wraps unary calls, so streams are checked by this
+// interceptor, which reads the same "authorization: Bearer" metadata.
+func StreamAuthWithUserClient(userClient *client.UserClient) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+
+		var token string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			for _, auth := range md.Get("authorization") {
+				if strings.HasPrefix(auth, "Bearer ") {
+					token = auth[7:] // Remove "Bearer " prefix
+					break
+				}
+			}
+		}
+
+		if token == "" {
+			return status.Error(codes.Unauthenticated, "authentication required: missing token")
+		}
+
+		userID, username, err := userClient.ValidateToken(ctx, token)
+		if err != nil {
+			return status.Errorf(codes.Unauthenticated, "authentication failed: %v", err)
+		}
+
+		ctx = context.WithValue(ctx, UserIDKey, userID)
+		ctx = context.WithValue(ctx, UsernameKey, username)
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticatedStream carries the authenticated user in its context
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
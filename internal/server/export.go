@@ -0,0 +1,384 @@
+package server
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/biz"
+	"github.com/yourusername/chat-app/internal/client"
+	"github.com/yourusername/chat-app/internal/middleware"
+	"github.com/yourusername/chat-app/internal/service"
+	"github.com/yourusername/chat-app/internal/storage"
+)
+
+// Export formats
+const (
+	exportJSONL = "jsonl"
+	exportCSV   = "csv"
+	exportHTML  = "html"
+)
+
+// exportRecord is one message of an export
+type exportRecord struct {
+	ID              int64            `json:"id"`
+	ParentMessageID int64            `json:"parent_message_id,omitempty"`
+	CreatedAt       string           `json:"created_at"` // RFC 3339
+	EditedAt        string           `json:"edited_at,omitempty"`
+	UserID          int64            `json:"user_id"`
+	Username        string           `json:"username"`
+	Type            string           `json:"type"`
+	Format          string           `json:"format"`
+	Content         string           `json:"content"`
+	FileName        string           `json:"file_name,omitempty"`
+	FileURL         string           `json:"file_url,omitempty"`
+	Attachment      string           `json:"attachment,omitempty"` // path of the file inside a zip export
+	Reactions       map[string]int32 `json:"reactions,omitempty"`
+}
+
+// exportAttachment is a file to bundle into a zip export
+type exportAttachment struct {
+	path    string
+	fileURL string
+}
+
+// exportEncoder writes an export in one format
+type exportEncoder interface {
+	begin(roomName string) error
+	write(message *chatV1.Message, record *exportRecord) error
+	flush() error
+	end() error
+}
+
+// HandleExport creates a room history export handler:
+//
+//	GET /api/v1/export?room_id=10&format=jsonl|csv|html&attachments=urls|zip
+//
+// History is streamed batch by batch; with attachments=zip the transcript
+// and the room's files are bundled into one archive.
+func HandleExport(chatService *service.ChatService, roomService *service.RoomService, store *storage.MinioStorage, userClient *client.UserClient, logger log.Logger) http.HandlerFunc {
+	log := log.NewHelper(log.With(logger, "module", "server/export"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Set CORS headers
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+		if r.Method == "OPTIONS" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if r.Method != "GET" {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		userID, err := authenticateRequest(r, userClient)
+		if err != nil {
+			log.Warnf("Export authentication failed: %v", err)
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		query := r.URL.Query()
+		roomID, err := strconv.ParseInt(query.Get("room_id"), 10, 64)
+		if err != nil || roomID <= 0 {
+			writeError(w, http.StatusBadRequest, "room_id is required")
+			return
+		}
+		format := query.Get("format")
+		if format == "" {
+			format = exportJSONL
+		}
+		if format != exportJSONL && format != exportCSV && format != exportHTML {
+			writeError(w, http.StatusBadRequest, "format must be jsonl, csv or html")
+			return
+		}
+		bundle := query.Get("attachments") == "zip"
+		if bundle && store == nil {
+			writeError(w, http.StatusBadRequest, "attachments can't be bundled without file storage")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), middleware.UserIDKey, userID)
+
+		// Checks access up front, while an error status can still be sent
+		room, err := roomService.GetRoom(ctx, &chatV1.GetRoomRequest{Id: roomID})
+		if err != nil {
+			if errors.Is(err, biz.ErrRoomAccessDenied) {
+				writeError(w, http.StatusForbidden, "access denied to room")
+				return
+			}
+			log.Errorf("Failed to get room %d for export: %v", roomID, err)
+			writeError(w, http.StatusNotFound, "room not found")
+			return
+		}
+
+		fileName := fmt.Sprintf("room-%d.%s", roomID, format)
+		var out io.Writer = w
+		var archive *zip.Writer
+		if bundle {
+			w.Header().Set("Content-Type", "application/zip")
+			w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="room-%d.zip"`, roomID))
+			archive = zip.NewWriter(w)
+			if out, err = archive.Create(fileName); err != nil {
+				log.Errorf("Failed to start export archive: %v", err)
+				return
+			}
+		} else {
+			w.Header().Set("Content-Type", exportContentType(format))
+			w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+		}
+
+		encoder := newExportEncoder(format, out)
+		if err := encoder.begin(room.Name); err != nil {
+			log.Warnf("Export of room %d aborted: %v", roomID, err)
+			return
+		}
+
+		flusher, _ := w.(http.Flusher)
+		var attachments []exportAttachment
+		exported := 0
+		err = chatService.ExportMessages(ctx, roomID, func(messages []*chatV1.Message) error {
+			for _, message := range messages {
+				record := newExportRecord(message)
+				if bundle && message.FileUrl != "" {
+					record.Attachment = exportAttachmentPath(message)
+					attachments = append(attachments, exportAttachment{path: record.Attachment, fileURL: message.FileUrl})
+				}
+				if err := encoder.write(message, record); err != nil {
+					return err
+				}
+			}
+			exported += len(messages)
+
+			if err := encoder.flush(); err != nil {
+				return err
+			}
+			if archive != nil {
+				return archive.Flush()
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+			return nil
+		})
+		if err == nil {
+			err = encoder.end()
+		}
+		if err != nil {
+			// The response has started, so the client sees a truncated export
+			log.Errorf("Export of room %d aborted after %d messages: %v", roomID, exported, err)
+			return
+		}
+
+		if archive != nil {
+			for _, attachment := range attachments {
+				if err := bundleAttachment(r.Context(), archive, store, attachment); err != nil {
+					log.Warnf("Failed to bundle %s into export of room %d: %v", attachment.fileURL, roomID, err)
+				}
+			}
+			if err := archive.Close(); err != nil {
+				log.Errorf("Failed to finish export archive of room %d: %v", roomID, err)
+				return
+			}
+		}
+
+		log.Infof("Room %d exported by user %d: %d messages, format=%s, attachments=%d",
+			roomID, userID, exported, format, len(attachments))
+	}
+}
+
+// bundleAttachment copies a stored file into the export archive
+func bundleAttachment(ctx context.Context, archive *zip.Writer, store *storage.MinioStorage, attachment exportAttachment) error {
+	file, err := store.GetFile(ctx, attachment.fileURL)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	entry, err := archive.Create(attachment.path)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, file)
+	return err
+}
+
+// exportAttachmentPath names a message's file inside a zip export,
+// prefixed with the message ID so names never collide
+func exportAttachmentPath(message *chatV1.Message) string {
+	name := path.Base(strings.ReplaceAll(message.FileName, "\\", "/"))
+	if name == "." || name == "/" || name == "" {
+		name = path.Base(message.FileUrl)
+	}
+	return fmt.Sprintf("attachments/%d-%s", message.Id, name)
+}
+
+// newExportRecord flattens a message for export
+func newExportRecord(message *chatV1.Message) *exportRecord {
+	record := &exportRecord{
+		ID:              message.Id,
+		ParentMessageID: message.ParentMessageId,
+		CreatedAt:       time.Unix(message.CreatedAt, 0).UTC().Format(time.RFC3339),
+		UserID:          message.UserId,
+		Username:        message.Username,
+		Type:            message.Type,
+		Format:          message.Format,
+		Content:         message.Content,
+		FileName:        message.FileName,
+		FileURL:         message.FileUrl,
+	}
+	if message.EditedAt != 0 {
+		record.EditedAt = time.Unix(message.EditedAt, 0).UTC().Format(time.RFC3339)
+	}
+	if len(message.Reactions) > 0 {
+		record.Reactions = make(map[string]int32, len(message.Reactions))
+		for _, reaction := range message.Reactions {
+			record.Reactions[reaction.Emoji] = reaction.Count
+		}
+	}
+	return record
+}
+
+func exportContentType(format string) string {
+	switch format {
+	case exportCSV:
+		return "text/csv; charset=utf-8"
+	case exportHTML:
+		return "text/html; charset=utf-8"
+	default:
+		return "application/x-ndjson"
+	}
+}
+
+func newExportEncoder(format string, w io.Writer) exportEncoder {
+	switch format {
+	case exportCSV:
+		return &csvExportEncoder{w: csv.NewWriter(w)}
+	case exportHTML:
+		return &htmlExportEncoder{w: w}
+	default:
+		return &jsonlExportEncoder{enc: json.NewEncoder(w)}
+	}
+}
+
+// jsonlExportEncoder writes one JSON record per line
+type jsonlExportEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *jsonlExportEncoder) begin(roomName string) error { return nil }
+
+func (e *jsonlExportEncoder) write(message *chatV1.Message, record *exportRecord) error {
+	return e.enc.Encode(record)
+}
+
+func (e *jsonlExportEncoder) flush() error { return nil }
+
+func (e *jsonlExportEncoder) end() error { return nil }
+
+// csvExportEncoder writes a header row and one row per message
+type csvExportEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvExportEncoder) begin(roomName string) error {
+	return e.w.Write([]string{"id", "parent_message_id", "created_at", "edited_at", "user_id", "username",
+		"type", "format", "content", "file_name", "file_url", "attachment"})
+}
+
+func (e *csvExportEncoder) write(message *chatV1.Message, record *exportRecord) error {
+	parentID := ""
+	if record.ParentMessageID != 0 {
+		parentID = strconv.FormatInt(record.ParentMessageID, 10)
+	}
+	return e.w.Write([]string{
+		strconv.FormatInt(record.ID, 10), parentID, record.CreatedAt, record.EditedAt,
+		strconv.FormatInt(record.UserID, 10), csvCell(record.Username), record.Type, record.Format,
+		csvCell(record.Content), csvCell(record.FileName), csvCell(record.FileURL), csvCell(record.Attachment),
+	})
+}
+
+// csvCell quotes user text that a spreadsheet would run as a formula
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func (e *csvExportEncoder) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvExportEncoder) end() error { return e.flush() }
+
+// htmlExportEncoder writes a standalone transcript page
+type htmlExportEncoder struct {
+	w io.Writer
+}
+
+var exportHTMLHead = template.Must(template.New("head").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.}} - chat export</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Roboto, sans-serif; max-width: 860px; margin: 2em auto; color: #1d1c1d; }
+.message { padding: 6px 0; border-bottom: 1px solid #eee; }
+.reply { margin-left: 2em; }
+.system { color: #616061; font-style: italic; }
+.meta { color: #616061; font-size: 0.85em; }
+.author { font-weight: bold; margin-right: 0.5em; }
+.content { white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>{{.}}</h1>
+`))
+
+var exportHTMLMessage = template.Must(template.New("message").Parse(`<div class="message{{if .Record.ParentMessageID}} reply{{end}}{{if eq .Record.Type "system"}} system{{end}}" id="m{{.Record.ID}}">
+<div class="meta"><span class="author">{{.Record.Username}}</span><time datetime="{{.Record.CreatedAt}}">{{.Record.CreatedAt}}</time>{{if .Record.EditedAt}} (edited){{end}}{{if .Record.ParentMessageID}} &middot; reply to <a href="#m{{.Record.ParentMessageID}}">#{{.Record.ParentMessageID}}</a>{{end}}</div>
+{{if .HTML}}<div class="content">{{.HTML}}</div>{{else}}<div class="content">{{.Record.Content}}</div>{{end}}
+{{if .Link}}<div class="file"><a href="{{.Link}}">{{if .Record.FileName}}{{.Record.FileName}}{{else}}attachment{{end}}</a></div>{{end}}
+</div>
+`))
+
+func (e *htmlExportEncoder) begin(roomName string) error {
+	return exportHTMLHead.Execute(e.w, roomName)
+}
+
+func (e *htmlExportEncoder) write(message *chatV1.Message, record *exportRecord) error {
+	link := record.FileURL
+	if record.Attachment != "" {
+		link = record.Attachment
+	}
+	return exportHTMLMessage.Execute(e.w, struct {
+		Record *exportRecord
+		HTML   template.HTML // already sanitized when the markdown was rendered
+		Link   string
+	}{record, template.HTML(message.ContentHtml), link})
+}
+
+func (e *htmlExportEncoder) flush() error { return nil }
+
+func (e *htmlExportEncoder) end() error {
+	_, err := io.WriteString(e.w, "</body>\n</html>\n")
+	return err
+}
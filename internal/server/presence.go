@@ -0,0 +1,156 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/yourusername/chat-app/internal/biz"
+)
+
+const (
+	// presenceSweepInterval is how often connections that missed their
+	// heartbeats are expired
+	presenceSweepInterval = 30 * time.Second
+	// presenceSweepBatch is how many connections are expired per round
+	presenceSweepBatch = 500
+	// presenceIdleGap is how long a connection goes without frames before
+	// the next one is reported at once rather than with the next heartbeat
+	presenceIdleGap = time.Minute
+)
+
+// PresenceSweeper expires the connections of chat instances that died
+// without closing them. Every chat replica runs one; only one replica
+// expires each connection.
+type PresenceSweeper struct {
+	uc       *biz.PresenceUseCase
+	stop     chan struct{}
+	stopOnce sync.Once
+	log      *log.Helper
+}
+
+// NewPresenceSweeper creates a presence sweeper.
+// It implements transport.Server so kratos starts and stops it with the app.
+func NewPresenceSweeper(uc *biz.PresenceUseCase, logger log.Logger) *PresenceSweeper {
+	return &PresenceSweeper{
+		uc:   uc,
+		stop: make(chan struct{}),
+		log:  log.NewHelper(log.With(logger, "module", "server/presence")),
+	}
+}
+
+// Start expires stale connections until the sweeper is stopped
+func (s *PresenceSweeper) Start(ctx context.Context) error {
+	s.log.Infof("Presence sweeper started, interval=%s", presenceSweepInterval)
+
+	ticker := time.NewTicker(presenceSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.stop:
+			return nil
+		case <-ticker.C:
+			s.sweep(ctx)
+		}
+	}
+}
+
+// Stop stops the sweep loop
+func (s *PresenceSweeper) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.stop) })
+	s.log.Info("Presence sweeper stopped")
+	return nil
+}
+
+// sweep expires stale connections in batches until none are left
+func (s *PresenceSweeper) sweep(ctx context.Context) {
+	for {
+		expired, err := s.uc.ExpireConnections(ctx, presenceSweepBatch)
+		if err != nil {
+			s.log.Errorf("Failed to expire connections: %v", err)
+			return
+		}
+		if expired > 0 {
+			s.log.Infof("Expired %d stale connections", expired)
+		}
+		if expired < presenceSweepBatch {
+			return
+		}
+	}
+}
+
+// startPresence puts an authenticated connection online and keeps it
+// there until the connection closes. Only the goroutine owning the
+// connection (the read pump, or the SSE handler) calls it.
+func (c *Client) startPresence() {
+	if c.Hub.presence == nil || c.presenceDone != nil {
+		return
+	}
+
+	connID := make([]byte, 8)
+	_, _ = rand.Read(connID)
+	c.presenceDone = make(chan struct{})
+	c.presenceWake = make(chan struct{}, 1)
+	c.activeAt.Store(time.Now().Unix())
+
+	go c.runPresence(c.ID, hex.EncodeToString(connID))
+}
+
+// stopPresence takes the connection offline
+func (c *Client) stopPresence() {
+	if c.presenceDone != nil {
+		close(c.presenceDone)
+	}
+}
+
+// markActive records a frame from the client. After an idle gap it is
+// reported right away, so an away user comes back online without waiting
+// for the next heartbeat.
+func (c *Client) markActive() {
+	now := time.Now().Unix()
+	previous := c.activeAt.Swap(now)
+	if c.presenceWake == nil || now-previous < int64(presenceIdleGap/time.Second) {
+		return
+	}
+	select {
+	case c.presenceWake <- struct{}{}:
+	default:
+	}
+}
+
+// runPresence heartbeats the connection until it closes. Every presence
+// write of a connection happens here, so its disconnect can't be followed
+// by a late heartbeat.
+func (c *Client) runPresence(userID int64, connID string) {
+	ctx := context.Background()
+	if err := c.Hub.presence.Connect(ctx, userID, connID); err != nil {
+		c.Hub.log.Warnf("Failed to connect presence of user %d: %v", userID, err)
+	}
+
+	ticker := time.NewTicker(biz.PresenceHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.presenceDone:
+			if err := c.Hub.presence.Disconnect(ctx, userID, connID); err != nil {
+				c.Hub.log.Warnf("Failed to disconnect presence of user %d: %v", userID, err)
+			}
+			return
+		case <-ticker.C:
+		case <-c.presenceWake:
+		}
+
+		activeAt := time.Unix(c.activeAt.Load(), 0)
+		if err := c.Hub.presence.Heartbeat(ctx, userID, connID, activeAt); err != nil {
+			c.Hub.log.Warnf("Failed to heartbeat presence of user %d: %v", userID, err)
+		}
+	}
+}
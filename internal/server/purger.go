@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/yourusername/chat-app/internal/biz"
+	"github.com/yourusername/chat-app/internal/conf"
+)
+
+const (
+	// purgeInterval is how often retention policies are applied
+	purgeInterval = 5 * time.Minute
+	// purgeBatch is how many messages are purged per round
+	purgeBatch = 500
+)
+
+// RetentionPurger hard-deletes messages their room's retention policy no
+// longer keeps. Every chat replica runs one; the data layer skips rows
+// another replica is already purging.
+type RetentionPurger struct {
+	uc            *biz.ChatUseCase
+	defaultPolicy biz.RetentionPolicy
+	stop          chan struct{}
+	stopOnce      sync.Once
+	log           *log.Helper
+}
+
+// NewRetentionPurger creates a retention purger. Rooms without their own
+// policy follow c.Retention, which defaults to keeping messages forever.
+// It implements transport.Server so kratos starts and stops it with the app.
+func NewRetentionPurger(uc *biz.ChatUseCase, c *conf.Data, logger log.Logger) *RetentionPurger {
+	helper := log.NewHelper(log.With(logger, "module", "server/purger"))
+
+	defaultPolicy := biz.RetentionPolicy{Mode: biz.RetentionForever}
+	if r := c.GetRetention(); r.GetMode() != "" {
+		policy := biz.RetentionPolicy{Mode: r.Mode, Value: r.Value}
+		if policy.Mode == biz.RetentionDefault || policy.Validate() != nil {
+			helper.Errorf("Invalid default retention %s/%d, keeping messages forever", r.Mode, r.Value)
+		} else {
+			defaultPolicy = policy
+		}
+	}
+
+	return &RetentionPurger{
+		uc:            uc,
+		defaultPolicy: defaultPolicy,
+		stop:          make(chan struct{}),
+		log:           helper,
+	}
+}
+
+// Start purges messages until the purger is stopped
+func (p *RetentionPurger) Start(ctx context.Context) error {
+	p.log.Infof("Retention purger started, interval=%s, default=%s/%d",
+		purgeInterval, p.defaultPolicy.Mode, p.defaultPolicy.Value)
+
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-p.stop:
+			return nil
+		case <-ticker.C:
+			p.purge(ctx)
+		}
+	}
+}
+
+// Stop stops the purge loop
+func (p *RetentionPurger) Stop(ctx context.Context) error {
+	p.stopOnce.Do(func() { close(p.stop) })
+	p.log.Info("Retention purger stopped")
+	return nil
+}
+
+// purge deletes messages past retention in batches until none are left
+func (p *RetentionPurger) purge(ctx context.Context) {
+	for {
+		deleted, err := p.uc.PurgeRetainedMessages(ctx, p.defaultPolicy, purgeBatch)
+		if err != nil {
+			p.log.Errorf("Failed to purge messages: %v", err)
+			return
+		}
+		if deleted > 0 {
+			p.log.Infof("Purged %d messages past retention", deleted)
+		}
+		if deleted < purgeBatch {
+			return
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/yourusername/chat-app/internal/biz"
+)
+
+const (
+	// reapInterval is how often expired messages are deleted
+	reapInterval = 10 * time.Second
+	// reapBatch is how many expired messages are deleted per round
+	reapBatch = 500
+)
+
+// MessageReaper hard-deletes expired ephemeral messages. Every chat replica
+// runs one; the data layer skips rows another replica is already deleting.
+type MessageReaper struct {
+	uc       *biz.ChatUseCase
+	stop     chan struct{}
+	stopOnce sync.Once
+	log      *log.Helper
+}
+
+// NewMessageReaper creates an expired message reaper.
+// It implements transport.Server so kratos starts and stops it with the app.
+func NewMessageReaper(uc *biz.ChatUseCase, logger log.Logger) *MessageReaper {
+	return &MessageReaper{
+		uc:   uc,
+		stop: make(chan struct{}),
+		log:  log.NewHelper(log.With(logger, "module", "server/reaper")),
+	}
+}
+
+// Start reaps expired messages until the reaper is stopped
+func (r *MessageReaper) Start(ctx context.Context) error {
+	r.log.Infof("Message reaper started, interval=%s", reapInterval)
+
+	ticker := time.NewTicker(reapInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.stop:
+			return nil
+		case <-ticker.C:
+			r.reap(ctx)
+		}
+	}
+}
+
+// Stop stops the reap loop
+func (r *MessageReaper) Stop(ctx context.Context) error {
+	r.stopOnce.Do(func() { close(r.stop) })
+	r.log.Info("Message reaper stopped")
+	return nil
+}
+
+// reap deletes expired messages in batches until none are left
+func (r *MessageReaper) reap(ctx context.Context) {
+	for {
+		deleted, err := r.uc.ReapExpiredMessages(ctx, reapBatch)
+		if err != nil {
+			r.log.Errorf("Failed to reap expired messages: %v", err)
+			return
+		}
+		if deleted > 0 {
+			r.log.Infof("Reaped %d expired messages", deleted)
+		}
+		if deleted < reapBatch {
+			return
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/yourusername/chat-app/internal/biz"
+)
+
+const (
+	// scheduleDispatchInterval is how often due scheduled messages are checked
+	scheduleDispatchInterval = 5 * time.Second
+	// scheduleDispatchBatch is how many messages are claimed per round
+	scheduleDispatchBatch = 100
+)
+
+// ScheduleDispatcher sends scheduled messages when they are due and closes
+// polls that reach their close time. Every chat replica runs one; messages
+// and polls are claimed first, so replicas don't handle the same one at once.
+type ScheduleDispatcher struct {
+	uc       *biz.ChatUseCase
+	stop     chan struct{}
+	stopOnce sync.Once
+	log      *log.Helper
+}
+
+// NewScheduleDispatcher creates a scheduled message dispatcher.
+// It implements transport.Server so kratos starts and stops it with the app.
+func NewScheduleDispatcher(uc *biz.ChatUseCase, logger log.Logger) *ScheduleDispatcher {
+	return &ScheduleDispatcher{
+		uc:   uc,
+		stop: make(chan struct{}),
+		log:  log.NewHelper(log.With(logger, "module", "server/scheduler")),
+	}
+}
+
+// Start dispatches due messages until the dispatcher is stopped
+func (d *ScheduleDispatcher) Start(ctx context.Context) error {
+	d.log.Infof("Schedule dispatcher started, interval=%s", scheduleDispatchInterval)
+
+	ticker := time.NewTicker(scheduleDispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-d.stop:
+			return nil
+		case <-ticker.C:
+			d.dispatch(ctx)
+			d.closePolls(ctx)
+		}
+	}
+}
+
+// Stop stops the dispatch loop
+func (d *ScheduleDispatcher) Stop(ctx context.Context) error {
+	d.stopOnce.Do(func() { close(d.stop) })
+	d.log.Info("Schedule dispatcher stopped")
+	return nil
+}
+
+// dispatch sends due messages in batches until none are left
+func (d *ScheduleDispatcher) dispatch(ctx context.Context) {
+	for {
+		sent, err := d.uc.DispatchScheduledMessages(ctx, scheduleDispatchBatch)
+		if err != nil {
+			d.log.Errorf("Failed to dispatch scheduled messages: %v", err)
+			return
+		}
+		if sent > 0 {
+			d.log.Infof("Dispatched %d scheduled messages", sent)
+		}
+		if sent < scheduleDispatchBatch {
+			return
+		}
+	}
+}
+
+// closePolls closes due polls in batches until none are left
+func (d *ScheduleDispatcher) closePolls(ctx context.Context) {
+	for {
+		closed, err := d.uc.CloseDuePolls(ctx, scheduleDispatchBatch)
+		if err != nil {
+			d.log.Errorf("Failed to close due polls: %v", err)
+			return
+		}
+		if closed > 0 {
+			d.log.Infof("Closed %d due polls", closed)
+		}
+		if closed < scheduleDispatchBatch {
+			return
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
 	userV1 "github.com/yourusername/chat-app/api/user/v1"
+	"github.com/yourusername/chat-app/internal/biz"
 	"github.com/yourusername/chat-app/internal/client"
 	"github.com/yourusername/chat-app/internal/conf"
 	"github.com/yourusername/chat-app/internal/middleware"
@@ -67,6 +68,7 @@ func NewHTTPServer(
 	userService *service.UserService,
 	roomService *service.RoomService,
 	chatService *service.ChatService,
+	presence *biz.PresenceUseCase,
 	redisClient *redis.Client,
 	logger log.Logger,
 ) *http.Server {
@@ -109,7 +111,7 @@ func NewHTTPServer(
 	// CORS is handled by the middleware filter above
 
 	// Create and start WebSocket hub
-	hub := NewHub(chatService, roomService, redisClient, logger)
+	hub := NewHub(chatService, roomService, presence, redisClient, logger)
 	go hub.Run()
 
 	// Register HTTP handlers
@@ -161,6 +163,9 @@ func NewHTTPServerWithUserClient(
 	c *conf.Server,
 	roomService *service.RoomService,
 	chatService *service.ChatService,
+	importService *service.ImportService,
+	presenceService *service.PresenceService,
+	presence *biz.PresenceUseCase,
 	redisClient *redis.Client,
 	userClient *client.UserClient,
 	minioStorage *storage.MinioStorage,
@@ -196,21 +201,29 @@ func NewHTTPServerWithUserClient(
 	srv := http.NewServer(opts...)
 
 	// Create WebSocket hub with User Client (calls User Service for auth)
-	hub := NewHubWithUserClient(chatService, roomService, redisClient, userClient, logger)
+	hub := NewHubWithUserClient(chatService, roomService, presence, redisClient, userClient, logger)
 	go hub.Run()
 
 	// Register HTTP handlers (Chat Service only - no UserService)
 	chatV1.RegisterRoomServiceHTTPServer(srv, roomService)
 	chatV1.RegisterChatServiceHTTPServer(srv, chatService)
+	chatV1.RegisterImportServiceHTTPServer(srv, importService)
+	chatV1.RegisterPresenceServiceHTTPServer(srv, presenceService)
 
 	// WebSocket endpoint - uses userClient for auth
 	srv.HandleFunc("/ws", HandleWebSocketWithUserClient(hub, userClient))
 
+	// Server-Sent Events fallback for clients that can't open a WebSocket
+	srv.HandleFunc("/sse", HandleSSE(hub, userClient))
+
 	// File upload endpoint
 	if minioStorage != nil {
 		srv.HandleFunc("/api/v1/upload", HandleUpload(minioStorage, userClient, logger))
 	}
 
+	// Room history export endpoint
+	srv.HandleFunc("/api/v1/export", HandleExport(chatService, roomService, minioStorage, userClient, logger))
+
 	// Static files
 	webDir := netHttp.Dir("./web")
 	srv.HandlePrefix("/web/", netHttp.StripPrefix("/web/", netHttp.FileServer(webDir)))
@@ -0,0 +1,384 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/biz"
+	"github.com/yourusername/chat-app/internal/client"
+	"github.com/yourusername/chat-app/internal/middleware"
+)
+
+const (
+	// sseKeepAlive is how often an idle stream gets a comment line, so
+	// proxies keep it open and a client that went away is noticed
+	sseKeepAlive = 25 * time.Second
+	// sseRetryMillis is the reconnect delay suggested to clients
+	sseRetryMillis = 3000
+	// maxSSERooms is how many rooms one stream can follow
+	maxSSERooms = 20
+	// sseReplayPage is how many messages are replayed per query
+	sseReplayPage = 100
+)
+
+// sseEvents are the hub frames relayed to SSE clients
+var sseEvents = map[string]bool{
+	"new_message": true,
+	"user_joined": true,
+	"user_left":   true,
+}
+
+// sseFrame is a hub frame sent to one of a stream's rooms
+type sseFrame struct {
+	roomID int64
+	data   []byte
+}
+
+// sseCursor is the last message delivered in each room. It is sent as the
+// event ID ("10:503,11:498"), so a reconnect resumes every room where it
+// left off.
+type sseCursor map[int64]int64
+
+func (c sseCursor) String() string {
+	roomIDs := make([]int64, 0, len(c))
+	for roomID := range c {
+		roomIDs = append(roomIDs, roomID)
+	}
+	sort.Slice(roomIDs, func(i, j int) bool { return roomIDs[i] < roomIDs[j] })
+
+	parts := make([]string, len(roomIDs))
+	for i, roomID := range roomIDs {
+		parts[i] = fmt.Sprintf("%d:%d", roomID, c[roomID])
+	}
+	return strings.Join(parts, ",")
+}
+
+// parseSSECursor reads a Last-Event-ID, skipping malformed entries
+func parseSSECursor(id string) sseCursor {
+	cursor := make(sseCursor)
+	for _, part := range strings.Split(id, ",") {
+		room, message, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			continue
+		}
+		roomID, err := strconv.ParseInt(room, 10, 64)
+		if err != nil || roomID <= 0 {
+			continue
+		}
+		messageID, err := strconv.ParseInt(message, 10, 64)
+		if err != nil || messageID < 0 {
+			continue
+		}
+		cursor[roomID] = messageID
+	}
+	return cursor
+}
+
+// sseStream is one SSE connection. It joins the hub as one client per
+// room, and the clients' frames are merged into a single event stream.
+type sseStream struct {
+	hub     *Hub
+	w       http.ResponseWriter
+	flusher http.Flusher
+	roomIDs []int64
+	clients []*Client
+	frames  chan sseFrame
+	closed  chan struct{}
+
+	// cursor is what the client has received; replayed holds each room's
+	// replayed message IDs. Instances may commit concurrent messages out of
+	// ID order, so only those live messages are duplicates.
+	cursor   sseCursor
+	replayed map[int64]map[int64]bool
+}
+
+// HandleSSE creates the Server-Sent Events handler, a fallback for clients
+// whose proxies block WebSocket upgrades:
+//
+//	GET /sse?room_id=1&room_id=2
+//
+// The stream carries the rooms' new_message, user_joined and user_left
+// events; messages are sent with the REST API. A reconnect with
+// Last-Event-ID first replays the messages it missed.
+func HandleSSE(hub *Hub, userClient *client.UserClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			writeError(w, http.StatusInternalServerError, "streaming unsupported")
+			return
+		}
+
+		userID, username, err := authenticateUser(r, userClient)
+		if err != nil {
+			hub.log.Warnf("SSE authentication failed: %v", err)
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		roomIDs, err := parseSSERooms(r.URL.Query()["room_id"])
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// The stream outlives the server's request timeout
+		ctx := context.WithoutCancel(r.Context())
+		ctx = context.WithValue(ctx, middleware.UserIDKey, userID)
+		ctx = context.WithValue(ctx, middleware.UsernameKey, username)
+
+		// Checks access up front, while an error status can still be sent
+		for _, roomID := range roomIDs {
+			if _, err := hub.roomService.GetRoom(ctx, &chatV1.GetRoomRequest{Id: roomID}); err != nil {
+				if errors.Is(err, biz.ErrRoomAccessDenied) {
+					writeError(w, http.StatusForbidden, fmt.Sprintf("access denied to room %d", roomID))
+					return
+				}
+				hub.log.Errorf("Failed to get room %d for SSE: %v", roomID, err)
+				writeError(w, http.StatusNotFound, fmt.Sprintf("room %d not found", roomID))
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no") // nginx must not buffer events
+		w.WriteHeader(http.StatusOK)
+		if _, err := fmt.Fprintf(w, "retry: %d\n\n", sseRetryMillis); err != nil {
+			return
+		}
+		flusher.Flush()
+
+		stream := &sseStream{
+			hub:      hub,
+			w:        w,
+			flusher:  flusher,
+			roomIDs:  roomIDs,
+			frames:   make(chan sseFrame, 256),
+			closed:   make(chan struct{}),
+			cursor:   make(sseCursor, len(roomIDs)),
+			replayed: make(map[int64]map[int64]bool, len(roomIDs)),
+		}
+
+		// Joins before replaying so nothing sent in between is missed
+		stream.join(userID, username, requestIP(r))
+		defer stream.leave()
+
+		if err := stream.replay(ctx, parseSSECursor(r.Header.Get("Last-Event-ID"))); err != nil {
+			hub.log.Warnf("SSE replay for user %d ended: %v", userID, err)
+			return
+		}
+
+		hub.log.Infow("SSE connected", "user_id", userID, "rooms", len(roomIDs), "cursor", stream.cursor.String())
+		stream.run(r.Context())
+		hub.log.Infow("SSE disconnected", "user_id", userID)
+	}
+}
+
+// parseSSERooms reads the room_id query values
+func parseSSERooms(values []string) ([]int64, error) {
+	seen := make(map[int64]bool, len(values))
+	var roomIDs []int64
+	for _, value := range values {
+		roomID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || roomID <= 0 {
+			return nil, fmt.Errorf("invalid room_id %q", value)
+		}
+		if !seen[roomID] {
+			seen[roomID] = true
+			roomIDs = append(roomIDs, roomID)
+		}
+	}
+	if len(roomIDs) == 0 {
+		return nil, fmt.Errorf("room_id is required")
+	}
+	if len(roomIDs) > maxSSERooms {
+		return nil, fmt.Errorf("at most %d rooms per stream", maxSSERooms)
+	}
+	return roomIDs, nil
+}
+
+// join registers a hub client for every room of the stream. The first
+// client also keeps the user's presence.
+func (s *sseStream) join(userID int64, username, ip string) {
+	for _, roomID := range s.roomIDs {
+		client := &Client{
+			ID:          userID,
+			Username:    username,
+			Send:        make(chan []byte, 256),
+			Hub:         s.hub,
+			RoomID:      roomID,
+			ConnectedAt: time.Now(),
+			IP:          ip,
+		}
+		s.clients = append(s.clients, client)
+		s.hub.register <- client
+		go s.forward(client)
+	}
+	s.clients[0].startPresence()
+}
+
+// leave unregisters the stream's clients; the hub closes their channels,
+// which ends the forwarders
+func (s *sseStream) leave() {
+	close(s.closed)
+	s.clients[0].stopPresence()
+	for _, client := range s.clients {
+		s.hub.unregister <- client
+	}
+}
+
+// forward moves a client's frames into the stream until the hub drops it
+func (s *sseStream) forward(client *Client) {
+	for data := range client.Send {
+		select {
+		case s.frames <- sseFrame{roomID: client.RoomID, data: data}:
+		case <-s.closed:
+		}
+	}
+}
+
+// replay sends the messages after the client's cursor, oldest first. Rooms
+// the cursor doesn't cover start at their latest message.
+func (s *sseStream) replay(ctx context.Context, resume sseCursor) error {
+	// Every room is in the cursor before the first event, so a reconnect
+	// during the replay resumes all of them
+	for _, roomID := range s.roomIDs {
+		if afterID, ok := resume[roomID]; ok {
+			s.cursor[roomID] = afterID
+			continue
+		}
+		latest, err := s.hub.chatService.GetMessages(ctx, &chatV1.GetMessagesRequest{RoomId: roomID, Limit: 1})
+		if err != nil {
+			return err
+		}
+		if len(latest.Messages) > 0 {
+			s.cursor[roomID] = latest.Messages[0].Id
+		} else {
+			s.cursor[roomID] = 0
+		}
+	}
+
+	for _, roomID := range s.roomIDs {
+		if _, ok := resume[roomID]; !ok {
+			continue
+		}
+		// A room that was empty (cursor 0) replays its latest page
+		replayed := make(map[int64]bool)
+		s.replayed[roomID] = replayed
+		for {
+			page, err := s.hub.chatService.GetMessages(ctx, &chatV1.GetMessagesRequest{
+				RoomId:  roomID,
+				Limit:   sseReplayPage,
+				AfterId: s.cursor[roomID],
+			})
+			if err != nil {
+				return err
+			}
+			// Pages are newest first
+			for i := len(page.Messages) - 1; i >= 0; i-- {
+				msgBytes, _ := json.Marshal(buildNewMessage(newRedisMessage(page.Messages[i])))
+				s.cursor[roomID] = page.Messages[i].Id
+				replayed[page.Messages[i].Id] = true
+				if err := s.write("new_message", s.cursor.String(), msgBytes); err != nil {
+					return err
+				}
+			}
+			if len(page.Messages) == 0 || !page.HasMore {
+				break
+			}
+		}
+	}
+
+	// An ID without data moves the client's Last-Event-ID to the full cursor
+	return s.write("", s.cursor.String(), nil)
+}
+
+// run relays live frames until the client goes away
+func (s *sseStream) run(ctx context.Context) {
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+
+	done := ctx.Done()
+	for {
+		select {
+		case <-done:
+			// The server's request timeout passing doesn't end the stream;
+			// a client that goes away after it is noticed by a failed write
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return
+			}
+			done = nil
+		case <-keepAlive.C:
+			if _, err := io.WriteString(s.w, ": ping\n\n"); err != nil {
+				return
+			}
+			s.flusher.Flush()
+		case frame := <-s.frames:
+			if err := s.relay(frame); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// relay writes a hub frame as an event, if SSE clients receive its type
+func (s *sseStream) relay(frame sseFrame) error {
+	var header struct {
+		Type      string `json:"type"`
+		MessageID int64  `json:"message_id"`
+	}
+	if err := json.Unmarshal(frame.data, &header); err != nil || !sseEvents[header.Type] {
+		return nil
+	}
+	if header.Type != "new_message" {
+		return s.write(header.Type, "", frame.data)
+	}
+
+	// Skip what the replay already sent; each message is published once
+	if replayed := s.replayed[frame.roomID]; replayed[header.MessageID] {
+		delete(replayed, header.MessageID)
+		return nil
+	}
+	if header.MessageID > s.cursor[frame.roomID] {
+		s.cursor[frame.roomID] = header.MessageID
+	}
+	return s.write(header.Type, s.cursor.String(), frame.data)
+}
+
+// write sends one event; frames are single-line JSON
+func (s *sseStream) write(event, id string, data []byte) error {
+	var b strings.Builder
+	if id != "" {
+		b.WriteString("id: " + id + "\n")
+	}
+	if event != "" {
+		b.WriteString("event: " + event + "\n")
+	}
+	if data != nil {
+		b.WriteString("data: ")
+		b.Write(data)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if _, err := io.WriteString(s.w, b.String()); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
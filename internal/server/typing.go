@@ -0,0 +1,195 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/yourusername/chat-app/internal/metrics"
+)
+
+// Typing frames relayed between instances through the room's Redis channel
+const (
+	typingStartEvent = "typing_start"
+	typingStopEvent  = "typing_stop"
+)
+
+const (
+	// typingTTL is how long a typing_start counts without a refresh. Clients
+	// repeat typing_start while the user types, so a client that crashes or
+	// an instance that dies without sending typing_stop drops out on its own.
+	typingTTL = 6 * time.Second
+
+	// typingThrottle is the shortest gap between relayed typing_start frames
+	// of one connection; faster repeats are dropped
+	typingThrottle = 2 * time.Second
+
+	// typingSweepInterval is how often each hub expires stale typists
+	typingSweepInterval = time.Second
+)
+
+// typist is a user typing in a room, as last heard by this hub
+type typist struct {
+	username  string
+	expiresAt time.Time
+}
+
+// TypingUser is an entry of the typing event sent to clients
+type TypingUser struct {
+	UserID   int64  `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// startTyping relays a typing_start from the connection, at most once per
+// typingThrottle. Only the connection's read pump calls it.
+func (c *Client) startTyping() {
+	if c.typingRoomID != 0 && c.typingRoomID != c.RoomID {
+		c.stopTyping()
+	}
+
+	// The throttle outlives typing_stop, so alternating start and stop
+	// frames can't flood Redis either
+	now := time.Now()
+	if now.Sub(c.typingSentAt) < typingThrottle {
+		return
+	}
+
+	if c.publishTyping(typingStartEvent, c.RoomID) {
+		c.typingRoomID = c.RoomID
+		c.typingSentAt = now
+	}
+}
+
+// stopTyping relays a typing_stop if the connection is marked as typing.
+// It runs on typing_stop frames and whenever the connection sends a
+// message, leaves its room or closes. Only a relayed typing_start marks the
+// connection, so stops are relayed at most once per typingThrottle too.
+func (c *Client) stopTyping() {
+	if c.typingRoomID == 0 {
+		return
+	}
+	roomID := c.typingRoomID
+	c.typingRoomID = 0
+	c.publishTyping(typingStopEvent, roomID)
+}
+
+// publishTyping sends a typing frame to every instance. Typing state only
+// ever lives in the hubs' memory; nothing is stored.
+func (c *Client) publishTyping(event string, roomID int64) bool {
+	if c.Hub.redisClient == nil {
+		return false
+	}
+
+	msgBytes, _ := json.Marshal(RedisMessage{
+		RoomID:   roomID,
+		UserID:   c.ID,
+		Username: c.Username,
+		Event:    event,
+	})
+
+	redisStart := time.Now()
+	if err := c.Hub.redisClient.Publish(context.Background(), fmt.Sprintf("room:%d", roomID), msgBytes).Err(); err != nil {
+		c.Hub.log.Warnf("Failed to publish %s for user %d in room %d: %v", event, c.ID, roomID, err)
+		return false
+	}
+	metrics.RecordRedisOperation("publish_typing", redisStart)
+	return true
+}
+
+// handleTyping applies a typing frame from any instance to the hub's view
+// of the room, and tells local clients if the set of typists changed
+func (h *Hub) handleTyping(redisMsg *RedisMessage) {
+	h.typingMu.Lock()
+	typists := h.typing[redisMsg.RoomID]
+	_, wasTyping := typists[redisMsg.UserID]
+
+	changed := false
+	switch redisMsg.Event {
+	case typingStartEvent:
+		if typists == nil {
+			typists = make(map[int64]*typist)
+			h.typing[redisMsg.RoomID] = typists
+		}
+		typists[redisMsg.UserID] = &typist{username: redisMsg.Username, expiresAt: time.Now().Add(typingTTL)}
+		changed = !wasTyping
+	case typingStopEvent:
+		delete(typists, redisMsg.UserID)
+		if len(typists) == 0 {
+			delete(h.typing, redisMsg.RoomID)
+		}
+		changed = wasTyping
+	}
+	// Sent under typingMu so snapshots reach clients in the order they were taken
+	if changed {
+		h.broadcastTyping(redisMsg.RoomID, typingUsers(typists))
+	}
+	h.typingMu.Unlock()
+}
+
+// expireTyping drops typists whose last typing_start is older than
+// typingTTL and updates their rooms
+func (h *Hub) expireTyping() {
+	ticker := time.NewTicker(typingSweepInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		h.typingMu.Lock()
+		for roomID, typists := range h.typing {
+			expired := false
+			for userID, t := range typists {
+				if now.After(t.expiresAt) {
+					delete(typists, userID)
+					expired = true
+				}
+			}
+			if !expired {
+				continue
+			}
+			h.broadcastTyping(roomID, typingUsers(typists))
+			if len(typists) == 0 {
+				delete(h.typing, roomID)
+			}
+		}
+		h.typingMu.Unlock()
+	}
+}
+
+// broadcastTyping sends the room's typists to its local clients; an empty
+// list means nobody is typing. Clients leave themselves out when rendering.
+// Callers hold typingMu; safeSend never blocks, so this is cheap.
+func (h *Hub) broadcastTyping(roomID int64, users []TypingUser) {
+	// Copied without GetRoomClients' logging, as typing updates are frequent
+	h.mu.RLock()
+	clients := make([]*Client, 0, len(h.rooms[roomID]))
+	for client := range h.rooms[roomID] {
+		clients = append(clients, client)
+	}
+	h.mu.RUnlock()
+	if len(clients) == 0 {
+		return
+	}
+
+	if users == nil {
+		users = []TypingUser{}
+	}
+	msgBytes, _ := json.Marshal(map[string]interface{}{
+		"type":    "typing",
+		"room_id": roomID,
+		"users":   users,
+	})
+	for _, client := range clients {
+		client.safeSend(msgBytes)
+	}
+}
+
+// typingUsers lists a room's typists in a stable order
+func typingUsers(typists map[int64]*typist) []TypingUser {
+	users := make([]TypingUser, 0, len(typists))
+	for userID, t := range typists {
+		users = append(users, TypingUser{UserID: userID, Username: t.username})
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].UserID < users[j].UserID })
+	return users
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/yourusername/chat-app/internal/biz"
+)
+
+const (
+	// unfurlInterval is how often queued messages are unfurled
+	unfurlInterval = 2 * time.Second
+	// unfurlBatch is how many queued messages are unfurled per round
+	unfurlBatch = 20
+)
+
+// LinkPreviewWorker fetches previews for links in new and edited messages.
+// Every chat replica runs one; the data layer skips messages another
+// replica has already claimed.
+type LinkPreviewWorker struct {
+	uc       *biz.ChatUseCase
+	stop     chan struct{}
+	stopOnce sync.Once
+	log      *log.Helper
+}
+
+// NewLinkPreviewWorker creates a link preview worker.
+// It implements transport.Server so kratos starts and stops it with the app.
+func NewLinkPreviewWorker(uc *biz.ChatUseCase, logger log.Logger) *LinkPreviewWorker {
+	return &LinkPreviewWorker{
+		uc:   uc,
+		stop: make(chan struct{}),
+		log:  log.NewHelper(log.With(logger, "module", "server/unfurler")),
+	}
+}
+
+// Start unfurls queued messages until the worker is stopped
+func (w *LinkPreviewWorker) Start(ctx context.Context) error {
+	w.log.Infof("Link preview worker started, interval=%s", unfurlInterval)
+
+	// In-flight fetches are abandoned on stop; their messages are not retried
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-w.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(unfurlInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			w.unfurl(ctx)
+		}
+	}
+}
+
+// Stop stops the unfurl loop
+func (w *LinkPreviewWorker) Stop(ctx context.Context) error {
+	w.stopOnce.Do(func() { close(w.stop) })
+	w.log.Info("Link preview worker stopped")
+	return nil
+}
+
+// unfurl processes queued messages in batches until none are left
+func (w *LinkPreviewWorker) unfurl(ctx context.Context) {
+	for {
+		processed, err := w.uc.UnfurlLinks(ctx, unfurlBatch)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.log.Errorf("Failed to unfurl links: %v", err)
+			}
+			return
+		}
+		if processed > 0 {
+			w.log.Infof("Unfurled links of %d messages", processed)
+		}
+		if processed < unfurlBatch {
+			return
+		}
+	}
+}
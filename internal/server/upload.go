@@ -48,7 +48,7 @@ func HandleUpload(store *storage.MinioStorage, userClient *client.UserClient, lo
 		}
 
 		// Authenticate via User Service (like WebSocket)
-		userID, err := authenticateUpload(r, userClient)
+		userID, err := authenticateRequest(r, userClient)
 		if err != nil {
 			log.Warnf("Upload authentication failed: %v", err)
 			writeError(w, http.StatusUnauthorized, "unauthorized")
@@ -114,16 +114,23 @@ func HandleUpload(store *storage.MinioStorage, userClient *client.UserClient, lo
 	}
 }
 
-// authenticateUpload extracts token and validates via User Service
-func authenticateUpload(r *http.Request, userClient *client.UserClient) (int64, error) {
+// authenticateRequest extracts token and validates via User Service
+func authenticateRequest(r *http.Request, userClient *client.UserClient) (int64, error) {
+	userID, _, err := authenticateUser(r, userClient)
+	return userID, err
+}
+
+// authenticateUser validates the request's Bearer token and returns the
+// user's ID and username
+func authenticateUser(r *http.Request, userClient *client.UserClient) (int64, string, error) {
 	authHeader := r.Header.Get("Authorization")
 	if authHeader == "" {
-		return 0, fmt.Errorf("missing authorization header")
+		return 0, "", fmt.Errorf("missing authorization header")
 	}
 
 	parts := strings.SplitN(authHeader, " ", 2)
 	if len(parts) != 2 || parts[0] != "Bearer" {
-		return 0, fmt.Errorf("invalid authorization header format")
+		return 0, "", fmt.Errorf("invalid authorization header format")
 	}
 
 	token := parts[1]
@@ -132,12 +139,12 @@ func authenticateUpload(r *http.Request, userClient *client.UserClient) (int64,
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	userID, _, err := userClient.ValidateToken(ctx, token)
+	userID, username, err := userClient.ValidateToken(ctx, token)
 	if err != nil {
-		return 0, fmt.Errorf("invalid token: %w", err)
+		return 0, "", fmt.Errorf("invalid token: %w", err)
 	}
 
-	return userID, nil
+	return userID, username, nil
 }
 
 func writeError(w http.ResponseWriter, status int, message string) {
@@ -16,6 +16,7 @@ import (
 	"github.com/redis/go-redis/v9"
 
 	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/biz"
 	"github.com/yourusername/chat-app/internal/client"
 	"github.com/yourusername/chat-app/internal/metrics"
 	"github.com/yourusername/chat-app/internal/middleware"
@@ -40,6 +41,17 @@ type Client struct {
 	RoomID      int64
 	ConnectedAt time.Time // Track connection time
 	IP          string    // Client IP address
+
+	// Room the connection last reported typing in (0 = not typing), and
+	// when it last relayed typing_start; only the read pump touches these
+	typingRoomID int64
+	typingSentAt time.Time
+
+	// Presence heartbeat of an authenticated connection; activeAt is the
+	// unix time of the client's last frame
+	presenceDone chan struct{}
+	presenceWake chan struct{}
+	activeAt     atomic.Int64
 }
 
 // RedisMessage represents a message published to Redis Pub/Sub
@@ -56,6 +68,26 @@ type RedisMessage struct {
 	FileName string `json:"file_name,omitempty"`
 	FileSize int64  `json:"file_size,omitempty"`
 	MimeType string `json:"mime_type,omitempty"`
+	// Ephemeral messages are removed by a message_expired event at this time
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+	// Snapshot of the quoted or forwarded message
+	QuotedMessage *chatV1.QuotedMessage `json:"quoted_message,omitempty"`
+	IsForwarded   bool                  `json:"is_forwarded,omitempty"`
+	// Markdown messages carry their sanitized HTML rendering
+	Format      string `json:"format,omitempty"`
+	ContentHTML string `json:"content_html,omitempty"`
+	// Poll messages carry the poll; later tallies arrive as poll_updated events
+	Poll *chatV1.Poll `json:"poll,omitempty"`
+	// System messages record room lifecycle events (joins, renames, ...)
+	SystemEvent *chatV1.SystemEvent `json:"system_event,omitempty"`
+	// ClientMsgID lets the sender match the message to its optimistic copy
+	ClientMsgID string `json:"client_msg_id,omitempty"`
+	// Room events other than new messages (e.g. message_edited) set Event
+	// and carry their fields in Data
+	Event string          `json:"event,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+	// Targeted events (e.g. mentioned) go only to these users, in any room
+	UserIDs []int64 `json:"user_ids,omitempty"`
 }
 
 // safeSend safely sends a message to a client's channel with panic recovery
@@ -104,9 +136,16 @@ type Hub struct {
 	// User Client for microservices mode (calls User Service for auth)
 	userClient *client.UserClient
 
+	// Presence of authenticated connections, shared across instances
+	presence *biz.PresenceUseCase
+
 	// Performance monitoring
 	droppedMessages  atomic.Int64 // Messages dropped due to full buffer
 	activeBroadcasts atomic.Int64 // Currently running broadcast goroutines
+
+	// Users typing in each room, from every instance's typing frames
+	typingMu sync.Mutex
+	typing   map[int64]map[int64]*typist
 }
 
 // WebSocketMessage represents messages between client and server
@@ -122,18 +161,35 @@ type WebSocketMessage struct {
 	FileName    string `json:"file_name,omitempty"`
 	FileSize    int64  `json:"file_size,omitempty"`
 	MimeType    string `json:"mime_type,omitempty"`
+	// Target message for edit_message / delete_message
+	MessageID int64 `json:"message_id,omitempty"`
+	// Thread root for send_message replies
+	ParentMessageID int64 `json:"parent_message_id,omitempty"`
+	// Seconds until a send_message expires (0 uses the room default)
+	ExpiresIn int32 `json:"expires_in,omitempty"`
+	// Message quoted by send_message
+	QuotedMessageID int64 `json:"quoted_message_id,omitempty"`
+	// Content format for send_message: plain (default) or markdown
+	Format string `json:"format,omitempty"`
+	// Question and options for send_message with message_type=poll
+	Poll *chatV1.PollRequest `json:"poll,omitempty"`
+	// Sender-generated ID of a send_message; a retry with the same ID
+	// returns the original message, and new_message echoes it back
+	ClientMsgID string `json:"client_msg_id,omitempty"`
 }
 
 // NewHub creates a new WebSocket hub (monolith mode)
-func NewHub(chatService *service.ChatService, roomService *service.RoomService, redisClient *redis.Client, logger log.Logger) *Hub {
+func NewHub(chatService *service.ChatService, roomService *service.RoomService, presence *biz.PresenceUseCase, redisClient *redis.Client, logger log.Logger) *Hub {
 	hub := &Hub{
 		rooms:       make(map[int64]map[*Client]bool),
 		register:    make(chan *Client, 100),
 		unregister:  make(chan *Client, 100),
 		chatService: chatService,
 		roomService: roomService,
+		presence:    presence,
 		redisClient: redisClient,
 		log:         log.NewHelper(logger),
+		typing:      make(map[int64]map[int64]*typist),
 	}
 
 	// Start Redis subscriber
@@ -142,21 +198,26 @@ func NewHub(chatService *service.ChatService, roomService *service.RoomService,
 	// Start performance monitor
 	go hub.monitorPerformance()
 
+	// Expire typists whose clients stopped refreshing
+	go hub.expireTyping()
+
 	return hub
 }
 
 // NewHubWithUserClient creates a new WebSocket hub (microservices mode)
 // Uses userClient to call User Service for authentication
-func NewHubWithUserClient(chatService *service.ChatService, roomService *service.RoomService, redisClient *redis.Client, userClient *client.UserClient, logger log.Logger) *Hub {
+func NewHubWithUserClient(chatService *service.ChatService, roomService *service.RoomService, presence *biz.PresenceUseCase, redisClient *redis.Client, userClient *client.UserClient, logger log.Logger) *Hub {
 	hub := &Hub{
 		rooms:       make(map[int64]map[*Client]bool),
 		register:    make(chan *Client, 100),
 		unregister:  make(chan *Client, 100),
 		chatService: chatService,
 		roomService: roomService,
+		presence:    presence,
 		redisClient: redisClient,
 		userClient:  userClient,
 		log:         log.NewHelper(logger),
+		typing:      make(map[int64]map[int64]*typist),
 	}
 
 	// Start Redis subscriber
@@ -165,6 +226,9 @@ func NewHubWithUserClient(chatService *service.ChatService, roomService *service
 	// Start performance monitor
 	go hub.monitorPerformance()
 
+	// Expire typists whose clients stopped refreshing
+	go hub.expireTyping()
+
 	return hub
 }
 
@@ -325,14 +389,7 @@ func HandleWebSocket(hub *Hub, jwtSecret string) http.HandlerFunc {
 // Uses userClient to call User Service for authentication
 func HandleWebSocketWithUserClient(hub *Hub, userClient *client.UserClient) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// Get client IP
-		clientIP := r.Header.Get("X-Real-IP")
-		if clientIP == "" {
-			clientIP = r.Header.Get("X-Forwarded-For")
-		}
-		if clientIP == "" {
-			clientIP = r.RemoteAddr
-		}
+		clientIP := requestIP(r)
 
 		hub.log.Infow("WebSocket connection attempt", "ip", clientIP)
 
@@ -357,6 +414,18 @@ func HandleWebSocketWithUserClient(hub *Hub, userClient *client.UserClient) http
 	}
 }
 
+// requestIP returns the client IP of a request, as forwarded by the proxy
+func requestIP(r *http.Request) string {
+	clientIP := r.Header.Get("X-Real-IP")
+	if clientIP == "" {
+		clientIP = r.Header.Get("X-Forwarded-For")
+	}
+	if clientIP == "" {
+		clientIP = r.RemoteAddr
+	}
+	return clientIP
+}
+
 // readPumpWithUserClient handles incoming messages using User Service for auth
 func (c *Client) readPumpWithUserClient(userClient *client.UserClient) {
 	defer func() {
@@ -370,6 +439,8 @@ func (c *Client) readPumpWithUserClient(userClient *client.UserClient) {
 			"duration_seconds", time.Since(c.ConnectedAt).Seconds(),
 		)
 
+		c.stopTyping()
+		c.stopPresence()
 		if c.ID != 0 && c.RoomID != 0 {
 			c.Hub.unregister <- c
 		}
@@ -391,6 +462,7 @@ func (c *Client) readPumpWithUserClient(userClient *client.UserClient) {
 			}
 			break
 		}
+		c.markActive()
 
 		switch msg.Type {
 		case "auth":
@@ -399,6 +471,7 @@ func (c *Client) readPumpWithUserClient(userClient *client.UserClient) {
 				c.sendError("Authentication failed")
 				return
 			}
+			c.startPresence()
 			c.sendSuccess("Authenticated successfully")
 
 		case "join_room":
@@ -420,8 +493,43 @@ func (c *Client) readPumpWithUserClient(userClient *client.UserClient) {
 				c.sendError(fmt.Sprintf("Failed to send message: %v", err))
 				continue
 			}
+			c.stopTyping()
+
+		case "edit_message":
+			if c.ID == 0 {
+				c.sendError("Please authenticate first")
+				continue
+			}
+			if err := c.editMessage(&msg); err != nil {
+				c.sendError(fmt.Sprintf("Failed to edit message: %v", err))
+				continue
+			}
+
+		case "delete_message":
+			if c.ID == 0 {
+				c.sendError("Please authenticate first")
+				continue
+			}
+			if err := c.deleteMessage(&msg); err != nil {
+				c.sendError(fmt.Sprintf("Failed to delete message: %v", err))
+				continue
+			}
+
+		case "typing_start":
+			if c.ID == 0 || c.RoomID == 0 {
+				c.sendError("Please authenticate and join a room first")
+				continue
+			}
+			c.startTyping()
+
+		case "typing_stop":
+			c.stopTyping()
+
+		case "active":
+			// Only reports user activity, keeping the user from going away
 
 		case "leave_room":
+			c.stopTyping()
 			if c.RoomID != 0 {
 				c.Hub.unregister <- c
 				c.RoomID = 0
@@ -468,6 +576,8 @@ func (c *Client) authenticateWithUserClient(tokenString string, userClient *clie
 // readPump handles incoming messages from the client
 func (c *Client) readPump(jwtSecret string) {
 	defer func() {
+		c.stopTyping()
+		c.stopPresence()
 		if c.ID != 0 && c.RoomID != 0 {
 			c.Hub.unregister <- c
 		}
@@ -489,6 +599,7 @@ func (c *Client) readPump(jwtSecret string) {
 			}
 			break
 		}
+		c.markActive()
 
 		switch msg.Type {
 		case "auth":
@@ -497,6 +608,7 @@ func (c *Client) readPump(jwtSecret string) {
 				c.sendError("Authentication failed")
 				return
 			}
+			c.startPresence()
 			c.sendSuccess("Authenticated successfully")
 
 		case "join_room":
@@ -522,9 +634,51 @@ func (c *Client) readPump(jwtSecret string) {
 				c.sendError(fmt.Sprintf("Failed to send message: %v", err))
 				continue
 			}
+			c.stopTyping()
+
+		case "edit_message":
+			// Edit one of the user's own messages
+			if c.ID == 0 {
+				c.sendError("Please authenticate first")
+				continue
+			}
+
+			if err := c.editMessage(&msg); err != nil {
+				c.sendError(fmt.Sprintf("Failed to edit message: %v", err))
+				continue
+			}
+
+		case "delete_message":
+			// Delete own message, or any message as a room moderator
+			if c.ID == 0 {
+				c.sendError("Please authenticate first")
+				continue
+			}
+
+			if err := c.deleteMessage(&msg); err != nil {
+				c.sendError(fmt.Sprintf("Failed to delete message: %v", err))
+				continue
+			}
+
+		case "typing_start":
+			// The user is typing in the current room; repeat while they type
+			if c.ID == 0 || c.RoomID == 0 {
+				c.sendError("Please authenticate and join a room first")
+				continue
+			}
+			c.startTyping()
+
+		case "typing_stop":
+			// The user stopped typing or cleared their draft
+			c.stopTyping()
+
+		case "active":
+			// The user is active (e.g. scrolling) without sending anything;
+			// any frame counts, this one only keeps the user from going away
 
 		case "leave_room":
 			// Leave the current room
+			c.stopTyping()
 			if c.RoomID != 0 {
 				c.Hub.unregister <- c
 				c.RoomID = 0
@@ -627,6 +781,7 @@ func (c *Client) joinRoom(roomID int64) error {
 	}
 
 	// Leave current room if in one
+	c.stopTyping()
 	if c.RoomID != 0 {
 		c.Hub.unregister <- c
 	}
@@ -636,12 +791,20 @@ func (c *Client) joinRoom(roomID int64) error {
 	c.Hub.log.Infof("Sending client to register channel: user_id=%d, room_id=%d", c.ID, roomID)
 	c.Hub.register <- c
 
-	// Send room info to client
+	// Send room info to client, with the current pins
 	roomInfo := map[string]interface{}{
 		"type":    "room_joined",
 		"room_id": room.Id,
 		"room":    room,
 	}
+	pins, err := c.Hub.chatService.ListPinnedMessages(ctx, &chatV1.ListPinnedMessagesRequest{
+		RoomId: roomID,
+	})
+	if err != nil {
+		c.Hub.log.Warnf("Failed to load pins for room_id=%d: %v", roomID, err)
+	} else {
+		roomInfo["pins"] = pins.Pins
+	}
 	msgBytes, _ := json.Marshal(roomInfo)
 	c.safeSend(msgBytes)
 
@@ -677,7 +840,7 @@ func (c *Client) sendMessage(wsMsg *WebSocketMessage) error {
 	}
 
 	// Validate based on type
-	if msgType == "text" && wsMsg.Content == "" {
+	if msgType == "text" && wsMsg.Content == "" && wsMsg.QuotedMessageID == 0 {
 		return fmt.Errorf("empty message")
 	}
 	if (msgType == "image" || msgType == "file") && wsMsg.FileURL == "" {
@@ -689,7 +852,7 @@ func (c *Client) sendMessage(wsMsg *WebSocketMessage) error {
 	ctx = context.WithValue(ctx, middleware.UserIDKey, c.ID)
 	ctx = context.WithValue(ctx, middleware.UsernameKey, c.Username)
 
-	msg, err := c.Hub.chatService.SendMessage(ctx, &chatV1.SendMessageRequest{
+	msg, replayed, err := c.Hub.chatService.SendMessageReplayable(ctx, &chatV1.SendMessageRequest{
 		RoomId:   c.RoomID,
 		Content:  wsMsg.Content,
 		Type:     msgType,
@@ -697,6 +860,13 @@ func (c *Client) sendMessage(wsMsg *WebSocketMessage) error {
 		FileName: wsMsg.FileName,
 		FileSize: wsMsg.FileSize,
 		MimeType: wsMsg.MimeType,
+
+		ParentMessageId: wsMsg.ParentMessageID,
+		ExpiresIn:       wsMsg.ExpiresIn,
+		QuotedMessageId: wsMsg.QuotedMessageID,
+		Format:          wsMsg.Format,
+		Poll:            wsMsg.Poll,
+		ClientMsgId:     wsMsg.ClientMsgID,
 	})
 	if err != nil {
 		c.Hub.log.Errorw("Failed to send message",
@@ -719,19 +889,21 @@ func (c *Client) sendMessage(wsMsg *WebSocketMessage) error {
 		"duration_ms", time.Since(startTime).Milliseconds(),
 	)
 
+	// Thread replies are published by the chat service as thread_reply
+	if msg.ParentMessageId != 0 {
+		metrics.RecordMessageSent("public")
+		metrics.RecordMessageLatency(startTime)
+		return nil
+	}
+
 	// Publish to Redis instead of local broadcast
-	redisMsg := RedisMessage{
-		RoomID:    msg.RoomId,
-		MessageID: msg.Id,
-		UserID:    msg.UserId,
-		Username:  msg.Username,
-		Content:   msg.Content,
-		CreatedAt: msg.CreatedAt,
-		Type:      msg.Type,
-		FileURL:   msg.FileUrl,
-		FileName:  msg.FileName,
-		FileSize:  msg.FileSize,
-		MimeType:  msg.MimeType,
+	redisMsg := newRedisMessage(msg)
+
+	// The room already saw a replayed send; only the retrying sender needs it
+	if replayed {
+		msgBytes, _ := json.Marshal(buildNewMessage(redisMsg))
+		c.safeSend(msgBytes)
+		return nil
 	}
 
 	msgBytes, _ := json.Marshal(redisMsg)
@@ -748,6 +920,41 @@ func (c *Client) sendMessage(wsMsg *WebSocketMessage) error {
 	return nil
 }
 
+// editMessage edits a message through the chat service.
+// The service publishes message_edited, so nothing is broadcast here.
+func (c *Client) editMessage(wsMsg *WebSocketMessage) error {
+	if wsMsg.MessageID <= 0 {
+		return fmt.Errorf("message_id required")
+	}
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, middleware.UserIDKey, c.ID)
+	ctx = context.WithValue(ctx, middleware.UsernameKey, c.Username)
+
+	_, err := c.Hub.chatService.EditMessage(ctx, &chatV1.EditMessageRequest{
+		MessageId: wsMsg.MessageID,
+		Content:   wsMsg.Content,
+	})
+	return err
+}
+
+// deleteMessage deletes a message through the chat service.
+// The service publishes message_deleted, so nothing is broadcast here.
+func (c *Client) deleteMessage(wsMsg *WebSocketMessage) error {
+	if wsMsg.MessageID <= 0 {
+		return fmt.Errorf("message_id required")
+	}
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, middleware.UserIDKey, c.ID)
+	ctx = context.WithValue(ctx, middleware.UsernameKey, c.Username)
+
+	_, err := c.Hub.chatService.DeleteMessage(ctx, &chatV1.DeleteMessageRequest{
+		MessageId: wsMsg.MessageID,
+	})
+	return err
+}
+
 // sendError sends an error message to the client
 func (c *Client) sendError(message string) {
 	errMsg := map[string]interface{}{
@@ -783,9 +990,21 @@ func (h *Hub) subscribeToRedis() {
 			continue
 		}
 
+		// Typing frames are folded into the hub's typing state, not relayed as is
+		if redisMsg.Event == typingStartEvent || redisMsg.Event == typingStopEvent {
+			h.handleTyping(&redisMsg)
+			continue
+		}
+
 		h.log.Infof("Received from Redis: channel=%s, room=%d, user=%s, content=%s",
 			msg.Channel, redisMsg.RoomID, redisMsg.Username, redisMsg.Content)
 
+		// Targeted events follow the user rather than the room
+		if len(redisMsg.UserIDs) > 0 {
+			h.sendToUsers(redisMsg.UserIDs, buildEventMessage(&redisMsg))
+			continue
+		}
+
 		// Broadcast to local WebSocket clients in this room
 		h.mu.RLock()
 		clients := h.rooms[redisMsg.RoomID]
@@ -797,23 +1016,11 @@ func (h *Hub) subscribeToRedis() {
 		}
 
 		// Build WebSocket message
-		msgData := map[string]interface{}{
-			"type":       "new_message",
-			"message_id": redisMsg.MessageID,
-			"room_id":    redisMsg.RoomID,
-			"user_id":    redisMsg.UserID,
-			"username":   redisMsg.Username,
-			"content":    redisMsg.Content,
-			"created_at": redisMsg.CreatedAt,
-		}
-
-		// Add file fields if present
-		if redisMsg.FileURL != "" {
-			msgData["message_type"] = redisMsg.Type
-			msgData["file_url"] = redisMsg.FileURL
-			msgData["file_name"] = redisMsg.FileName
-			msgData["file_size"] = redisMsg.FileSize
-			msgData["mime_type"] = redisMsg.MimeType
+		var msgData map[string]interface{}
+		if redisMsg.Event != "" {
+			msgData = buildEventMessage(&redisMsg)
+		} else {
+			msgData = buildNewMessage(&redisMsg)
 		}
 
 		msgBytes, _ := json.Marshal(msgData)
@@ -828,3 +1035,117 @@ func (h *Hub) subscribeToRedis() {
 		metrics.RecordBroadcastDuration(broadcastStart)
 	}
 }
+
+// sendToUsers delivers a payload to every local connection of the given users
+func (h *Hub) sendToUsers(userIDs []int64, msgData map[string]interface{}) {
+	targets := make(map[int64]bool, len(userIDs))
+	for _, userID := range userIDs {
+		targets[userID] = true
+	}
+
+	var recipients []*Client
+	h.mu.RLock()
+	for _, clients := range h.rooms {
+		for client := range clients {
+			if targets[client.ID] {
+				recipients = append(recipients, client)
+			}
+		}
+	}
+	h.mu.RUnlock()
+
+	if len(recipients) == 0 {
+		return
+	}
+
+	msgBytes, _ := json.Marshal(msgData)
+	for _, client := range recipients {
+		go client.safeSend(msgBytes)
+	}
+}
+
+// newRedisMessage builds the Redis payload of a new message
+func newRedisMessage(msg *chatV1.Message) *RedisMessage {
+	return &RedisMessage{
+		RoomID:    msg.RoomId,
+		MessageID: msg.Id,
+		UserID:    msg.UserId,
+		Username:  msg.Username,
+		Content:   msg.Content,
+		CreatedAt: msg.CreatedAt,
+		Type:      msg.Type,
+		FileURL:   msg.FileUrl,
+		FileName:  msg.FileName,
+		FileSize:  msg.FileSize,
+		MimeType:  msg.MimeType,
+		ExpiresAt: msg.ExpiresAt,
+
+		QuotedMessage: msg.QuotedMessage,
+		IsForwarded:   msg.IsForwarded,
+		Format:        msg.Format,
+		ContentHTML:   msg.ContentHtml,
+		Poll:          msg.Poll,
+		SystemEvent:   msg.SystemEvent,
+		ClientMsgID:   msg.ClientMsgId,
+	}
+}
+
+// buildNewMessage builds the new_message payload sent to WebSocket clients
+func buildNewMessage(redisMsg *RedisMessage) map[string]interface{} {
+	msgData := map[string]interface{}{
+		"type":       "new_message",
+		"message_id": redisMsg.MessageID,
+		"room_id":    redisMsg.RoomID,
+		"user_id":    redisMsg.UserID,
+		"username":   redisMsg.Username,
+		"content":    redisMsg.Content,
+		"created_at": redisMsg.CreatedAt,
+	}
+
+	// Add file fields if present
+	if redisMsg.FileURL != "" {
+		msgData["message_type"] = redisMsg.Type
+		msgData["file_url"] = redisMsg.FileURL
+		msgData["file_name"] = redisMsg.FileName
+		msgData["file_size"] = redisMsg.FileSize
+		msgData["mime_type"] = redisMsg.MimeType
+	}
+	if redisMsg.ExpiresAt != 0 {
+		msgData["expires_at"] = redisMsg.ExpiresAt
+	}
+	if redisMsg.QuotedMessage != nil {
+		msgData["quoted_message"] = redisMsg.QuotedMessage
+	}
+	if redisMsg.IsForwarded {
+		msgData["is_forwarded"] = true
+	}
+	if redisMsg.Format == "markdown" {
+		msgData["format"] = redisMsg.Format
+		msgData["content_html"] = redisMsg.ContentHTML
+	}
+	if redisMsg.Poll != nil {
+		msgData["message_type"] = redisMsg.Type
+		msgData["poll"] = redisMsg.Poll
+	}
+	if redisMsg.SystemEvent != nil {
+		msgData["message_type"] = redisMsg.Type
+		msgData["system_event"] = redisMsg.SystemEvent
+	}
+	if redisMsg.ClientMsgID != "" {
+		msgData["client_msg_id"] = redisMsg.ClientMsgID
+	}
+
+	return msgData
+}
+
+// buildEventMessage builds the payload for a room event (message_edited, ...)
+// by flattening its data next to the event type
+func buildEventMessage(redisMsg *RedisMessage) map[string]interface{} {
+	msgData := make(map[string]interface{})
+	if len(redisMsg.Data) > 0 {
+		_ = json.Unmarshal(redisMsg.Data, &msgData)
+	}
+	msgData["type"] = redisMsg.Event
+	msgData["room_id"] = redisMsg.RoomID
+	return msgData
+}
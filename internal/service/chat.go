@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 
@@ -10,6 +11,9 @@ import (
 	"github.com/yourusername/chat-app/internal/middleware"
 )
 
+// deletedMessagePlaceholder is rendered in place of a tombstone's content
+const deletedMessagePlaceholder = "message deleted"
+
 // ChatService implements the chat/messaging service
 type ChatService struct {
 	chatV1.UnimplementedChatServiceServer
@@ -26,9 +30,10 @@ func NewChatService(uc *biz.ChatUseCase, logger log.Logger) *ChatService {
 	}
 }
 
-// SendMessage sends a message to a room
+// SendMessage sends a message to a room.
+// WebSocket clients publish their own sends; API sends are published here,
+// so the room's WebSocket and SSE clients receive them.
 func (s *ChatService) SendMessage(ctx context.Context, req *chatV1.SendMessageRequest) (*chatV1.Message, error) {
-	// Get user ID from context (set by authentication middleware)
 	userID, err := s.getUserIDFromContext(ctx)
 	if err != nil {
 		return nil, err
@@ -39,15 +44,104 @@ func (s *ChatService) SendMessage(ctx context.Context, req *chatV1.SendMessageRe
 		return nil, err
 	}
 
-	return &chatV1.Message{
-		Id:        message.ID,
-		RoomId:    message.RoomID,
-		UserId:    message.UserID,
-		Username:  message.Username,
-		Content:   message.Content,
-		Type:      message.Type,
-		IsEdited:  message.IsEdited,
-		CreatedAt: message.CreatedAt.Unix(),
+	// Thread replies are published as thread_reply, retries were published already
+	if message.ParentMessageID == 0 && !message.Replayed {
+		s.uc.PublishMessage(ctx, message)
+	}
+
+	return toProtoMessage(message), nil
+}
+
+// SendMessageReplayable sends a message and also reports whether it was a
+// retry that returned the earlier send with the same client_msg_id, which
+// must not be broadcast again
+func (s *ChatService) SendMessageReplayable(ctx context.Context, req *chatV1.SendMessageRequest) (*chatV1.Message, bool, error) {
+	// Get user ID from context (set by authentication middleware)
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	message, err := s.uc.SendMessage(ctx, userID, req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return toProtoMessage(message), message.Replayed, nil
+}
+
+// ForwardMessage forwards a message into another room
+func (s *ChatService) ForwardMessage(ctx context.Context, req *chatV1.ForwardMessageRequest) (*chatV1.Message, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.uc.ForwardMessage(ctx, userID, req.MessageId, req.RoomId, req.Comment)
+	if err != nil {
+		s.log.Errorf("Failed to forward message %d to room %d: %v", req.MessageId, req.RoomId, err)
+		return nil, err
+	}
+
+	return toProtoMessage(message), nil
+}
+
+// ScheduleMessage schedules a message to be sent later
+func (s *ChatService) ScheduleMessage(ctx context.Context, req *chatV1.ScheduleMessageRequest) (*chatV1.ScheduledMessage, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Message == nil {
+		return nil, biz.ErrInvalidMessage
+	}
+
+	scheduled, err := s.uc.ScheduleMessage(ctx, userID, req.Message, time.Unix(req.SendAt, 0))
+	if err != nil {
+		s.log.Errorf("Failed to schedule message: %v", err)
+		return nil, err
+	}
+
+	return toProtoScheduledMessage(scheduled), nil
+}
+
+// ListScheduledMessages lists the caller's pending scheduled messages
+func (s *ChatService) ListScheduledMessages(ctx context.Context, req *chatV1.ListScheduledMessagesRequest) (*chatV1.ListScheduledMessagesResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled, err := s.uc.ListScheduledMessages(ctx, userID, req.RoomId)
+	if err != nil {
+		s.log.Errorf("Failed to list scheduled messages for user %d: %v", userID, err)
+		return nil, err
+	}
+
+	protoScheduled := make([]*chatV1.ScheduledMessage, 0, len(scheduled))
+	for _, message := range scheduled {
+		protoScheduled = append(protoScheduled, toProtoScheduledMessage(message))
+	}
+
+	return &chatV1.ListScheduledMessagesResponse{
+		ScheduledMessages: protoScheduled,
+	}, nil
+}
+
+// CancelScheduledMessage cancels a pending scheduled message
+func (s *ChatService) CancelScheduledMessage(ctx context.Context, req *chatV1.CancelScheduledMessageRequest) (*chatV1.CancelScheduledMessageResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.uc.CancelScheduledMessage(ctx, userID, req.Id); err != nil {
+		s.log.Errorf("Failed to cancel scheduled message %d: %v", req.Id, err)
+		return nil, err
+	}
+
+	return &chatV1.CancelScheduledMessageResponse{
+		Success: true,
 	}, nil
 }
 
@@ -61,52 +155,348 @@ func (s *ChatService) GetMessages(ctx context.Context, req *chatV1.GetMessagesRe
 
 	// Set default limit if not provided
 	limit := req.Limit
-	if limit == 0 {
+	if limit <= 0 {
 		limit = 50 // Default to 50 messages
 	}
 	if limit > 100 {
 		limit = 100 // Max 100 messages per request
 	}
 
-	// Calculate offset based on before_id
-	// For now, we'll use simple offset-based pagination
-	// TODO: Implement cursor-based pagination with before_id
-	offset := int32(0)
-
 	// Get messages from business logic layer
-	messages, total, err := s.uc.ListMessages(ctx, userID, req.RoomId, limit, offset)
+	page, err := s.uc.ListMessages(ctx, userID, req.RoomId, limit, biz.MessageCursor{
+		BeforeID: req.BeforeId,
+		AfterID:  req.AfterId,
+		AroundID: req.AroundId,
+	})
 	if err != nil {
 		s.log.Errorf("Failed to get messages for room %d: %v", req.RoomId, err)
 		return nil, err
 	}
 
 	// Convert biz messages to proto messages
-	protoMessages := make([]*chatV1.Message, len(messages))
-	for i, msg := range messages {
-		protoMessages[i] = &chatV1.Message{
-			Id:        msg.ID,
-			RoomId:    msg.RoomID,
-			UserId:    msg.UserID,
-			Username:  msg.Username,
-			Content:   msg.Content,
-			Type:      msg.Type,
-			IsEdited:  msg.IsEdited,
-			CreatedAt: msg.CreatedAt.Unix(),
-		}
-		if msg.EditedAt != nil {
-			protoMessages[i].EditedAt = msg.EditedAt.Unix()
-		}
+	protoMessages := make([]*chatV1.Message, len(page.Messages))
+	for i, msg := range page.Messages {
+		protoMessages[i] = toProtoMessage(msg)
 	}
 
-	// Determine if there are more messages
-	hasMore := total > limit
-
 	return &chatV1.GetMessagesResponse{
 		Messages: protoMessages,
+		HasMore:  page.HasMore,
+		HasNewer: page.HasNewer,
+	}, nil
+}
+
+// SearchMessages searches messages in the caller's rooms
+func (s *ChatService) SearchMessages(ctx context.Context, req *chatV1.SearchMessagesRequest) (*chatV1.SearchMessagesResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default limit if not provided
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	filter := &biz.SearchFilter{
+		Query:    req.Query,
+		RoomID:   req.RoomId,
+		AuthorID: req.UserId,
+		Type:     req.Type,
+		FileName: req.FileName,
+		BeforeID: req.BeforeId,
+	}
+	if req.From > 0 {
+		from := time.Unix(req.From, 0)
+		filter.From = &from
+	}
+	if req.To > 0 {
+		to := time.Unix(req.To, 0)
+		filter.To = &to
+	}
+
+	results, hasMore, err := s.uc.SearchMessages(ctx, userID, filter, limit)
+	if err != nil {
+		s.log.Errorf("Failed to search messages: %v", err)
+		return nil, err
+	}
+
+	protoResults := make([]*chatV1.SearchResult, 0, len(results))
+	for _, result := range results {
+		protoResults = append(protoResults, &chatV1.SearchResult{
+			Message: toProtoMessage(result.Message),
+			Snippet: result.Snippet,
+		})
+	}
+
+	return &chatV1.SearchMessagesResponse{
+		Results: protoResults,
+		HasMore: hasMore,
+	}, nil
+}
+
+// GetThread retrieves a thread root message and its replies
+func (s *ChatService) GetThread(ctx context.Context, req *chatV1.GetThreadRequest) (*chatV1.GetThreadResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default limit if not provided
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	root, replies, hasMore, err := s.uc.GetThread(ctx, userID, req.MessageId, limit, req.AfterId)
+	if err != nil {
+		s.log.Errorf("Failed to get thread %d: %v", req.MessageId, err)
+		return nil, err
+	}
+
+	protoReplies := make([]*chatV1.Message, 0, len(replies))
+	for _, reply := range replies {
+		protoReplies = append(protoReplies, toProtoMessage(reply))
+	}
+
+	return &chatV1.GetThreadResponse{
+		Root:    toProtoMessage(root),
+		Replies: protoReplies,
+		HasMore: hasMore,
+	}, nil
+}
+
+// StreamMessages sends a room's messages to a gRPC client as they arrive,
+// after replaying those newer than after_message_id. Send blocks while the
+// client's flow-control window is full.
+func (s *ChatService) StreamMessages(req *chatV1.StreamMessagesRequest, stream chatV1.ChatService_StreamMessagesServer) error {
+	ctx := stream.Context()
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.uc.StreamMessages(ctx, userID, req.RoomId, req.AfterMessageId, func(message *biz.Message) error {
+		return stream.Send(toProtoMessage(message))
+	})
+	if ctx.Err() != nil {
+		// The client cancelled or went away
+		return nil
+	}
+	if err != nil {
+		s.log.Errorf("Stream of room %d to user %d ended: %v", req.RoomId, userID, err)
+	}
+	return err
+}
+
+// EditMessage edits the content of the caller's own message
+func (s *ChatService) EditMessage(ctx context.Context, req *chatV1.EditMessageRequest) (*chatV1.Message, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.uc.EditMessage(ctx, userID, req.MessageId, req.Content)
+	if err != nil {
+		s.log.Errorf("Failed to edit message %d: %v", req.MessageId, err)
+		return nil, err
+	}
+
+	return toProtoMessage(message), nil
+}
+
+// DeleteMessage replaces a message with a tombstone
+func (s *ChatService) DeleteMessage(ctx context.Context, req *chatV1.DeleteMessageRequest) (*chatV1.DeleteMessageResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.uc.DeleteMessage(ctx, userID, req.MessageId); err != nil {
+		s.log.Errorf("Failed to delete message %d: %v", req.MessageId, err)
+		return nil, err
+	}
+
+	return &chatV1.DeleteMessageResponse{
+		Success: true,
+	}, nil
+}
+
+// AddReaction adds the caller's emoji reaction to a message
+func (s *ChatService) AddReaction(ctx context.Context, req *chatV1.AddReactionRequest) (*chatV1.AddReactionResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reactions, err := s.uc.AddReaction(ctx, userID, req.MessageId, req.Emoji)
+	if err != nil {
+		s.log.Errorf("Failed to add reaction to message %d: %v", req.MessageId, err)
+		return nil, err
+	}
+
+	return &chatV1.AddReactionResponse{
+		Reactions: toProtoReactions(reactions),
+	}, nil
+}
+
+// RemoveReaction removes the caller's emoji reaction from a message
+func (s *ChatService) RemoveReaction(ctx context.Context, req *chatV1.RemoveReactionRequest) (*chatV1.RemoveReactionResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reactions, err := s.uc.RemoveReaction(ctx, userID, req.MessageId, req.Emoji)
+	if err != nil {
+		s.log.Errorf("Failed to remove reaction from message %d: %v", req.MessageId, err)
+		return nil, err
+	}
+
+	return &chatV1.RemoveReactionResponse{
+		Reactions: toProtoReactions(reactions),
+	}, nil
+}
+
+// ListMentions lists the caller's unread mentions across all rooms
+func (s *ChatService) ListMentions(ctx context.Context, req *chatV1.ListMentionsRequest) (*chatV1.ListMentionsResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default limit if not provided
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	mentions, hasMore, err := s.uc.ListMentions(ctx, userID, limit, req.BeforeId)
+	if err != nil {
+		s.log.Errorf("Failed to list mentions for user %d: %v", userID, err)
+		return nil, err
+	}
+
+	protoMentions := make([]*chatV1.Mention, 0, len(mentions))
+	for _, mention := range mentions {
+		protoMentions = append(protoMentions, &chatV1.Mention{
+			Id:          mention.ID,
+			Message:     toProtoMessage(mention.Message),
+			MentionType: mention.Type,
+			CreatedAt:   mention.CreatedAt.Unix(),
+		})
+	}
+
+	return &chatV1.ListMentionsResponse{
+		Mentions: protoMentions,
 		HasMore:  hasMore,
 	}, nil
 }
 
+// VotePoll replaces the caller's votes in a poll
+func (s *ChatService) VotePoll(ctx context.Context, req *chatV1.VotePollRequest) (*chatV1.VotePollResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	poll, err := s.uc.VotePoll(ctx, userID, req.MessageId, req.OptionIds)
+	if err != nil {
+		s.log.Errorf("Failed to vote in poll %d: %v", req.MessageId, err)
+		return nil, err
+	}
+
+	return &chatV1.VotePollResponse{
+		Poll: toProtoPoll(poll),
+	}, nil
+}
+
+// ClosePoll closes a poll to further votes
+func (s *ChatService) ClosePoll(ctx context.Context, req *chatV1.ClosePollRequest) (*chatV1.ClosePollResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	poll, err := s.uc.ClosePoll(ctx, userID, req.MessageId)
+	if err != nil {
+		s.log.Errorf("Failed to close poll %d: %v", req.MessageId, err)
+		return nil, err
+	}
+
+	return &chatV1.ClosePollResponse{
+		Poll: toProtoPoll(poll),
+	}, nil
+}
+
+// PinMessage pins a message to its room
+func (s *ChatService) PinMessage(ctx context.Context, req *chatV1.PinMessageRequest) (*chatV1.PinMessageResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pin, err := s.uc.PinMessage(ctx, userID, req.MessageId)
+	if err != nil {
+		s.log.Errorf("Failed to pin message %d: %v", req.MessageId, err)
+		return nil, err
+	}
+
+	return &chatV1.PinMessageResponse{
+		Pin: toProtoPin(pin),
+	}, nil
+}
+
+// UnpinMessage removes a message's pin
+func (s *ChatService) UnpinMessage(ctx context.Context, req *chatV1.UnpinMessageRequest) (*chatV1.UnpinMessageResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.uc.UnpinMessage(ctx, userID, req.MessageId); err != nil {
+		s.log.Errorf("Failed to unpin message %d: %v", req.MessageId, err)
+		return nil, err
+	}
+
+	return &chatV1.UnpinMessageResponse{
+		Success: true,
+	}, nil
+}
+
+// ListPinnedMessages lists the messages pinned in a room
+func (s *ChatService) ListPinnedMessages(ctx context.Context, req *chatV1.ListPinnedMessagesRequest) (*chatV1.ListPinnedMessagesResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pins, err := s.uc.ListPinnedMessages(ctx, userID, req.RoomId)
+	if err != nil {
+		s.log.Errorf("Failed to list pins for room %d: %v", req.RoomId, err)
+		return nil, err
+	}
+
+	protoPins := make([]*chatV1.Pin, 0, len(pins))
+	for _, pin := range pins {
+		protoPins = append(protoPins, toProtoPin(pin))
+	}
+
+	return &chatV1.ListPinnedMessagesResponse{
+		Pins: protoPins,
+	}, nil
+}
+
 // MarkAsRead marks a message as read
 func (s *ChatService) MarkAsRead(ctx context.Context, req *chatV1.MarkAsReadRequest) (*chatV1.MarkAsReadResponse, error) {
 	// Get user ID from context
@@ -126,6 +516,134 @@ func (s *ChatService) MarkAsRead(ctx context.Context, req *chatV1.MarkAsReadRequ
 	}, nil
 }
 
+// MarkRoomRead moves the caller's read cursor in a room forward
+func (s *ChatService) MarkRoomRead(ctx context.Context, req *chatV1.MarkRoomReadRequest) (*chatV1.MarkRoomReadResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lastRead, unread, err := s.uc.MarkRoomRead(ctx, userID, req.RoomId, req.UpToMessageId)
+	if err != nil {
+		s.log.Errorf("Failed to mark room %d read: %v", req.RoomId, err)
+		return nil, err
+	}
+
+	return &chatV1.MarkRoomReadResponse{
+		LastReadMessageId: lastRead,
+		UnreadCount:       unread,
+	}, nil
+}
+
+// ListReadCursors lists every member's read cursor in a room
+func (s *ChatService) ListReadCursors(ctx context.Context, req *chatV1.ListReadCursorsRequest) (*chatV1.ListReadCursorsResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cursors, err := s.uc.ListReadCursors(ctx, userID, req.RoomId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chatV1.ListReadCursorsResponse{
+		Cursors: toProtoReadCursors(cursors),
+	}, nil
+}
+
+// GetMessageReaders lists the members who have seen a message
+func (s *ChatService) GetMessageReaders(ctx context.Context, req *chatV1.GetMessageReadersRequest) (*chatV1.GetMessageReadersResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	readers, err := s.uc.GetMessageReaders(ctx, userID, req.MessageId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chatV1.GetMessageReadersResponse{
+		Readers: toProtoReadCursors(readers),
+	}, nil
+}
+
+// GetUnreadMessages lists the messages past the caller's read cursor in a room, oldest first
+func (s *ChatService) GetUnreadMessages(ctx context.Context, req *chatV1.GetUnreadMessagesRequest) (*chatV1.GetUnreadMessagesResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	messages, hasMore, err := s.uc.GetUnreadMessages(ctx, userID, req.RoomId, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	protoMessages := make([]*chatV1.Message, len(messages))
+	for i, msg := range messages {
+		protoMessages[i] = toProtoMessage(msg)
+	}
+
+	return &chatV1.GetUnreadMessagesResponse{
+		Messages: protoMessages,
+		HasMore:  hasMore,
+	}, nil
+}
+
+// GetUnreadSummary reports the caller's unread and mention counts in each of their rooms
+func (s *ChatService) GetUnreadSummary(ctx context.Context, req *chatV1.GetUnreadSummaryRequest) (*chatV1.GetUnreadSummaryResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rooms, err := s.uc.GetUnreadSummary(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	protoRooms := make([]*chatV1.RoomUnread, len(rooms))
+	for i, room := range rooms {
+		protoRooms[i] = &chatV1.RoomUnread{
+			RoomId:               room.RoomID,
+			UnreadCount:          room.UnreadCount,
+			MentionCount:         room.MentionCount,
+			FirstUnreadMessageId: room.FirstUnreadMessageID,
+		}
+	}
+
+	return &chatV1.GetUnreadSummaryResponse{
+		Rooms: protoRooms,
+	}, nil
+}
+
+// ExportMessages streams a room's full history to fn, oldest first, in batches.
+// It backs the HTTP export endpoint rather than an RPC.
+func (s *ChatService) ExportMessages(ctx context.Context, roomID int64, fn func([]*chatV1.Message) error) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.uc.ExportMessages(ctx, userID, roomID, func(messages []*biz.Message) error {
+		protoMessages := make([]*chatV1.Message, len(messages))
+		for i, msg := range messages {
+			protoMessages[i] = toProtoMessage(msg)
+		}
+		return fn(protoMessages)
+	})
+}
+
 // getUserIDFromContext extracts user ID from request context
 // This would be set by an authentication middleware
 func (s *ChatService) getUserIDFromContext(ctx context.Context) (int64, error) {
@@ -140,3 +658,189 @@ func (s *ChatService) getUserIDFromContext(ctx context.Context) (int64, error) {
 
 	return userID, nil
 }
+
+// toProtoMessage converts a biz message to its API representation
+func toProtoMessage(message *biz.Message) *chatV1.Message {
+	protoMessage := &chatV1.Message{
+		Id:        message.ID,
+		RoomId:    message.RoomID,
+		UserId:    message.UserID,
+		Username:  message.Username,
+		Content:   message.Content,
+		Type:      message.Type,
+		IsEdited:  message.IsEdited,
+		CreatedAt: message.CreatedAt.Unix(),
+		FileUrl:   message.FileURL,
+		FileName:  message.FileName,
+		FileSize:  message.FileSize,
+		MimeType:  message.MimeType,
+
+		ParentMessageId: message.ParentMessageID,
+		ReplyCount:      message.ReplyCount,
+		Reactions:       toProtoReactions(message.Reactions),
+		QuotedMessage:   toProtoQuotedMessage(message.QuotedMessage),
+		IsForwarded:     message.IsForwarded,
+		LinkPreviews:    toProtoLinkPreviews(message.LinkPreviews),
+		Format:          message.Format,
+		ContentHtml:     message.ContentHTML,
+		Poll:            toProtoPoll(message.Poll),
+		SystemEvent:     toProtoSystemEvent(message.SystemEvent),
+		ClientMsgId:     message.ClientMsgID,
+	}
+	if message.EditedAt != nil {
+		protoMessage.EditedAt = message.EditedAt.Unix()
+	}
+	if message.LastReplyAt != nil {
+		protoMessage.LastReplyAt = message.LastReplyAt.Unix()
+	}
+	if message.ExpiresAt != nil {
+		protoMessage.ExpiresAt = message.ExpiresAt.Unix()
+	}
+	if message.IsDeleted {
+		protoMessage.Content = deletedMessagePlaceholder
+		protoMessage.ContentHtml = ""
+		protoMessage.Poll = nil
+		protoMessage.IsDeleted = true
+		if message.DeletedAt != nil {
+			protoMessage.DeletedAt = message.DeletedAt.Unix()
+		}
+	}
+	return protoMessage
+}
+
+// toProtoQuotedMessage converts a quote snapshot to its API representation
+func toProtoQuotedMessage(quoted *biz.QuotedMessage) *chatV1.QuotedMessage {
+	if quoted == nil {
+		return nil
+	}
+	return &chatV1.QuotedMessage{
+		MessageId: quoted.MessageID,
+		RoomId:    quoted.RoomID,
+		UserId:    quoted.UserID,
+		Username:  quoted.Username,
+		Content:   quoted.Content,
+		Type:      quoted.Type,
+		FileUrl:   quoted.FileURL,
+		FileName:  quoted.FileName,
+		FileSize:  quoted.FileSize,
+		MimeType:  quoted.MimeType,
+		CreatedAt: quoted.CreatedAt.Unix(),
+	}
+}
+
+// toProtoPoll converts a poll and its tally to its API representation
+func toProtoPoll(poll *biz.Poll) *chatV1.Poll {
+	if poll == nil {
+		return nil
+	}
+	protoPoll := &chatV1.Poll{
+		Question:       poll.Question,
+		MultipleChoice: poll.MultipleChoice,
+		Anonymous:      poll.Anonymous,
+		IsClosed:       poll.IsClosed(time.Now()),
+		VoterCount:     poll.VoterCount,
+	}
+	for _, option := range poll.Options {
+		protoPoll.Options = append(protoPoll.Options, &chatV1.PollOption{
+			Id:        option.ID,
+			Text:      option.Text,
+			VoteCount: option.VoteCount,
+			VotedByMe: option.VotedByMe,
+			VoterIds:  option.VoterIDs,
+		})
+	}
+	if poll.ClosesAt != nil {
+		protoPoll.ClosesAt = poll.ClosesAt.Unix()
+	}
+	if poll.ClosedAt != nil {
+		protoPoll.ClosedAt = poll.ClosedAt.Unix()
+	}
+	return protoPoll
+}
+
+// toProtoReadCursors converts read cursors to their API representation
+func toProtoReadCursors(cursors []*biz.ReadCursor) []*chatV1.ReadCursor {
+	protoCursors := make([]*chatV1.ReadCursor, 0, len(cursors))
+	for _, cursor := range cursors {
+		protoCursor := &chatV1.ReadCursor{
+			UserId:            cursor.UserID,
+			Username:          cursor.Username,
+			LastReadMessageId: cursor.LastReadMessageID,
+		}
+		if cursor.ReadAt != nil {
+			protoCursor.ReadAt = cursor.ReadAt.Unix()
+		}
+		protoCursors = append(protoCursors, protoCursor)
+	}
+	return protoCursors
+}
+
+// toProtoSystemEvent converts a system message's event to its API representation
+func toProtoSystemEvent(event *biz.SystemEvent) *chatV1.SystemEvent {
+	if event == nil {
+		return nil
+	}
+	return &chatV1.SystemEvent{
+		Kind:     event.Kind,
+		ActorId:  event.ActorID,
+		TargetId: event.TargetID,
+		Details:  event.Details,
+	}
+}
+
+// toProtoLinkPreviews converts link previews to their API representation
+func toProtoLinkPreviews(previews []*biz.LinkPreview) []*chatV1.LinkPreview {
+	if len(previews) == 0 {
+		return nil
+	}
+	protoPreviews := make([]*chatV1.LinkPreview, 0, len(previews))
+	for _, preview := range previews {
+		protoPreviews = append(protoPreviews, &chatV1.LinkPreview{
+			Url:         preview.URL,
+			Title:       preview.Title,
+			Description: preview.Description,
+			ImageUrl:    preview.ImageURL,
+			SiteName:    preview.SiteName,
+		})
+	}
+	return protoPreviews
+}
+
+// toProtoReactions converts aggregated biz reactions to their API representation
+func toProtoReactions(reactions []*biz.Reaction) []*chatV1.Reaction {
+	if len(reactions) == 0 {
+		return nil
+	}
+	protoReactions := make([]*chatV1.Reaction, 0, len(reactions))
+	for _, reaction := range reactions {
+		protoReactions = append(protoReactions, &chatV1.Reaction{
+			Emoji:       reaction.Emoji,
+			Count:       reaction.Count,
+			ReactedByMe: reaction.ReactedByMe,
+		})
+	}
+	return protoReactions
+}
+
+// toProtoPin converts a biz pin to its API representation
+func toProtoPin(pin *biz.Pin) *chatV1.Pin {
+	return &chatV1.Pin{
+		Message:          toProtoMessage(pin.Message),
+		PinnedBy:         pin.PinnedBy,
+		PinnedByUsername: pin.PinnedByUsername,
+		PinnedAt:         pin.PinnedAt.Unix(),
+	}
+}
+
+// toProtoScheduledMessage converts a biz scheduled message to its API representation
+func toProtoScheduledMessage(scheduled *biz.ScheduledMessage) *chatV1.ScheduledMessage {
+	return &chatV1.ScheduledMessage{
+		Id:        scheduled.ID,
+		UserId:    scheduled.UserID,
+		Message:   scheduled.Request,
+		SendAt:    scheduled.SendAt.Unix(),
+		Status:    scheduled.Status,
+		MessageId: scheduled.MessageID,
+		CreatedAt: scheduled.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"os"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/biz"
+	"github.com/yourusername/chat-app/internal/importer"
+	"github.com/yourusername/chat-app/internal/middleware"
+)
+
+// ImportService implements the history import service
+type ImportService struct {
+	chatV1.UnimplementedImportServiceServer
+
+	uc  *biz.ImportUseCase
+	log *log.Helper
+}
+
+// NewImportService creates a new import service
+func NewImportService(uc *biz.ImportUseCase, logger log.Logger) *ImportService {
+	return &ImportService{
+		uc:  uc,
+		log: log.NewHelper(log.With(logger, "module", "service/import")),
+	}
+}
+
+// ImportHistory downloads an export and imports it in the background
+func (s *ImportService) ImportHistory(ctx context.Context, req *chatV1.ImportHistoryRequest) (*chatV1.ImportJob, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Format != importer.FormatSlack && req.Format != importer.FormatJSONL {
+		return nil, importer.ErrUnknownFormat
+	}
+	if req.FileUrl == "" {
+		return nil, biz.ErrInvalidImport
+	}
+
+	job, err := s.uc.StartImport(ctx, userID, req.Format, func(ctx context.Context) (biz.ImportSource, func(), error) {
+		file, err := importer.Download(ctx, req.FileUrl)
+		if err != nil {
+			return nil, nil, err
+		}
+		release := func() {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}
+
+		src, err := importer.Open(req.Format, file, req.SlackToken)
+		if err != nil {
+			release()
+			return nil, nil, err
+		}
+		return src, release, nil
+	})
+	if err != nil {
+		s.log.Errorf("Failed to start import for user %d: %v", userID, err)
+		return nil, err
+	}
+
+	return toProtoImportJob(job), nil
+}
+
+// GetImportJob returns the state of an import started by the caller
+func (s *ImportService) GetImportJob(ctx context.Context, req *chatV1.GetImportJobRequest) (*chatV1.ImportJob, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.uc.GetImportJob(ctx, userID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoImportJob(job), nil
+}
+
+// getUserIDFromContext extracts user ID from request context
+func (s *ImportService) getUserIDFromContext(ctx context.Context) (int64, error) {
+	userID, ok := ctx.Value(middleware.UserIDKey).(int64)
+	if !ok {
+		return 0, biz.ErrUserNotFound
+	}
+
+	if userID <= 0 {
+		return 0, biz.ErrUserNotFound
+	}
+
+	return userID, nil
+}
+
+// toProtoImportJob converts an import job to its API representation
+func toProtoImportJob(job *biz.ImportJob) *chatV1.ImportJob {
+	protoJob := &chatV1.ImportJob{
+		Id:        job.ID,
+		Format:    job.Format,
+		Status:    job.Status,
+		Error:     job.Error,
+		StartedAt: job.StartedAt.Unix(),
+		Report: &chatV1.ImportReport{
+			Rooms:            int32(job.Report.Rooms),
+			UsersMatched:     int32(job.Report.UsersMatched),
+			UsersCreated:     int32(job.Report.UsersCreated),
+			MessagesImported: int32(job.Report.MessagesImported),
+			MessagesSkipped:  int32(job.Report.MessagesSkipped),
+			FilesUploaded:    int32(job.Report.FilesUploaded),
+			FilesFailed:      int32(job.Report.FilesFailed),
+		},
+	}
+	if !job.FinishedAt.IsZero() {
+		protoJob.FinishedAt = job.FinishedAt.Unix()
+	}
+	return protoJob
+}
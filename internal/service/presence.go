@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	chatV1 "github.com/yourusername/chat-app/api/chat/v1"
+	"github.com/yourusername/chat-app/internal/biz"
+	"github.com/yourusername/chat-app/internal/middleware"
+)
+
+// PresenceService implements the presence service
+type PresenceService struct {
+	chatV1.UnimplementedPresenceServiceServer
+
+	uc  *biz.PresenceUseCase
+	log *log.Helper
+}
+
+// NewPresenceService creates a new presence service
+func NewPresenceService(uc *biz.PresenceUseCase, logger log.Logger) *PresenceService {
+	return &PresenceService{
+		uc:  uc,
+		log: log.NewHelper(log.With(logger, "module", "service/presence")),
+	}
+}
+
+// GetPresence returns the live status of a batch of users
+func (s *PresenceService) GetPresence(ctx context.Context, req *chatV1.GetPresenceRequest) (*chatV1.GetPresenceResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	presences, err := s.uc.GetPresence(ctx, userID, req.UserIds)
+	if err != nil {
+		return nil, err
+	}
+
+	protoPresences := make([]*chatV1.UserPresence, len(presences))
+	for i, presence := range presences {
+		protoPresences[i] = &chatV1.UserPresence{
+			UserId:      presence.UserID,
+			Status:      presence.Status,
+			LastSeen:    presence.LastSeen.Unix(),
+			Connections: int32(presence.Connections),
+		}
+	}
+
+	return &chatV1.GetPresenceResponse{
+		Presences: protoPresences,
+	}, nil
+}
+
+// getUserIDFromContext extracts user ID from request context
+func (s *PresenceService) getUserIDFromContext(ctx context.Context) (int64, error) {
+	userID, ok := ctx.Value(middleware.UserIDKey).(int64)
+	if !ok {
+		return 0, biz.ErrUserNotFound
+	}
+
+	if userID <= 0 {
+		return 0, biz.ErrUserNotFound
+	}
+
+	return userID, nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 
@@ -45,6 +46,7 @@ func (s *RoomService) CreateRoom(ctx context.Context, req *chatV1.CreateRoomRequ
 		Description: room.Description,
 		Type:        room.Type,
 		CreatedBy:   room.CreatedBy,
+		MessageTtl:  int32(room.MessageTTL / time.Second),
 		CreatedAt:   room.CreatedAt.Unix(),
 	}, nil
 }
@@ -68,6 +70,8 @@ func (s *RoomService) GetRoom(ctx context.Context, req *chatV1.GetRoomRequest) (
 		Description: room.Description,
 		Type:        room.Type,
 		CreatedBy:   room.CreatedBy,
+		MessageTtl:  int32(room.MessageTTL / time.Second),
+		Retention:   toProtoRetentionPolicy(room.Retention),
 		CreatedAt:   room.CreatedAt.Unix(),
 	}, nil
 }
@@ -177,6 +181,103 @@ func (s *RoomService) LeaveRoom(ctx context.Context, req *chatV1.LeaveRoomReques
 	}, nil
 }
 
+// SetMessageTTL sets the room's default message lifetime
+func (s *RoomService) SetMessageTTL(ctx context.Context, req *chatV1.SetMessageTTLRequest) (*chatV1.Room, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.uc.SetMessageTTL(ctx, userID, req.RoomId, time.Duration(req.MessageTtl)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chatV1.Room{
+		Id:          room.ID,
+		Name:        room.Name,
+		Description: room.Description,
+		Type:        room.Type,
+		CreatedBy:   room.CreatedBy,
+		MessageTtl:  int32(room.MessageTTL / time.Second),
+		Retention:   toProtoRetentionPolicy(room.Retention),
+		CreatedAt:   room.CreatedAt.Unix(),
+	}, nil
+}
+
+// SetRetentionPolicy sets how long a room keeps its messages
+func (s *RoomService) SetRetentionPolicy(ctx context.Context, req *chatV1.SetRetentionPolicyRequest) (*chatV1.Room, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy biz.RetentionPolicy
+	if req.Retention != nil {
+		policy = biz.RetentionPolicy{Mode: req.Retention.Mode, Value: req.Retention.Value}
+	}
+
+	room, err := s.uc.SetRetentionPolicy(ctx, userID, req.RoomId, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chatV1.Room{
+		Id:          room.ID,
+		Name:        room.Name,
+		Description: room.Description,
+		Type:        room.Type,
+		CreatedBy:   room.CreatedBy,
+		MessageTtl:  int32(room.MessageTTL / time.Second),
+		Retention:   toProtoRetentionPolicy(room.Retention),
+		CreatedAt:   room.CreatedAt.Unix(),
+	}, nil
+}
+
+// RenameRoom renames a room
+func (s *RoomService) RenameRoom(ctx context.Context, req *chatV1.RenameRoomRequest) (*chatV1.Room, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.uc.RenameRoom(ctx, userID, req.RoomId, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chatV1.Room{
+		Id:          room.ID,
+		Name:        room.Name,
+		Description: room.Description,
+		Type:        room.Type,
+		CreatedBy:   room.CreatedBy,
+		MessageTtl:  int32(room.MessageTTL / time.Second),
+		Retention:   toProtoRetentionPolicy(room.Retention),
+		CreatedAt:   room.CreatedAt.Unix(),
+	}, nil
+}
+
+// SetMemberRole changes a member's role in a room
+func (s *RoomService) SetMemberRole(ctx context.Context, req *chatV1.SetMemberRoleRequest) (*chatV1.RoomMember, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.uc.SetMemberRole(ctx, userID, req.RoomId, req.UserId, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chatV1.RoomMember{
+		UserId:   member.UserID,
+		Username: member.Username,
+		Role:     member.Role,
+		JoinedAt: member.JoinedAt.Unix(),
+	}, nil
+}
+
 // getUserIDFromContext extracts user ID from request context
 // This would be set by an authentication middleware
 func (s *RoomService) getUserIDFromContext(ctx context.Context) (int64, error) {
@@ -191,3 +292,14 @@ func (s *RoomService) getUserIDFromContext(ctx context.Context) (int64, error) {
 
 	return userID, nil
 }
+
+// toProtoRetentionPolicy converts a room's retention policy, leaving it out if unknown
+func toProtoRetentionPolicy(policy biz.RetentionPolicy) *chatV1.RetentionPolicy {
+	if policy.Mode == "" {
+		return nil
+	}
+	return &chatV1.RetentionPolicy{
+		Mode:  policy.Mode,
+		Value: policy.Value,
+	}
+}
@@ -142,6 +142,21 @@ func (s *MinioStorage) DeleteFile(ctx context.Context, fileURL string) error {
 	return nil
 }
 
+// GetFile opens a stored file for reading. The caller must close it.
+func (s *MinioStorage) GetFile(ctx context.Context, fileURL string) (io.ReadCloser, error) {
+	objectName := extractObjectName(fileURL, s.publicURL, s.bucketName)
+	if objectName == "" {
+		return nil, fmt.Errorf("invalid file URL")
+	}
+
+	object, err := s.client.GetObject(ctx, s.bucketName, objectName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	return object, nil
+}
+
 // IsImage checks if the mime type is an image
 func IsImage(mimeType string) bool {
 	return strings.HasPrefix(mimeType, "image/")
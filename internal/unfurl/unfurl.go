@@ -0,0 +1,311 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	ErrBlockedAddress = errors.New("unfurl: address is not publicly routable")
+	ErrUnsupportedURL = errors.New("unfurl: only http and https URLs are supported")
+	ErrNotHTML        = errors.New("unfurl: response is not an HTML page")
+	ErrNoPreview      = errors.New("unfurl: page has no preview metadata")
+)
+
+const (
+	defaultTimeout   = 5 * time.Second
+	defaultMaxBytes  = 512 * 1024
+	defaultUserAgent = "ChatAppBot/1.0 (+link preview)"
+	maxRedirects     = 3
+
+	maxTitleLength       = 300
+	maxDescriptionLength = 1000
+)
+
+// blockedNetworks are non-public ranges not covered by the net.IP helpers
+var blockedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",     // "this" network
+	"100.64.0.0/10", // carrier-grade NAT
+	"192.0.0.0/24",  // IETF protocol assignments
+	"198.18.0.0/15", // benchmarking
+	"240.0.0.0/4",   // reserved
+	"64:ff9b::/96",  // NAT64, can embed private IPv4 addresses
+	"2001:db8::/32", // documentation
+)
+
+// Preview is the link metadata extracted from a page
+type Preview struct {
+	URL         string `json:"url"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+	SiteName    string `json:"site_name,omitempty"`
+}
+
+// Config contains fetcher limits. Zero values use the defaults.
+type Config struct {
+	Timeout   time.Duration // whole request, including redirects
+	MaxBytes  int64         // most of the body read when looking for metadata
+	UserAgent string
+}
+
+// Fetcher downloads pages and extracts OpenGraph / Twitter card metadata.
+// It only connects to public addresses, checked after DNS resolution so
+// redirects and rebinding can't reach internal services.
+type Fetcher struct {
+	client    *http.Client
+	maxBytes  int64
+	userAgent string
+	// allowIP decides whether a resolved address may be dialed
+	allowIP func(ip net.IP) bool
+}
+
+// NewFetcher creates a link preview fetcher
+func NewFetcher(cfg *Config) *Fetcher {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	f := &Fetcher{
+		maxBytes:  cfg.MaxBytes,
+		userAgent: cfg.UserAgent,
+		allowIP:   IsPublicIP,
+	}
+	if f.maxBytes <= 0 {
+		f.maxBytes = defaultMaxBytes
+	}
+	if f.userAgent == "" {
+		f.userAgent = defaultUserAgent
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: f.checkAddress,
+	}
+	f.client = &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// No proxy: the address check must see the real destination
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   timeout,
+			ResponseHeaderTimeout: timeout,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("unfurl: stopped after %d redirects", maxRedirects)
+			}
+			return checkScheme(req.URL)
+		},
+	}
+	return f
+}
+
+// Fetch downloads rawURL and returns its preview metadata
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*Preview, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("unfurl: invalid URL: %w", err)
+	}
+	if err := checkScheme(target); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unfurl: invalid request: %w", err)
+	}
+	req.Header.Set("User-Agent", f.userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrBlockedAddress) {
+			return nil, ErrBlockedAddress
+		}
+		return nil, fmt.Errorf("unfurl: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unfurl: unexpected status %d", resp.StatusCode)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, ErrNotHTML
+	}
+
+	preview := parsePreview(io.LimitReader(resp.Body, f.maxBytes), resp.Request.URL)
+	if preview.Title == "" && preview.Description == "" && preview.ImageURL == "" {
+		return nil, ErrNoPreview
+	}
+	preview.URL = rawURL
+	return preview, nil
+}
+
+// checkAddress runs before each connection with the resolved address
+func (f *Fetcher) checkAddress(network, address string, _ syscall.RawConn) error {
+	return checkAddress(address, f.allowIP)
+}
+
+// PublicAddressOnly is a net.Dialer Control function that refuses
+// connections to addresses that aren't publicly routable. It runs after DNS
+// resolution, so redirects and rebinding can't reach internal services.
+func PublicAddressOnly(network, address string, _ syscall.RawConn) error {
+	return checkAddress(address, IsPublicIP)
+}
+
+func checkAddress(address string, allowIP func(ip net.IP) bool) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return ErrBlockedAddress
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !allowIP(ip) {
+		return ErrBlockedAddress
+	}
+	return nil
+}
+
+// parsePreview reads meta tags from the document head.
+// OpenGraph tags win over Twitter card tags, which win over plain HTML.
+func parsePreview(body io.Reader, base *url.URL) *Preview {
+	meta := make(map[string]string)
+	var title string
+
+	tokenizer := html.NewTokenizer(body)
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		if tokenType != html.StartTagToken && tokenType != html.SelfClosingTagToken {
+			if tokenType == html.EndTagToken {
+				if name, _ := tokenizer.TagName(); string(name) == "head" {
+					break
+				}
+			}
+			continue
+		}
+
+		name, hasAttr := tokenizer.TagName()
+		switch string(name) {
+		case "body":
+			return buildPreview(meta, title, base)
+		case "title":
+			if title == "" && tokenizer.Next() == html.TextToken {
+				title = string(tokenizer.Text())
+			}
+		case "meta":
+			var key, content string
+			for hasAttr {
+				var attr, value []byte
+				attr, value, hasAttr = tokenizer.TagAttr()
+				switch strings.ToLower(string(attr)) {
+				case "property", "name":
+					key = strings.ToLower(strings.TrimSpace(string(value)))
+				case "content":
+					content = strings.TrimSpace(string(value))
+				}
+			}
+			if key != "" && content != "" {
+				if _, seen := meta[key]; !seen {
+					meta[key] = content
+				}
+			}
+		}
+	}
+	return buildPreview(meta, title, base)
+}
+
+// buildPreview picks each field from the first tag that set it
+func buildPreview(meta map[string]string, title string, base *url.URL) *Preview {
+	first := func(keys ...string) string {
+		for _, key := range keys {
+			if value := meta[key]; value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+
+	preview := &Preview{
+		Title:       truncate(first("og:title", "twitter:title"), maxTitleLength),
+		Description: truncate(first("og:description", "twitter:description", "description"), maxDescriptionLength),
+		SiteName:    truncate(first("og:site_name"), maxTitleLength),
+	}
+	if preview.Title == "" {
+		preview.Title = truncate(strings.TrimSpace(title), maxTitleLength)
+	}
+	if image := first("og:image:secure_url", "og:image", "twitter:image", "twitter:image:src"); image != "" {
+		if ref, err := url.Parse(image); err == nil {
+			resolved := base.ResolveReference(ref)
+			if resolved.Scheme == "http" || resolved.Scheme == "https" {
+				preview.ImageURL = resolved.String()
+			}
+		}
+	}
+	return preview
+}
+
+// checkScheme only allows web URLs
+func checkScheme(u *url.URL) error {
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrUnsupportedURL
+	}
+	return nil
+}
+
+// IsPublicIP reports whether ip is a publicly routable unicast address
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// truncate shortens s to at most n runes
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -0,0 +1,213 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestFetcher creates a fetcher that may dial the loopback httptest server
+func newTestFetcher(cfg *Config) *Fetcher {
+	f := NewFetcher(cfg)
+	f.allowIP = func(ip net.IP) bool { return true }
+	return f
+}
+
+// newPageServer serves body as an HTML page
+func newPageServer(body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func TestFetch_OpenGraph(t *testing.T) {
+	// Arrange
+	server := newPageServer(`<html><head>
+		<title>Fallback</title>
+		<meta property="og:title" content="Release notes">
+		<meta property="og:description" content="What changed in 2.0">
+		<meta property="og:image" content="/img/cover.png">
+		<meta property="og:site_name" content="Example">
+		<meta name="twitter:title" content="Twitter title">
+	</head><body></body></html>`)
+	defer server.Close()
+	fetcher := newTestFetcher(nil)
+
+	// Act
+	preview, err := fetcher.Fetch(context.Background(), server.URL+"/post")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if preview.Title != "Release notes" || preview.Description != "What changed in 2.0" || preview.SiteName != "Example" {
+		t.Errorf("unexpected preview %+v", preview)
+	}
+	if preview.ImageURL != server.URL+"/img/cover.png" {
+		t.Errorf("expected image resolved against the page, got %q", preview.ImageURL)
+	}
+	if preview.URL != server.URL+"/post" {
+		t.Errorf("expected preview URL %q, got %q", server.URL+"/post", preview.URL)
+	}
+}
+
+func TestFetch_TwitterCardAndTitleFallback(t *testing.T) {
+	// Arrange
+	server := newPageServer(`<html><head>
+		<title>Page &amp; title</title>
+		<meta name="twitter:description" content="Card description">
+		<meta name="twitter:image" content="https://cdn.example.com/card.png">
+	</head></html>`)
+	defer server.Close()
+	fetcher := newTestFetcher(nil)
+
+	// Act
+	preview, err := fetcher.Fetch(context.Background(), server.URL)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if preview.Title != "Page & title" || preview.Description != "Card description" {
+		t.Errorf("unexpected preview %+v", preview)
+	}
+	if preview.ImageURL != "https://cdn.example.com/card.png" {
+		t.Errorf("expected twitter image, got %q", preview.ImageURL)
+	}
+}
+
+func TestFetch_BlocksPrivateAddress(t *testing.T) {
+	// Arrange
+	server := newPageServer(`<html><head><title>Internal</title></head></html>`)
+	defer server.Close()
+	fetcher := NewFetcher(nil)
+
+	// Act
+	_, err := fetcher.Fetch(context.Background(), server.URL)
+
+	// Assert
+	if !errors.Is(err, ErrBlockedAddress) {
+		t.Errorf("expected ErrBlockedAddress, got %v", err)
+	}
+}
+
+func TestFetch_BlocksRedirectToPrivateAddress(t *testing.T) {
+	// Arrange
+	internal := newPageServer(`<html><head><title>Internal</title></head></html>`)
+	defer internal.Close()
+	redirect := httptest.NewServer(http.RedirectHandler(internal.URL, http.StatusFound))
+	defer redirect.Close()
+
+	fetcher := NewFetcher(nil)
+	redirectHost := strings.TrimPrefix(redirect.URL, "http://")
+	fetcher.allowIP = func(ip net.IP) bool { return false }
+	fetcher.client.Transport.(*http.Transport).DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		// The redirect server stands in for a public host; everything else is checked
+		if addr == redirectHost {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		}
+		return (&net.Dialer{Control: fetcher.checkAddress}).DialContext(ctx, network, addr)
+	}
+
+	// Act
+	_, err := fetcher.Fetch(context.Background(), redirect.URL)
+
+	// Assert
+	if !errors.Is(err, ErrBlockedAddress) {
+		t.Errorf("expected ErrBlockedAddress, got %v", err)
+	}
+}
+
+func TestFetch_UnsupportedScheme(t *testing.T) {
+	// Arrange
+	fetcher := NewFetcher(nil)
+
+	// Act
+	_, err := fetcher.Fetch(context.Background(), "file:///etc/passwd")
+
+	// Assert
+	if !errors.Is(err, ErrUnsupportedURL) {
+		t.Errorf("expected ErrUnsupportedURL, got %v", err)
+	}
+}
+
+func TestFetch_NotHTML(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write([]byte("%PDF-1.4"))
+	}))
+	defer server.Close()
+	fetcher := newTestFetcher(nil)
+
+	// Act
+	_, err := fetcher.Fetch(context.Background(), server.URL)
+
+	// Assert
+	if !errors.Is(err, ErrNotHTML) {
+		t.Errorf("expected ErrNotHTML, got %v", err)
+	}
+}
+
+func TestFetch_SizeLimit(t *testing.T) {
+	// Arrange: the metadata starts after the read limit
+	server := newPageServer(`<html><head><!--` + strings.Repeat("x", 4096) + `-->
+		<meta property="og:title" content="Too far"></head></html>`)
+	defer server.Close()
+	fetcher := newTestFetcher(&Config{MaxBytes: 1024})
+
+	// Act
+	_, err := fetcher.Fetch(context.Background(), server.URL)
+
+	// Assert
+	if !errors.Is(err, ErrNoPreview) {
+		t.Errorf("expected ErrNoPreview, got %v", err)
+	}
+}
+
+func TestFetch_Timeout(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+	fetcher := newTestFetcher(&Config{Timeout: 50 * time.Millisecond})
+
+	// Act
+	start := time.Now()
+	_, err := fetcher.Fetch(context.Background(), server.URL)
+
+	// Assert
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected fetch to give up quickly, took %v", elapsed)
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	// Arrange
+	blocked := []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1", "::1", "fc00::1", "::ffff:10.0.0.1", "0.0.0.0"}
+	allowed := []string{"93.184.216.34", "2606:4700::1111"}
+
+	// Act & Assert
+	for _, addr := range blocked {
+		if IsPublicIP(net.ParseIP(addr)) {
+			t.Errorf("expected %s to be blocked", addr)
+		}
+	}
+	for _, addr := range allowed {
+		if !IsPublicIP(net.ParseIP(addr)) {
+			t.Errorf("expected %s to be allowed", addr)
+		}
+	}
+}
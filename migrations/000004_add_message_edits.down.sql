@@ -0,0 +1,3 @@
+-- Remove message edit history
+DROP INDEX IF EXISTS idx_message_edits_message_id;
+DROP TABLE IF EXISTS message_edits;
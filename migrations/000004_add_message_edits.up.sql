@@ -0,0 +1,9 @@
+-- Edit history: one row per edit holding the content before the change
+CREATE TABLE IF NOT EXISTS message_edits (
+    id BIGSERIAL PRIMARY KEY,
+    message_id BIGINT REFERENCES messages(id) ON DELETE CASCADE,
+    previous_content TEXT NOT NULL,
+    edited_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_edits_message_id ON message_edits(message_id, edited_at DESC);
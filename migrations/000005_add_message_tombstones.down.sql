@@ -0,0 +1,3 @@
+-- Remove soft delete columns from messages table
+ALTER TABLE messages DROP COLUMN IF EXISTS deleted_by;
+ALTER TABLE messages DROP COLUMN IF EXISTS deleted_at;
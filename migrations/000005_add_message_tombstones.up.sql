@@ -0,0 +1,6 @@
+-- Soft delete: deleted messages stay in history as tombstones
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS deleted_by BIGINT REFERENCES users(id);
+
+COMMENT ON COLUMN messages.deleted_at IS 'When the message was deleted (NULL if not deleted)';
+COMMENT ON COLUMN messages.deleted_by IS 'User who deleted the message (author or room admin/moderator)';
@@ -0,0 +1,5 @@
+-- Remove thread columns from messages table
+DROP INDEX IF EXISTS idx_messages_parent_id;
+ALTER TABLE messages DROP COLUMN IF EXISTS last_reply_at;
+ALTER TABLE messages DROP COLUMN IF EXISTS reply_count;
+ALTER TABLE messages DROP COLUMN IF EXISTS parent_message_id;
@@ -0,0 +1,9 @@
+-- Threaded replies: a reply points at its root message, roots keep reply stats
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS parent_message_id BIGINT REFERENCES messages(id) ON DELETE CASCADE;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS reply_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS last_reply_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_messages_parent_id ON messages(parent_message_id, id) WHERE parent_message_id IS NOT NULL;
+
+COMMENT ON COLUMN messages.parent_message_id IS 'Thread root this message replies to (NULL for room timeline messages)';
+COMMENT ON COLUMN messages.reply_count IS 'Number of thread replies (root messages only)';
@@ -0,0 +1,3 @@
+-- Remove message mentions
+DROP INDEX IF EXISTS idx_message_mentions_unread;
+DROP TABLE IF EXISTS message_mentions;
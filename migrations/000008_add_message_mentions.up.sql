@@ -0,0 +1,13 @@
+-- Mentions: one row per mentioned user per message, read_at set once seen
+CREATE TABLE IF NOT EXISTS message_mentions (
+    id BIGSERIAL PRIMARY KEY,
+    message_id BIGINT REFERENCES messages(id) ON DELETE CASCADE,
+    room_id BIGINT REFERENCES rooms(id) ON DELETE CASCADE,
+    user_id BIGINT REFERENCES users(id) ON DELETE CASCADE,
+    mention_type VARCHAR(10) NOT NULL DEFAULT 'user', -- user, room, here
+    read_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(message_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_mentions_unread ON message_mentions(user_id, id DESC) WHERE read_at IS NULL;
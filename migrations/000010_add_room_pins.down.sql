@@ -0,0 +1,2 @@
+-- Remove pinned messages
+DROP TABLE IF EXISTS room_pins;
@@ -0,0 +1,10 @@
+-- Pinned messages: at most one pin per message, newest pins listed first
+CREATE TABLE IF NOT EXISTS room_pins (
+    room_id BIGINT REFERENCES rooms(id) ON DELETE CASCADE,
+    message_id BIGINT REFERENCES messages(id) ON DELETE CASCADE,
+    pinned_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    pinned_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (room_id, message_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_room_pins_room_pinned_at ON room_pins(room_id, pinned_at DESC);
@@ -0,0 +1,2 @@
+-- Remove scheduled messages
+DROP TABLE IF EXISTS scheduled_messages;
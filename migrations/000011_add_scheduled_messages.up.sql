@@ -0,0 +1,23 @@
+-- Scheduled messages: composed now, sent by the chat service dispatcher at send_at.
+-- Status moves pending -> sending -> sent/failed, or pending -> cancelled.
+CREATE TABLE IF NOT EXISTS scheduled_messages (
+    id BIGSERIAL PRIMARY KEY,
+    room_id BIGINT REFERENCES rooms(id) ON DELETE CASCADE,
+    user_id BIGINT REFERENCES users(id) ON DELETE CASCADE,
+    content TEXT NOT NULL DEFAULT '',
+    type VARCHAR(20) DEFAULT 'text',
+    file_url VARCHAR(500) NOT NULL DEFAULT '',
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    file_size BIGINT NOT NULL DEFAULT 0,
+    mime_type VARCHAR(100) NOT NULL DEFAULT '',
+    parent_message_id BIGINT REFERENCES messages(id) ON DELETE CASCADE,
+    send_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    message_id BIGINT REFERENCES messages(id) ON DELETE SET NULL,
+    error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Dispatcher scans due pending messages; users list their own
+CREATE INDEX IF NOT EXISTS idx_scheduled_messages_due ON scheduled_messages(send_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_scheduled_messages_user ON scheduled_messages(user_id, send_at);
@@ -0,0 +1,6 @@
+-- Remove message expiry
+DROP INDEX IF EXISTS idx_messages_expires_at;
+
+ALTER TABLE scheduled_messages DROP COLUMN IF EXISTS expires_in;
+ALTER TABLE messages DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE rooms DROP COLUMN IF EXISTS message_ttl_seconds;
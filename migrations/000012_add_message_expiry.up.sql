@@ -0,0 +1,10 @@
+-- Ephemeral messages: rooms set a default lifetime, messages carry their expiry
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS message_ttl_seconds INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+ALTER TABLE scheduled_messages ADD COLUMN IF NOT EXISTS expires_in INTEGER NOT NULL DEFAULT 0;
+
+-- The reaper scans for expired messages
+CREATE INDEX IF NOT EXISTS idx_messages_expires_at ON messages(expires_at) WHERE expires_at IS NOT NULL;
+
+COMMENT ON COLUMN rooms.message_ttl_seconds IS 'Default message lifetime (0 = messages never expire)';
+COMMENT ON COLUMN messages.expires_at IS 'When the reaper hard-deletes the message (NULL = never)';
@@ -0,0 +1,7 @@
+-- Remove message quotes and forwards
+DROP INDEX IF EXISTS idx_messages_quoted_file_url;
+
+ALTER TABLE scheduled_messages DROP COLUMN IF EXISTS quoted_message_id;
+ALTER TABLE messages DROP COLUMN IF EXISTS is_forwarded;
+ALTER TABLE messages DROP COLUMN IF EXISTS quoted_message;
+ALTER TABLE messages DROP COLUMN IF EXISTS quoted_message_id;
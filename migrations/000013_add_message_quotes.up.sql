@@ -0,0 +1,12 @@
+-- Quotes and forwards embed a snapshot of the original message,
+-- so they survive the original being edited or deleted
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS quoted_message_id BIGINT;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS quoted_message JSONB;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS is_forwarded BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE scheduled_messages ADD COLUMN IF NOT EXISTS quoted_message_id BIGINT NOT NULL DEFAULT 0;
+
+-- Deleting a message keeps its attachment while a snapshot still points at it
+CREATE INDEX IF NOT EXISTS idx_messages_quoted_file_url ON messages((quoted_message->>'file_url')) WHERE quoted_message IS NOT NULL;
+
+COMMENT ON COLUMN messages.quoted_message_id IS 'Message quoted or forwarded (no foreign key: the original may be gone)';
+COMMENT ON COLUMN messages.quoted_message IS 'Snapshot of the quoted message taken at send time';
@@ -0,0 +1,3 @@
+-- Remove link previews
+DROP TABLE IF EXISTS link_preview_jobs;
+DROP TABLE IF EXISTS link_previews;
@@ -0,0 +1,22 @@
+-- Link previews: OpenGraph / Twitter card metadata of URLs in a message,
+-- filled in by the chat service unfurler after the message is sent
+CREATE TABLE IF NOT EXISTS link_previews (
+    id BIGSERIAL PRIMARY KEY,
+    message_id BIGINT NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    position INT NOT NULL DEFAULT 0,
+    url TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    image_url TEXT NOT NULL DEFAULT '',
+    site_name TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(message_id, url)
+);
+
+-- Messages waiting to be unfurled, claimed by one chat replica at a time
+CREATE TABLE IF NOT EXISTS link_preview_jobs (
+    message_id BIGINT PRIMARY KEY REFERENCES messages(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_link_preview_jobs_created_at ON link_preview_jobs(created_at);
@@ -0,0 +1,4 @@
+-- Remove message formats
+ALTER TABLE scheduled_messages DROP COLUMN IF EXISTS format;
+ALTER TABLE messages DROP COLUMN IF EXISTS content_html;
+ALTER TABLE messages DROP COLUMN IF EXISTS format;
@@ -0,0 +1,5 @@
+-- Markdown messages keep the raw source in content and a sanitized
+-- HTML rendering for clients that can't render markdown
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS format VARCHAR(20) NOT NULL DEFAULT 'plain';
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS content_html TEXT;
+ALTER TABLE scheduled_messages ADD COLUMN IF NOT EXISTS format VARCHAR(20) NOT NULL DEFAULT 'plain';
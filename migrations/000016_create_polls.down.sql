@@ -0,0 +1,5 @@
+-- Remove polls
+ALTER TABLE scheduled_messages DROP COLUMN IF EXISTS poll;
+DROP TABLE IF EXISTS poll_votes;
+DROP TABLE IF EXISTS poll_options;
+DROP TABLE IF EXISTS polls;
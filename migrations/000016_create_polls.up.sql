@@ -0,0 +1,35 @@
+-- Polls: a poll message's question and options, and each user's votes.
+-- closed_at is set when the poll is closed by hand or reaches closes_at.
+CREATE TABLE IF NOT EXISTS polls (
+    message_id BIGINT PRIMARY KEY REFERENCES messages(id) ON DELETE CASCADE,
+    question TEXT NOT NULL,
+    multiple_choice BOOLEAN NOT NULL DEFAULT FALSE,
+    anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+    closes_at TIMESTAMP,
+    closed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS poll_options (
+    message_id BIGINT NOT NULL REFERENCES polls(message_id) ON DELETE CASCADE,
+    option_id INT NOT NULL,
+    text TEXT NOT NULL,
+    PRIMARY KEY (message_id, option_id)
+);
+
+CREATE TABLE IF NOT EXISTS poll_votes (
+    message_id BIGINT NOT NULL,
+    option_id INT NOT NULL,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (message_id, option_id, user_id),
+    FOREIGN KEY (message_id, option_id) REFERENCES poll_options(message_id, option_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_poll_votes_user ON poll_votes(message_id, user_id);
+
+-- Open polls with a close time, scanned by the dispatcher
+CREATE INDEX IF NOT EXISTS idx_polls_closes_at ON polls(closes_at) WHERE closed_at IS NULL AND closes_at IS NOT NULL;
+
+-- Scheduled poll messages keep the poll definition until they are sent
+ALTER TABLE scheduled_messages ADD COLUMN IF NOT EXISTS poll JSONB;
@@ -0,0 +1,3 @@
+-- Remove system messages
+DELETE FROM messages WHERE type = 'system';
+ALTER TABLE messages DROP COLUMN IF EXISTS system_event;
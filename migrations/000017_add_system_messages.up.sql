@@ -0,0 +1,3 @@
+-- System messages record room lifecycle events (joins, leaves, renames,
+-- role changes, pins) in history; system_event holds the structured record
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS system_event JSONB;
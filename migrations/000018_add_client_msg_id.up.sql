@@ -0,0 +1,6 @@
+-- Client message IDs make sends idempotent: a retried send with the same
+-- (user, client_msg_id) returns the original message instead of a duplicate
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS client_msg_id VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_user_client_msg_id
+    ON messages(user_id, client_msg_id) WHERE client_msg_id IS NOT NULL;
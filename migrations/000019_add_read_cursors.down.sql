@@ -0,0 +1,15 @@
+-- Remove read cursors (per-message receipts are not restored)
+DROP INDEX IF EXISTS idx_messages_room_timeline;
+
+CREATE TABLE IF NOT EXISTS message_reads (
+    id BIGSERIAL PRIMARY KEY,
+    message_id BIGINT REFERENCES messages(id) ON DELETE CASCADE,
+    user_id BIGINT REFERENCES users(id) ON DELETE CASCADE,
+    read_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(message_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_message_reads_user_id ON message_reads(user_id);
+CREATE INDEX IF NOT EXISTS idx_message_reads_message_id ON message_reads(message_id);
+
+ALTER TABLE room_members DROP COLUMN IF EXISTS last_read_at;
+ALTER TABLE room_members DROP COLUMN IF EXISTS last_read_message_id;
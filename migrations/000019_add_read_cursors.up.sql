@@ -0,0 +1,26 @@
+-- Read cursors: each member's read position in a room is the last message
+-- they have read, replacing the per-message message_reads rows
+ALTER TABLE room_members ADD COLUMN IF NOT EXISTS last_read_message_id BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE room_members ADD COLUMN IF NOT EXISTS last_read_at TIMESTAMP;
+
+-- Members start caught up, so rooms don't open with their whole history unread
+UPDATE room_members rm
+SET last_read_message_id = m.max_id
+FROM (SELECT room_id, MAX(id) AS max_id FROM messages GROUP BY room_id) m
+WHERE rm.room_id = m.room_id;
+
+-- Carry existing receipts over as each member's newest read message
+UPDATE room_members rm
+SET last_read_message_id = r.message_id, last_read_at = r.read_at
+FROM (
+    SELECT m.room_id, mr.user_id, MAX(mr.message_id) AS message_id, MAX(mr.read_at) AS read_at
+    FROM message_reads mr
+    JOIN messages m ON m.id = mr.message_id
+    GROUP BY m.room_id, mr.user_id
+) r
+WHERE rm.room_id = r.room_id AND rm.user_id = r.user_id;
+
+DROP TABLE IF EXISTS message_reads;
+
+-- Unread counts scan a room's messages past a cursor
+CREATE INDEX IF NOT EXISTS idx_messages_room_timeline ON messages(room_id, id) WHERE parent_message_id IS NULL;
@@ -0,0 +1,3 @@
+-- Remove room retention policies
+ALTER TABLE rooms DROP COLUMN IF EXISTS retention_value;
+ALTER TABLE rooms DROP COLUMN IF EXISTS retention_mode;
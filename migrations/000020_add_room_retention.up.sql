@@ -0,0 +1,4 @@
+-- Retention policies: a room keeps its messages forever, for N days or
+-- only its newest N messages. NULL follows the server's default policy.
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS retention_mode VARCHAR(16);
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS retention_value INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+-- Remove import keys
+DROP INDEX IF EXISTS idx_messages_import_key;
+DROP INDEX IF EXISTS idx_rooms_import_key;
+ALTER TABLE messages DROP COLUMN IF EXISTS import_key;
+ALTER TABLE rooms DROP COLUMN IF EXISTS import_key;
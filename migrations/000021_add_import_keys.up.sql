@@ -0,0 +1,8 @@
+-- Import keys: the ID a room or message had in the system it was imported
+-- from (e.g. a Slack channel or message timestamp). Re-running an import
+-- matches on them, so nothing is created twice.
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS import_key VARCHAR(255);
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS import_key VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_rooms_import_key ON rooms(import_key) WHERE import_key IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_import_key ON messages(import_key) WHERE import_key IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Remove scheduled message claim times
+DROP INDEX IF EXISTS idx_scheduled_messages_claimed;
+ALTER TABLE scheduled_messages DROP COLUMN IF EXISTS claimed_at;
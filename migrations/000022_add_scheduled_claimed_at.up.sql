@@ -0,0 +1,8 @@
+-- Claim time of scheduled messages being sent. A claim that is never
+-- completed (the dispatcher crashed or was stopped) lapses and the message
+-- is claimed again; resends are idempotent through client_msg_id.
+-- Messages stuck in 'sending' before this migration have no claim time
+-- and are retried on the first dispatch.
+ALTER TABLE scheduled_messages ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_messages_claimed ON scheduled_messages(claimed_at) WHERE status = 'sending';
@@ -0,0 +1,2 @@
+-- Remove link preview job claim times
+ALTER TABLE link_preview_jobs DROP COLUMN IF EXISTS claimed_at;
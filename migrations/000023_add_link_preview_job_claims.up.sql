@@ -0,0 +1,4 @@
+-- Claim time of link preview jobs being unfurled. A job is deleted once its
+-- previews are stored; a claim that is never completed (the worker crashed
+-- or was stopped) lapses and the job is claimed again.
+ALTER TABLE link_preview_jobs ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP;